@@ -1,20 +1,34 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
-	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/admin"
 	"cs-projects-backpack/pkg/config"
-	"cs-projects-backpack/pkg/lighter"
+	"cs-projects-backpack/pkg/engine"
+	"cs-projects-backpack/pkg/journal"
+	"cs-projects-backpack/pkg/keystore"
 	"cs-projects-backpack/pkg/logger"
-	"cs-projects-backpack/pkg/strategy"
+	"cs-projects-backpack/pkg/report"
 )
 
 func main() {
@@ -31,6 +45,46 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// 子命令: 导出成交日志
+	if len(os.Args) > 1 && os.Args[1] == "export-journal" {
+		if err := runJournalExport(cfg, os.Args[2:], log); err != nil {
+			log.Fatal("Journal export failed", zap.Error(err))
+		}
+		return
+	}
+
+	// 子命令: 创建Lighter加密密钥文件
+	if len(os.Args) > 1 && os.Args[1] == "keystore" {
+		if err := runKeystore(cfg, os.Args[2:], log); err != nil {
+			log.Fatal("Keystore creation failed", zap.Error(err))
+		}
+		return
+	}
+
+	// 子命令: 生成盈亏日报
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		if err := runReport(cfg, os.Args[2:], log); err != nil {
+			log.Fatal("Report generation failed", zap.Error(err))
+		}
+		return
+	}
+
+	// 子命令: 预览紧急平仓 (查询运行中的实例，不下单)
+	if len(os.Args) > 1 && os.Args[1] == "close-preview" {
+		if err := runClosePreview(cfg, os.Args[2:]); err != nil {
+			log.Fatal("Emergency close preview failed", zap.Error(err))
+		}
+		return
+	}
+
+	// 子命令: 手动下单、撤单和平仓 (直接使用配置的交易所客户端，不经过策略)
+	if len(os.Args) > 1 && (os.Args[1] == "order" || os.Args[1] == "position") {
+		if err := runManual(cfg, os.Args[1:]); err != nil {
+			log.Fatal("Manual command failed", zap.Error(err))
+		}
+		return
+	}
+
 	log.Info("Starting Trading Bot",
 		zap.String("app_name", cfg.App.Name),
 		zap.String("version", cfg.App.Version),
@@ -38,42 +92,98 @@ func main() {
 		zap.String("strategy_type", cfg.Strategy.Type),
 	)
 
-	if err := cfg.Validate(); err != nil {
-		log.Fatal("Configuration validation failed", zap.Error(err))
+	eng, err := engine.New(cfg)
+	if err != nil {
+		log.Fatal("Failed to create engine", zap.Error(err))
 	}
 
 	log.Info("Configuration loaded successfully")
 
-	// 创建可取消的上下文和信号处理
+	// 引擎、信号处理和HTTP服务作为同一组子系统运行：任一子系统返回错误时取消 runCtx，
+	// 引擎按 shutdown.mode 收尾后其余子系统依次退出
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	group, runCtx := errgroup.WithContext(ctx)
+
+	// 管理API和信号处理在引擎收尾期间保持可用，引擎退出后关闭
+	serveCtx, stopServing := context.WithCancel(context.Background())
+	defer stopServing()
 
-	// 设置信号处理
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	// SIGUSR1 暂停开新仓，SIGUSR2 恢复
+	pauseChan := make(chan os.Signal, 1)
+	signal.Notify(pauseChan, syscall.SIGUSR1, syscall.SIGUSR2)
+	defer signal.Stop(pauseChan)
+
+	group.Go(func() error {
+		defer stopServing()
+		return eng.Run(runCtx)
+	})
+
+	group.Go(func() error {
+		select {
+		case sig := <-sigChan:
+			log.Info("Received shutdown signal", zap.String("signal", sig.String()))
+			log.Info("Initiating graceful shutdown...", zap.String("mode", cfg.Shutdown.Mode))
+			cancel()
+		case <-runCtx.Done():
+		}
 
-	go func() {
-		sig := <-sigChan
-		log.Info("Received shutdown signal", zap.String("signal", sig.String()))
-		log.Info("Initiating graceful shutdown...")
-		cancel()
-	}()
-
-	switch cfg.Strategy.Type {
-	case "lighter":
-		err = runLighterStrategy(ctx, cfg, log)
-	case "binance":
-		err = runBinanceStrategy(ctx, cfg, log)
-	case "arbitrage":
-		err = runArbitrageStrategy(ctx, cfg, log)
-	case "dynamic_hedge":
-		err = runDynamicHedgeStrategy(ctx, cfg, log)
-	default:
-		log.Fatal("Unknown strategy type", zap.String("type", cfg.Strategy.Type))
+		// 收尾 (drain/cancel/flatten) 期间再次收到信号时立即退出
+		select {
+		case sig := <-sigChan:
+			log.Warn("Received second shutdown signal, exiting immediately", zap.String("signal", sig.String()))
+			logger.Sync()
+			os.Exit(1)
+		case <-serveCtx.Done():
+		}
+		return nil
+	})
+
+	group.Go(func() error {
+		for {
+			select {
+			case <-serveCtx.Done():
+				return nil
+			case sig := <-pauseChan:
+				var err error
+				if sig == syscall.SIGUSR1 {
+					err = eng.Pause("signal " + sig.String())
+				} else {
+					err = eng.Resume("signal " + sig.String())
+				}
+				if err != nil {
+					log.Warn("Failed to handle pause signal", zap.String("signal", sig.String()), zap.Error(err))
+				}
+			}
+		}
+	})
+
+	// 管理API
+	if cfg.Admin.Enabled {
+		adminServer := admin.NewServer(&cfg.Admin, eng)
+		group.Go(func() error {
+			return adminServer.Run(serveCtx)
+		})
+	}
+
+	// 性能分析
+	if cfg.Pprof.Enabled {
+		pprofServer := admin.NewPprofServer(&cfg.Pprof)
+		group.Go(func() error {
+			return pprofServer.Run(serveCtx)
+		})
 	}
 
+	err = group.Wait()
+
 	if err != nil {
-		if errors.Is(ctx.Err(), context.Canceled) {
+		if errors.Is(err, engine.ErrKilled) {
+			log.Warn("Strategy halted by kill switch", zap.Any("kill_switch", eng.Status().KillSwitch))
+		} else if errors.Is(err, context.Canceled) {
 			log.Info("Strategy stopped due to shutdown signal")
 		} else {
 			log.Fatal("Strategy execution failed", zap.Error(err))
@@ -83,226 +193,264 @@ func main() {
 	}
 }
 
-func runLighterStrategy(ctx context.Context, cfg *config.Config, log *zap.Logger) error {
-	log.Info("=== Running Lighter Strategy ===")
-
-	lighterClient, err := lighter.NewClient(&cfg.Lighter)
-	if err != nil {
-		return fmt.Errorf("failed to create Lighter client: %w", err)
+// runJournalExport 导出成交日志: export-journal -format csv|parquet -out <file> [-journal <path>]
+func runJournalExport(cfg *config.Config, args []string, log *zap.Logger) error {
+	fs := flag.NewFlagSet("export-journal", flag.ContinueOnError)
+	format := fs.String("format", journal.FormatCSV, "export format: csv or parquet")
+	out := fs.String("out", "", "output file path (default: trades.<format>)")
+	journalPath := fs.String("journal", cfg.Journal.Path, "trade journal path")
+	if err := fs.Parse(args); err != nil {
+		return err
 	}
 
-	lighterStrategy := strategy.NewLighterStrategy(lighterClient)
+	if *out == "" {
+		*out = "trades." + *format
+	}
 
-	lighterConfig := &strategy.LighterConfig{
-		USDTAmount: cfg.Trading.USDTAmount,
-		Leverage:   cfg.Trading.Leverage,
+	count, err := journal.Export(*journalPath, *format, *out)
+	if err != nil {
+		return err
 	}
 
-	log.Info("Press Ctrl+C to stop the strategy...")
+	log.Info("Trade journal exported",
+		zap.String("journal", *journalPath),
+		zap.String("format", *format),
+		zap.String("output", *out),
+		zap.Int("entries", count),
+	)
 
-	errChan := make(chan error, 1)
-	go func() {
-		errChan <- lighterStrategy.ExecuteBTCETHPair(ctx, lighterConfig)
-	}()
+	return nil
+}
 
-	select {
-	case <-ctx.Done():
-		log.Info("Lighter strategy stopped due to shutdown signal")
-		return ctx.Err()
-	case err := <-errChan:
+// runKeystore 创建Lighter加密密钥文件: keystore [-out <file>]，私钥和口令在终端输入
+func runKeystore(cfg *config.Config, args []string, log *zap.Logger) error {
+	fs := flag.NewFlagSet("keystore", flag.ContinueOnError)
+	out := fs.String("out", cfg.Lighter.Keystore, "keystore output path")
+	if err := fs.Parse(args); err != nil {
 		return err
 	}
-}
-
-func runBinanceStrategy(ctx context.Context, cfg *config.Config, log *zap.Logger) error {
-	log.Info("=== Running Binance Strategy ===")
+	if *out == "" {
+		*out = "data/lighter.keystore"
+	}
 
-	binanceClient, err := binance.NewClient(&cfg.Binance)
+	keyHex, err := keystore.Prompt("Lighter private key (hex): ")
+	if err != nil {
+		return err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(keyHex)))
 	if err != nil {
-		return fmt.Errorf("failed to create Binance client: %w", err)
+		return fmt.Errorf("failed to decode private key hex: %w", err)
+	}
+	if len(key) != 40 {
+		return fmt.Errorf("invalid private key length: expected 40 bytes, got %d", len(key))
 	}
 
-	binanceStrategy := strategy.NewBinanceStrategy(binanceClient)
+	passphrase, err := keystore.Prompt("Keystore passphrase: ")
+	if err != nil {
+		return err
+	}
+	confirm, err := keystore.Prompt("Repeat passphrase: ")
+	if err != nil {
+		return err
+	}
+	if len(passphrase) == 0 || !bytes.Equal(passphrase, confirm) {
+		return fmt.Errorf("passphrases are empty or do not match")
+	}
 
-	binanceConfig := &strategy.BinanceConfig{
-		USDCAmount:    float64(cfg.Trading.USDCAmount),
-		SpreadPercent: cfg.Strategy.SpreadPercent,
+	if err := os.MkdirAll(filepath.Dir(*out), 0700); err != nil {
+		return fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+	if err := keystore.Save(*out, key, passphrase); err != nil {
+		return err
 	}
 
-	log.Info("Press Ctrl+C to stop the strategy...")
+	log.Info("Lighter keystore created", zap.String("path", *out))
+	return nil
+}
 
-	errChan := make(chan error, 1)
-	go func() {
-		errChan <- binanceStrategy.ExecuteBTCETHPair(ctx, binanceConfig)
-	}()
+// runClosePreview 预览紧急平仓: close-preview [-addr host:port] [-token <token>]，通过运行中实例的管理API查询，结果以JSON输出。
+// 不指定 -token 时使用 admin.tokens 中的第一个令牌，启用 admin.tls 时使用HTTPS
+func runClosePreview(cfg *config.Config, args []string) error {
+	var defaultToken string
+	if len(cfg.Admin.Tokens) > 0 {
+		defaultToken = cfg.Admin.Tokens[0].Token
+	}
 
-	select {
-	case <-ctx.Done():
-		log.Info("Binance strategy stopped due to shutdown signal")
-		return ctx.Err()
-	case err := <-errChan:
+	fs := flag.NewFlagSet("close-preview", flag.ContinueOnError)
+	addr := fs.String("addr", cfg.Admin.Listen, "admin API address of the running instance")
+	token := fs.String("token", defaultToken, "admin API token (read role)")
+	caFile := fs.String("ca", "", "CA certificate (PEM) to verify the admin API certificate, e.g. for self-signed certificates")
+	certFile := fs.String("cert", "", "client certificate (PEM) when admin.tls.client_ca_file is set")
+	keyFile := fs.String("key", "", "client certificate key (PEM)")
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+	if err := fs.Parse(args); err != nil {
 		return err
 	}
-}
 
-func runArbitrageStrategy(ctx context.Context, cfg *config.Config, log *zap.Logger) error {
-	log.Info("=== Running Arbitrage Strategy ===")
+	host := *addr
+	if strings.HasPrefix(host, ":") {
+		host = "127.0.0.1" + host
+	}
 
-	// Create Lighter client
-	lighterClient, err := lighter.NewClient(&cfg.Lighter)
-	if err != nil {
-		return fmt.Errorf("failed to create Lighter client: %w", err)
+	scheme := "http"
+	client := &http.Client{Timeout: *timeout}
+	if cfg.Admin.TLS.Enabled {
+		scheme = "https"
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if *caFile != "" {
+			pem, err := os.ReadFile(*caFile)
+			if err != nil {
+				return fmt.Errorf("failed to read CA certificate: %w", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("no certificates found in %s", *caFile)
+			}
+		}
+		if *certFile != "" {
+			cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+			if err != nil {
+				return fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
 	}
 
-	// Create Binance client
-	binanceClient, err := binance.NewClient(&cfg.Binance)
+	req, err := http.NewRequest(http.MethodGet, scheme+"://"+host+"/emergency-close/preview", nil)
 	if err != nil {
-		return fmt.Errorf("failed to create Binance client: %w", err)
+		return err
 	}
-
-	// Create individual strategies
-	lighterStrategy := strategy.NewLighterStrategy(lighterClient)
-	binanceStrategy := strategy.NewBinanceStrategy(binanceClient)
-
-	// Create arbitrage strategy
-	arbitrageStrategy := strategy.NewArbitrageStrategy(lighterStrategy, binanceStrategy)
-
-	arbitrageConfig := &strategy.ArbitrageConfig{
-		USDTAmount:    cfg.Trading.USDTAmount,
-		USDCAmount:    cfg.Trading.USDCAmount,
-		Leverage:      cfg.Trading.Leverage,
-		SpreadPercent: cfg.Strategy.SpreadPercent,
+	if *token != "" {
+		req.Header.Set("Authorization", "Bearer "+*token)
 	}
 
-	log.Info("Press Ctrl+C to stop the strategy...")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query admin API: %w", err)
+	}
+	defer resp.Body.Close()
 
-	errChan := make(chan error, 1)
-	go func() {
-		errChan <- arbitrageStrategy.ExecuteBTCETHArbitrage(ctx, arbitrageConfig)
-	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read admin API response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("admin API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
 
-	select {
-	case <-ctx.Done():
-		log.Info("Arbitrage strategy stopped due to shutdown signal")
-		return ctx.Err()
-	case err := <-errChan:
-		return err
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return fmt.Errorf("failed to format preview: %w", err)
 	}
+	out.WriteByte('\n')
+	_, err = out.WriteTo(os.Stdout)
+	return err
 }
 
-func runDynamicHedgeStrategy(ctx context.Context, cfg *config.Config, log *zap.Logger) error {
-	log.Info("=== Running Dynamic Hedge Strategy ===")
+// runManual 手动交易命令:
+//
+//	order place -venue binance|lighter -symbol BTC -side BUY|SELL -amount 100 [-price 65000]
+//	order cancel -symbol BTC [-id <orderID>]  (不指定 -id 时撤销该币种全部Binance挂单)
+//	position close -symbol BTC [-venue binance|lighter|all]
+//
+// 结果以JSON输出
+func runManual(cfg *config.Config, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: order place|cancel, position close")
+	}
+	command := args[0] + " " + args[1]
+
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	venue := fs.String("venue", "", "exchange: binance or lighter (position close also accepts all, default all)")
+	symbol := fs.String("symbol", "", "configured symbol, e.g. BTC")
+	side := fs.String("side", "", "order side: BUY or SELL")
+	amount := fs.Float64("amount", 0, "order amount in quote currency (USDT for Lighter)")
+	price := fs.Float64("price", 0, "Binance limit price (0 for market order)")
+	orderID := fs.Int64("id", 0, "Binance order ID to cancel (0 cancels all open orders of the symbol)")
+	timeout := fs.Duration("timeout", 30*time.Second, "command timeout")
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+	if *symbol == "" {
+		return fmt.Errorf("-symbol is required")
+	}
 
-	// Create Lighter client
-	lighterClient, err := lighter.NewClient(&cfg.Lighter)
+	eng, err := engine.New(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create Lighter client: %w", err)
+		return fmt.Errorf("failed to create engine: %w", err)
 	}
 
-	// Create Binance client
-	binanceClient, err := binance.NewClient(&cfg.Binance)
-	if err != nil {
-		return fmt.Errorf("failed to create Binance client: %w", err)
-	}
-
-	// Create individual strategies
-	lighterStrategy := strategy.NewLighterStrategy(lighterClient)
-	binanceStrategy := strategy.NewBinanceStrategy(binanceClient)
-
-	// Create dynamic hedge strategy
-	dynamicHedgeStrategy := strategy.NewDynamicHedgeStrategy(lighterStrategy, binanceStrategy)
-
-	// Configure dynamic hedge parameters
-	dynamicConfig := &strategy.DynamicHedgeConfig{
-		OrderSize:         float64(cfg.Trading.USDCAmount), // 使用USDC作为基准
-		MaxLeverage:       cfg.Strategy.MaxLeverage,
-		EmergencyLeverage: cfg.Strategy.EmergencyLeverage,
-		StopDuration:      cfg.Strategy.StopDuration,
-		MonitorInterval:   cfg.Strategy.MonitorInterval,
-		SpreadPercent:     cfg.Strategy.SpreadPercent,
-
-		// 持续交易配置
-		ContinuousMode:  cfg.Strategy.ContinuousMode,
-		TradingInterval: cfg.Strategy.TradingInterval,
-		VolumeTarget:    cfg.Strategy.VolumeTarget,
-		MaxDailyTrades:  cfg.Strategy.MaxDailyTrades,
-
-		// 对冲平衡配置
-		EnableHedgeBalancing: cfg.Strategy.EnableHedgeBalancing,
-		BalanceCheckInterval: cfg.Strategy.BalanceCheckInterval,
-		BalanceTolerance:     cfg.Strategy.BalanceTolerance,
-		MinBalanceAdjust:     cfg.Strategy.MinBalanceAdjust,
-
-		// 快速执行配置
-		EnableFastExecution:  cfg.Strategy.EnableFastExecution,
-		FastCheckInterval:    cfg.Strategy.FastCheckInterval,
-		MaxExecutionDelay:    cfg.Strategy.MaxExecutionDelay,
-		EnablePreExecution:   cfg.Strategy.EnablePreExecution,
-		PartialFillThreshold: cfg.Strategy.PartialFillThreshold,
-		MaxSlippagePercent:   cfg.Strategy.MaxSlippagePercent,
-	}
-
-	log.Info("Starting dynamic hedge strategy with config",
-		zap.Float64("order_size", dynamicConfig.OrderSize),
-		zap.Float64("max_leverage", dynamicConfig.MaxLeverage),
-		zap.Float64("emergency_leverage", dynamicConfig.EmergencyLeverage),
-		zap.Duration("stop_duration", dynamicConfig.StopDuration),
-		zap.Duration("monitor_interval", dynamicConfig.MonitorInterval),
-		zap.Bool("continuous_mode", dynamicConfig.ContinuousMode),
-		zap.Duration("trading_interval", dynamicConfig.TradingInterval),
-		zap.Float64("volume_target", dynamicConfig.VolumeTarget),
-		zap.Int("max_daily_trades", dynamicConfig.MaxDailyTrades),
-		zap.Bool("enable_hedge_balancing", dynamicConfig.EnableHedgeBalancing),
-		zap.Duration("balance_check_interval", dynamicConfig.BalanceCheckInterval),
-		zap.Float64("balance_tolerance", dynamicConfig.BalanceTolerance),
-		zap.Float64("min_balance_adjust", dynamicConfig.MinBalanceAdjust),
-		zap.Bool("enable_fast_execution", dynamicConfig.EnableFastExecution),
-		zap.Duration("fast_check_interval", dynamicConfig.FastCheckInterval),
-		zap.Duration("max_execution_delay", dynamicConfig.MaxExecutionDelay),
-		zap.Bool("enable_pre_execution", dynamicConfig.EnablePreExecution),
-		zap.Float64("partial_fill_threshold", dynamicConfig.PartialFillThreshold),
-		zap.Float64("max_slippage_percent", dynamicConfig.MaxSlippagePercent),
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
 
-	// Start the dynamic hedge strategy
-	if err := dynamicHedgeStrategy.Start(ctx, dynamicConfig); err != nil {
-		return fmt.Errorf("failed to start dynamic hedge strategy: %w", err)
+	var result interface{}
+	switch command {
+	case "order place":
+		result, err = eng.PlaceManualOrder(ctx, engine.ManualOrder{
+			Venue:  *venue,
+			Symbol: strings.ToUpper(*symbol),
+			Side:   strings.ToUpper(*side),
+			Amount: *amount,
+			Price:  *price,
+		})
+	case "order cancel":
+		if *orderID != 0 {
+			err = eng.CancelManualOrder(ctx, strings.ToUpper(*symbol), *orderID)
+			result = map[string]interface{}{"symbol": strings.ToUpper(*symbol), "cancelled": []int64{*orderID}}
+		} else {
+			var count int
+			count, err = eng.CancelManualOrders(ctx, strings.ToUpper(*symbol))
+			result = map[string]interface{}{"symbol": strings.ToUpper(*symbol), "cancelled_count": count}
+		}
+	case "position close":
+		if *venue == "" {
+			*venue = "all"
+		}
+		result, err = eng.CloseManualPosition(ctx, *venue, strings.ToUpper(*symbol))
+	default:
+		return fmt.Errorf("unknown command %q: expected order place, order cancel or position close", command)
+	}
+	if err != nil {
+		return err
 	}
 
-	log.Info("Dynamic hedge strategy started successfully")
-	log.Info("Press Ctrl+C to stop the strategy gracefully...")
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
 
-	// Wait for context cancellation (Ctrl+C)
-	<-ctx.Done()
+// runReport 生成盈亏日报: report [-date YYYY-MM-DD] [-dir <dir>] [-formats json,html]
+func runReport(cfg *config.Config, args []string, log *zap.Logger) error {
+	loc, err := time.LoadLocation(cfg.Report.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid report timezone: %w", err)
+	}
 
-	log.Info("Shutdown signal received, stopping dynamic hedge strategy...")
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	date := fs.String("date", time.Now().In(loc).AddDate(0, 0, -1).Format("2006-01-02"), "report date (default: yesterday)")
+	dir := fs.String("dir", cfg.Report.Dir, "output directory")
+	formats := fs.String("formats", strings.Join(cfg.Report.Formats, ","), "comma separated formats: json, html")
+	journalPath := fs.String("journal", cfg.Journal.Path, "trade journal path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
-	// 获取最终统计信息
-	if stats := dynamicHedgeStrategy.GetStats(); stats != nil {
-		log.Info("Final trading statistics",
-			zap.Float64("daily_volume", stats.DailyVolume),
-			zap.Int("daily_trades", stats.DailyTrades),
-			zap.Float64("total_volume", stats.TotalVolume),
-			zap.Int("total_trades", stats.TotalTrades),
-		)
+	day, err := time.ParseInLocation("2006-01-02", *date, loc)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: %w", *date, err)
 	}
 
-	// 获取执行性能统计
-	if execStats := dynamicHedgeStrategy.GetExecutionStats(); execStats != nil {
-		log.Info("Final execution performance statistics",
-			zap.Int64("total_executions", execStats.TotalExecutions),
-			zap.Int64("successful_executions", execStats.SuccessfulExecutions),
-			zap.Float64("success_rate", float64(execStats.SuccessfulExecutions)/float64(execStats.TotalExecutions)*100),
-			zap.Duration("average_delay", execStats.AverageDelay),
-			zap.Duration("min_delay", execStats.MinDelay),
-			zap.Duration("max_delay", execStats.MaxDelay),
-			zap.Any("delay_distribution", execStats.DelayBuckets),
-		)
+	daily, paths, err := report.Generate(*journalPath, day, loc, *dir, strings.Split(*formats, ","))
+	if err != nil {
+		return err
 	}
 
-	// 停止
-	dynamicHedgeStrategy.Stop()
-	log.Info("Dynamic hedge strategy stopped successfully")
+	log.Info("Daily PnL report generated",
+		zap.String("date", daily.Date),
+		zap.Float64("net_pnl", daily.Total.NetPnL),
+		zap.Strings("files", paths),
+	)
 
-	return ctx.Err()
+	return nil
 }
@@ -11,8 +11,10 @@ import (
 )
 
 type ArbitrageStrategy struct {
+	lifecycle
 	lighterStrategy *LighterStrategy
 	binanceStrategy *BinanceStrategy
+	config          *ArbitrageConfig
 	logger          *zap.Logger
 }
 
@@ -23,16 +25,35 @@ type ArbitrageConfig struct {
 	SpreadPercent float64 // Binance挂单价差百分比
 }
 
-func NewArbitrageStrategy(lighterStrategy *LighterStrategy, binanceStrategy *BinanceStrategy) *ArbitrageStrategy {
+func NewArbitrageStrategy(lighterStrategy *LighterStrategy, binanceStrategy *BinanceStrategy, config *ArbitrageConfig) *ArbitrageStrategy {
 	return &ArbitrageStrategy{
+		lifecycle:       newLifecycle(StrategyArbitrage),
 		lighterStrategy: lighterStrategy,
 		binanceStrategy: binanceStrategy,
+		config:          config,
 		logger:          logger.Named("arbitrage-strategy"),
 	}
 }
 
-func (s *ArbitrageStrategy) ExecuteBTCETHArbitrage(ctx context.Context, config *ArbitrageConfig) error {
-	s.logger.Info("Starting BTC-ETH dual-exchange arbitrage strategy",
+// Start 在后台执行一次套利，两边下单完成后结束
+func (s *ArbitrageStrategy) Start(ctx context.Context) error {
+	return s.start(ctx, func(ctx context.Context) error {
+		return s.executeArbitrage(ctx, s.config)
+	})
+}
+
+// Stop 停止策略
+func (s *ArbitrageStrategy) Stop() {
+	s.stop()
+}
+
+// Status 返回运行状态
+func (s *ArbitrageStrategy) Status() Status {
+	return s.status()
+}
+
+func (s *ArbitrageStrategy) executeArbitrage(ctx context.Context, config *ArbitrageConfig) error {
+	s.logger.Info("Starting dual-exchange pair arbitrage strategy",
 		zap.Int64("lighter_usdt_amount", config.USDTAmount),
 		zap.Int64("binance_usdc_amount", config.USDCAmount),
 		zap.Int("lighter_leverage", config.Leverage),
@@ -47,7 +68,7 @@ func (s *ArbitrageStrategy) ExecuteBTCETHArbitrage(ctx context.Context, config *
 		Leverage:   config.Leverage,
 	}
 
-	err := s.lighterStrategy.ExecuteBTCETHPair(ctx, lighterConfig)
+	err := s.lighterStrategy.ExecutePairs(ctx, lighterConfig)
 	if err != nil {
 		s.logger.Error("Lighter strategy execution failed", zap.Error(err))
 		return fmt.Errorf("lighter策略执行失败: %w", err)
@@ -63,7 +84,7 @@ func (s *ArbitrageStrategy) ExecuteBTCETHArbitrage(ctx context.Context, config *
 		SpreadPercent: config.SpreadPercent,
 	}
 
-	err = s.binanceStrategy.ExecuteBTCETHPair(ctx, binanceConfig)
+	err = s.binanceStrategy.ExecutePairs(ctx, binanceConfig)
 	if err != nil {
 		s.logger.Error("Binance strategy execution failed", zap.Error(err))
 		return fmt.Errorf("binance策略执行失败: %w", err)
@@ -71,12 +92,13 @@ func (s *ArbitrageStrategy) ExecuteBTCETHArbitrage(ctx context.Context, config *
 
 	// Summary
 	s.logger.Info("=== Arbitrage strategy completed successfully ===")
-	s.logger.Info("Positions summary",
-		zap.String("lighter_btc", "LONG with leverage"),
-		zap.String("lighter_eth", "SHORT with leverage"),
-		zap.String("binance_btc", "SHORT as maker"),
-		zap.String("binance_eth", "LONG as maker"),
-	)
+	for _, spec := range s.lighterStrategy.symbols.Specs() {
+		s.logger.Info("Positions summary",
+			zap.String("symbol", spec.Symbol),
+			zap.String("lighter_side", spec.LighterSide),
+			zap.String("binance_side", spec.BinanceSide()),
+		)
+	}
 
 	return nil
 }
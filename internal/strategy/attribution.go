@@ -0,0 +1,116 @@
+package strategy
+
+import (
+	"go.uber.org/zap"
+)
+
+// PnLAttribution 单个币种的盈亏归因，按对冲周期的环节拆分：
+// 开仓价差 + 对冲滑点 + 资金费 + 平仓价差 - 手续费。
+// 价差按成交时的参考价计算，与按开仓均价结算的已实现盈亏口径不同，持仓期间两边的基差变化不计入
+type PnLAttribution struct {
+	OpeningEdge   float64 `json:"opening_edge"`   // 开仓价差：开仓Maker成交价相对对冲参考价的收益
+	HedgeSlippage float64 `json:"hedge_slippage"` // 对冲滑点：对冲成交价相对参考价的损益 (含开仓和平仓)
+	Funding       float64 `json:"funding"`        // 资金费 (按持仓价值和费率估算)
+	Fees          float64 `json:"fees"`           // 已支付手续费 (两个交易所合计)
+	ClosingEdge   float64 `json:"closing_edge"`   // 平仓价差：平仓Maker成交价相对对冲参考价的收益
+	Total         float64 `json:"total"`          // 合计
+}
+
+// RecordHedge 记录一次Maker成交及其对冲的价差和滑点。value 为成交金额，makerSide 为Maker单方向，
+// referencePrice 为对冲前的参考价格 (0为未获取，此时全部计入价差)，closing 为平仓单 (含止损/止盈)。
+// hedgePrice 为0 (成交价未知) 时只按参考价记录价差，不计滑点；两者都未知时不记录
+func (pm *PositionManager) RecordHedge(symbol, makerSide string, closing bool, value, makerPrice, referencePrice, hedgePrice float64) {
+	if value <= 0 || makerPrice <= 0 {
+		return
+	}
+	if referencePrice <= 0 {
+		referencePrice = hedgePrice
+	}
+	if referencePrice <= 0 {
+		return
+	}
+
+	// Maker买入后在对冲交易所卖出：参考价高于买入价为价差收益，对冲卖价高于参考价为正滑点；Maker卖出则相反
+	qty := value / makerPrice
+	edge := qty * (referencePrice - makerPrice)
+	var slippage float64
+	if hedgePrice > 0 {
+		slippage = qty * (hedgePrice - referencePrice)
+	}
+	if makerSide == "SELL" {
+		edge, slippage = -edge, -slippage
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	a := pm.symbolAttribution(symbol)
+	if closing {
+		a.ClosingEdge += edge
+	} else {
+		a.OpeningEdge += edge
+	}
+	a.HedgeSlippage += slippage
+
+	pm.logger.Debug("Recorded hedge attribution",
+		zap.String("symbol", symbol),
+		zap.String("maker_side", makerSide),
+		zap.Bool("closing", closing),
+		zap.Float64("maker_price", makerPrice),
+		zap.Float64("reference_price", referencePrice),
+		zap.Float64("hedge_price", hedgePrice),
+		zap.Float64("edge", edge),
+		zap.Float64("slippage", slippage),
+	)
+}
+
+// AccrueFunding 累计币种的资金费 (正数为收入，负数为支出)
+func (pm *PositionManager) AccrueFunding(symbol string, amount float64) {
+	if amount == 0 {
+		return
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.symbolAttribution(symbol).Funding += amount
+}
+
+// symbolAttribution 获取币种的归因记录，不存在时创建 (调用方持有写锁)
+func (pm *PositionManager) symbolAttribution(symbol string) *PnLAttribution {
+	a, ok := pm.attribution[symbol]
+	if !ok {
+		a = &PnLAttribution{}
+		pm.attribution[symbol] = a
+	}
+	return a
+}
+
+// attributionSnapshot 复制各币种的归因，补充两个交易所的手续费并计算合计 (调用方持有读锁)
+func (pm *PositionManager) attributionSnapshot() map[string]*PnLAttribution {
+	result := make(map[string]*PnLAttribution)
+	get := func(symbol string) *PnLAttribution {
+		a, ok := result[symbol]
+		if !ok {
+			a = &PnLAttribution{}
+			result[symbol] = a
+		}
+		return a
+	}
+
+	for symbol, a := range pm.attribution {
+		*get(symbol) = *a
+	}
+	for _, positions := range []*ExchangePositions{pm.lighterPositions, pm.binancePositions} {
+		for symbol, pos := range positions.Positions {
+			if pos.Fees != 0 {
+				get(symbol).Fees += pos.Fees
+			}
+		}
+	}
+
+	for _, a := range result {
+		a.Total = a.OpeningEdge + a.HedgeSlippage + a.Funding + a.ClosingEdge - a.Fees
+	}
+	return result
+}
@@ -0,0 +1,322 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// hoursPerYear 年化换算使用的小时数
+const hoursPerYear = 365 * 24
+
+// BasisStrategy 期现基差策略
+//
+// 比较Binance现货与Lighter永续合约的价格，当年化基差超过阈值时，
+// 买入现货并做空永续 (正基差) 或卖出现货并做多永续 (负基差)；
+// 基差收敛到退出阈值以下或方向反转时自动平仓。
+type BasisStrategy struct {
+	lifecycle
+	lighterStrategy *LighterStrategy
+	binanceStrategy *BinanceStrategy
+	config          *BasisConfig
+	logger          *zap.Logger
+
+	positions map[string]*BasisPosition // symbol -> 持仓
+	mu        sync.RWMutex
+}
+
+// BasisConfig 期现基差配置
+type BasisConfig struct {
+	Symbols           []string      // 交易币种 (BTC, ETH)
+	OrderSize         float64       // 每侧下单规模 (USDT/USDC)
+	Leverage          int           // Lighter杠杆倍数
+	SpreadPercent     float64       // Binance挂单价差百分比
+	EntryAnnualized   float64       // 开仓所需年化基差 (0.15 = 15%)
+	ExitAnnualized    float64       // 平仓年化基差，低于该值时平仓
+	ConvergenceWindow time.Duration // 预期基差收敛周期，用于年化换算
+	CheckInterval     time.Duration // 检查间隔
+}
+
+// BasisPosition 期现基差持仓
+type BasisPosition struct {
+	Symbol          string    `json:"symbol"`
+	SpotSide        string    `json:"spot_side"` // Binance现货方向 BUY / SELL
+	PerpSide        string    `json:"perp_side"` // Lighter永续方向 BUY / SELL
+	Size            float64   `json:"size"`
+	EntryBasis      float64   `json:"entry_basis"`      // 开仓基差 (比例)
+	EntryAnnualized float64   `json:"entry_annualized"` // 开仓年化基差
+	OpenedAt        time.Time `json:"opened_at"`
+
+	Opening *BinanceLeg `json:"opening,omitempty"` // 开仓的Binance现货订单未完全成交
+	Closing *BinanceLeg `json:"closing,omitempty"` // 平仓的Binance现货订单未完全成交，成交后删除持仓
+}
+
+// BasisQuote 基差报价
+type BasisQuote struct {
+	SpotPrice  float64
+	PerpPrice  float64
+	Basis      float64 // (perp - spot) / spot
+	Annualized float64 // 按收敛周期年化的基差
+}
+
+func NewBasisStrategy(lighterStrategy *LighterStrategy, binanceStrategy *BinanceStrategy, config *BasisConfig) *BasisStrategy {
+	return &BasisStrategy{
+		lifecycle:       newLifecycle(StrategyBasis),
+		lighterStrategy: lighterStrategy,
+		binanceStrategy: binanceStrategy,
+		config:          config,
+		logger:          logger.Named("basis-strategy"),
+		positions:       make(map[string]*BasisPosition),
+	}
+}
+
+// Start 在后台运行期现基差策略，直到ctx取消或 Stop
+func (s *BasisStrategy) Start(ctx context.Context) error {
+	return s.start(ctx, func(ctx context.Context) error {
+		return s.run(ctx, s.config)
+	})
+}
+
+// Stop 停止策略
+func (s *BasisStrategy) Stop() {
+	s.stop()
+}
+
+// Status 返回运行状态
+func (s *BasisStrategy) Status() Status {
+	return s.status()
+}
+
+// run 运行期现基差策略，阻塞直到ctx取消
+func (s *BasisStrategy) run(ctx context.Context, config *BasisConfig) error {
+	s.logger.Info("Starting spot-perp basis strategy",
+		zap.Strings("symbols", config.Symbols),
+		zap.Float64("order_size", config.OrderSize),
+		zap.Int("leverage", config.Leverage),
+		zap.Float64("entry_annualized", config.EntryAnnualized),
+		zap.Float64("exit_annualized", config.ExitAnnualized),
+		zap.Duration("convergence_window", config.ConvergenceWindow),
+		zap.Duration("check_interval", config.CheckInterval),
+	)
+
+	ticker := time.NewTicker(config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, symbol := range config.Symbols {
+			if err := s.checkBasis(ctx, config, symbol); err != nil {
+				s.logger.Error("Basis check failed", zap.String("symbol", symbol), zap.Error(err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logOpenPositions()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetPositions 获取当前持仓
+func (s *BasisStrategy) GetPositions() map[string]*BasisPosition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	positions := make(map[string]*BasisPosition, len(s.positions))
+	for symbol, pos := range s.positions {
+		posCopy := *pos
+		posCopy.Opening = pos.Opening.clone()
+		posCopy.Closing = pos.Closing.clone()
+		positions[symbol] = &posCopy
+	}
+	return positions
+}
+
+// quote 获取现货和永续价格并计算基差
+func (s *BasisStrategy) quote(ctx context.Context, config *BasisConfig, symbol string) (*BasisQuote, error) {
+	marketIndex, err := s.lighterStrategy.marketIndex(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	spotPrice, err := s.binanceStrategy.client.GetCurrentPrice(ctx, s.binanceStrategy.pair(symbol))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spot price: %w", err)
+	}
+
+	perpPrice, err := s.lighterStrategy.client.GetLastPrice(ctx, marketIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get perp price: %w", err)
+	}
+
+	basis := (perpPrice - spotPrice) / spotPrice
+
+	return &BasisQuote{
+		SpotPrice:  spotPrice,
+		PerpPrice:  perpPrice,
+		Basis:      basis,
+		Annualized: basis * hoursPerYear / config.ConvergenceWindow.Hours(),
+	}, nil
+}
+
+// checkBasis 检查单个币种的基差并开/平仓
+func (s *BasisStrategy) checkBasis(ctx context.Context, config *BasisConfig, symbol string) error {
+	q, err := s.quote(ctx, config, symbol)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debug("Basis quote",
+		zap.String("symbol", symbol),
+		zap.Float64("spot_price", q.SpotPrice),
+		zap.Float64("perp_price", q.PerpPrice),
+		zap.Float64("basis", q.Basis),
+		zap.Float64("annualized", q.Annualized),
+	)
+
+	s.mu.RLock()
+	pos, hasPosition := s.positions[symbol]
+	s.mu.RUnlock()
+
+	if hasPosition && (pos.Opening != nil || pos.Closing != nil) {
+		return s.trackPending(ctx, config, pos)
+	}
+
+	if hasPosition {
+		// 基差收敛或方向反转时平仓
+		flipped := q.Basis*pos.EntryBasis < 0
+		if flipped || math.Abs(q.Annualized) <= config.ExitAnnualized {
+			s.logger.Info("Basis converged, unwinding position",
+				zap.String("symbol", symbol),
+				zap.Float64("entry_annualized", pos.EntryAnnualized),
+				zap.Float64("current_annualized", q.Annualized),
+				zap.Bool("flipped", flipped),
+			)
+			return s.unwind(ctx, config, pos)
+		}
+		return nil
+	}
+
+	if math.Abs(q.Annualized) < config.EntryAnnualized {
+		return nil
+	}
+
+	// 正基差：买现货、空永续；负基差：卖现货、多永续
+	spotSide, perpSide := "BUY", "SELL"
+	if q.Basis < 0 {
+		spotSide, perpSide = "SELL", "BUY"
+	}
+
+	s.logger.Info("Annualized basis above threshold, opening position",
+		zap.String("symbol", symbol),
+		zap.Float64("basis", q.Basis),
+		zap.Float64("annualized", q.Annualized),
+		zap.String("spot_side", spotSide),
+		zap.String("perp_side", perpSide),
+	)
+
+	orderID, err := placeCrossVenueLegs(ctx, s.lighterStrategy, s.binanceStrategy, symbol, perpSide, spotSide, config.OrderSize, config.Leverage, config.SpreadPercent)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.positions[symbol] = &BasisPosition{
+		Symbol:          symbol,
+		SpotSide:        spotSide,
+		PerpSide:        perpSide,
+		Size:            config.OrderSize,
+		EntryBasis:      q.Basis,
+		EntryAnnualized: q.Annualized,
+		OpenedAt:        time.Now(),
+		Opening:         &BinanceLeg{OrderID: orderID, Side: spotSide, Size: config.OrderSize},
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// unwind 反向下单平掉现货和永续仓位，Binance现货订单完全成交后才删除持仓
+func (s *BasisStrategy) unwind(ctx context.Context, config *BasisConfig, pos *BasisPosition) error {
+	spotSide := oppositeSide(pos.SpotSide)
+	orderID, err := placeCrossVenueLegs(ctx, s.lighterStrategy, s.binanceStrategy, pos.Symbol, oppositeSide(pos.PerpSide), spotSide, pos.Size, config.Leverage, config.SpreadPercent)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	pos.Closing = &BinanceLeg{OrderID: orderID, Side: spotSide, Size: pos.Size}
+	s.mu.Unlock()
+
+	s.logger.Info("Basis position unwinding, waiting for binance fill",
+		zap.String("symbol", pos.Symbol),
+		zap.Int64("binance_order_id", orderID),
+	)
+
+	return nil
+}
+
+// trackPending 跟踪开仓或平仓中未完全成交的Binance现货订单，平仓单成交后删除持仓
+func (s *BasisStrategy) trackPending(ctx context.Context, config *BasisConfig, pos *BasisPosition) error {
+	s.mu.RLock()
+	closing := pos.Closing != nil
+	leg := pos.Opening
+	if closing {
+		leg = pos.Closing
+	}
+	legCopy := *leg
+	s.mu.RUnlock()
+
+	filled, err := trackBinanceLeg(ctx, s.binanceStrategy, pos.Symbol, &legCopy, config.SpreadPercent)
+
+	s.mu.Lock()
+	*leg = legCopy
+	if filled {
+		if closing {
+			delete(s.positions, pos.Symbol)
+		} else {
+			pos.Opening = nil
+		}
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if !filled {
+		return nil
+	}
+
+	if closing {
+		s.logger.Info("Basis position unwound",
+			zap.String("symbol", pos.Symbol),
+			zap.Duration("held_for", time.Since(pos.OpenedAt)),
+		)
+	} else {
+		s.logger.Info("Basis position opened",
+			zap.String("symbol", pos.Symbol),
+			zap.Int64("binance_order_id", legCopy.OrderID),
+		)
+	}
+	return nil
+}
+
+// logOpenPositions 退出时输出未平仓位
+func (s *BasisStrategy) logOpenPositions() {
+	for symbol, pos := range s.GetPositions() {
+		s.logger.Warn("Basis position still open on shutdown",
+			zap.String("symbol", symbol),
+			zap.String("spot_side", pos.SpotSide),
+			zap.String("perp_side", pos.PerpSide),
+			zap.Float64("size", pos.Size),
+			zap.Time("opened_at", pos.OpenedAt),
+		)
+	}
+}
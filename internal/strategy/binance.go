@@ -0,0 +1,111 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+type BinanceStrategy struct {
+	lifecycle
+	client  BinanceClient
+	symbols *SymbolUniverse
+	config  *BinanceConfig // 作为其他策略的一腿时为nil
+	logger  *zap.Logger
+}
+
+type BinanceConfig struct {
+	USDCAmount    float64 // 每次交易的USDC数量
+	SpreadPercent float64 // 价差百分比
+}
+
+// NewBinanceStrategy 创建Binance策略，作为其他策略的一腿时config传nil
+func NewBinanceStrategy(client BinanceClient, symbols *SymbolUniverse, config *BinanceConfig) *BinanceStrategy {
+	return &BinanceStrategy{
+		lifecycle: newLifecycle(StrategyBinance),
+		client:    client,
+		symbols:   symbols,
+		config:    config,
+		logger:    logger.Named("binance-strategy"),
+	}
+}
+
+// Start 在后台按配置依次挂单，全部完成后结束
+func (s *BinanceStrategy) Start(ctx context.Context) error {
+	if s.config == nil {
+		return fmt.Errorf("binance strategy has no config")
+	}
+	return s.start(ctx, func(ctx context.Context) error {
+		return s.ExecutePairs(ctx, s.config)
+	})
+}
+
+// Stop 停止策略
+func (s *BinanceStrategy) Stop() {
+	s.stop()
+}
+
+// Status 返回运行状态
+func (s *BinanceStrategy) Status() Status {
+	return s.status()
+}
+
+// pollInterval 按Binance请求权重的自适应降频倍数放大轮询间隔
+func (s *BinanceStrategy) pollInterval(base time.Duration) time.Duration {
+	return time.Duration(float64(base) * s.client.PollingSlowdown())
+}
+
+// ExecutePairs 按配置的币种依次在Binance挂Maker单 (方向与Lighter侧相反)
+func (s *BinanceStrategy) ExecutePairs(ctx context.Context, config *BinanceConfig) error {
+	s.logger.Info("Starting Binance pair trading strategy",
+		zap.Int("symbols", len(s.symbols.Specs())),
+		zap.Float64("usdc_amount", config.USDCAmount),
+		zap.Float64("spread_percent", config.SpreadPercent),
+	)
+
+	for i, spec := range s.symbols.Specs() {
+		if i > 0 {
+			time.Sleep(1 * time.Second)
+		}
+
+		side := spec.BinanceSide()
+		s.logger.Info("Placing order on Binance",
+			zap.String("symbol", spec.Symbol),
+			zap.String("side", side),
+			zap.Float64("usdc_amount", config.USDCAmount),
+			zap.Float64("spread_percent", config.SpreadPercent),
+		)
+		orderID, err := s.placeMakerOrder(ctx, spec.Symbol, side, config.USDCAmount, config.SpreadPercent)
+		if err != nil {
+			s.logger.Error("Binance order failed", zap.String("symbol", spec.Symbol), zap.Error(err))
+			return fmt.Errorf("binance %s %s下单失败: %w", spec.Symbol, side, err)
+		}
+		s.logger.Info("Binance order successful",
+			zap.String("symbol", spec.Symbol),
+			zap.String("side", side),
+			zap.Int64("order_id", orderID),
+		)
+	}
+
+	s.logger.Info("Binance pair trading completed successfully")
+
+	return nil
+}
+
+// pair 将内部币种符号映射为Binance交易对
+func (s *BinanceStrategy) pair(symbol string) string {
+	return s.symbols.BinancePair(symbol)
+}
+
+// placeMakerOrder 按内部币种符号挂Maker限价单，返回订单ID
+func (s *BinanceStrategy) placeMakerOrder(ctx context.Context, symbol, side string, usdcAmount, spreadPercent float64) (int64, error) {
+	order, err := s.client.PlaceMakerOrder(ctx, s.pair(symbol), side, usdcAmount, spreadPercent)
+	if err != nil {
+		return 0, err
+	}
+	return order.OrderID, nil
+}
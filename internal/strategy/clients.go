@@ -0,0 +1,88 @@
+package strategy
+
+import (
+	"context"
+	"time"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/lighter"
+
+	"github.com/elliottech/lighter-go/types/txtypes"
+)
+
+// BinanceClient 策略使用的Binance客户端接口，由 *binance.Client 实现，测试时可注入模拟实现。
+// 交易对参数为Binance交易对 (如 BTCUSDC)
+type BinanceClient interface {
+	// Market 当前交易市场: spot, futures, margin
+	Market() string
+	// HasPositions 交易所是否提供持仓，现货市场返回 false
+	HasPositions() bool
+	// SupportsOCO 当前交易市场是否支持OCO
+	SupportsOCO() bool
+	// PollingSlowdown 请求权重接近上限时监控轮询间隔的倍数 (>=1)
+	PollingSlowdown() float64
+
+	// 行情
+	GetCurrentPrice(ctx context.Context, symbol string) (float64, error)
+	GetUSDCRate(ctx context.Context) (float64, error)
+	GetBookTicker(symbol string) (binance.BookTicker, bool)
+	GetMarkIndexPrice(ctx context.Context, symbol string) (mark, index float64, err error)
+	GetDepthNotional(ctx context.Context, symbol, side string, withinPercent float64) (float64, error)
+	EstimateMarketFill(ctx context.Context, symbol, side string, quantity float64) (best, avg float64, err error)
+	GetFundingRate(ctx context.Context, symbol string) (float64, error)
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]binance.Kline, error)
+	GetQuoteVolume(ctx context.Context, symbol string, start, end time.Time) (float64, error)
+
+	// 账户
+	GetAccountEquity(ctx context.Context) (float64, error)
+	GetFreeCollateral(ctx context.Context, symbol, side string) (free, leverage float64, err error)
+	GetBaseBalances(ctx context.Context) (map[string]float64, error)
+	GetSpotFreeBalance(ctx context.Context, asset string) (float64, error)
+	GetFreeStablecoins(ctx context.Context) (float64, error)
+	GetPositions(ctx context.Context) (map[string]binance.Position, error)
+	GetOpenOrders(ctx context.Context, symbol string) ([]binance.OpenOrder, error)
+
+	// 下单和撤单
+	PlaceMakerOrder(ctx context.Context, symbol, side string, usdcAmount float64, spreadPercent float64) (*binance.OrderStatus, error)
+	PlaceLimitOrderAt(ctx context.Context, symbol, side string, usdcAmount, price float64) (*binance.OrderStatus, error)
+	PlaceMarketOrders(ctx context.Context, reqs []binance.MarketOrderRequest) []binance.BatchOrderResult
+	PlaceStopOrder(ctx context.Context, symbol, side, kind string, quantity, stopPrice, limitPrice float64) (*binance.OrderStatus, error)
+	PlaceOCOOrder(ctx context.Context, symbol, side string, quantity, takeProfitPrice, stopPrice, stopLimitPrice float64) (*binance.OCOStatus, error)
+	GetOrder(ctx context.Context, symbol string, orderID int64) (*binance.OrderStatus, error)
+	CancelOrder(ctx context.Context, symbol string, orderID int64) error
+	CancelOrders(ctx context.Context, symbol string, orderIDs []int64) ([]int64, error)
+	CancelOCOOrder(ctx context.Context, symbol string, orderListID int64) error
+
+	// 钱包划转
+	TransferToFutures(ctx context.Context, asset string, amount float64) (int64, error)
+	TransferToSpot(ctx context.Context, asset string, amount float64) (int64, error)
+	Withdraw(ctx context.Context, asset, network, address string, amount float64) (string, error)
+}
+
+// LighterClient 策略使用的Lighter客户端接口，由 *lighter.Client 实现，测试时可注入模拟实现
+type LighterClient interface {
+	// 行情
+	GetLastPrice(ctx context.Context, marketIndex uint8) (float64, error)
+	GetMarkIndexPrice(ctx context.Context, marketIndex uint8) (mark, index float64, err error)
+	GetDepthNotional(ctx context.Context, marketIndex uint8, side string, withinPercent float64) (float64, error)
+	EstimateMarketFill(ctx context.Context, marketIndex uint8, side string, quantity float64) (best, avg float64, err error)
+	GetFundingRates(ctx context.Context) (map[string]float64, error)
+
+	// 账户
+	GetAccountEquity(ctx context.Context) (float64, error)
+	GetAvailableBalance(ctx context.Context) (float64, error)
+	GetPositions(ctx context.Context) ([]lighter.Position, error)
+	GetOpenOrders(ctx context.Context, marketIndex uint8) ([]lighter.OpenOrder, error)
+
+	// 下单和平仓
+	PlaceLong(ctx context.Context, marketIndex uint8, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error)
+	PlaceShort(ctx context.Context, marketIndex uint8, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error)
+	PlaceMarketOrder(ctx context.Context, req *lighter.MarketOrderRequest) (*txtypes.L2CreateOrderTxInfo, error)
+	PlaceMarketOrders(ctx context.Context, reqs []*lighter.MarketOrderRequest) ([]*txtypes.L2CreateOrderTxInfo, error)
+	ClosePositions(ctx context.Context, marketIndexes []uint8) ([]lighter.ClosedPosition, error)
+}
+
+var (
+	_ BinanceClient = (*binance.Client)(nil)
+	_ LighterClient = (*lighter.Client)(nil)
+)
@@ -0,0 +1,389 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/journal"
+	"cs-projects-backpack/pkg/killswitch"
+)
+
+// ClosingManager 平仓管理器
+type ClosingManager struct {
+	hedgeStrategy   *DynamicHedgeStrategy
+	positionManager *PositionManager
+	orderManager    *OrderManager
+	orderMonitor    *OrderMonitor
+	logger          *zap.Logger
+}
+
+// NewClosingManager 创建平仓管理器
+func NewClosingManager(hedgeStrategy *DynamicHedgeStrategy) *ClosingManager {
+	return &ClosingManager{
+		hedgeStrategy:   hedgeStrategy,
+		positionManager: hedgeStrategy.positionManager,
+		orderManager:    hedgeStrategy.orderManager,
+		orderMonitor:    hedgeStrategy.orderMonitor,
+		logger:          hedgeStrategy.logger.Named("closing-manager"),
+	}
+}
+
+// ExecuteClosingLogic 执行平仓逻辑，返回本轮平仓下单总金额
+func (cm *ClosingManager) ExecuteClosingLogic(ctx context.Context, config *DynamicHedgeConfig) (float64, error) {
+	cm.logger.Info("Starting closing logic execution")
+
+	// 1. 获取当前仓位状态
+	binancePositions := cm.positionManager.GetBinancePositions()
+	lighterPositions := cm.positionManager.GetLighterPositions()
+
+	// 2. 检查是否所有仓位都已为0
+	if cm.allPositionsZero(binancePositions, lighterPositions) {
+		cm.logger.Info("All positions are zero, closing phase completed")
+		return 0, nil
+	}
+
+	// 3. 所有有仓位且没有进行中订单的币种并发平仓
+	var targets []SymbolSpec
+	for _, spec := range cm.hedgeStrategy.symbols.Specs() {
+		pos := cm.ensurePosition(binancePositions, spec.Symbol)
+		if pos.Size == 0 || cm.hedgeStrategy.symbolBusy(spec.Symbol) {
+			continue
+		}
+		targets = append(targets, spec)
+	}
+
+	if len(targets) == 0 {
+		cm.logger.Debug("No symbols available for closing")
+		return 0, nil
+	}
+
+	var mu sync.Mutex
+	var volume float64
+	err := runPerSymbol(targets, func(spec SymbolSpec) error {
+		closeSize, err := cm.closeSymbol(ctx, config, spec, binancePositions.Positions[spec.Symbol])
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		volume += closeSize
+		mu.Unlock()
+		return nil
+	})
+
+	return volume, err
+}
+
+// closeSymbol 平掉单个币种的一笔仓位，返回平仓金额
+func (cm *ClosingManager) closeSymbol(ctx context.Context, config *DynamicHedgeConfig, spec SymbolSpec, pos *Position) (float64, error) {
+	// 空头平仓需要买入，多头平仓需要卖出；Lighter侧反向平掉对冲仓位
+	binanceSide := "SELL"
+	if pos.Size < 0 {
+		binanceSide = "BUY"
+	}
+	lighterSide := oppositeSide(binanceSide)
+
+	// 平仓金额取当前仓位价值和该币种订单大小的最小值
+	closeSize := math.Min(math.Abs(pos.Value), spec.OrderSize)
+
+	cm.logger.Info("Selected symbol for closing",
+		zap.String("symbol", spec.Symbol),
+		zap.Float64("position_value", pos.Value),
+		zap.String("binance_side", binanceSide),
+		zap.Float64("close_size", closeSize),
+	)
+
+	if err := cm.executeClosingSequence(ctx, config, spec.Symbol, binanceSide, lighterSide, closeSize); err != nil {
+		return 0, err
+	}
+	return closeSize, nil
+}
+
+// ExecuteEmergencyClosing 执行紧急平仓
+func (cm *ClosingManager) ExecuteEmergencyClosing(ctx context.Context, config *DynamicHedgeConfig) error {
+	cm.logger.Error("Executing emergency closing due to high leverage")
+
+	// 紧急平仓只降低风险，紧急停止后仍然放行
+	ctx = killswitch.Exempt(ctx)
+
+	// 紧急平仓使用市价单，快速执行
+	binancePositions := cm.positionManager.GetBinancePositions()
+	lighterPositions := cm.positionManager.GetLighterPositions()
+
+	// 撤销保护单，避免与市价平仓重复成交
+	if _, err := cm.hedgeStrategy.protectionManager.CancelAll(ctx); err != nil {
+		cm.logger.Error("Failed to cancel protective orders", zap.Error(err))
+	}
+
+	// 两个交易所各以一批市价单平掉所有仓位
+	cm.placeBinanceMarketOrders(ctx, binancePositions.Positions)
+	cm.placeLighterMarketOrders(ctx, lighterPositions.Positions)
+
+	return nil
+}
+
+// executeClosingSequence 执行平仓序列
+func (cm *ClosingManager) executeClosingSequence(
+	ctx context.Context,
+	config *DynamicHedgeConfig,
+	symbol, binanceSide, lighterSide string,
+	closeSize float64,
+) error {
+	cm.logger.Info("Executing closing sequence",
+		zap.String("symbol", symbol),
+		zap.String("binance_side", binanceSide),
+		zap.String("lighter_side", lighterSide),
+		zap.Float64("close_size", closeSize),
+	)
+
+	// 先撤销该币种的止损/止盈保护单，释放被占用的余额，避免平仓后保护单再次成交
+	if _, err := cm.hedgeStrategy.protectionManager.CancelForSymbol(ctx, symbol); err != nil {
+		return fmt.Errorf("failed to cancel protective orders: %w", err)
+	}
+
+	// 在Binance下Maker限价单并加入监控 (订单过大时按TWAP分片)
+	err := cm.hedgeStrategy.placeMakerOrder(ctx, config, symbol, binanceSide, OrderRoleClose, closeSize,
+		func(ctx context.Context, size float64) (string, error) {
+			return cm.placeBinanceClosingOrder(ctx, symbol, binanceSide, size, config)
+		})
+	if err != nil {
+		return fmt.Errorf("failed to place Binance closing order: %w", err)
+	}
+
+	return nil
+}
+
+// placeBinanceClosingOrder 在Binance下平仓订单
+func (cm *ClosingManager) placeBinanceClosingOrder(
+	ctx context.Context,
+	symbol, side string,
+	size float64,
+	config *DynamicHedgeConfig,
+) (string, error) {
+	spreadPercent := cm.hedgeStrategy.spreadPercent(config, symbol)
+
+	cm.logger.Info("Placing Binance closing order",
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("size", size),
+		zap.Float64("spread_percent", spreadPercent),
+	)
+
+	orderID, err := cm.hedgeStrategy.binanceStrategy.placeMakerOrder(ctx, symbol, side, size, spreadPercent)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", orderID), nil
+}
+
+// placeBinanceMarketOrders 以一批市价单平掉Binance仓位（紧急平仓用）。
+// 合约市场为只减仓单，成交后记录成交日志并更新本地仓位，单笔失败只记录日志
+func (cm *ClosingManager) placeBinanceMarketOrders(ctx context.Context, positions map[string]*Position) {
+	var symbols []string
+	var reqs []binance.MarketOrderRequest
+	for symbol, pos := range positions {
+		if pos.Size == 0 {
+			continue
+		}
+		side := "BUY"
+		if pos.Size > 0 {
+			side = "SELL"
+		}
+		symbols = append(symbols, symbol)
+		reqs = append(reqs, binance.MarketOrderRequest{
+			Symbol:     cm.hedgeStrategy.binanceStrategy.pair(symbol),
+			Side:       side,
+			Quantity:   math.Abs(pos.Size),
+			ReduceOnly: true,
+		})
+	}
+	if len(reqs) == 0 {
+		return
+	}
+
+	cm.logger.Warn("Placing Binance market orders for emergency closing", zap.Strings("symbols", symbols))
+
+	results := cm.hedgeStrategy.binanceStrategy.client.PlaceMarketOrders(ctx, reqs)
+	for i, r := range results {
+		symbol := symbols[i]
+		if r.Err != nil {
+			cm.logger.Error("Failed to place emergency Binance order",
+				zap.String("symbol", symbol),
+				zap.Error(r.Err),
+			)
+			continue
+		}
+
+		value := r.Order.ExecutedQty * r.Order.Price
+		cm.orderMonitor.recordJournal(&journal.Entry{
+			Venue:   "binance",
+			Symbol:  symbol,
+			Side:    r.Request.Side,
+			Size:    value,
+			Price:   r.Order.Price,
+			Fee:     cm.positionManager.Fee("binance", LiquidityTaker, value),
+			OrderID: fmt.Sprintf("%d", r.Order.OrderID),
+			CycleID: cm.hedgeStrategy.cycles.Current(symbol),
+			Reason:  "EMERGENCY",
+		})
+		// 成交均价未知时等待下一轮仓位同步
+		cm.positionManager.ApplyFill("binance", symbol, r.Request.Side, LiquidityTaker, value, r.Order.Price)
+	}
+}
+
+// placeLighterMarketOrders 以一批市价单平掉Lighter仓位（紧急平仓用）。
+// 按交易所实际持仓下只减仓单，本地仓位仅用于选择币种和核对
+func (cm *ClosingManager) placeLighterMarketOrders(ctx context.Context, positions map[string]*Position) {
+	var symbols []string
+	for symbol, pos := range positions {
+		if pos.Size != 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		return
+	}
+
+	cm.logger.Warn("Placing Lighter market orders for emergency closing", zap.Strings("symbols", symbols))
+
+	// 部分提交失败时已提交的平仓单照常记账
+	closed, err := cm.hedgeStrategy.lighterStrategy.closePositions(ctx, symbols)
+	if err != nil {
+		cm.logger.Error("Failed to place emergency Lighter orders",
+			zap.Strings("symbols", symbols),
+			zap.Int("submitted", len(closed)),
+			zap.Error(err),
+		)
+	}
+
+	for _, symbol := range symbols {
+		c, ok := closed[symbol]
+		if !ok {
+			continue
+		}
+		pos := c.Position
+		local := positions[symbol].Size
+
+		closeSide := "SELL"
+		if pos.Size < 0 {
+			closeSide = "BUY"
+		}
+		if math.Abs(pos.Size-local) > positionEpsilon {
+			cm.logger.Warn("Lighter position differs from local position",
+				zap.String("symbol", symbol),
+				zap.Float64("exchange_size", pos.Size),
+				zap.Float64("local_size", local),
+			)
+		}
+
+		value := math.Abs(pos.Value)
+		price := value / math.Abs(pos.Size)
+		cm.orderMonitor.recordJournal(&journal.Entry{
+			Venue:   "lighter",
+			Symbol:  symbol,
+			Side:    closeSide,
+			Size:    value,
+			Price:   price,
+			Fee:     cm.positionManager.Fee("lighter", LiquidityTaker, value),
+			OrderID: c.Tx.GetTxHash(),
+			CycleID: cm.hedgeStrategy.cycles.Current(symbol),
+			Reason:  "EMERGENCY",
+		})
+		cm.positionManager.ApplyFill("lighter", symbol, closeSide, LiquidityTaker, value, price)
+	}
+}
+
+// PlaceLighterClosingOrder 在Lighter下平仓订单（由OrderMonitor调用）
+func (cm *ClosingManager) PlaceLighterClosingOrder(
+	ctx context.Context,
+	symbol, side string,
+	size float64,
+) error {
+	cm.logger.Info("Placing Lighter closing order",
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("usdt_amount", size),
+	)
+
+	// 将USDC金额按USDC/USDT汇率转换为USDT金额
+	usdtAmount := int64(cm.hedgeStrategy.lighterNotional(symbol, size))
+	leverage := cm.hedgeStrategy.lighterLeverage(symbol)
+
+	_, err := cm.hedgeStrategy.lighterStrategy.placeMarketOrder(ctx, symbol, side, usdtAmount, leverage)
+	return err
+}
+
+// ensurePosition 确保仓位结构存在
+func (cm *ClosingManager) ensurePosition(positions *ExchangePositions, symbol string) *Position {
+	if pos, exists := positions.Positions[symbol]; exists {
+		return pos
+	}
+
+	// 如果不存在，创建空仓位
+	newPos := &Position{
+		Symbol:   symbol,
+		Size:     0,
+		Value:    0,
+		Leverage: 0,
+	}
+	positions.Positions[symbol] = newPos
+	return newPos
+}
+
+// allPositionsZero 检查是否所有仓位都为0
+func (cm *ClosingManager) allPositionsZero(binancePos, lighterPos *ExchangePositions) bool {
+	// 检查Binance仓位
+	for _, pos := range binancePos.Positions {
+		if pos.Size != 0 {
+			return false
+		}
+	}
+
+	// 检查Lighter仓位
+	for _, pos := range lighterPos.Positions {
+		if pos.Size != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetTotalPositionValue 获取总仓位价值
+func (cm *ClosingManager) GetTotalPositionValue() (float64, float64) {
+	binancePositions := cm.positionManager.GetBinancePositions()
+	lighterPositions := cm.positionManager.GetLighterPositions()
+
+	var binanceTotal, lighterTotal float64
+
+	for _, pos := range binancePositions.Positions {
+		binanceTotal += math.Abs(pos.Value)
+	}
+
+	for _, pos := range lighterPositions.Positions {
+		lighterTotal += math.Abs(pos.Value)
+	}
+
+	return binanceTotal, lighterTotal
+}
+
+// CheckClosingConditions 检查平仓条件
+func (cm *ClosingManager) CheckClosingConditions(config *DynamicHedgeConfig) (bool, string) {
+	riskStatus := cm.hedgeStrategy.riskManager.CheckRisk(cm.positionManager)
+
+	// 1. 检查是否达到紧急平仓条件
+	if riskStatus.Action == RiskActionEmergencyClose {
+		return true, riskStatus.Reason
+	}
+
+	// 2. 检查是否已停止开仓超过等待时间 (或平仓阶段尚未结束)
+	if riskStatus.Action == RiskActionStartClosing {
+		return true, riskStatus.Reason
+	}
+
+	return false, "closing conditions not met"
+}
@@ -0,0 +1,275 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+)
+
+// EventCollateralImbalance 两个交易所的可用保证金偏差过大，附带再平衡计划
+const EventCollateralImbalance = "COLLATERAL_IMBALANCE"
+
+// RebalancePlan 跨交易所保证金再平衡计划：从可用保证金多的交易所转出 Amount 到另一个交易所，使两边相等
+type RebalancePlan struct {
+	LighterFree  float64   `json:"lighter_free"`  // Lighter可用保证金
+	BinanceFree  float64   `json:"binance_free"`  // Binance可用稳定币
+	DriftPercent float64   `json:"drift_percent"` // 两边差额占合计的比例 (%)
+	Needed       bool      `json:"needed"`        // 偏差超过阈值，需要再平衡
+	From         string    `json:"from,omitempty"`
+	To           string    `json:"to,omitempty"`
+	Asset        string    `json:"asset,omitempty"`
+	Amount       float64   `json:"amount,omitempty"`
+	Executed     bool      `json:"executed"`                // 已自动提现 (仅Binance转出方向)
+	WithdrawID   string    `json:"withdraw_id,omitempty"`   // Binance提现ID
+	ExecuteError string    `json:"execute_error,omitempty"` // 自动提现失败原因
+	Halted       bool      `json:"halted"`                  // 提现失败后暂停自动提现，需人工确认后恢复
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CollateralRebalancer 跨交易所保证金再平衡助手：按 RebalanceCheckInterval 对比两个交易所的可用保证金，
+// 差额超过合计的 RebalanceThresholdPercent 时生成再平衡计划，告警并发布 COLLATERAL_IMBALANCE 事件 (计划未变时按
+// RebalanceAlertInterval 重复告警)。启用 RebalanceAutoWithdraw 时，Binance转出方向自动提现到配置的Lighter充值地址；
+// Lighter转出方向只告警，需人工提现
+type CollateralRebalancer struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+
+	mu           sync.RWMutex
+	plan         *RebalancePlan
+	lastAlertAt  time.Time
+	lastAlerted  float64 // 上次告警的金额，金额变化超过10%时立即告警
+	lastExecuted time.Time
+	haltErr      string // 提现失败原因，非空时不再自动提现，直到 ClearHalt
+}
+
+// NewCollateralRebalancer 创建保证金再平衡助手
+func NewCollateralRebalancer(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *CollateralRebalancer {
+	return &CollateralRebalancer{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("collateral-rebalancer"),
+	}
+}
+
+// Run 立即检查一次，之后按 RebalanceCheckInterval 检查，阻塞直到ctx取消或stop关闭
+func (cr *CollateralRebalancer) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(cr.config.RebalanceCheckInterval)
+	defer ticker.Stop()
+
+	cr.logger.Info("Collateral rebalancer started",
+		zap.Duration("interval", cr.config.RebalanceCheckInterval),
+		zap.Float64("threshold_percent", cr.config.RebalanceThresholdPercent),
+		zap.Bool("auto_withdraw", cr.config.RebalanceAutoWithdraw),
+	)
+
+	for {
+		cr.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Plan 返回最近一次检查生成的计划，尚未检查成功时返回nil
+func (cr *CollateralRebalancer) Plan() *RebalancePlan {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	if cr.plan == nil {
+		return nil
+	}
+	plan := *cr.plan
+	return &plan
+}
+
+// ClearHalt 人工确认提现失败已处理 (资金位置已核对)，恢复自动提现，返回之前的失败原因 (未暂停时为空)
+func (cr *CollateralRebalancer) ClearHalt() string {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	reason := cr.haltErr
+	cr.haltErr = ""
+	if cr.plan != nil {
+		cr.plan.Halted = false
+	}
+	if reason != "" {
+		cr.logger.Warn("Collateral auto withdraw resumed", zap.String("previous_error", reason))
+	}
+	return reason
+}
+
+// check 查询两个交易所的可用保证金并更新计划，查询失败时保留上一次的计划
+func (cr *CollateralRebalancer) check(ctx context.Context) {
+	lighterFree, err := cr.hedgeStrategy.lighterStrategy.client.GetAvailableBalance(ctx)
+	if err != nil {
+		cr.logger.Warn("Failed to get Lighter available balance", zap.Error(err))
+		return
+	}
+	binanceFree, err := cr.hedgeStrategy.binanceStrategy.client.GetFreeStablecoins(ctx)
+	if err != nil {
+		cr.logger.Warn("Failed to get Binance free balance", zap.Error(err))
+		return
+	}
+
+	plan := planRebalance(lighterFree, binanceFree, cr.config.RebalanceThresholdPercent, cr.config.RebalanceMinAmount)
+	plan.UpdatedAt = time.Now()
+	if plan.Needed {
+		plan.Asset = cr.config.RebalanceAsset
+		cr.handle(ctx, plan)
+	} else {
+		cr.mu.Lock()
+		resolved := cr.plan != nil && cr.plan.Needed
+		cr.lastAlerted = 0
+		cr.mu.Unlock()
+		if resolved {
+			cr.logger.Info("Collateral back in balance",
+				zap.Float64("lighter_free", lighterFree),
+				zap.Float64("binance_free", binanceFree),
+			)
+		}
+	}
+
+	cr.mu.Lock()
+	cr.plan = plan
+	cr.mu.Unlock()
+}
+
+// planRebalance 按两边可用保证金生成计划：差额占合计的比例超过 thresholdPercent，
+// 且转出金额 (差额的一半，向下取整) 不低于 minAmount 时需要再平衡
+func planRebalance(lighterFree, binanceFree, thresholdPercent, minAmount float64) *RebalancePlan {
+	plan := &RebalancePlan{LighterFree: lighterFree, BinanceFree: binanceFree}
+
+	total := lighterFree + binanceFree
+	if total <= 0 {
+		return plan
+	}
+	plan.DriftPercent = math.Abs(lighterFree-binanceFree) / total * 100
+
+	amount := math.Floor(math.Abs(lighterFree-binanceFree) / 2)
+	if plan.DriftPercent <= thresholdPercent || amount < minAmount || amount <= 0 {
+		return plan
+	}
+
+	plan.Needed = true
+	plan.Amount = amount
+	if binanceFree > lighterFree {
+		plan.From, plan.To = "binance", "lighter"
+	} else {
+		plan.From, plan.To = "lighter", "binance"
+	}
+	return plan
+}
+
+// handle 告警并按配置执行计划：金额较上次告警变化超过10%或距上次告警超过 RebalanceAlertInterval 时告警
+func (cr *CollateralRebalancer) handle(ctx context.Context, plan *RebalancePlan) {
+	if cr.config.RebalanceAutoWithdraw && plan.From == "binance" {
+		cr.execute(ctx, plan)
+	}
+
+	cr.mu.Lock()
+	changed := cr.lastAlerted == 0 || math.Abs(plan.Amount-cr.lastAlerted) > cr.lastAlerted*0.1
+	due := changed || plan.Executed || plan.ExecuteError != "" || time.Since(cr.lastAlertAt) >= cr.config.RebalanceAlertInterval
+	if due {
+		cr.lastAlertAt = time.Now()
+		cr.lastAlerted = plan.Amount
+	}
+	cr.mu.Unlock()
+	if !due {
+		return
+	}
+
+	cr.logger.Warn("Collateral imbalance, rebalancing needed",
+		zap.String("from", plan.From),
+		zap.String("to", plan.To),
+		zap.String("asset", plan.Asset),
+		zap.Float64("amount", plan.Amount),
+		zap.Float64("lighter_free", plan.LighterFree),
+		zap.Float64("binance_free", plan.BinanceFree),
+		zap.Float64("drift_percent", plan.DriftPercent),
+		zap.Bool("executed", plan.Executed),
+		zap.String("withdraw_id", plan.WithdrawID),
+		zap.String("execute_error", plan.ExecuteError),
+	)
+	cr.hedgeStrategy.bus.Publish("collateral-rebalancer", EventCollateralImbalance, map[string]interface{}{
+		"lighter_free":  plan.LighterFree,
+		"binance_free":  plan.BinanceFree,
+		"drift_percent": plan.DriftPercent,
+		"from":          plan.From,
+		"to":            plan.To,
+		"asset":         plan.Asset,
+		"amount":        plan.Amount,
+		"executed":      plan.Executed,
+		"withdraw_id":   plan.WithdrawID,
+		"execute_error": plan.ExecuteError,
+	})
+}
+
+// execute 从Binance提现到Lighter充值地址，金额不超过 RebalanceMaxWithdrawAmount；合约市场先划转到现货钱包。
+// 提现到账前可用保证金不会变化，距上次尝试不足 RebalanceAlertInterval 时不重复提现 (失败时同样等待)。
+// 提现失败时把已划转的金额转回合约钱包，并暂停自动提现直到人工 ClearHalt，避免反复划转把合约保证金转空
+func (cr *CollateralRebalancer) execute(ctx context.Context, plan *RebalancePlan) {
+	cr.mu.Lock()
+	haltErr := cr.haltErr
+	cr.mu.Unlock()
+	if haltErr != "" {
+		plan.Halted = true
+		plan.ExecuteError = haltErr
+		return
+	}
+
+	if !cr.lastExecuted.IsZero() && time.Since(cr.lastExecuted) < cr.config.RebalanceAlertInterval {
+		return
+	}
+	cr.lastExecuted = time.Now()
+
+	client := cr.hedgeStrategy.binanceStrategy.client
+	amount := math.Min(plan.Amount, cr.config.RebalanceMaxWithdrawAmount)
+
+	if client.Market() == binance.MarketFutures {
+		if _, err := client.TransferToSpot(ctx, plan.Asset, amount); err != nil {
+			plan.ExecuteError = err.Error()
+			cr.logger.Error("Failed to move collateral to spot wallet for withdrawal", zap.Error(err))
+			return
+		}
+	}
+
+	id, err := client.Withdraw(ctx, plan.Asset, cr.config.RebalanceWithdrawNetwork, cr.config.RebalanceDepositAddress, amount)
+	if err != nil {
+		cr.logger.Error("Failed to withdraw collateral to Lighter", zap.Error(err))
+		haltErr = "withdraw failed: " + err.Error()
+
+		if client.Market() == binance.MarketFutures {
+			if _, backErr := client.TransferToFutures(ctx, plan.Asset, amount); backErr != nil {
+				cr.logger.Error("Failed to move collateral back to futures wallet",
+					zap.String("asset", plan.Asset),
+					zap.Float64("amount", amount),
+					zap.Error(backErr),
+				)
+				haltErr += fmt.Sprintf("; %.2f %s left in spot wallet: %v", amount, plan.Asset, backErr)
+			}
+		}
+
+		cr.mu.Lock()
+		cr.haltErr = haltErr
+		cr.mu.Unlock()
+		plan.Halted = true
+		plan.ExecuteError = haltErr
+		return
+	}
+
+	plan.Amount = amount
+	plan.Executed = true
+	plan.WithdrawID = id
+}
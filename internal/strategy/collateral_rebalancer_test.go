@@ -0,0 +1,70 @@
+package strategy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRebalancerHaltsAfterFailedWithdraw(t *testing.T) {
+	tests := []struct {
+		name         string
+		transferBack error   // 转回合约钱包的错误
+		spotLeft     float64 // 失败后留在现货钱包的金额
+	}{
+		{name: "moved back to futures"},
+		{name: "move back fails", transferBack: errors.New("transfer unavailable"), spotLeft: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHedge(t)
+			h.binance.equity = 20000
+			config := &DynamicHedgeConfig{
+				RebalanceThresholdPercent:  10,
+				RebalanceMinAmount:         10,
+				RebalanceAsset:             "USDC",
+				RebalanceAutoWithdraw:      true,
+				RebalanceMaxWithdrawAmount: 1000,
+			}
+			cr := NewCollateralRebalancer(h.DynamicHedgeStrategy, config)
+
+			h.binance.failNext("Withdraw", errors.New("address not whitelisted"))
+			h.binance.failNext("TransferToFutures", tt.transferBack)
+			cr.check(t.Context())
+
+			plan := cr.Plan()
+			if plan == nil || !plan.Halted || plan.Executed {
+				t.Fatalf("plan after failed withdraw = %+v, want halted", plan)
+			}
+			if got := h.binance.spotBalance(); got != tt.spotLeft {
+				t.Fatalf("spot balance = %v, want %v", got, tt.spotLeft)
+			}
+			if tt.transferBack != nil && !strings.Contains(plan.ExecuteError, "left in spot wallet") {
+				t.Fatalf("execute error = %q, want amount left in spot wallet", plan.ExecuteError)
+			}
+
+			// 暂停期间不再划转
+			cr.check(t.Context())
+			if n := h.binance.count("TransferToSpot"); n != 1 {
+				t.Fatalf("transfers to spot while halted = %d, want 1", n)
+			}
+			if plan := cr.Plan(); !plan.Halted {
+				t.Fatalf("plan while halted = %+v, want halted", plan)
+			}
+
+			// 人工恢复后重新提现
+			if previous := cr.ClearHalt(); previous == "" {
+				t.Fatal("ClearHalt returned no previous error")
+			}
+			cr.check(t.Context())
+			plan = cr.Plan()
+			if !plan.Executed || plan.Halted || plan.WithdrawID == "" {
+				t.Fatalf("plan after resume = %+v, want executed", plan)
+			}
+			if got := h.binance.spotBalance(); got != tt.spotLeft {
+				t.Fatalf("spot balance after withdraw = %v, want %v", got, tt.spotLeft)
+			}
+		})
+	}
+}
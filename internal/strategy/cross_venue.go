@@ -0,0 +1,130 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/elliottech/lighter-go/types/txtypes"
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/lighter"
+)
+
+// BinanceLeg 已挂出但尚未确认成交的Binance限价单
+type BinanceLeg struct {
+	OrderID int64   `json:"order_id"`
+	Side    string  `json:"side"`   // BUY / SELL
+	Size    float64 `json:"size"`   // 需要成交的总金额 (USDC)
+	Filled  float64 `json:"filled"` // 已撤销或过期的订单累计成交金额 (USDC)
+}
+
+// clone 返回副本，nil 时返回 nil
+func (l *BinanceLeg) clone() *BinanceLeg {
+	if l == nil {
+		return nil
+	}
+	c := *l
+	return &c
+}
+
+// placeCrossVenueLegs 在两个交易所分别下单：Lighter市价 (Taker)，Binance限价 (Maker)，返回Binance订单ID。
+// size 为每侧下单金额 (USDT/USDC)，Lighter按整数USDT下单，不接受小数金额。
+// Lighter已成交而Binance下单失败时以反向市价单撤回Lighter一侧，避免留下单边敞口
+func placeCrossVenueLegs(
+	ctx context.Context,
+	lighterStrategy *LighterStrategy,
+	binanceStrategy *BinanceStrategy,
+	symbol, lighterSide, binanceSide string,
+	size float64,
+	leverage int,
+	spreadPercent float64,
+) (int64, error) {
+	if size <= 0 || size != math.Trunc(size) {
+		return 0, fmt.Errorf("%s 下单金额必须为正整数 USDT: %v", symbol, size)
+	}
+
+	lighterTx, err := lighterStrategy.placeMarketOrder(ctx, symbol, lighterSide, int64(size), leverage)
+	if err != nil {
+		return 0, fmt.Errorf("lighter %s %s下单失败: %w", symbol, lighterSide, err)
+	}
+
+	binanceOrderID, err := binanceStrategy.placeMakerOrder(ctx, symbol, binanceSide, size, spreadPercent)
+	if err != nil {
+		lighterStrategy.logger.Error("Binance leg failed, reverting lighter leg",
+			zap.String("symbol", symbol),
+			zap.String("lighter_side", lighterSide),
+			zap.String("lighter_tx_hash", lighterTx.GetTxHash()),
+			zap.Error(err),
+		)
+		if _, revertErr := lighterStrategy.revertMarketOrder(ctx, lighterTx); revertErr != nil {
+			return 0, fmt.Errorf("binance %s %s下单失败: %w; 撤回lighter仓位失败: %v", symbol, binanceSide, err, revertErr)
+		}
+		return 0, fmt.Errorf("binance %s %s下单失败，已撤回lighter仓位: %w", symbol, binanceSide, err)
+	}
+
+	lighterStrategy.logger.Info("Cross-venue legs placed",
+		zap.String("symbol", symbol),
+		zap.String("lighter_side", lighterSide),
+		zap.String("lighter_tx_hash", lighterTx.GetTxHash()),
+		zap.String("binance_side", binanceSide),
+		zap.Int64("binance_order_id", binanceOrderID),
+	)
+
+	return binanceOrderID, nil
+}
+
+// trackBinanceLeg 查询Binance订单的成交情况，完全成交时返回true。
+// 订单被撤销、过期或拒绝时累计其成交金额，并按剩余金额重新挂单 (更新 leg.OrderID)
+func trackBinanceLeg(ctx context.Context, binanceStrategy *BinanceStrategy, symbol string, leg *BinanceLeg, spreadPercent float64) (bool, error) {
+	status, err := binanceStrategy.client.GetOrder(ctx, binanceStrategy.pair(symbol), leg.OrderID)
+	if err != nil {
+		return false, err
+	}
+
+	switch status.Status {
+	case "FILLED":
+		return true, nil
+	case "NEW", "PARTIALLY_FILLED", "PENDING_NEW":
+		return false, nil
+	}
+
+	leg.Filled += status.ExecutedQty * status.Price
+	remaining := leg.Size - leg.Filled
+	if remaining <= 0 {
+		return true, nil
+	}
+
+	orderID, err := binanceStrategy.placeMakerOrder(ctx, symbol, leg.Side, remaining, spreadPercent)
+	if err != nil {
+		return false, fmt.Errorf("binance %s 剩余 %.2f 重新挂单失败: %w", symbol, remaining, err)
+	}
+
+	binanceStrategy.logger.Info("Binance leg re-placed",
+		zap.String("symbol", symbol),
+		zap.String("side", leg.Side),
+		zap.Int64("previous_order_id", leg.OrderID),
+		zap.String("previous_status", status.Status),
+		zap.Int64("order_id", orderID),
+		zap.Float64("remaining", remaining),
+	)
+	leg.OrderID = orderID
+	return false, nil
+}
+
+// revertMarketOrder 以相同数量的反向市价单撤回已成交的Lighter市价单
+func (s *LighterStrategy) revertMarketOrder(ctx context.Context, tx *txtypes.L2CreateOrderTxInfo) (*txtypes.L2CreateOrderTxInfo, error) {
+	return s.client.PlaceMarketOrder(ctx, &lighter.MarketOrderRequest{
+		MarketIndex: tx.MarketIndex,
+		BaseAmount:  tx.BaseAmount,
+		IsAsk:       1 - tx.IsAsk,
+	})
+}
+
+// oppositeSide 返回相反方向
+func oppositeSide(side string) string {
+	if side == "BUY" {
+		return "SELL"
+	}
+	return "BUY"
+}
@@ -0,0 +1,203 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxClosedCycles 保留的已结束周期数量
+const maxClosedCycles = 50
+
+// cycleFlatNotional 两个交易所的仓位价值都低于该值 (USD) 时视为已平仓，忽略现货余额的零头
+const cycleFlatNotional = 1.0
+
+// HedgeCycle 对冲周期：币种从空仓开始开仓，到两个交易所的仓位都平掉为一个周期。
+// 期间的Binance Maker单、Lighter对冲、平衡调整和平仓都带同一个周期ID，用于在日志、成交日志和管理API中串联
+type HedgeCycle struct {
+	ID        string    `json:"id"`
+	Symbol    string    `json:"symbol"`
+	StartedAt time.Time `json:"started_at"`
+	ClosedAt  time.Time `json:"closed_at,omitempty"` // 零值为进行中
+	Orders    []string  `json:"orders"`              // 关联的订单ID和交易哈希 (按时间顺序)
+}
+
+// CycleTracker 对冲周期管理：每个币种同一时间最多一个进行中的周期
+type CycleTracker struct {
+	mu     sync.Mutex
+	active map[string]*HedgeCycle // symbol -> 进行中的周期
+	closed []*HedgeCycle          // 最近结束的周期 (最多 maxClosedCycles 个)
+	seq    int
+	logger *zap.Logger
+}
+
+// NewCycleTracker 创建对冲周期管理
+func NewCycleTracker(logger *zap.Logger) *CycleTracker {
+	return &CycleTracker{
+		active: make(map[string]*HedgeCycle),
+		logger: logger,
+	}
+}
+
+// ID 返回币种进行中的周期ID，没有时开始新周期
+func (ct *CycleTracker) ID(symbol string) string {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if c, ok := ct.active[symbol]; ok {
+		return c.ID
+	}
+
+	ct.seq++
+	now := time.Now()
+	c := &HedgeCycle{
+		ID:        fmt.Sprintf("%s-%s-%d", symbol, now.UTC().Format("20060102T150405"), ct.seq),
+		Symbol:    symbol,
+		StartedAt: now,
+	}
+	ct.active[symbol] = c
+
+	ct.logger.Info("Hedge cycle started", zap.String("cycle_id", c.ID), zap.String("symbol", symbol))
+	return c.ID
+}
+
+// Current 返回币种进行中的周期ID，没有时返回空字符串
+func (ct *CycleTracker) Current(symbol string) string {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if c, ok := ct.active[symbol]; ok {
+		return c.ID
+	}
+	return ""
+}
+
+// Resume 恢复重启前进行中的周期 (订单恢复时调用)，币种已有进行中的周期时忽略
+func (ct *CycleTracker) Resume(symbol, id string) {
+	if id == "" {
+		return
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if _, ok := ct.active[symbol]; ok {
+		return
+	}
+	ct.active[symbol] = &HedgeCycle{ID: id, Symbol: symbol, StartedAt: time.Now()}
+	ct.logger.Info("Hedge cycle resumed", zap.String("cycle_id", id), zap.String("symbol", symbol))
+}
+
+// Link 把订单ID或交易哈希关联到进行中的周期
+func (ct *CycleTracker) Link(id, orderID string) {
+	if id == "" || orderID == "" {
+		return
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	for _, c := range ct.active {
+		if c.ID == id {
+			c.Orders = append(c.Orders, orderID)
+			return
+		}
+	}
+}
+
+// Close 结束币种进行中的周期，返回结束的周期 (没有进行中的周期时返回nil)
+func (ct *CycleTracker) Close(symbol string) *HedgeCycle {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	c, ok := ct.active[symbol]
+	if !ok {
+		return nil
+	}
+	delete(ct.active, symbol)
+	c.ClosedAt = time.Now()
+
+	ct.closed = append(ct.closed, c)
+	if extra := len(ct.closed) - maxClosedCycles; extra > 0 {
+		ct.closed = ct.closed[extra:]
+	}
+
+	closed := *c
+	closed.Orders = append([]string(nil), c.Orders...)
+	return &closed
+}
+
+// Cycles 返回进行中和最近结束的周期，按开始时间排序
+func (ct *CycleTracker) Cycles() []HedgeCycle {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	cycles := make([]HedgeCycle, 0, len(ct.active)+len(ct.closed))
+	for _, c := range ct.closed {
+		cycles = append(cycles, *c)
+	}
+	for _, c := range ct.active {
+		cycles = append(cycles, *c)
+	}
+	for i := range cycles {
+		cycles[i].Orders = append([]string(nil), cycles[i].Orders...)
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].StartedAt.Before(cycles[j].StartedAt)
+	})
+	return cycles
+}
+
+// activeSymbols 返回有进行中周期的币种
+func (ct *CycleTracker) activeSymbols() []string {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	symbols := make([]string, 0, len(ct.active))
+	for symbol := range ct.active {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// closeFlatCycles 结束已平仓的周期：两个交易所的仓位都已平掉，且该币种没有活跃订单或进行中的分片执行
+func (s *DynamicHedgeStrategy) closeFlatCycles() {
+	lighter := s.positionManager.positionsSnapshot("lighter")
+	binance := s.positionManager.positionsSnapshot("binance")
+
+	for _, symbol := range s.cycles.activeSymbols() {
+		if s.symbolBusy(symbol) {
+			continue
+		}
+		if math.Abs(lighter[symbol].Value) >= cycleFlatNotional || math.Abs(binance[symbol].Value) >= cycleFlatNotional {
+			continue
+		}
+
+		c := s.cycles.Close(symbol)
+		if c == nil {
+			continue
+		}
+		duration := c.ClosedAt.Sub(c.StartedAt)
+		s.logger.Info("Hedge cycle closed",
+			zap.String("cycle_id", c.ID),
+			zap.String("symbol", symbol),
+			zap.Duration("duration", duration),
+			zap.Int("orders", len(c.Orders)),
+		)
+		s.bus.Publish("dynamic-hedge", EventCycleClosed, map[string]interface{}{
+			"cycle_id":    c.ID,
+			"symbol":      symbol,
+			"duration_ms": duration.Milliseconds(),
+			"orders":      len(c.Orders),
+		})
+	}
+}
+
+// GetHedgeCycles 获取进行中和最近结束的对冲周期
+func (s *DynamicHedgeStrategy) GetHedgeCycles() []HedgeCycle {
+	return s.cycles.Cycles()
+}
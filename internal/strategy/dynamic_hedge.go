@@ -0,0 +1,1542 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"cs-projects-backpack/pkg/eventbus"
+	"cs-projects-backpack/pkg/journal"
+	"cs-projects-backpack/pkg/lighter"
+	"cs-projects-backpack/pkg/logger"
+	"cs-projects-backpack/pkg/pricefeed"
+	"cs-projects-backpack/pkg/retry"
+)
+
+// subsystemStopTimeout Stop 等待后台子系统退出的最长时间
+const subsystemStopTimeout = 10 * time.Second
+
+// DynamicHedgeStrategy 动态对冲策略
+type DynamicHedgeStrategy struct {
+	lighterStrategy      *LighterStrategy
+	binanceStrategy      *BinanceStrategy
+	symbols              *SymbolUniverse // 可交易币种
+	positionManager      *PositionManager
+	orderManager         *OrderManager
+	orderMonitor         *OrderMonitor
+	riskManager          *RiskManager
+	openingManager       *OpeningManager
+	closingManager       *ClosingManager
+	statsManager         *TradingStatsManager
+	hedgeBalancer        *HedgeBalancer
+	fastExecutionManager *FastExecutionManager
+	flattenManager       *FlattenManager
+	protectionManager    *ProtectionManager
+	spreadMonitor        *SpreadMonitor        // 价差触发开仓 (nil为按固定间隔开仓)
+	liquidationMonitor   *LiquidationMonitor   // 强平价监控 (nil为不启用)
+	reconciler           *Reconciler           // 仓位对账 (nil为直接以交易所持仓为准)
+	volatilityTracker    *VolatilityTracker    // 滚动波动率 (nil为不启用)
+	shadowTrader         *ShadowTrader         // 影子模式 (nil为不启用)
+	collateralRebalancer *CollateralRebalancer // 跨交易所保证金再平衡 (nil为不启用)
+	usdcRate             *USDCRateTracker      // USDC/USDT汇率 (nil为按1:1换算)
+	fundingTracker       *FundingTracker       // 资金费估算 (nil为不启用)
+	cycles               *CycleTracker         // 对冲周期ID
+	orderStore           *OrderStore           // 活跃订单持久化 (nil为不保存，启动时不恢复)
+	statsStore           *StatsStore           // 统计持久化 (nil为不保存，启动时不恢复)
+	priceFeed            *pricefeed.Feed       // 多源聚合价格 (nil为不启用)
+	slicedExecutor       SlicedExecutor
+	config               *DynamicHedgeConfig
+	logger               *zap.Logger
+
+	// 策略状态
+	isRunning     bool
+	startedAt     time.Time
+	paused        bool   // 暂停开新仓，已有订单的监控和对冲照常进行
+	currentPhase  string // OPENING, CLOSING, STOPPED
+	mu            sync.RWMutex
+	stopChan      chan struct{}
+	lastStopTime  time.Time // 本轮停止开仓的开始时间 (零值表示正常开仓)
+	lastTradeTime time.Time
+	lastEquityAt  time.Time       // 最近一次刷新账户权益的时间
+	slicing       map[string]bool // 正在分片执行的币种
+	balanceMu     sync.Mutex      // 平衡检查和调整互斥，避免监控循环与手动调整重复下单
+	cycleMu       sync.Mutex      // 监控循环的周期与关闭时的平仓互斥
+	quiesced      bool            // 关闭平仓已开始，监控循环不再执行周期 (由 cycleMu 保护)
+
+	// 主监控循环，看门狗发现卡住时取消并重新启动
+	runCtx        context.Context // Start 传入的上下文
+	loopMu        sync.Mutex
+	loopCancel    context.CancelFunc
+	loopHeartbeat heartbeat
+
+	// 后台子系统 (监控循环、看门狗等)，任一返回错误时策略异常结束
+	group errgroup.Group
+
+	// 策略异常结束 (如看门狗发现循环卡住)，引擎收到后按退出流程收尾
+	failed   chan error
+	failOnce sync.Once
+
+	// Binance现货基准余额 (symbol -> 数量)，首次同步仓位时记录
+	binanceBaseline map[string]float64
+
+	// 事件总线 (策略内部组件发布，供统计、通知和管理API订阅)
+	bus *eventbus.Bus
+}
+
+// 策略事件类型
+const (
+	EventPhaseChanged  = "PHASE_CHANGED"
+	EventTradeRecorded = "TRADE_RECORDED"
+
+	EventOrderFilled       = "ORDER_FILLED"             // 订单成交 (含部分成交)
+	EventOrderCancelled    = "ORDER_CANCELLED"          // 订单撤销
+	EventHedgeExecuted     = "HEDGE_EXECUTED"           // 对冲单完成
+	EventHedgeFailed       = "HEDGE_FAILED"             // 对冲单失败，留下单边敞口
+	EventDelayExceeded     = "EXECUTION_DELAY_EXCEEDED" // 对冲完成但执行延迟超过 MaxExecutionDelay
+	EventRiskActionChanged = "RISK_ACTION_CHANGED"      // 风控行动变化
+	EventHedgeImbalance    = "HEDGE_IMBALANCE"          // 两个交易所仓位不平衡
+	EventBalanceAdjusted   = "BALANCE_ADJUSTED"         // 仓位平衡调整完成
+
+	EventLiquidationWarning  = "LIQUIDATION_WARNING"  // 仓位标记价格接近强平价格
+	EventPositionDiscrepancy = "POSITION_DISCREPANCY" // 本地仓位与交易所持仓不一致
+	EventWalletTransfer      = "WALLET_TRANSFER"      // Binance现货钱包划转到合约钱包
+	EventCycleClosed         = "CYCLE_CLOSED"         // 对冲周期结束 (两个交易所的仓位都已平掉)
+)
+
+// DynamicHedgeConfig 动态对冲配置
+type DynamicHedgeConfig struct {
+	OrderSize         float64       // 每次下单规模 (1000U)
+	MaxLeverage       float64       // 最大杠杆率 (3倍停止开仓)
+	EmergencyLeverage float64       // 紧急平仓杠杆率 (5倍)
+	StopDuration      time.Duration // 停止开仓后等待时间 (10分钟)
+	EquityRefresh     time.Duration // 账户权益刷新间隔，用于计算实际杠杆率 (0为只在权益未知时查询)
+	MonitorInterval   time.Duration // 监控间隔
+	SpreadPercent     float64       // Binance价差百分比
+
+	// 回撤风控配置
+	StartingEquity           float64 // 权益高点的初始值 (0为从首次获取的实测权益开始)，权益为两个交易所实测账户权益合计
+	MaxDrawdownPercent       float64 // 回撤超过该比例停止开仓 (0为不启用)
+	EmergencyDrawdownPercent float64 // 回撤超过该比例紧急平仓 (0为不启用)
+	MaxVenueNotional         float64 // 单个交易所持仓名义金额上限 (0为不限制)
+
+	// 持续交易配置
+	ContinuousMode  bool          // 是否启用持续交易模式
+	TradingInterval time.Duration // 交易间隔 (每次交易后等待时间)
+	VolumeTarget    float64       // 日交易量目标 (USDT)
+	MaxDailyTrades  int           // 每日最大交易次数
+
+	// 交易时间窗口：窗口外不开新仓，只管理和平掉已有仓位 (空为不限制)
+	TradingWindows  []TradingWindow
+	SessionLocation *time.Location // 交易时间窗口和日终清仓的时区，nil为本地时区
+
+	// 日统计日切配置 (日交易量、日交易次数)
+	StatsLocation  *time.Location // 日切时区，nil为本地时区
+	StatsResetHour int            // 日切时刻 (0-23点)
+
+	// 对冲平衡配置
+	EnableHedgeBalancing bool          // 是否启用对冲平衡检查
+	BalanceCheckInterval time.Duration // 平衡检查间隔
+	BalanceTolerance     float64       // 平衡容差百分比
+	MinBalanceAdjust     float64       // 最小平衡调整金额
+
+	// 快速执行配置
+	EnableFastExecution       bool            // 是否启用快速执行
+	FastCheckInterval         time.Duration   // 快速检查间隔
+	MaxExecutionDelay         time.Duration   // 最大执行延迟
+	EnablePreExecution        bool            // 启用预执行 (部分成交即对冲)
+	PartialFillThreshold      float64         // 部分成交阈值
+	EnableConcurrentExecution bool            // 启用并发对冲 (多笔成交同时对冲)
+	MaxConcurrentOrders       int             // 同时处理的订单数上限
+	MaxSlippagePercent        float64         // 最大滑点百分比
+	RejectOnSlippage          bool            // 对冲滑点超限时拒绝对冲 (false为仅告警)
+	RetryPolicy               retry.Policy    // 对冲下单重试策略 (MaxAttempts为0时使用默认策略)
+	DelayBuckets              []time.Duration // 执行延迟直方图分桶上界 (为空时使用默认分桶)
+
+	// 日终清仓配置
+	EnableDailyFlatten bool   // 是否启用日终清仓
+	FlattenTime        string // 每日清仓时间 (HH:MM)
+	FlattenResumeTime  string // 恢复开仓时间 (HH:MM)
+
+	// 分片执行配置
+	EnableTWAP       bool          // 订单相对盘口过大时使用分片执行
+	ExecutionAlgo    string        // 分片执行算法: twap, vwap
+	VWAPLookback     time.Duration // VWAP平均成交量回看区间
+	TWAPWindow       time.Duration // 分片执行时间窗口
+	TWAPSlices       int           // 子订单数量
+	TWAPDepthPercent float64       // 统计盘口深度的价格范围 (%)
+	TWAPDepthRatio   float64       // 订单金额超过深度的该比例时启用TWAP
+
+	// 止损止盈配置
+	EnableProtectiveOrders bool    // 开仓成交后在Binance挂止损/止盈保护单
+	StopLossPercent        float64 // 止损触发价相对开仓价的偏移 (%)
+	TakeProfitPercent      float64 // 止盈触发价相对开仓价的偏移 (%)
+
+	// 挂单超时配置
+	MaxOrderAge        time.Duration // Maker单最长挂单时间，超时撤单 (0为不限制)
+	RepriceStaleOrders bool          // 超时撤单后按最新价格重挂剩余金额
+
+	// 追价配置
+	EnableOrderChasing    bool          // 市场价远离挂单价时撤单重挂
+	ChaseThresholdPercent float64       // 市场价偏离挂单价超过该比例时追价 (%)
+	ChaseInterval         time.Duration // 同一币种两次追价检查的最小间隔
+	MaxChases             int           // 单笔订单最多重挂次数 (0为不限制)
+
+	// 余额检查
+	EnableBalanceCheck    bool    // 开仓前检查两个交易所的可用保证金，不足时跳过本轮开仓
+	EnableAutoTransfer    bool    // Binance合约钱包保证金不足时从现货钱包划转
+	AutoTransferAsset     string  // 划转的资产
+	AutoTransferMinAmount float64 // 单次最少划转金额，减少频繁的小额划转
+	AutoTransferMaxAmount float64 // 单次最多划转金额 (0为不限制)
+
+	// 跨交易所保证金再平衡
+	EnableCollateralRebalance  bool          // 对比两个交易所的可用保证金，偏差过大时生成再平衡计划并告警
+	RebalanceCheckInterval     time.Duration // 检查间隔
+	RebalanceThresholdPercent  float64       // 两边差额占合计的比例超过该值时再平衡 (%)
+	RebalanceMinAmount         float64       // 转出金额低于该值时忽略
+	RebalanceAlertInterval     time.Duration // 计划未变时重复告警的间隔，也是两次自动提现的最小间隔
+	RebalanceAsset             string        // 转移的资产
+	RebalanceAutoWithdraw      bool          // Binance转出方向自动提现到Lighter充值地址
+	RebalanceWithdrawNetwork   string        // 提现网络 (如 ARBITRUM)
+	RebalanceDepositAddress    string        // Lighter充值地址 (需在Binance提现白名单中)
+	RebalanceMaxWithdrawAmount float64       // 单次最多自动提现金额
+
+	// USDC/USDT汇率
+	EnableUSDCRate  bool          // Binance交易对以USDC计价时，Lighter对冲金额按USDCUSDT实时汇率换算 (否则按1:1)
+	USDCRateRefresh time.Duration // 汇率刷新间隔
+
+	// 盈亏归因
+	EnableFundingAccrual   bool          // 按持仓和资金费率估算资金费，计入盈亏归因
+	FundingAccrualInterval time.Duration // 资金费累计间隔
+
+	// 流动性限额配置
+	EnableLiquiditySizing bool    // 按Lighter盘口深度限制开仓金额
+	LiquidityDepthPercent float64 // 统计盘口深度的价格范围 (%)
+	MaxLiquidityRatio     float64 // 单笔开仓金额不超过深度的该比例
+	MinOrderSize          float64 // 限额后低于该金额时跳过本轮开仓 (USDT)
+
+	// 价差触发配置
+	EnableSpreadTrigger bool          // 仅在跨交易所价差有利时开仓
+	SpreadCheckInterval time.Duration // 价差检查间隔
+	MinSpreadPercent    float64       // 扣除往返手续费后的最小价差 (%)
+
+	// 标记价格偏离保护
+	EnableDivergenceGuard  bool    // 永续合约标记价格偏离指数价格过大时暂停开仓
+	MaxMarkIndexDivergence float64 // 标记价格相对指数价格的最大偏离 (%)
+
+	// 强平价监控
+	EnableLiquidationMonitor bool    // 标记价格接近强平价格时告警
+	LiquidationAlertPercent  float64 // 标记价格距强平价格小于该比例时告警 (%)
+	LiquidationDeRiskPercent float64 // 标记价格距强平价格小于该比例时减仓该币种 (%)，0为仅告警
+
+	// 仓位对账
+	EnableReconciliation       bool    // 同步交易所持仓前与本地仓位核对
+	ReconcileToleranceNotional float64 // 差异金额低于该值时忽略 (USDT)
+	ReconcileHaltNotional      float64 // 差异金额达到该值时暂停开新仓 (USDT，0为不暂停)
+	ReconcileAutoCorrect       bool    // 确认差异后以交易所持仓修正本地仓位 (false为保留本地仓位，仅告警)
+
+	// 订单恢复
+	UntrackedOrderAction string // 启动时交易所上未记录挂单的处理方式: cancel, adopt, ignore
+
+	// 统计持久化
+	StatsSnapshotInterval time.Duration // 统计快照保存间隔
+
+	// 滚动波动率
+	EnableVolatility   bool          // 按Binance K线计算各币种已实现波动率和平均真实波幅
+	VolatilityInterval string        // K线周期，如 1m, 5m, 1h
+	VolatilityWindow   int           // 参与计算的K线数量
+	VolatilityRefresh  time.Duration // 刷新间隔
+
+	// 波动率自适应价差 (需启用滚动波动率)
+	EnableAdaptiveSpread        bool    // 按ATR缩放Binance挂单价差
+	AdaptiveSpreadReferenceATR  float64 // 基准ATR (%)，ATR等于该值时使用配置的价差
+	AdaptiveSpreadMinMultiplier float64 // 价差缩放倍数下限 (市场平静时收窄)
+	AdaptiveSpreadMaxMultiplier float64 // 价差缩放倍数上限 (市场剧烈波动时放宽)
+
+	// 影子模式：按另一组参数虚拟挂单和成交，不下单
+	EnableShadow bool
+	ShadowParams ShadowParams
+
+	// 看门狗：检查主监控循环和订单监控循环的心跳
+	EnableWatchdog       bool
+	WatchdogInterval     time.Duration // 检查间隔
+	WatchdogStallTimeout time.Duration // 超过该时长没有心跳视为卡住
+	WatchdogAction       string        // restart, exit
+	WatchdogMaxRestarts  int           // 同一循环连续重启次数上限，用尽后退出
+
+	// 各交易所手续费率，计入盈亏和盈亏平衡价差
+	Fees FeeSchedule
+	// 各交易所按近30天成交量的手续费等级表 (exchange -> 按成交量升序)，用于估算当前等级
+	FeeTiers map[string][]FeeTier
+}
+
+// Position 仓位信息
+type Position struct {
+	Symbol   string  `json:"symbol"`   // BTC, ETH
+	Size     float64 `json:"size"`     // 仓位大小 (正数做多，负数做空)
+	Value    float64 `json:"value"`    // 仓位价值 (USDT/USDC)
+	Leverage float64 `json:"leverage"` // 杠杆率
+
+	EntryPrice    float64 `json:"entry_price"`    // 开仓均价
+	MarkPrice     float64 `json:"mark_price"`     // 标记价格
+	UnrealizedPnL float64 `json:"unrealized_pnl"` // 未实现盈亏
+	RealizedPnL   float64 `json:"realized_pnl"`   // 已实现盈亏 (累计，未扣手续费)
+	Fees          float64 `json:"fees"`           // 已支付手续费 (累计)
+
+	LiquidationPrice float64 `json:"liquidation_price"` // 交易所返回的强平价格 (0为未提供)
+}
+
+// ExchangePositions 交易所仓位
+type ExchangePositions struct {
+	Exchange  string               `json:"exchange"`
+	Positions map[string]*Position `json:"positions"` // symbol -> position
+	Leverage  float64              `json:"leverage"`  // 总杠杆率
+	Equity    float64              `json:"equity"`    // 账户权益 (0表示尚未获取)
+
+	LeverageUnknown bool      `json:"leverage_unknown,omitempty"` // 尚未获取到有效权益，杠杆率无法计算 (Leverage 为0)
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// PositionManager 仓位管理器
+type PositionManager struct {
+	lighterPositions *ExchangePositions
+	binancePositions *ExchangePositions
+	fees             FeeSchedule
+	volumes          *VolumeTracker             // 近30天成交量，估算手续费等级
+	attribution      map[string]*PnLAttribution // symbol -> 盈亏归因 (价差、滑点、资金费)
+	mu               sync.RWMutex
+	logger           *zap.Logger
+}
+
+// OrderManager 订单管理器
+type OrderManager struct {
+	activeOrders map[string]*ActiveOrder // orderID -> order
+	store        *OrderStore             // 活跃订单持久化 (nil为不保存)
+	mu           sync.RWMutex
+	logger       *zap.Logger
+}
+
+// ActiveOrder 活跃订单
+type ActiveOrder struct {
+	ID           string    `json:"id"`
+	Exchange     string    `json:"exchange"`
+	Symbol       string    `json:"symbol"`
+	Side         string    `json:"side"` // BUY, SELL
+	Size         float64   `json:"size"`
+	Price        float64   `json:"price"`
+	Status       string    `json:"status"` // PENDING, PARTIAL, FILLED, CANCELLED
+	FilledSize   float64   `json:"filled_size"`
+	HedgedSize   float64   `json:"hedged_size"`         // 已完成对冲的成交量，部分成交时只对冲超出的部分
+	RecordedSize float64   `json:"recorded_size"`       // 已记入成交日志的成交量，对冲失败重试时不重复记录
+	Role         string    `json:"role"`                // OPEN, CLOSE, STOP_LOSS, TAKE_PROFIT
+	ParentID     string    `json:"parent_id,omitempty"` // 保护单对应的开仓订单ID
+	ListID       string    `json:"list_id,omitempty"`   // 保护单所属的OCO订单组ID
+	CycleID      string    `json:"cycle_id,omitempty"`  // 所属的对冲周期ID
+	Chases       int       `json:"chases"`              // 撤单重挂次数 (重挂后的订单继承)
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// 订单用途
+const (
+	OrderRoleOpen       = "OPEN"
+	OrderRoleClose      = "CLOSE"
+	OrderRoleStopLoss   = "STOP_LOSS"
+	OrderRoleTakeProfit = "TAKE_PROFIT"
+)
+
+// isProtective 是否为止损/止盈保护单
+func (o *ActiveOrder) isProtective() bool {
+	return o.Role == OrderRoleStopLoss || o.Role == OrderRoleTakeProfit
+}
+
+// RiskManager 风控管理器
+type RiskManager struct {
+	config  *DynamicHedgeConfig
+	symbols *SymbolUniverse
+	bus     *eventbus.Bus
+	logger  *zap.Logger
+
+	mu            sync.Mutex
+	lastAction    RiskAction // 上一次检查的风控行动，变化时发布事件
+	highWaterMark float64    // 权益高点
+	lastStopTime  time.Time  // 因杠杆或回撤超限停止开仓的开始时间，由策略传入 (零值表示未停止)
+	closing       bool       // 停止开仓超过 StopDuration 后进入平仓阶段，持续到仓位全部为0
+	closingBy     string     // 触发平仓阶段的指标: leverage, drawdown
+
+	leverageLevels map[string]int // 各交易所当前的杠杆预警档位 (leverageAlertPercents 中已越过的档数)
+}
+
+func NewDynamicHedgeStrategy(
+	lighterStrategy *LighterStrategy,
+	binanceStrategy *BinanceStrategy,
+	config *DynamicHedgeConfig,
+) *DynamicHedgeStrategy {
+	strategy := &DynamicHedgeStrategy{
+		lighterStrategy: lighterStrategy,
+		binanceStrategy: binanceStrategy,
+		config:          config,
+		symbols:         binanceStrategy.symbols,
+		positionManager: NewPositionManager(),
+		orderManager:    NewOrderManager(),
+		riskManager:     NewRiskManager(binanceStrategy.symbols),
+		statsManager:    NewTradingStatsManager(),
+		logger:          logger.Named("dynamic-hedge"),
+		stopChan:        make(chan struct{}),
+		currentPhase:    "INITIALIZED",
+		slicing:         make(map[string]bool),
+		failed:          make(chan error, 1),
+		bus:             eventbus.New(),
+		cycles:          NewCycleTracker(logger.Named("hedge-cycle")),
+	}
+	strategy.riskManager.bus = strategy.bus
+
+	// 初始化子管理器
+	strategy.orderMonitor = NewOrderMonitor(
+		strategy.orderManager,
+		strategy.positionManager,
+		lighterStrategy,
+		binanceStrategy,
+	)
+	strategy.orderMonitor.SetEventBus(strategy.bus)
+	strategy.orderMonitor.SetCycleTracker(strategy.cycles)
+	strategy.orderMonitor.SetPanicHandler(strategy.handlePanic)
+	strategy.openingManager = NewOpeningManager(strategy)
+	strategy.closingManager = NewClosingManager(strategy)
+	strategy.hedgeBalancer = NewHedgeBalancer(strategy)
+	strategy.fastExecutionManager = NewFastExecutionManager(strategy)
+	strategy.flattenManager = NewFlattenManager(strategy)
+	strategy.protectionManager = NewProtectionManager(strategy)
+
+	return strategy
+}
+
+func NewPositionManager() *PositionManager {
+	return &PositionManager{
+		lighterPositions: &ExchangePositions{
+			Exchange:        "lighter",
+			Positions:       make(map[string]*Position),
+			LeverageUnknown: true,
+		},
+		binancePositions: &ExchangePositions{
+			Exchange:        "binance",
+			Positions:       make(map[string]*Position),
+			LeverageUnknown: true,
+		},
+		volumes:     NewVolumeTracker(),
+		attribution: make(map[string]*PnLAttribution),
+		logger:      logger.Named("position-manager"),
+	}
+}
+
+func NewOrderManager() *OrderManager {
+	return &OrderManager{
+		activeOrders: make(map[string]*ActiveOrder),
+		logger:       logger.Named("order-manager"),
+	}
+}
+
+func NewRiskManager(symbols *SymbolUniverse) *RiskManager {
+	return &RiskManager{
+		symbols: symbols,
+		logger:  logger.Named("risk-manager"),
+	}
+}
+
+// Start 启动动态对冲策略，监控循环在后台运行直到 Stop
+func (s *DynamicHedgeStrategy) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isRunning {
+		return fmt.Errorf("strategy is already running")
+	}
+
+	config := s.config
+	s.riskManager.config = config
+	s.isRunning = true
+	s.startedAt = time.Now()
+
+	s.statsManager.SetDayBoundary(config.StatsLocation, config.StatsResetHour)
+	if s.statsStore != nil {
+		s.restoreStats()
+		s.goSafe("stats-store", func() error {
+			s.runStatsSnapshots(ctx, config.StatsSnapshotInterval, s.stopChan)
+			return nil
+		})
+	}
+	s.positionManager.SetFeeSchedule(config.Fees)
+	for exchange, tiers := range config.FeeTiers {
+		s.positionManager.volumes.SetTiers(exchange, tiers)
+	}
+
+	if config.EnableTWAP {
+		s.slicedExecutor = s.newSlicedExecutor(config)
+	}
+
+	s.logger.Info("Starting dynamic hedge strategy",
+		zap.Float64("order_size", config.OrderSize),
+		zap.Float64("max_leverage", config.MaxLeverage),
+		zap.Float64("emergency_leverage", config.EmergencyLeverage),
+		zap.Duration("stop_duration", config.StopDuration),
+		zap.Duration("equity_refresh", config.EquityRefresh),
+	)
+
+	// 配置快速执行
+	if config.EnableFastExecution {
+		fastConfig := &FastExecutionConfig{
+			HighFrequencyMode:         true,
+			CheckInterval:             config.FastCheckInterval,
+			MaxExecutionDelay:         config.MaxExecutionDelay,
+			EnablePreExecution:        config.EnablePreExecution,
+			PartialFillThreshold:      config.PartialFillThreshold,
+			EnablePriceProtection:     true,
+			MaxSlippagePercent:        config.MaxSlippagePercent,
+			PriceValidityWindow:       1 * time.Second,
+			RejectOnSlippage:          config.RejectOnSlippage,
+			EnableConcurrentExecution: config.EnableConcurrentExecution,
+			MaxConcurrentOrders:       config.MaxConcurrentOrders,
+			EnableRetry:               true,
+			RetryPolicy:               defaultHedgeRetryPolicy(),
+			DelayBuckets:              config.DelayBuckets,
+		}
+		if config.RetryPolicy.MaxAttempts > 0 {
+			fastConfig.RetryPolicy = config.RetryPolicy
+		}
+		s.fastExecutionManager.UpdateConfig(fastConfig)
+		s.orderMonitor.SetFastExecutionManager(s.fastExecutionManager)
+		s.orderMonitor.SetCheckInterval(config.FastCheckInterval)
+
+		s.logger.Info("Fast execution enabled",
+			zap.Duration("check_interval", config.FastCheckInterval),
+			zap.Duration("max_delay", config.MaxExecutionDelay),
+			zap.Bool("pre_execution", config.EnablePreExecution),
+			zap.Float64("partial_threshold", config.PartialFillThreshold),
+			zap.Bool("concurrent_execution", config.EnableConcurrentExecution),
+			zap.Int("max_concurrent_orders", config.MaxConcurrentOrders),
+		)
+	}
+
+	// 配置止损止盈保护单
+	if config.EnableProtectiveOrders {
+		s.protectionManager.config = config
+		s.orderMonitor.SetProtectionManager(s.protectionManager)
+
+		s.logger.Info("Protective orders enabled",
+			zap.Float64("stop_loss_percent", config.StopLossPercent),
+			zap.Float64("take_profit_percent", config.TakeProfitPercent),
+		)
+	}
+
+	// 配置Maker单超时撤单和追价
+	s.orderMonitor.SetRepriceFunc(func(ctx context.Context, order *ActiveOrder, remaining float64) (string, error) {
+		return s.repriceOrder(ctx, config, order, remaining)
+	})
+	if config.MaxOrderAge > 0 {
+		s.orderMonitor.SetStaleOrderPolicy(config.MaxOrderAge, config.RepriceStaleOrders)
+	}
+	if config.EnableOrderChasing {
+		s.orderMonitor.SetChasePolicy(config.ChaseThresholdPercent, config.ChaseInterval, config.MaxChases)
+	}
+
+	// 启动价差监控
+	if config.EnableSpreadTrigger {
+		s.spreadMonitor = NewSpreadMonitor(s, config)
+		s.goSafe("spread-monitor", func() error {
+			s.spreadMonitor.Run(ctx, s.stopChan)
+			return nil
+		})
+	}
+
+	// 启动滚动波动率
+	if config.EnableVolatility {
+		s.volatilityTracker = NewVolatilityTracker(s, config)
+		s.goSafe("volatility", func() error {
+			s.volatilityTracker.Run(ctx, s.stopChan)
+			return nil
+		})
+	}
+
+	// 启动影子模式
+	if config.EnableShadow {
+		s.shadowTrader = NewShadowTrader(s, config)
+		s.goSafe("shadow", func() error {
+			s.shadowTrader.Run(ctx, s.stopChan)
+			return nil
+		})
+	}
+
+	// 启动跨交易所保证金再平衡
+	if config.EnableCollateralRebalance {
+		s.collateralRebalancer = NewCollateralRebalancer(s, config)
+		s.goSafe("collateral-rebalancer", func() error {
+			s.collateralRebalancer.Run(ctx, s.stopChan)
+			return nil
+		})
+	}
+
+	// 启动USDC/USDT汇率跟踪
+	if config.EnableUSDCRate {
+		s.usdcRate = NewUSDCRateTracker(s, config)
+		s.goSafe("usdc-rate", func() error {
+			s.usdcRate.Run(ctx, s.stopChan)
+			return nil
+		})
+	}
+
+	// 启动资金费估算
+	if config.EnableFundingAccrual {
+		s.fundingTracker = NewFundingTracker(s, config)
+		s.goSafe("funding-tracker", func() error {
+			s.fundingTracker.Run(ctx, s.stopChan)
+			return nil
+		})
+	}
+
+	// 配置强平价监控
+	if config.EnableLiquidationMonitor {
+		s.liquidationMonitor = NewLiquidationMonitor(s, config)
+
+		s.logger.Info("Liquidation monitor enabled",
+			zap.Float64("alert_percent", config.LiquidationAlertPercent),
+			zap.Float64("derisk_percent", config.LiquidationDeRiskPercent),
+		)
+	}
+
+	// 配置仓位对账
+	if config.EnableReconciliation {
+		s.reconciler = NewReconciler(s, config)
+
+		s.logger.Info("Position reconciliation enabled",
+			zap.Float64("tolerance_notional", config.ReconcileToleranceNotional),
+			zap.Float64("halt_notional", config.ReconcileHaltNotional),
+			zap.Bool("auto_correct", config.ReconcileAutoCorrect),
+		)
+	}
+
+	// 恢复上次运行留下的订单
+	if s.orderStore != nil {
+		if err := s.recoverOrders(ctx, config); err != nil {
+			return fmt.Errorf("failed to recover orders: %w", err)
+		}
+	}
+
+	// 启动订单监控
+	if err := s.orderMonitor.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start order monitor: %w", err)
+	}
+
+	// 启动主监控循环
+	s.runCtx = ctx
+	s.startMonitoringLoop()
+
+	// 启动看门狗
+	if config.EnableWatchdog {
+		watchdog := NewWatchdog(s, config)
+		s.goSafe("watchdog", func() error {
+			return watchdog.Run(ctx, s.stopChan)
+		})
+	}
+
+	return nil
+}
+
+// Stop 停止策略
+func (s *DynamicHedgeStrategy) Stop() {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+
+	s.logger.Info("Stopping dynamic hedge strategy")
+
+	// 停止订单监控
+	s.orderMonitor.Stop()
+
+	// 保存最终统计，下次启动时恢复
+	if s.statsStore != nil {
+		s.saveStats()
+	}
+
+	close(s.stopChan)
+	s.isRunning = false
+	s.mu.Unlock()
+
+	// 等待后台子系统退出，调用方应先取消 Start 传入的上下文以中断进行中的请求。
+	// 等待时不持有 s.mu，退出中的子系统仍可读写策略状态 (如 setPhase)
+	s.waitSubsystems()
+}
+
+// waitSubsystems 等待子系统组内的goroutine全部退出，最多等待 subsystemStopTimeout
+func (s *DynamicHedgeStrategy) waitSubsystems() {
+	done := make(chan struct{})
+	go func() {
+		_ = s.group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(subsystemStopTimeout):
+		s.logger.Warn("Timed out waiting for background subsystems to stop", zap.Duration("timeout", subsystemStopTimeout))
+	}
+}
+
+// Status 返回运行状态
+func (s *DynamicHedgeStrategy) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return Status{
+		Type:      StrategyDynamicHedge,
+		Running:   s.isRunning,
+		Phase:     s.currentPhase,
+		StartedAt: s.startedAt,
+	}
+}
+
+// Done Stop 后关闭
+func (s *DynamicHedgeStrategy) Done() <-chan struct{} {
+	return s.stopChan
+}
+
+// Failed 策略异常结束时收到原因，正常运行和 Stop 时不会收到
+func (s *DynamicHedgeStrategy) Failed() <-chan error {
+	return s.failed
+}
+
+// fail 报告策略异常结束，只报告第一次
+func (s *DynamicHedgeStrategy) fail(err error) {
+	s.failOnce.Do(func() {
+		s.logger.Error("Dynamic hedge strategy failed", zap.Error(err))
+		s.failed <- err
+	})
+}
+
+// startMonitoringLoop 以 runCtx 的子上下文启动主监控循环
+func (s *DynamicHedgeStrategy) startMonitoringLoop() {
+	s.loopMu.Lock()
+	defer s.loopMu.Unlock()
+
+	ctx, cancel := context.WithCancel(s.runCtx)
+	s.loopCancel = cancel
+	s.loopHeartbeat.beat()
+	s.goSafe("monitoring-loop", func() error {
+		s.monitoringLoop(ctx, s.config)
+		return nil
+	})
+}
+
+// restartMonitoringLoop 取消卡住的主监控循环 (中断进行中的请求) 并启动新的循环。
+// 旧循环解除阻塞后随上下文取消退出
+func (s *DynamicHedgeStrategy) restartMonitoringLoop() {
+	s.loopMu.Lock()
+	cancel := s.loopCancel
+	s.loopMu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	s.logger.Warn("Restarting monitoring loop")
+	s.startMonitoringLoop()
+}
+
+// monitoringLoop 主监控循环，每个周期结束后记录心跳
+func (s *DynamicHedgeStrategy) monitoringLoop(ctx context.Context, config *DynamicHedgeConfig) {
+	ticker := time.NewTicker(config.MonitorInterval)
+	defer ticker.Stop()
+
+	// 出现有利价差时立即执行一个周期，不等待下一次定时
+	var spreadSignals <-chan struct{}
+	if s.spreadMonitor != nil {
+		spreadSignals = s.spreadMonitor.Signals()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Context cancelled, stopping monitoring loop")
+			return
+		case <-s.stopChan:
+			s.logger.Info("Stop signal received, stopping monitoring loop")
+			return
+		case <-ticker.C:
+			s.runCycle(ctx, config)
+		case <-spreadSignals:
+			s.runCycle(ctx, config)
+		}
+		s.loopHeartbeat.beat()
+
+		// 请求权重接近上限时放慢轮询
+		ticker.Reset(s.binanceStrategy.pollInterval(config.MonitorInterval))
+	}
+}
+
+// runCycle 执行一个周期，panic时恢复并暂停开新仓，监控循环继续运行。quiesceCycles 之后不再执行
+func (s *DynamicHedgeStrategy) runCycle(ctx context.Context, config *DynamicHedgeConfig) {
+	defer s.recoverPanic("monitoring-loop")
+
+	s.cycleMu.Lock()
+	defer s.cycleMu.Unlock()
+	if s.quiesced {
+		return
+	}
+
+	if err := s.executeCycle(ctx, config); err != nil {
+		s.logger.Error("Error in execution cycle", zap.Error(err))
+	}
+}
+
+// quiesceCycles 等待进行中的周期结束，之后监控循环只记录心跳、不再执行周期，
+// 避免关闭时的平仓与周期中的开仓、平仓或紧急平仓同时下单
+func (s *DynamicHedgeStrategy) quiesceCycles() {
+	s.cycleMu.Lock()
+	defer s.cycleMu.Unlock()
+	s.quiesced = true
+}
+
+// executeCycle 执行一个周期的策略逻辑
+func (s *DynamicHedgeStrategy) executeCycle(ctx context.Context, config *DynamicHedgeConfig) error {
+	// 1. 更新统计信息
+	s.updateStats(config)
+
+	// 2. 检查日交易限制
+	if config.ContinuousMode && s.shouldPauseForDay(config) {
+		s.setPhase("DAILY_LIMIT_REACHED")
+		s.logger.Info("Daily trading limit reached, pausing until next day")
+		return nil
+	}
+
+	// 3. 更新仓位信息
+	if err := s.updatePositions(ctx); err != nil {
+		return fmt.Errorf("failed to update positions: %w", err)
+	}
+	s.refreshEquity(ctx, config)
+	s.closeFlatCycles()
+
+	// 4. 强平价监控：接近强平价时告警，进入减仓缓冲区时减仓该币种
+	if s.liquidationMonitor != nil {
+		if symbols := s.liquidationMonitor.Check(); len(symbols) > 0 {
+			volume, err := s.liquidationMonitor.DeRisk(ctx, symbols)
+			if volume > 0 {
+				s.recordTrade(volume, "DERISK")
+				s.lastTradeTime = time.Now()
+			}
+			if err != nil {
+				s.logger.Error("Failed to de-risk positions near liquidation", zap.Error(err))
+			}
+		}
+	}
+
+	// 5. 日终清仓窗口内只撤单平仓，不再开新仓
+	if config.EnableDailyFlatten && s.flattenManager.InFlattenWindow(config, time.Now()) {
+		s.setPhase("FLATTENED")
+		return s.flattenManager.ExecuteFlatten(ctx, config)
+	}
+
+	// 6. 检查对冲平衡性
+	if config.EnableHedgeBalancing {
+		if err := s.checkAndAdjustHedgeBalance(ctx, config); err != nil {
+			s.logger.Error("Failed to check hedge balance", zap.Error(err))
+			// 不中断主流程，继续执行
+		}
+	}
+
+	// 7. 检查风险状态
+	riskStatus := s.riskManager.CheckRisk(s.positionManager)
+
+	// 记录风险状态
+	s.logger.Debug("Risk status check",
+		zap.String("action", riskStatus.Action.String()),
+		zap.Float64("max_leverage", riskStatus.MaxLeverage),
+		zap.Float64("drawdown_percent", riskStatus.DrawdownPercent),
+		zap.Strings("blocked_symbols", riskStatus.BlockedSymbols),
+		zap.String("reason", riskStatus.Reason),
+	)
+
+	// 8. 根据风险状态执行相应逻辑
+	switch riskStatus.Action {
+	case RiskActionContinueOpening:
+		s.setLastStopTime(time.Time{})
+		if s.IsPaused() {
+			s.setPhase("PAUSED")
+			return nil
+		}
+		if !s.inTradingSession(config, time.Now()) {
+			s.setPhase("OUT_OF_SESSION")
+			return nil
+		}
+		return s.executeContinuousOpening(ctx, config)
+	case RiskActionStopOpening:
+		// 权益未知时无法判断杠杆，只停止开仓，不开始平仓计时
+		if riskStatus.Trigger == RiskTriggerEquity {
+			s.setPhase("EQUITY_UNKNOWN")
+			return nil
+		}
+		if s.lastStopTime.IsZero() {
+			s.setLastStopTime(time.Now())
+			s.logger.Warn("Stop opening started, closing will begin after stop duration",
+				zap.String("trigger", riskStatus.Trigger),
+				zap.Duration("stop_duration", config.StopDuration),
+			)
+		}
+		if riskStatus.Trigger == RiskTriggerDrawdown {
+			s.setPhase("DRAWDOWN_LIMIT")
+			s.logger.Warn("Stopping position opening due to drawdown limit")
+			return nil
+		}
+		s.setPhase("LEVERAGE_LIMIT")
+		s.logger.Warn("Stopping position opening due to leverage limit")
+		return nil
+	case RiskActionStartClosing:
+		return s.executeContinuousClosing(ctx, config)
+	case RiskActionEmergencyClose:
+		s.setPhase("EMERGENCY_CLOSING")
+		return s.closingManager.ExecuteEmergencyClosing(ctx, config)
+	}
+
+	return nil
+}
+
+// setLastStopTime 记录停止开仓的开始时间并同步给风控管理器，零值表示恢复正常开仓
+func (s *DynamicHedgeStrategy) setLastStopTime(t time.Time) {
+	if s.lastStopTime.Equal(t) {
+		return
+	}
+	s.lastStopTime = t
+	s.riskManager.SetLastStopTime(t)
+}
+
+// Pause 暂停开新仓：订单监控、对冲、平衡检查和风控平仓照常运行，返回是否发生变化
+func (s *DynamicHedgeStrategy) Pause() bool {
+	s.mu.Lock()
+	changed := !s.paused
+	s.paused = true
+	s.mu.Unlock()
+
+	if changed {
+		s.logger.Warn("Opening paused, existing orders remain monitored and hedged")
+	}
+	return changed
+}
+
+// Resume 恢复开新仓，返回是否发生变化
+func (s *DynamicHedgeStrategy) Resume() bool {
+	s.mu.Lock()
+	changed := s.paused
+	s.paused = false
+	s.mu.Unlock()
+
+	if changed {
+		s.logger.Info("Opening resumed")
+	}
+	return changed
+}
+
+// IsPaused 是否已暂停开新仓
+func (s *DynamicHedgeStrategy) IsPaused() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paused
+}
+
+// executeContinuousOpening 执行持续开仓
+func (s *DynamicHedgeStrategy) executeContinuousOpening(ctx context.Context, config *DynamicHedgeConfig) error {
+	// 检查是否可以进行新的交易
+	if !s.canStartNewTrade(config) {
+		return nil
+	}
+
+	// 任一交易所保证金不足时跳过本轮开仓，已有订单的监控和对冲照常进行
+	if config.EnableBalanceCheck {
+		if ok, reason := s.openingManager.CheckBalance(ctx, config); !ok {
+			if s.GetPhase() != "INSUFFICIENT_BALANCE" {
+				s.logger.Warn("Insufficient balance, skipping opening", zap.String("reason", reason))
+			}
+			s.setPhase("INSUFFICIENT_BALANCE")
+			return nil
+		}
+	}
+
+	s.setPhase("OPENING")
+	s.logger.Info("Starting continuous opening phase")
+
+	// 执行开仓逻辑
+	volume, err := s.openingManager.ExecuteOpeningLogic(ctx, config)
+
+	// 记录交易 (部分币种失败时仍记录已下单部分)
+	if volume > 0 {
+		s.recordTrade(volume, "OPENING")
+		s.lastTradeTime = time.Now()
+	}
+
+	if err != nil {
+		s.logger.Error("Opening logic failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// executeContinuousClosing 执行持续平仓
+func (s *DynamicHedgeStrategy) executeContinuousClosing(ctx context.Context, config *DynamicHedgeConfig) error {
+	s.setPhase("CLOSING")
+	s.logger.Info("Starting continuous closing phase")
+
+	// 执行平仓逻辑
+	volume, err := s.closingManager.ExecuteClosingLogic(ctx, config)
+
+	// 记录交易
+	if volume > 0 {
+		s.recordTrade(volume, "CLOSING")
+		s.lastTradeTime = time.Now()
+	}
+
+	if err != nil {
+		s.logger.Error("Closing logic failed", zap.Error(err))
+		return err
+	}
+
+	// 检查是否所有仓位已平仓，如果是则重新开始开仓
+	if s.allPositionsZero() {
+		s.setPhase("READY_FOR_OPENING")
+		s.logger.Info("All positions closed, ready for new opening cycle")
+	}
+
+	return nil
+}
+
+// canStartNewTrade 检查是否可以开始新交易 (活跃订单和分片执行按币种检查，见 symbolBusy)
+func (s *DynamicHedgeStrategy) canStartNewTrade(config *DynamicHedgeConfig) bool {
+	// 1. 检查交易间隔
+	if !s.lastTradeTime.IsZero() && time.Since(s.lastTradeTime) < config.TradingInterval {
+		return false
+	}
+
+	// 2. 检查日交易次数限制
+	if config.MaxDailyTrades > 0 && s.statsManager.ShouldPauseTradingForDay(config.MaxDailyTrades) {
+		return false
+	}
+
+	return true
+}
+
+// symbolBusy 币种是否有未完成订单或分片执行任务，各币种互不阻塞
+func (s *DynamicHedgeStrategy) symbolBusy(symbol string) bool {
+	if s.orderManager.HasActiveOrders(symbol) {
+		return true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.slicing[symbol]
+}
+
+// setSlicing 标记币种分片执行状态
+func (s *DynamicHedgeStrategy) setSlicing(symbol string, running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if running {
+		s.slicing[symbol] = true
+	} else {
+		delete(s.slicing, symbol)
+	}
+}
+
+// lighterLeverage 获取币种的Lighter下单杠杆
+func (s *DynamicHedgeStrategy) lighterLeverage(symbol string) int {
+	return s.lighterStrategy.leverage(symbol)
+}
+
+// spreadPercent 获取币种的Binance价差百分比，未单独配置时使用策略配置。
+// 启用波动率自适应价差时按ATR缩放
+func (s *DynamicHedgeStrategy) spreadPercent(config *DynamicHedgeConfig, symbol string) float64 {
+	spread := config.SpreadPercent
+	if spec, err := s.symbols.Get(symbol); err == nil && spec.SpreadPercent > 0 {
+		spread = spec.SpreadPercent
+	}
+	if config.EnableAdaptiveSpread {
+		spread = s.adaptiveSpread(config, symbol, spread)
+	}
+	return spread
+}
+
+// adaptiveSpread 按 ATR / 基准ATR 缩放价差，倍数限制在 [AdaptiveSpreadMinMultiplier, AdaptiveSpreadMaxMultiplier]。
+// 波动大时放宽价差降低被动成交后的逆向选择，波动小时收窄价差提高成交率；
+// 波动率尚未计算成功或超过3个刷新周期未更新时使用原价差
+func (s *DynamicHedgeStrategy) adaptiveSpread(config *DynamicHedgeConfig, symbol string, spread float64) float64 {
+	estimate, ok := s.volatility(symbol)
+	if !ok || estimate.ATRPercent <= 0 || time.Since(estimate.UpdatedAt) > 3*config.VolatilityRefresh {
+		return spread
+	}
+
+	multiplier := estimate.ATRPercent / config.AdaptiveSpreadReferenceATR
+	multiplier = max(config.AdaptiveSpreadMinMultiplier, min(multiplier, config.AdaptiveSpreadMaxMultiplier))
+
+	s.logger.Debug("Adaptive spread applied",
+		zap.String("symbol", symbol),
+		zap.Float64("atr_percent", estimate.ATRPercent),
+		zap.Float64("multiplier", multiplier),
+		zap.Float64("base_spread_percent", spread),
+		zap.Float64("spread_percent", spread*multiplier),
+	)
+	return spread * multiplier
+}
+
+// shouldPauseForDay 检查是否应该暂停一天的交易
+func (s *DynamicHedgeStrategy) shouldPauseForDay(config *DynamicHedgeConfig) bool {
+	if !config.ContinuousMode {
+		return false
+	}
+
+	// 检查日交易量目标
+	volumeReached, tradesReached := s.statsManager.CheckDailyTargets(config.VolumeTarget, config.MaxDailyTrades)
+
+	if volumeReached && tradesReached {
+		return true
+	}
+
+	return false
+}
+
+// allPositionsZero 检查所有仓位是否为0
+func (s *DynamicHedgeStrategy) allPositionsZero() bool {
+	lighterPositions := s.positionManager.GetLighterPositions()
+	binancePositions := s.positionManager.GetBinancePositions()
+
+	// 检查Lighter仓位
+	for _, pos := range lighterPositions.Positions {
+		if pos.Size != 0 {
+			return false
+		}
+	}
+
+	// 检查Binance仓位
+	for _, pos := range binancePositions.Positions {
+		if pos.Size != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setPhase 设置当前阶段
+func (s *DynamicHedgeStrategy) setPhase(phase string) {
+	s.mu.Lock()
+	oldPhase := s.currentPhase
+	s.currentPhase = phase
+	s.mu.Unlock()
+
+	s.statsManager.UpdatePhase(phase)
+
+	if oldPhase != phase {
+		s.emitEvent(EventPhaseChanged, map[string]interface{}{
+			"old_phase": oldPhase,
+			"new_phase": phase,
+		})
+	}
+}
+
+// recordTrade 记录交易
+func (s *DynamicHedgeStrategy) recordTrade(volume float64, tradeType string) {
+	s.statsManager.RecordTrade(volume, tradeType)
+
+	s.emitEvent(EventTradeRecorded, map[string]interface{}{
+		"volume": volume,
+		"type":   tradeType,
+	})
+}
+
+// EventBus 返回策略事件总线
+func (s *DynamicHedgeStrategy) EventBus() *eventbus.Bus {
+	return s.bus
+}
+
+// emitEvent 发布策略事件
+func (s *DynamicHedgeStrategy) emitEvent(eventType string, fields map[string]interface{}) {
+	s.bus.Publish("dynamic-hedge", eventType, fields)
+}
+
+// GetPhase 获取当前阶段
+func (s *DynamicHedgeStrategy) GetPhase() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentPhase
+}
+
+// updateStats 更新统计信息
+func (s *DynamicHedgeStrategy) updateStats(config *DynamicHedgeConfig) {
+	// 更新活跃订单数
+	activeOrders := s.orderManager.GetActiveOrders()
+	s.statsManager.UpdateActiveOrders(len(activeOrders))
+
+	// 更新交易量进度
+	if config.VolumeTarget > 0 {
+		s.statsManager.UpdateVolumeProgress(config.VolumeTarget)
+	}
+
+	// 更新日盈亏
+	s.statsManager.UpdatePnL(s.positionManager.GetPnL().TotalPnL)
+
+	// 定期输出统计日志 (每分钟一次)
+	if time.Since(s.lastTradeTime) > time.Minute {
+		s.statsManager.LogStats()
+	}
+}
+
+// updatePositions 从两个交易所同步持仓，并按最新价格标记未实现盈亏。
+// Binance现货没有持仓概念，按余额相对基准的变化计算仓位 (见 syncBaseline)
+func (s *DynamicHedgeStrategy) updatePositions(ctx context.Context) error {
+	lighterPositions, err := s.lighterStrategy.client.GetPositions(ctx)
+	if err != nil {
+		return fmt.Errorf("lighter: %w", err)
+	}
+
+	byMarket := make(map[uint8]lighter.Position, len(lighterPositions))
+	for _, pos := range lighterPositions {
+		byMarket[pos.MarketIndex] = pos
+	}
+
+	for _, spec := range s.symbols.Specs() {
+		lp := byMarket[spec.LighterMarketIndex]
+		var lighterMark float64
+		if lp.Size != 0 {
+			lighterMark = lp.Value / lp.Size
+		}
+		s.syncPosition("lighter", spec.Symbol, lp.Size, lp.EntryPrice, lighterMark, lp.LiquidationPrice)
+	}
+	s.markSynced("lighter")
+
+	if s.binanceStrategy.client.HasPositions() {
+		err = s.syncBinancePositions(ctx)
+	} else {
+		err = s.syncBinanceBalances(ctx, byMarket)
+	}
+	if err != nil {
+		return fmt.Errorf("binance: %w", err)
+	}
+	s.markSynced("binance")
+
+	s.positionManager.CalculateTotalLeverage()
+	return nil
+}
+
+// syncPosition 用交易所持仓覆盖本地仓位，启用对账时先核对，未确认或不自动修正的差异保留本地仓位
+func (s *DynamicHedgeStrategy) syncPosition(exchange, symbol string, size, entryPrice, markPrice, liquidationPrice float64) {
+	if s.reconciler != nil && !s.reconciler.Reconcile(exchange, symbol, size, markPrice) {
+		return
+	}
+	s.positionManager.SyncPosition(exchange, symbol, size, entryPrice, markPrice, liquidationPrice)
+}
+
+// markSynced 交易所完成一轮持仓同步
+func (s *DynamicHedgeStrategy) markSynced(exchange string) {
+	if s.reconciler != nil {
+		s.reconciler.MarkSynced(exchange)
+	}
+}
+
+// syncBinancePositions 同步Binance合约持仓
+func (s *DynamicHedgeStrategy) syncBinancePositions(ctx context.Context) error {
+	positions, err := s.binanceStrategy.client.GetPositions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range s.symbols.Specs() {
+		pos := positions[spec.Symbol]
+		s.syncPosition("binance", spec.Symbol, pos.Size, pos.EntryPrice, pos.MarkPrice, pos.LiquidationPrice)
+	}
+	return nil
+}
+
+// syncBinanceBalances 按现货余额相对基准的变化同步Binance仓位
+func (s *DynamicHedgeStrategy) syncBinanceBalances(ctx context.Context, lighterPositions map[uint8]lighter.Position) error {
+	balances, err := s.binanceStrategy.client.GetBaseBalances(ctx)
+	if err != nil {
+		return err
+	}
+
+	if s.binanceBaseline == nil {
+		s.syncBaseline(lighterPositions, balances)
+	}
+
+	for _, spec := range s.symbols.Specs() {
+		size := balances[spec.Symbol] - s.binanceBaseline[spec.Symbol]
+		if math.Abs(size) < positionEpsilon {
+			size = 0
+		}
+		var mark float64
+		if size != 0 {
+			mark, err = s.binanceStrategy.client.GetCurrentPrice(ctx, s.binanceStrategy.pair(spec.Symbol))
+			if err != nil {
+				s.logger.Warn("Failed to get mark price", zap.String("symbol", spec.Symbol), zap.Error(err))
+			}
+		}
+		s.syncPosition("binance", spec.Symbol, size, 0, mark, 0)
+	}
+	return nil
+}
+
+// syncBaseline 首次同步时记录Binance各币种的基准余额。启动时视为已对冲：
+// Binance仓位 = -Lighter仓位，其余余额作为库存不计入仓位
+func (s *DynamicHedgeStrategy) syncBaseline(lighterPositions map[uint8]lighter.Position, balances map[string]float64) {
+	s.binanceBaseline = make(map[string]float64, len(balances))
+	for _, spec := range s.symbols.Specs() {
+		lighterSize := lighterPositions[spec.LighterMarketIndex].Size
+		s.binanceBaseline[spec.Symbol] = balances[spec.Symbol] + lighterSize
+
+		s.logger.Info("Recorded Binance baseline balance",
+			zap.String("symbol", spec.Symbol),
+			zap.Float64("balance", balances[spec.Symbol]),
+			zap.Float64("lighter_position", lighterSize),
+			zap.Float64("baseline", s.binanceBaseline[spec.Symbol]),
+		)
+	}
+}
+
+// refreshEquity 按配置间隔查询两个交易所的账户权益，用于计算实际杠杆率。
+// 查询失败时保留上一次的权益，不中断主流程；尚未获取到有效权益时每个周期都重新查询
+func (s *DynamicHedgeStrategy) refreshEquity(ctx context.Context, config *DynamicHedgeConfig) {
+	if !s.positionManager.LeverageUnknown() &&
+		(config.EquityRefresh <= 0 || time.Since(s.lastEquityAt) < config.EquityRefresh) {
+		return
+	}
+	s.lastEquityAt = time.Now()
+
+	if equity, err := s.lighterStrategy.client.GetAccountEquity(ctx); err != nil {
+		s.logger.Warn("Failed to refresh Lighter account equity", zap.Error(err))
+	} else {
+		s.positionManager.UpdateEquity("lighter", equity)
+	}
+
+	if equity, err := s.binanceStrategy.client.GetAccountEquity(ctx); err != nil {
+		s.logger.Warn("Failed to refresh Binance account equity", zap.Error(err))
+	} else {
+		s.positionManager.UpdateEquity("binance", equity)
+	}
+}
+
+// GetStrategy 获取策略实例（供外部访问）
+func (s *DynamicHedgeStrategy) GetStrategy() *DynamicHedgeStrategy {
+	return s
+}
+
+// SetTradeJournal 设置成交日志，所有成交和对冲都会写入该日志
+func (s *DynamicHedgeStrategy) SetTradeJournal(j *journal.Journal) {
+	s.orderMonitor.SetTradeJournal(j)
+
+	// 从已有成交恢复近30天成交量
+	entries, err := journal.ReadAll(j.Path())
+	if err != nil {
+		s.logger.Warn("Failed to read trade journal for 30-day volume", zap.Error(err))
+		return
+	}
+	s.positionManager.volumes.Seed(entries)
+}
+
+// SetPriceFeed 设置多源聚合价格，用于对冲价格保护和对冲平衡检查
+func (s *DynamicHedgeStrategy) SetPriceFeed(feed *pricefeed.Feed) {
+	s.priceFeed = feed
+}
+
+// GetPositionSummary 获取仓位摘要
+func (s *DynamicHedgeStrategy) GetPositionSummary() map[string]interface{} {
+	return s.positionManager.GetPositionSummary()
+}
+
+// GetOrderSummary 获取订单摘要
+func (s *DynamicHedgeStrategy) GetOrderSummary() map[string]*ActiveOrder {
+	return s.orderManager.GetActiveOrders()
+}
+
+// IsRunning 检查策略是否运行中
+func (s *DynamicHedgeStrategy) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.isRunning
+}
+
+// GetStats 获取交易统计信息 (包含已实现/未实现盈亏)
+func (s *DynamicHedgeStrategy) GetStats() *TradingStats {
+	if s.statsManager == nil {
+		return nil
+	}
+
+	pnl := s.positionManager.GetPnL()
+	s.statsManager.UpdatePnL(pnl.TotalPnL)
+	stats := s.statsManager.GetStats()
+	stats.RealizedPnL = pnl.RealizedPnL
+	stats.UnrealizedPnL = pnl.UnrealizedPnL
+	stats.Fees = pnl.Fees
+	stats.FeeTiers = s.positionManager.volumes.Status()
+	stats.TotalPnL = pnl.TotalPnL
+
+	return stats
+}
+
+// GetPnL 获取按交易所拆分的盈亏汇总
+func (s *DynamicHedgeStrategy) GetPnL() *PnLSummary {
+	return s.positionManager.GetPnL()
+}
+
+// checkAndAdjustHedgeBalance 检查并调整对冲平衡
+func (s *DynamicHedgeStrategy) checkAndAdjustHedgeBalance(ctx context.Context, config *DynamicHedgeConfig) error {
+	s.balanceMu.Lock()
+	defer s.balanceMu.Unlock()
+
+	// 配置对冲平衡器参数
+	if config.BalanceTolerance > 0 {
+		s.hedgeBalancer.SetBalanceTolerance(config.BalanceTolerance)
+	}
+	if config.MinBalanceAdjust > 0 {
+		s.hedgeBalancer.SetMinAdjustAmount(config.MinBalanceAdjust)
+	}
+
+	// 检查对冲平衡状态
+	balanceStatus, err := s.hedgeBalancer.CheckHedgeBalance()
+	if err != nil {
+		return fmt.Errorf("failed to check hedge balance: %w", err)
+	}
+
+	s.logger.Debug("Hedge balance status",
+		zap.Bool("is_balanced", balanceStatus.IsBalanced),
+		zap.Int("imbalances_count", len(balanceStatus.Imbalances)),
+		zap.Float64("total_imbalance_value", balanceStatus.TotalImbalanceValue),
+	)
+
+	// 如果存在不平衡且需要调整
+	if !balanceStatus.IsBalanced && len(balanceStatus.Imbalances) > 0 {
+		s.logger.Warn("Hedge imbalance detected, attempting to adjust",
+			zap.Int("imbalances", len(balanceStatus.Imbalances)),
+			zap.Float64("total_imbalance", balanceStatus.TotalImbalanceValue),
+		)
+
+		// 设置策略阶段为平衡调整
+		s.setPhase("BALANCE_ADJUSTING")
+
+		// 执行平衡调整
+		if err := s.hedgeBalancer.ExecuteBalanceAdjustment(ctx, config, balanceStatus); err != nil {
+			s.logger.Error("Failed to execute balance adjustment", zap.Error(err))
+			return fmt.Errorf("failed to execute balance adjustment: %w", err)
+		}
+
+		s.logger.Info("Hedge balance adjustment completed successfully")
+		s.setPhase("BALANCE_ADJUSTED")
+	}
+
+	return nil
+}
+
+// GetHedgeBalanceStatus 获取当前对冲平衡状态
+func (s *DynamicHedgeStrategy) GetHedgeBalanceStatus() (*HedgeBalanceStatus, error) {
+	return s.hedgeBalancer.CheckHedgeBalance()
+}
+
+// ForceBalanceAdjustment 强制执行平衡调整 (不要求启用 EnableHedgeBalancing)，config 为nil时使用策略配置
+func (s *DynamicHedgeStrategy) ForceBalanceAdjustment(ctx context.Context, config *DynamicHedgeConfig) error {
+	if config == nil {
+		config = s.config
+	}
+	s.logger.Info("Force balance adjustment requested")
+	return s.checkAndAdjustHedgeBalance(ctx, config)
+}
+
+// GetExecutionStats 获取快速执行统计信息
+func (s *DynamicHedgeStrategy) GetExecutionStats() *ExecutionStats {
+	if s.fastExecutionManager == nil {
+		return nil
+	}
+	return s.fastExecutionManager.GetExecutionStats()
+}
+
+// ExecutionMetrics 获取快速执行的Prometheus指标 (对冲执行延迟直方图)
+func (s *DynamicHedgeStrategy) ExecutionMetrics() prometheus.Collector {
+	return s.fastExecutionManager
+}
+
+// GetSpreadQuotes 获取各币种最新的跨交易所价差，未启用价差触发时返回nil
+func (s *DynamicHedgeStrategy) GetSpreadQuotes() map[string]SpreadQuote {
+	if s.spreadMonitor == nil {
+		return nil
+	}
+	return s.spreadMonitor.Quotes()
+}
+
+// GetVolatility 获取各币种最近一次的波动率估计，未启用滚动波动率时返回nil
+func (s *DynamicHedgeStrategy) GetVolatility() map[string]VolatilityEstimate {
+	if s.volatilityTracker == nil {
+		return nil
+	}
+	return s.volatilityTracker.Estimates()
+}
+
+// RecoverStreamGap 行情推送断线重连后调用：立即通过REST核对全部活跃订单，
+// 断线期间成交的订单不必等到下一个检查周期才对冲
+func (s *DynamicHedgeStrategy) RecoverStreamGap(gap time.Duration) {
+	s.logger.Info("Checking active orders after stream gap", zap.Duration("gap", gap))
+	s.orderMonitor.TriggerCheck()
+}
+
+// GetShadowReport 获取影子模式与实盘的对比，未启用影子模式时返回nil
+func (s *DynamicHedgeStrategy) GetShadowReport() *ShadowReport {
+	if s.shadowTrader == nil {
+		return nil
+	}
+	return s.shadowTrader.Report()
+}
+
+// GetRebalancePlan 获取最近一次的跨交易所保证金再平衡计划，未启用或尚未检查成功时返回nil
+func (s *DynamicHedgeStrategy) GetRebalancePlan() *RebalancePlan {
+	if s.collateralRebalancer == nil {
+		return nil
+	}
+	return s.collateralRebalancer.Plan()
+}
+
+// ResumeRebalance 人工确认后恢复因提现失败暂停的自动提现，返回之前的失败原因 (未暂停时为空)。未启用时返回false
+func (s *DynamicHedgeStrategy) ResumeRebalance() (string, bool) {
+	if s.collateralRebalancer == nil {
+		return "", false
+	}
+	return s.collateralRebalancer.ClearHalt(), true
+}
+
+// volatility 获取币种的波动率估计，未启用或尚未计算成功时返回 false
+func (s *DynamicHedgeStrategy) volatility(symbol string) (VolatilityEstimate, bool) {
+	if s.volatilityTracker == nil {
+		return VolatilityEstimate{}, false
+	}
+	return s.volatilityTracker.Get(symbol)
+}
+
+// GetLiquidationRisks 获取处于强平告警缓冲区的仓位，未启用强平价监控时返回nil
+func (s *DynamicHedgeStrategy) GetLiquidationRisks() []LiquidationRisk {
+	if s.liquidationMonitor == nil {
+		return nil
+	}
+	return s.liquidationMonitor.Risks()
+}
+
+// GetPositionDiscrepancies 获取尚未消除的仓位差异，未启用对账时返回nil
+func (s *DynamicHedgeStrategy) GetPositionDiscrepancies() []PositionDiscrepancy {
+	if s.reconciler == nil {
+		return nil
+	}
+	return s.reconciler.Discrepancies()
+}
+
+// LogExecutionPerformance 记录执行性能指标
+func (s *DynamicHedgeStrategy) LogExecutionPerformance() {
+	if s.fastExecutionManager != nil {
+		s.fastExecutionManager.LogPerformanceMetrics()
+	}
+}
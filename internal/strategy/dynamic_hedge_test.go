@@ -0,0 +1,111 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopReleasesLockWhileWaitingForSubsystems(t *testing.T) {
+	h := newTestHedge(t)
+	s := h.DynamicHedgeStrategy
+	s.isRunning = true
+
+	// 退出时需要写策略状态的子系统
+	s.goSafe("phase-writer", func() error {
+		<-s.stopChan
+		s.setPhase("STOPPED")
+		return nil
+	})
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(subsystemStopTimeout / 2):
+		t.Fatal("Stop blocked waiting for a subsystem that needs the strategy lock")
+	}
+	if phase := s.Status().Phase; phase != "STOPPED" {
+		t.Fatalf("phase = %q, want STOPPED", phase)
+	}
+}
+
+func TestFlattenShutdownQuiescesCycles(t *testing.T) {
+	h := newTestHedge(t)
+	if _, err := h.lighter.PlaceShort(t.Context(), 1, 100, 3); err != nil {
+		t.Fatalf("PlaceShort: %v", err)
+	}
+	h.positionManager.UpdateLighterPosition("BTC", &Position{Symbol: "BTC", Size: -0.002, Value: -100})
+
+	if err := h.Shutdown(ShutdownModeFlatten, 0); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if got := h.lighter.position(1); got != 0 {
+		t.Fatalf("lighter position after flatten = %v, want 0", got)
+	}
+	closes := len(h.lighter.orders())
+
+	// 关闭平仓后监控循环的周期不再执行 (不再同步仓位或下单)
+	syncs := h.lighter.count("GetPositions")
+	h.runCycle(t.Context(), h.config)
+	if got := h.lighter.count("GetPositions"); got != syncs {
+		t.Fatalf("GetPositions calls after shutdown = %d, want %d", got, syncs)
+	}
+	if got := len(h.lighter.orders()); got != closes {
+		t.Fatalf("lighter orders after shutdown = %d, want %d", got, closes)
+	}
+}
+
+func TestFlattenCancelsMakersThroughOrderMonitor(t *testing.T) {
+	h := newTestHedge(t)
+
+	if _, err := h.openingManager.ExecuteOpeningLogic(t.Context(), h.config); err != nil {
+		t.Fatalf("ExecuteOpeningLogic: %v", err)
+	}
+	_, id := h.onlyOrder(t)
+
+	// 上一轮检查之后新增的成交在撤单时确认并对冲
+	h.binance.fillNotional(id, 30)
+	if n, err := h.flattenManager.CancelAllOrders(t.Context()); n != 1 {
+		t.Fatalf("CancelAllOrders = %d, %v, want 1", n, err)
+	}
+	if _, sell := h.lighterHedged(); sell != 30 {
+		t.Fatalf("lighter hedged = %d, want 30", sell)
+	}
+	if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+		t.Fatalf("active orders after flatten cancel = %d, want 0", n)
+	}
+}
+
+func TestFlattenWindowUsesSessionTimezone(t *testing.T) {
+	h := newTestHedge(t)
+	tokyo := time.FixedZone("JST", 9*3600)
+	config := &DynamicHedgeConfig{FlattenTime: "23:00", FlattenResumeTime: "01:00", SessionLocation: tokyo}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"before window", time.Date(2026, 1, 5, 13, 59, 0, 0, time.UTC), false},
+		{"window start", time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC), true},
+		{"after midnight", time.Date(2026, 1, 5, 15, 30, 0, 0, time.UTC), true},
+		{"resume", time.Date(2026, 1, 5, 16, 0, 0, 0, time.UTC), false},
+		{"utc clock inside window", time.Date(2026, 1, 5, 23, 30, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.flattenManager.InFlattenWindow(config, tt.now); got != tt.want {
+				t.Fatalf("InFlattenWindow(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+
+	start := h.flattenManager.windowStart(config, time.Date(2026, 1, 5, 15, 30, 0, 0, time.UTC))
+	if want := time.Date(2026, 1, 5, 23, 0, 0, 0, tokyo); !start.Equal(want) {
+		t.Fatalf("windowStart = %v, want %v", start, want)
+	}
+}
@@ -0,0 +1,227 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"cs-projects-backpack/pkg/binance"
+)
+
+// EmergencyCloseOrder 紧急平仓将发送的一笔市价单 (预览，不下单)
+type EmergencyCloseOrder struct {
+	Exchange        string  `json:"exchange"`
+	Symbol          string  `json:"symbol"`
+	Side            string  `json:"side"`
+	Quantity        float64 `json:"quantity"` // 基础资产数量
+	ReduceOnly      bool    `json:"reduce_only"`
+	EntryPrice      float64 `json:"entry_price"`
+	BestPrice       float64 `json:"best_price"`               // 盘口最优价 (0为未获取)
+	ExpectedPrice   float64 `json:"expected_price"`           // 按盘口逐档成交估算的均价 (0为无法估算)
+	SlippagePercent float64 `json:"slippage_percent"`         // 成交均价相对最优价的不利滑点 (%)
+	Notional        float64 `json:"notional"`                 // 预计成交金额
+	Fee             float64 `json:"fee"`                      // 预计Taker手续费
+	RealizedPnL     float64 `json:"realized_pnl"`             // 按开仓均价预计实现的盈亏 (未扣手续费)
+	EstimateError   string  `json:"estimate_error,omitempty"` // 估算失败原因 (盘口深度不足等)，此时按最优价或开仓价计算金额
+}
+
+// VenueClosePreview 单个交易所紧急平仓后的预计余额
+type VenueClosePreview struct {
+	Equity          float64 `json:"equity"`                 // 当前账户权益
+	SlippageCost    float64 `json:"slippage_cost"`          // 相对最优价的滑点成本
+	Fees            float64 `json:"fees"`                   // 预计手续费
+	ProjectedEquity float64 `json:"projected_equity"`       // 平仓后预计权益 = 权益 - 滑点成本 - 手续费
+	EquityError     string  `json:"equity_error,omitempty"` // 查询权益失败的原因，此时不计算平仓后权益
+}
+
+// EmergencyClosePreview 紧急平仓预览：按当前仓位和盘口计算紧急平仓将发送的市价单，不下单也不撤单
+type EmergencyClosePreview struct {
+	Orders           []EmergencyCloseOrder         `json:"orders"`
+	ProtectiveOrders int                           `json:"protective_orders"` // 平仓前将撤销的保护单数量
+	Venues           map[string]*VenueClosePreview `json:"venues"`            // lighter, binance
+	GeneratedAt      time.Time                     `json:"generated_at"`
+}
+
+// PreviewEmergencyClosing 计算 ExecuteEmergencyClosing 将发送的市价单及预计滑点和平仓后余额。
+// 与实际执行一致：Binance按本地仓位下单，Lighter按交易所实际持仓下只减仓单
+func (cm *ClosingManager) PreviewEmergencyClosing(ctx context.Context) (*EmergencyClosePreview, error) {
+	preview := &EmergencyClosePreview{
+		Orders: []EmergencyCloseOrder{},
+		Venues: map[string]*VenueClosePreview{
+			"lighter": {},
+			"binance": {},
+		},
+		GeneratedAt: time.Now(),
+	}
+
+	for _, order := range cm.orderManager.GetActiveOrders() {
+		if order.isProtective() {
+			preview.ProtectiveOrders++
+		}
+	}
+
+	binanceOrders := cm.previewBinanceOrders(ctx)
+	lighterOrders, err := cm.previewLighterOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	preview.Orders = append(preview.Orders, binanceOrders...)
+	preview.Orders = append(preview.Orders, lighterOrders...)
+
+	for _, o := range preview.Orders {
+		venue := preview.Venues[o.Exchange]
+		if o.BestPrice > 0 && o.ExpectedPrice > 0 {
+			venue.SlippageCost += o.Quantity * math.Abs(o.ExpectedPrice-o.BestPrice)
+		}
+		venue.Fees += o.Fee
+	}
+
+	cm.previewEquity(preview.Venues["lighter"], func() (float64, error) {
+		return cm.hedgeStrategy.lighterStrategy.client.GetAccountEquity(ctx)
+	})
+	cm.previewEquity(preview.Venues["binance"], func() (float64, error) {
+		return cm.hedgeStrategy.binanceStrategy.client.GetAccountEquity(ctx)
+	})
+
+	return preview, nil
+}
+
+// previewBinanceOrders 按本地仓位生成Binance市价平仓单，合约市场为只减仓单
+func (cm *ClosingManager) previewBinanceOrders(ctx context.Context) []EmergencyCloseOrder {
+	client := cm.hedgeStrategy.binanceStrategy.client
+	positions := cm.positionManager.positionsSnapshot("binance")
+
+	var orders []EmergencyCloseOrder
+	for _, symbol := range sortedSymbols(positions) {
+		pos := positions[symbol]
+		if pos.Size == 0 {
+			continue
+		}
+		o := EmergencyCloseOrder{
+			Exchange:   "binance",
+			Symbol:     symbol,
+			Side:       closingSide(pos.Size),
+			Quantity:   math.Abs(pos.Size),
+			ReduceOnly: client.Market() == binance.MarketFutures,
+			EntryPrice: pos.EntryPrice,
+		}
+		best, avg, err := client.EstimateMarketFill(ctx, cm.hedgeStrategy.binanceStrategy.pair(symbol), o.Side, o.Quantity)
+		cm.applyFillEstimate(&o, best, avg, err)
+		orders = append(orders, o)
+	}
+	return orders
+}
+
+// previewLighterOrders 按交易所实际持仓生成Lighter只减仓市价单，只包含本地有仓位的币种
+func (cm *ClosingManager) previewLighterOrders(ctx context.Context) ([]EmergencyCloseOrder, error) {
+	local := cm.positionManager.positionsSnapshot("lighter")
+	wanted := make(map[uint8]string)
+	for symbol, pos := range local {
+		if pos.Size == 0 {
+			continue
+		}
+		marketIndex, err := cm.hedgeStrategy.lighterStrategy.marketIndex(symbol)
+		if err != nil {
+			return nil, err
+		}
+		wanted[marketIndex] = symbol
+	}
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+
+	client := cm.hedgeStrategy.lighterStrategy.client
+	positions, err := client.GetPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Lighter positions: %w", err)
+	}
+
+	var orders []EmergencyCloseOrder
+	for _, pos := range positions {
+		symbol, ok := wanted[pos.MarketIndex]
+		if !ok || pos.Size == 0 {
+			continue
+		}
+		o := EmergencyCloseOrder{
+			Exchange:   "lighter",
+			Symbol:     symbol,
+			Side:       closingSide(pos.Size),
+			Quantity:   math.Abs(pos.Size),
+			ReduceOnly: true,
+			EntryPrice: pos.EntryPrice,
+		}
+		best, avg, err := client.EstimateMarketFill(ctx, pos.MarketIndex, o.Side, o.Quantity)
+		cm.applyFillEstimate(&o, best, avg, err)
+		orders = append(orders, o)
+	}
+
+	sort.Slice(orders, func(i, j int) bool { return orders[i].Symbol < orders[j].Symbol })
+	return orders, nil
+}
+
+// applyFillEstimate 按盘口估算结果计算滑点、成交金额、手续费和预计盈亏。
+// 无法估算成交均价时按最优价计算金额，最优价也未获取时按开仓价
+func (cm *ClosingManager) applyFillEstimate(o *EmergencyCloseOrder, best, avg float64, err error) {
+	if err != nil {
+		o.EstimateError = err.Error()
+	}
+	o.BestPrice = best
+	o.ExpectedPrice = avg
+
+	price := avg
+	if price <= 0 {
+		price = best
+	}
+	if price <= 0 {
+		price = o.EntryPrice
+	}
+	if best > 0 && avg > 0 {
+		o.SlippagePercent = math.Abs(avg-best) / best * 100
+	}
+
+	o.Notional = o.Quantity * price
+	o.Fee = cm.positionManager.Fee(o.Exchange, LiquidityTaker, o.Notional)
+	if o.EntryPrice > 0 {
+		// 卖出平多按 (成交价 - 开仓价) 计算，买入平空相反
+		o.RealizedPnL = (price - o.EntryPrice) * o.Quantity
+		if o.Side == "BUY" {
+			o.RealizedPnL = -o.RealizedPnL
+		}
+	}
+}
+
+// previewEquity 查询当前账户权益并计算平仓后预计权益
+func (cm *ClosingManager) previewEquity(venue *VenueClosePreview, query func() (float64, error)) {
+	equity, err := query()
+	if err != nil {
+		venue.EquityError = err.Error()
+		return
+	}
+	venue.Equity = equity
+	venue.ProjectedEquity = equity - venue.SlippageCost - venue.Fees
+}
+
+// closingSide 平掉仓位的下单方向：多头卖出，空头买入
+func closingSide(size float64) string {
+	if size > 0 {
+		return "SELL"
+	}
+	return "BUY"
+}
+
+// sortedSymbols 按币种排序的仓位键，保证预览结果顺序稳定
+func sortedSymbols(positions map[string]Position) []string {
+	symbols := make([]string, 0, len(positions))
+	for symbol := range positions {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// PreviewEmergencyClosing 紧急平仓预览，不下单
+func (s *DynamicHedgeStrategy) PreviewEmergencyClosing(ctx context.Context) (*EmergencyClosePreview, error) {
+	return s.closingManager.PreviewEmergencyClosing(ctx)
+}
@@ -0,0 +1,154 @@
+package strategy
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 分片执行算法
+const (
+	ExecutionAlgoTWAP = "twap"
+	ExecutionAlgoVWAP = "vwap"
+)
+
+// SlicedExecutor 分片执行器：把目标金额拆分为多笔子订单在时间窗口内下达
+type SlicedExecutor interface {
+	Name() string
+	IsRunning() bool
+	Execute(ctx context.Context, symbol string, target float64, place SliceFunc) (*ExecutionResult, error)
+}
+
+// SliceFunc 下达一笔子订单，notional 为子订单金额 (USDT/USDC)
+type SliceFunc func(ctx context.Context, index int, notional float64) error
+
+// ExecutionResult 分片执行结果
+type ExecutionResult struct {
+	Target   float64       `json:"target"`   // 目标金额
+	Executed float64       `json:"executed"` // 已下单金额
+	Slices   int           `json:"slices"`   // 已下达的子订单数
+	Duration time.Duration `json:"duration"`
+}
+
+// newSlicedExecutor 根据配置创建分片执行器
+func (s *DynamicHedgeStrategy) newSlicedExecutor(config *DynamicHedgeConfig) SlicedExecutor {
+	if config.ExecutionAlgo != ExecutionAlgoVWAP {
+		return NewTWAPExecutor(config.TWAPWindow, config.TWAPSlices, s.logger)
+	}
+
+	volume := func(ctx context.Context, symbol string, start, end time.Time) (float64, error) {
+		return s.binanceStrategy.client.GetQuoteVolume(ctx, s.binanceStrategy.pair(symbol), start, end)
+	}
+	return NewVWAPExecutor(config.TWAPWindow, config.TWAPSlices, config.VWAPLookback, volume, s.logger)
+}
+
+// placeMakerOrder 下Binance Maker单并加入监控；订单金额相对盘口深度过大时改用TWAP/VWAP分片执行。
+// role 为订单用途 (OrderRoleOpen/OrderRoleClose)，place 负责实际下单并返回订单ID。
+func (s *DynamicHedgeStrategy) placeMakerOrder(
+	ctx context.Context,
+	config *DynamicHedgeConfig,
+	symbol, side, role string,
+	size float64,
+	place func(ctx context.Context, size float64) (string, error),
+) error {
+	placeAndTrack := func(ctx context.Context, size float64) error {
+		orderID, err := place(ctx, size)
+		if err != nil {
+			return err
+		}
+
+		s.trackMakerOrder(orderID, symbol, side, role, s.cycles.ID(symbol), size, 0)
+		return nil
+	}
+
+	if !s.shouldSliceOrder(ctx, config, symbol, side, size) {
+		return placeAndTrack(ctx, size)
+	}
+
+	// 分片执行在后台进行，期间该币种不会开始新的交易 (见 symbolBusy)
+	s.setSlicing(symbol, true)
+	s.goSafe("sliced-execution", func() error {
+		defer s.setSlicing(symbol, false)
+
+		_, err := s.slicedExecutor.Execute(ctx, symbol, size, func(ctx context.Context, _ int, notional float64) error {
+			return placeAndTrack(ctx, notional)
+		})
+		if err != nil {
+			s.logger.Error("Sliced execution failed",
+				zap.String("algo", s.slicedExecutor.Name()),
+				zap.String("symbol", symbol),
+				zap.String("side", side),
+				zap.Error(err),
+			)
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// trackMakerOrder 将已挂出的Binance Maker单加入监控并关联到对冲周期，chases 为该笔订单已重挂的次数
+func (s *DynamicHedgeStrategy) trackMakerOrder(orderID, symbol, side, role, cycleID string, size float64, chases int) {
+	s.orderManager.AddOrder(&ActiveOrder{
+		ID:        orderID,
+		Exchange:  "binance",
+		Symbol:    symbol,
+		Side:      side,
+		Size:      size,
+		Status:    "PENDING",
+		Role:      role,
+		CycleID:   cycleID,
+		Chases:    chases,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+
+	s.logger.Info("Binance maker order placed and added to monitoring",
+		zap.String("order_id", orderID),
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.String("role", role),
+		zap.String("cycle_id", cycleID),
+		zap.Float64("size", size),
+	)
+	s.cycles.Link(cycleID, orderID)
+}
+
+// repriceOrder 按最新最优价重新挂出订单的剩余金额，新订单沿用原订单用途并累加重挂次数，返回新订单ID
+func (s *DynamicHedgeStrategy) repriceOrder(ctx context.Context, config *DynamicHedgeConfig, order *ActiveOrder, remaining float64) (string, error) {
+	id, err := s.binanceStrategy.placeMakerOrder(ctx, order.Symbol, order.Side, remaining, s.spreadPercent(config, order.Symbol))
+	if err != nil {
+		return "", err
+	}
+
+	orderID := strconv.FormatInt(id, 10)
+	s.trackMakerOrder(orderID, order.Symbol, order.Side, order.Role, order.CycleID, remaining, order.Chases+1)
+	return orderID, nil
+}
+
+// shouldSliceOrder 订单金额超过盘口深度的一定比例时分片执行
+func (s *DynamicHedgeStrategy) shouldSliceOrder(ctx context.Context, config *DynamicHedgeConfig, symbol, side string, size float64) bool {
+	if s.slicedExecutor == nil || config.TWAPSlices <= 1 {
+		return false
+	}
+
+	depth, err := s.binanceStrategy.client.GetDepthNotional(ctx, s.binanceStrategy.pair(symbol), side, config.TWAPDepthPercent)
+	if err != nil {
+		s.logger.Warn("Failed to get book depth, placing single order", zap.String("symbol", symbol), zap.Error(err))
+		return false
+	}
+
+	sliced := size > depth*config.TWAPDepthRatio
+
+	s.logger.Debug("Checked book depth for sliced execution",
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("size", size),
+		zap.Float64("depth_notional", depth),
+		zap.Bool("sliced", sliced),
+	)
+
+	return sliced
+}
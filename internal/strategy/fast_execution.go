@@ -0,0 +1,689 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/retry"
+)
+
+// DefaultExecutionDelayBuckets 对冲执行延迟直方图默认分桶上界
+var DefaultExecutionDelayBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+}
+
+// FastExecutionManager 快速执行管理器 - 优化Binance到Lighter的执行延迟
+type FastExecutionManager struct {
+	hedgeStrategy   *DynamicHedgeStrategy
+	orderManager    *OrderManager
+	positionManager *PositionManager
+	logger          *zap.Logger
+
+	// 执行配置
+	config *FastExecutionConfig
+
+	// 延迟统计
+	executionStats *ExecutionStats
+	delayHistogram prometheus.Histogram // 成功对冲的执行延迟分布，通过 /metrics 导出
+	mu             sync.RWMutex
+
+	// 对冲交易所最新价格缓存 (symbol -> 价格)，有效期为 PriceValidityWindow
+	priceMu    sync.Mutex
+	priceCache map[string]cachedPrice
+}
+
+// cachedPrice 缓存的市场价格
+type cachedPrice struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// FastExecutionConfig 快速执行配置
+type FastExecutionConfig struct {
+	// 检查频率优化
+	HighFrequencyMode bool          // 高频检查模式
+	CheckInterval     time.Duration // 订单检查间隔 (默认200ms)
+	MaxExecutionDelay time.Duration // 最大允许执行延迟 (默认500ms)
+
+	// 预执行机制
+	EnablePreExecution   bool    // 启用预执行 (部分成交即开始对冲)
+	PartialFillThreshold float64 // 部分成交阈值 (50%即开始对冲)
+
+	// 价格保护
+	EnablePriceProtection bool          // 启用价格保护
+	MaxSlippagePercent    float64       // 最大滑点百分比 (默认0.1%)
+	PriceValidityWindow   time.Duration // 价格有效期窗口 (默认1秒)
+	RejectOnSlippage      bool          // 滑点超限时拒绝对冲 (false为仅告警并继续对冲)
+
+	// 并发优化
+	EnableConcurrentExecution bool // 启用并发执行
+	MaxConcurrentOrders       int  // 最大并发订单数
+
+	// 重试机制
+	EnableRetry bool         // 启用重试
+	RetryPolicy retry.Policy // 对冲下单重试策略 (指数退避 + 抖动)
+
+	// 延迟直方图分桶上界 (升序)，为空时使用 DefaultExecutionDelayBuckets
+	DelayBuckets []time.Duration
+}
+
+// ExecutionStats 执行统计信息
+type ExecutionStats struct {
+	TotalExecutions      int64         `json:"total_executions"`
+	SuccessfulExecutions int64         `json:"successful_executions"`
+	FailedExecutions     int64         `json:"failed_executions"`
+	AverageDelay         time.Duration `json:"average_delay"`
+	MinDelay             time.Duration `json:"min_delay"`
+	MaxDelay             time.Duration `json:"max_delay"`
+	LastExecutionTime    time.Time     `json:"last_execution_time"`
+	DelayBreaches        int64         `json:"delay_breaches"` // 执行延迟超过 MaxExecutionDelay 的成功对冲次数
+
+	// 价格保护
+	PriceRejections int64   `json:"price_rejections"`  // 滑点超限被拒绝的对冲次数
+	SlippageAlerts  int64   `json:"slippage_alerts"`   // 滑点超限但仍执行的对冲次数
+	MaxSlippageSeen float64 `json:"max_slippage_seen"` // 观察到的最大不利滑点 (%)
+
+	// 最大不利偏移 (MAE)：Maker成交到对冲完成之间，未对冲敞口按观察到的价格计算的最大浮亏
+	AvgAdversePercent   float64 `json:"avg_adverse_percent"`   // 成功对冲的平均MAE (%)
+	MaxAdversePercent   float64 `json:"max_adverse_percent"`   // 单次对冲的最大MAE (%)
+	MaxAdverseExcursion float64 `json:"max_adverse_excursion"` // 单次对冲的最大MAE金额 (USD)
+
+	// 延迟分布
+	DelayHistogram []DelayBucket `json:"delay_histogram"`
+}
+
+// DelayBucket 延迟直方图分桶，Count 为延迟不超过 UpperBound 的成功执行次数 (累计)，
+// 最后一个分桶的 UpperBound 为0，表示全部成功执行
+type DelayBucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      int64         `json:"count"`
+}
+
+// ExecutionContext 执行上下文
+type ExecutionContext struct {
+	OrderID        string        `json:"order_id"`
+	Symbol         string        `json:"symbol"`
+	OriginalSide   string        `json:"original_side"`
+	HedgeSide      string        `json:"hedge_side"`
+	Size           float64       `json:"size"`
+	OriginalPrice  float64       `json:"original_price"`
+	ExecutionPrice float64       `json:"execution_price"`        // 对冲成交价 (0为未知：Lighter市价单提交时没有成交回报)
+	MarketPrice    float64       `json:"market_price,omitempty"` // 对冲前的参考价格 (0为未获取)
+	HedgeTxHash    string        `json:"hedge_tx_hash,omitempty"`
+	StartTime      time.Time     `json:"start_time"`
+	DetectionTime  time.Time     `json:"detection_time"`
+	ExecutionTime  time.Time     `json:"execution_time"`
+	CompletionTime time.Time     `json:"completion_time"`
+	TotalDelay     time.Duration `json:"total_delay"`
+	Success        bool          `json:"success"`
+	ErrorMessage   string        `json:"error_message,omitempty"`
+
+	// 最大不利偏移 (MAE)：按Binance最优挂单中间价、参考价格和对冲成交价采样，0为未出现不利偏移
+	AdversePercent   float64 `json:"adverse_percent"`   // 相对Maker成交价的最大不利偏移 (%)
+	AdverseExcursion float64 `json:"adverse_excursion"` // 对应的未对冲敞口浮亏 (USD)
+}
+
+// NewFastExecutionManager 创建快速执行管理器
+func NewFastExecutionManager(hedgeStrategy *DynamicHedgeStrategy) *FastExecutionManager {
+	return &FastExecutionManager{
+		hedgeStrategy:   hedgeStrategy,
+		orderManager:    hedgeStrategy.orderManager,
+		positionManager: hedgeStrategy.positionManager,
+		logger:          hedgeStrategy.logger.Named("fast-execution"),
+		config:          NewDefaultFastExecutionConfig(),
+		executionStats:  NewExecutionStats(),
+		delayHistogram:  newDelayHistogram(DefaultExecutionDelayBuckets),
+		priceCache:      make(map[string]cachedPrice),
+	}
+}
+
+// NewDefaultFastExecutionConfig 创建默认快速执行配置
+func NewDefaultFastExecutionConfig() *FastExecutionConfig {
+	return &FastExecutionConfig{
+		HighFrequencyMode:         true,
+		CheckInterval:             200 * time.Millisecond,
+		MaxExecutionDelay:         500 * time.Millisecond,
+		EnablePreExecution:        true,
+		PartialFillThreshold:      0.5, // 50%
+		EnablePriceProtection:     true,
+		MaxSlippagePercent:        0.1, // 0.1%
+		PriceValidityWindow:       1 * time.Second,
+		EnableConcurrentExecution: true,
+		MaxConcurrentOrders:       3,
+		EnableRetry:               true,
+		RetryPolicy:               defaultHedgeRetryPolicy(),
+		DelayBuckets:              DefaultExecutionDelayBuckets,
+	}
+}
+
+// newDelayHistogram 创建对冲执行延迟直方图，分桶上界按秒导出
+func newDelayHistogram(buckets []time.Duration) prometheus.Histogram {
+	bounds := make([]float64, len(buckets))
+	for i, b := range buckets {
+		bounds[i] = b.Seconds()
+	}
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "hedge_execution_delay_seconds",
+		Help:    "Delay from Binance fill detection to completed Lighter hedge, successful executions only.",
+		Buckets: bounds,
+	})
+}
+
+// defaultHedgeRetryPolicy 对冲下单默认重试策略，退避比普通接口更短以控制对冲延迟
+func defaultHedgeRetryPolicy() retry.Policy {
+	return retry.Policy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NewExecutionStats 创建执行统计
+func NewExecutionStats() *ExecutionStats {
+	return &ExecutionStats{
+		MinDelay: time.Hour, // 初始化为一个大值
+	}
+}
+
+// ExecuteFastHedge 快速执行对冲交易
+func (fem *FastExecutionManager) ExecuteFastHedge(
+	ctx context.Context,
+	orderID, symbol, originalSide string,
+	size, originalPrice float64,
+) (*ExecutionContext, error) {
+	execCtx := &ExecutionContext{
+		OrderID:       orderID,
+		Symbol:        symbol,
+		OriginalSide:  originalSide,
+		Size:          size,
+		OriginalPrice: originalPrice,
+		StartTime:     time.Now(),
+	}
+
+	fem.logger.Info("Starting fast hedge execution",
+		zap.String("order_id", orderID),
+		zap.String("symbol", symbol),
+		zap.String("side", originalSide),
+		zap.Float64("size", size),
+		zap.Float64("price", originalPrice),
+	)
+
+	// 1. 确定对冲方向
+	hedgeSide := fem.determineHedgeSide(symbol, originalSide)
+	execCtx.HedgeSide = hedgeSide
+	fem.sampleExcursion(execCtx)
+
+	// 2. 价格保护检查
+	if fem.config.EnablePriceProtection {
+		market, err := fem.validatePrice(ctx, symbol, originalSide, originalPrice)
+		execCtx.MarketPrice = market
+		execCtx.observe(market)
+		if err != nil {
+			execCtx.Success = false
+			execCtx.ErrorMessage = fmt.Sprintf("price validation failed: %v", err)
+			execCtx.CompletionTime = time.Now()
+			fem.updateStats(execCtx)
+			return execCtx, err
+		}
+	}
+
+	execCtx.DetectionTime = time.Now()
+
+	// 3. 执行对冲交易
+	executionPrice, err := fem.executeHedgeWithRetry(ctx, execCtx)
+	if err != nil {
+		execCtx.Success = false
+		execCtx.ErrorMessage = err.Error()
+		fem.updateStats(execCtx)
+		return execCtx, err
+	}
+
+	execCtx.ExecutionPrice = executionPrice
+	execCtx.observe(executionPrice)
+	execCtx.ExecutionTime = time.Now()
+	execCtx.CompletionTime = time.Now()
+	execCtx.TotalDelay = execCtx.CompletionTime.Sub(execCtx.StartTime)
+	execCtx.Success = true
+
+	// 4. 更新统计信息
+	fem.updateStats(execCtx)
+
+	fem.logger.Info("Fast hedge execution completed",
+		zap.String("order_id", orderID),
+		zap.Duration("total_delay", execCtx.TotalDelay),
+		zap.Float64("execution_price", executionPrice),
+		zap.Float64("adverse_percent", execCtx.AdversePercent),
+		zap.Float64("adverse_excursion", execCtx.AdverseExcursion),
+		zap.Bool("success", true),
+	)
+
+	return execCtx, nil
+}
+
+// sampleExcursion 按Binance最优挂单中间价 (推送缓存，不请求接口) 采样未对冲敞口的不利偏移
+func (fem *FastExecutionManager) sampleExcursion(execCtx *ExecutionContext) {
+	pair := fem.hedgeStrategy.binanceStrategy.pair(execCtx.Symbol)
+	if t, ok := fem.hedgeStrategy.binanceStrategy.client.GetBookTicker(pair); ok {
+		execCtx.observe(t.Mid())
+	}
+}
+
+// observe 用观察到的价格更新最大不利偏移：Maker买入后价格下跌、卖出后价格上涨为不利
+func (execCtx *ExecutionContext) observe(price float64) {
+	if price <= 0 || execCtx.OriginalPrice <= 0 {
+		return
+	}
+
+	adverse := (execCtx.OriginalPrice - price) / execCtx.OriginalPrice
+	if execCtx.OriginalSide == "SELL" {
+		adverse = -adverse
+	}
+	if adverse*100 > execCtx.AdversePercent {
+		execCtx.AdversePercent = adverse * 100
+		execCtx.AdverseExcursion = execCtx.Size * adverse
+	}
+}
+
+// determineHedgeSide 确定对冲方向
+func (fem *FastExecutionManager) determineHedgeSide(symbol, originalSide string) string {
+	// Binance成交 -> Lighter反向对冲
+	if _, err := fem.hedgeStrategy.symbols.Get(symbol); err != nil {
+		fem.logger.Warn("Unexpected trading pair for hedge",
+			zap.String("symbol", symbol),
+			zap.String("side", originalSide),
+		)
+	}
+	return oppositeSide(originalSide)
+}
+
+// validatePrice 验证对冲价格：比较Maker成交价与Lighter当前价格，计算对冲方向上的不利滑点。
+// 超过 MaxSlippagePercent 时按配置拒绝对冲或仅告警，结果计入执行统计。返回参考价格 (未获取时为0)
+func (fem *FastExecutionManager) validatePrice(ctx context.Context, symbol, originalSide string, price float64) (float64, error) {
+	if price <= 0 {
+		fem.logger.Warn("Fill price unknown, skipping price validation", zap.String("symbol", symbol))
+		return 0, nil
+	}
+
+	market, err := fem.getMarketPrice(ctx, symbol)
+	if err != nil {
+		// 取价失败不阻塞对冲，避免留下单边敞口
+		fem.logger.Warn("Failed to get market price for validation", zap.String("symbol", symbol), zap.Error(err))
+		return 0, nil
+	}
+
+	// Maker买入后在Lighter卖出，市价低于成交价为不利滑点；Maker卖出后买入则相反
+	slippage := (price - market) / price * 100
+	if originalSide == "SELL" {
+		slippage = -slippage
+	}
+
+	fem.logger.Debug("Validating execution price",
+		zap.String("symbol", symbol),
+		zap.Float64("fill_price", price),
+		zap.Float64("market_price", market),
+		zap.Float64("slippage_percent", slippage),
+		zap.Float64("max_slippage", fem.config.MaxSlippagePercent),
+	)
+
+	if slippage <= fem.config.MaxSlippagePercent {
+		fem.recordSlippage(slippage, false, false)
+		return market, nil
+	}
+
+	reject := fem.config.RejectOnSlippage
+	fem.recordSlippage(slippage, true, reject)
+
+	fem.logger.Warn("Hedge slippage exceeds limit",
+		zap.String("symbol", symbol),
+		zap.String("side", originalSide),
+		zap.Float64("fill_price", price),
+		zap.Float64("market_price", market),
+		zap.Float64("slippage_percent", slippage),
+		zap.Float64("max_slippage", fem.config.MaxSlippagePercent),
+		zap.Bool("rejected", reject),
+	)
+
+	if reject {
+		return market, fmt.Errorf("slippage %.4f%% exceeds limit %.4f%% (fill %.6f, market %.6f)",
+			slippage, fem.config.MaxSlippagePercent, price, market)
+	}
+	return market, nil
+}
+
+// getMarketPrice 获取参考市场价格：启用聚合价格时取多源中位数，
+// 否则取对冲交易所 (Lighter) 的最新价格，PriceValidityWindow 内复用缓存
+func (fem *FastExecutionManager) getMarketPrice(ctx context.Context, symbol string) (float64, error) {
+	if feed := fem.hedgeStrategy.priceFeed; feed != nil {
+		p, err := feed.Price(ctx, symbol)
+		if err == nil {
+			return p.Median, nil
+		}
+		fem.logger.Warn("Aggregated price unavailable, falling back to Lighter price",
+			zap.String("symbol", symbol),
+			zap.Error(err),
+		)
+	}
+
+	fem.priceMu.Lock()
+	cached, ok := fem.priceCache[symbol]
+	fem.priceMu.Unlock()
+
+	if ok && time.Since(cached.fetchedAt) < fem.config.PriceValidityWindow {
+		return cached.price, nil
+	}
+
+	price, err := fem.hedgeStrategy.lighterStrategy.lastPrice(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	fem.priceMu.Lock()
+	fem.priceCache[symbol] = cachedPrice{price: price, fetchedAt: time.Now()}
+	fem.priceMu.Unlock()
+
+	return price, nil
+}
+
+// recordSlippage 记录价格保护统计
+func (fem *FastExecutionManager) recordSlippage(slippage float64, exceeded, rejected bool) {
+	fem.mu.Lock()
+	defer fem.mu.Unlock()
+
+	stats := fem.executionStats
+	if slippage > stats.MaxSlippageSeen {
+		stats.MaxSlippageSeen = slippage
+	}
+	switch {
+	case rejected:
+		stats.PriceRejections++
+	case exceeded:
+		stats.SlippageAlerts++
+	}
+}
+
+// executeHedgeWithRetry 带重试的对冲执行
+func (fem *FastExecutionManager) executeHedgeWithRetry(ctx context.Context, execCtx *ExecutionContext) (float64, error) {
+	policy := fem.config.RetryPolicy
+	if !fem.config.EnableRetry {
+		policy.MaxAttempts = 1
+	}
+
+	return retry.DoValue(ctx, policy, "lighter hedge "+execCtx.Symbol, func(ctx context.Context) (float64, error) {
+		fem.sampleExcursion(execCtx)
+		return fem.executeLighterHedge(ctx, execCtx)
+	})
+}
+
+// executeLighterHedge 在Lighter执行对冲交易，交易所接受提交后返回。
+// 提交结果中的价格是市价单的保护价 (NilOrderPrice) 而非成交价，成交价未知时返回0
+func (fem *FastExecutionManager) executeLighterHedge(ctx context.Context, execCtx *ExecutionContext) (float64, error) {
+	fem.logger.Info("Executing Lighter hedge with optimized parameters",
+		zap.String("symbol", execCtx.Symbol),
+		zap.String("side", execCtx.HedgeSide),
+		zap.Float64("size", execCtx.Size),
+	)
+
+	usdtAmount := int64(math.Round(fem.hedgeStrategy.lighterNotional(execCtx.Symbol, execCtx.Size)))
+	leverage := fem.hedgeStrategy.lighterLeverage(execCtx.Symbol)
+
+	order, err := fem.hedgeStrategy.lighterStrategy.placeMarketOrder(ctx, execCtx.Symbol, execCtx.HedgeSide, usdtAmount, leverage)
+	if err != nil {
+		return 0, fmt.Errorf("failed to place %s %s on Lighter: %w", execCtx.Symbol, execCtx.HedgeSide, err)
+	}
+	execCtx.HedgeTxHash = order.GetTxHash()
+	return 0, nil
+}
+
+// updateStats 更新执行统计
+func (fem *FastExecutionManager) updateStats(execCtx *ExecutionContext) {
+	fem.mu.Lock()
+	defer fem.mu.Unlock()
+
+	stats := fem.executionStats
+	stats.TotalExecutions++
+	stats.LastExecutionTime = execCtx.CompletionTime
+
+	if execCtx.Success {
+		stats.SuccessfulExecutions++
+
+		delay := execCtx.TotalDelay
+
+		// 更新延迟统计
+		if delay < stats.MinDelay {
+			stats.MinDelay = delay
+		}
+		if delay > stats.MaxDelay {
+			stats.MaxDelay = delay
+		}
+		if fem.IsDelayExcessive(delay) {
+			stats.DelayBreaches++
+			fem.logger.Warn("Hedge execution delay exceeds limit",
+				zap.String("order_id", execCtx.OrderID),
+				zap.String("symbol", execCtx.Symbol),
+				zap.String("hedge_side", execCtx.HedgeSide),
+				zap.Float64("size", execCtx.Size),
+				zap.String("hedge_tx_hash", execCtx.HedgeTxHash),
+				zap.Duration("delay", delay),
+				zap.Duration("max_delay", fem.config.MaxExecutionDelay),
+			)
+		}
+
+		// 更新平均延迟
+		if stats.SuccessfulExecutions == 1 {
+			stats.AverageDelay = delay
+		} else {
+			// 增量更新平均值
+			stats.AverageDelay = time.Duration(
+				(int64(stats.AverageDelay)*int64(stats.SuccessfulExecutions-1) + int64(delay)) /
+					int64(stats.SuccessfulExecutions),
+			)
+		}
+
+		// 更新延迟分布
+		fem.delayHistogram.Observe(delay.Seconds())
+
+		// 更新最大不利偏移
+		n := float64(stats.SuccessfulExecutions)
+		stats.AvgAdversePercent += (execCtx.AdversePercent - stats.AvgAdversePercent) / n
+		if execCtx.AdversePercent > stats.MaxAdversePercent {
+			stats.MaxAdversePercent = execCtx.AdversePercent
+		}
+		if execCtx.AdverseExcursion > stats.MaxAdverseExcursion {
+			stats.MaxAdverseExcursion = execCtx.AdverseExcursion
+		}
+	} else {
+		stats.FailedExecutions++
+	}
+
+	// 记录统计日志
+	fem.logger.Debug("Execution stats updated",
+		zap.Int64("total", stats.TotalExecutions),
+		zap.Int64("successful", stats.SuccessfulExecutions),
+		zap.Int64("failed", stats.FailedExecutions),
+		zap.Duration("avg_delay", stats.AverageDelay),
+		zap.Duration("current_delay", execCtx.TotalDelay),
+	)
+}
+
+// GetExecutionStats 获取执行统计
+func (fem *FastExecutionManager) GetExecutionStats() *ExecutionStats {
+	fem.mu.RLock()
+	defer fem.mu.RUnlock()
+
+	// 返回副本
+	stats := &ExecutionStats{
+		TotalExecutions:      fem.executionStats.TotalExecutions,
+		SuccessfulExecutions: fem.executionStats.SuccessfulExecutions,
+		FailedExecutions:     fem.executionStats.FailedExecutions,
+		AverageDelay:         fem.executionStats.AverageDelay,
+		MinDelay:             fem.executionStats.MinDelay,
+		MaxDelay:             fem.executionStats.MaxDelay,
+		LastExecutionTime:    fem.executionStats.LastExecutionTime,
+		DelayBreaches:        fem.executionStats.DelayBreaches,
+		PriceRejections:      fem.executionStats.PriceRejections,
+		SlippageAlerts:       fem.executionStats.SlippageAlerts,
+		MaxSlippageSeen:      fem.executionStats.MaxSlippageSeen,
+		AvgAdversePercent:    fem.executionStats.AvgAdversePercent,
+		MaxAdversePercent:    fem.executionStats.MaxAdversePercent,
+		MaxAdverseExcursion:  fem.executionStats.MaxAdverseExcursion,
+		DelayHistogram:       delayBuckets(fem.delayHistogram),
+	}
+
+	return stats
+}
+
+// RestoreStats 恢复上次运行保存的执行统计。延迟直方图无法恢复，从零开始
+func (fem *FastExecutionManager) RestoreStats(saved *ExecutionStats) {
+	fem.mu.Lock()
+	defer fem.mu.Unlock()
+
+	stats := fem.executionStats
+	stats.TotalExecutions = saved.TotalExecutions
+	stats.SuccessfulExecutions = saved.SuccessfulExecutions
+	stats.FailedExecutions = saved.FailedExecutions
+	stats.AverageDelay = saved.AverageDelay
+	stats.MaxDelay = saved.MaxDelay
+	stats.LastExecutionTime = saved.LastExecutionTime
+	stats.DelayBreaches = saved.DelayBreaches
+	stats.PriceRejections = saved.PriceRejections
+	stats.SlippageAlerts = saved.SlippageAlerts
+	stats.MaxSlippageSeen = saved.MaxSlippageSeen
+	stats.AvgAdversePercent = saved.AvgAdversePercent
+	stats.MaxAdversePercent = saved.MaxAdversePercent
+	stats.MaxAdverseExcursion = saved.MaxAdverseExcursion
+	if saved.SuccessfulExecutions > 0 {
+		stats.MinDelay = saved.MinDelay
+	}
+
+	fem.logger.Info("Execution stats restored",
+		zap.Int64("total_executions", stats.TotalExecutions),
+		zap.Int64("successful_executions", stats.SuccessfulExecutions),
+		zap.Duration("average_delay", stats.AverageDelay),
+	)
+}
+
+// delayBuckets 读取直方图当前的累计分桶计数
+func delayBuckets(h prometheus.Histogram) []DelayBucket {
+	var m dto.Metric
+	if err := h.Write(&m); err != nil {
+		return nil
+	}
+
+	hist := m.GetHistogram()
+	buckets := make([]DelayBucket, 0, len(hist.GetBucket())+1)
+	for _, b := range hist.GetBucket() {
+		buckets = append(buckets, DelayBucket{
+			UpperBound: time.Duration(b.GetUpperBound() * float64(time.Second)),
+			Count:      int64(b.GetCumulativeCount()),
+		})
+	}
+	buckets = append(buckets, DelayBucket{Count: int64(hist.GetSampleCount())})
+	return buckets
+}
+
+// delayBreachesDesc 执行延迟超限次数，随执行统计持久化，重启后延续
+var delayBreachesDesc = prometheus.NewDesc(
+	"hedge_execution_delay_breaches_total",
+	"Successful hedges whose delay exceeded the configured max execution delay.",
+	nil, nil,
+)
+
+// Describe 实现 prometheus.Collector，导出执行延迟直方图和延迟超限次数
+func (fem *FastExecutionManager) Describe(ch chan<- *prometheus.Desc) {
+	fem.mu.RLock()
+	h := fem.delayHistogram
+	fem.mu.RUnlock()
+	h.Describe(ch)
+	ch <- delayBreachesDesc
+}
+
+// Collect 实现 prometheus.Collector
+func (fem *FastExecutionManager) Collect(ch chan<- prometheus.Metric) {
+	fem.mu.RLock()
+	h := fem.delayHistogram
+	breaches := fem.executionStats.DelayBreaches
+	fem.mu.RUnlock()
+	h.Collect(ch)
+	ch <- prometheus.MustNewConstMetric(delayBreachesDesc, prometheus.CounterValue, float64(breaches))
+}
+
+// UpdateConfig 更新执行配置
+func (fem *FastExecutionManager) UpdateConfig(config *FastExecutionConfig) {
+	fem.mu.Lock()
+	defer fem.mu.Unlock()
+
+	fem.config = config
+
+	// 按配置的分桶重建直方图，已有的延迟分布清零
+	buckets := config.DelayBuckets
+	if len(buckets) == 0 {
+		buckets = DefaultExecutionDelayBuckets
+	}
+	fem.delayHistogram = newDelayHistogram(buckets)
+	fem.logger.Info("Fast execution config updated",
+		zap.Duration("check_interval", config.CheckInterval),
+		zap.Duration("max_delay", config.MaxExecutionDelay),
+		zap.Bool("high_frequency", config.HighFrequencyMode),
+		zap.Bool("pre_execution", config.EnablePreExecution),
+		zap.Float64("partial_threshold", config.PartialFillThreshold),
+	)
+}
+
+// IsDelayExcessive 检查延迟是否超过 MaxExecutionDelay，未配置 (0) 时不限制
+func (fem *FastExecutionManager) IsDelayExcessive(delay time.Duration) bool {
+	return fem.config.MaxExecutionDelay > 0 && delay > fem.config.MaxExecutionDelay
+}
+
+// MaxExecutionDelay 最大允许执行延迟
+func (fem *FastExecutionManager) MaxExecutionDelay() time.Duration {
+	return fem.config.MaxExecutionDelay
+}
+
+// Concurrency 同时执行的对冲数上限，未启用并发执行时为1
+func (fem *FastExecutionManager) Concurrency() int {
+	if !fem.config.EnableConcurrentExecution || fem.config.MaxConcurrentOrders < 1 {
+		return 1
+	}
+	return fem.config.MaxConcurrentOrders
+}
+
+// ShouldPreExecute 部分成交比例达到 PartialFillThreshold 时提前对冲已成交部分 (之后的成交随到随补)，
+// 未达到阈值或未启用预执行时等待完全成交或撤单后一并对冲
+func (fem *FastExecutionManager) ShouldPreExecute(filledSize, size float64) bool {
+	if !fem.config.EnablePreExecution || size <= 0 {
+		return false
+	}
+	return filledSize/size >= fem.config.PartialFillThreshold
+}
+
+// LogPerformanceMetrics 记录性能指标
+func (fem *FastExecutionManager) LogPerformanceMetrics() {
+	stats := fem.GetExecutionStats()
+
+	fem.logger.Info("Fast execution performance metrics",
+		zap.Int64("total_executions", stats.TotalExecutions),
+		zap.Int64("successful_executions", stats.SuccessfulExecutions),
+		zap.Float64("success_rate", float64(stats.SuccessfulExecutions)/float64(stats.TotalExecutions)*100),
+		zap.Duration("average_delay", stats.AverageDelay),
+		zap.Duration("min_delay", stats.MinDelay),
+		zap.Duration("max_delay", stats.MaxDelay),
+		zap.Any("delay_distribution", stats.DelayHistogram),
+		zap.Int64("price_rejections", stats.PriceRejections),
+		zap.Int64("slippage_alerts", stats.SlippageAlerts),
+		zap.Float64("max_slippage_seen", stats.MaxSlippageSeen),
+	)
+}
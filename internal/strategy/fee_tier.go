@@ -0,0 +1,157 @@
+package strategy
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/journal"
+	"cs-projects-backpack/pkg/logger"
+)
+
+// feeTierWindowDays 手续费等级按近30天成交量评定 (按UTC自然日统计)
+const feeTierWindowDays = 30
+
+// FeeTier 手续费等级：近30天成交量达到 MinVolume 时适用的费率 (%)，负数费率为返佣
+type FeeTier struct {
+	Name         string
+	MinVolume    float64
+	MakerPercent float64
+	TakerPercent float64
+}
+
+// FeeTierStatus 交易所的近30天成交量和估算的手续费等级
+type FeeTierStatus struct {
+	Volume30d    float64 `json:"volume_30d"`               // 近30天成交额 (USDT/USDC)
+	Tier         string  `json:"tier,omitempty"`           // 当前等级 (未配置等级表时为空)
+	MakerPercent float64 `json:"maker_percent"`            // 当前等级Maker费率 (%)，负数为返佣
+	TakerPercent float64 `json:"taker_percent"`            // 当前等级Taker费率 (%)
+	NextTier     string  `json:"next_tier,omitempty"`      // 下一等级
+	VolumeToNext float64 `json:"volume_to_next,omitempty"` // 距下一等级还差的成交额
+}
+
+// VolumeTracker 按交易所统计近30天成交量 (按UTC自然日分桶)，根据等级表估算当前手续费等级
+type VolumeTracker struct {
+	tiers  map[string][]FeeTier // exchange -> 按 MinVolume 升序的等级表
+	logger *zap.Logger
+
+	mu    sync.Mutex
+	daily map[string]map[time.Time]float64 // exchange -> UTC日期 -> 成交额
+	tier  map[string]string                // exchange -> 上一次估算的等级，变化时记录日志
+}
+
+// NewVolumeTracker 创建成交量统计
+func NewVolumeTracker() *VolumeTracker {
+	return &VolumeTracker{
+		tiers:  make(map[string][]FeeTier),
+		logger: logger.Named("volume-tracker"),
+		daily:  make(map[string]map[time.Time]float64),
+		tier:   make(map[string]string),
+	}
+}
+
+// SetTiers 设置交易所的手续费等级表 (按 MinVolume 升序)
+func (vt *VolumeTracker) SetTiers(exchange string, tiers []FeeTier) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	vt.tiers[exchange] = tiers
+	vt.tier[exchange] = vt.status(exchange, time.Now()).Tier
+}
+
+// Record 记录一笔成交额
+func (vt *VolumeTracker) Record(exchange string, value float64, at time.Time) {
+	if value <= 0 {
+		return
+	}
+
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	day := utcDay(at)
+	if day.Before(vt.windowStart(time.Now())) {
+		return
+	}
+	if vt.daily[exchange] == nil {
+		vt.daily[exchange] = make(map[time.Time]float64)
+	}
+	vt.daily[exchange][day] += value
+
+	status := vt.status(exchange, time.Now())
+	if prev, ok := vt.tier[exchange]; ok && prev != status.Tier {
+		vt.logger.Info("Estimated fee tier changed",
+			zap.String("exchange", exchange),
+			zap.String("from", prev),
+			zap.String("to", status.Tier),
+			zap.Float64("volume_30d", status.Volume30d),
+			zap.Float64("maker_percent", status.MakerPercent),
+			zap.Float64("taker_percent", status.TakerPercent),
+		)
+	}
+	vt.tier[exchange] = status.Tier
+}
+
+// Seed 从成交日志恢复近30天成交量，重启后不从0开始计算
+func (vt *VolumeTracker) Seed(entries []*journal.Entry) {
+	for _, e := range entries {
+		if e.Reason == "FUNDING" {
+			continue
+		}
+		vt.Record(e.Venue, e.Size, e.Time)
+	}
+}
+
+// Status 返回各交易所的近30天成交量和估算等级
+func (vt *VolumeTracker) Status() map[string]*FeeTierStatus {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	now := time.Now()
+	result := make(map[string]*FeeTierStatus)
+	for exchange := range vt.daily {
+		result[exchange] = vt.status(exchange, now)
+	}
+	for exchange := range vt.tiers {
+		if _, ok := result[exchange]; !ok {
+			result[exchange] = vt.status(exchange, now)
+		}
+	}
+	return result
+}
+
+// status 计算交易所的近30天成交量和等级，同时清理窗口外的日期 (调用方需持有锁)
+func (vt *VolumeTracker) status(exchange string, now time.Time) *FeeTierStatus {
+	start := vt.windowStart(now)
+	status := &FeeTierStatus{}
+	for day, volume := range vt.daily[exchange] {
+		if day.Before(start) {
+			delete(vt.daily[exchange], day)
+			continue
+		}
+		status.Volume30d += volume
+	}
+
+	tiers := vt.tiers[exchange]
+	for _, tier := range tiers {
+		if status.Volume30d < tier.MinVolume {
+			status.NextTier = tier.Name
+			status.VolumeToNext = tier.MinVolume - status.Volume30d
+			break
+		}
+		status.Tier = tier.Name
+		status.MakerPercent = tier.MakerPercent
+		status.TakerPercent = tier.TakerPercent
+	}
+	return status
+}
+
+// windowStart 统计窗口的第一天 (含当天共30天)
+func (vt *VolumeTracker) windowStart(now time.Time) time.Time {
+	return utcDay(now).AddDate(0, 0, -(feeTierWindowDays - 1))
+}
+
+// utcDay 时间所在的UTC自然日
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,55 @@
+package strategy
+
+// 成交的流动性方向，决定按Maker还是Taker费率计费
+const (
+	LiquidityMaker = "MAKER"
+	LiquidityTaker = "TAKER"
+)
+
+// FeeSchedule 各交易所的Maker/Taker手续费率 (%)
+type FeeSchedule struct {
+	BinanceMakerPercent float64
+	BinanceTakerPercent float64
+	LighterMakerPercent float64
+	LighterTakerPercent float64
+}
+
+// RatePercent 交易所按流动性方向的手续费率 (%)，未知交易所为0
+func (f FeeSchedule) RatePercent(exchange, liquidity string) float64 {
+	switch {
+	case exchange == "binance" && liquidity == LiquidityMaker:
+		return f.BinanceMakerPercent
+	case exchange == "binance":
+		return f.BinanceTakerPercent
+	case exchange == "lighter" && liquidity == LiquidityMaker:
+		return f.LighterMakerPercent
+	case exchange == "lighter":
+		return f.LighterTakerPercent
+	default:
+		return 0
+	}
+}
+
+// Fee 一笔成交的手续费，value 为成交金额 (USDT/USDC)
+func (f FeeSchedule) Fee(exchange, liquidity string, value float64) float64 {
+	return value * f.RatePercent(exchange, liquidity) / 100
+}
+
+// OpenPercent 一次开仓两边的手续费率 (%)：Binance Maker挂单 + Lighter Taker对冲
+func (f FeeSchedule) OpenPercent() float64 {
+	return f.BinanceMakerPercent + f.LighterTakerPercent
+}
+
+// BreakEvenSpreadPercent 盈亏平衡价差 (%)：开仓时捕获的价差需覆盖开仓和平仓两次手续费
+func (f FeeSchedule) BreakEvenSpreadPercent() float64 {
+	return 2 * f.OpenPercent()
+}
+
+// orderLiquidity 监控订单成交的流动性方向：限价挂单按Maker计费，
+// 止损止盈单触发后以让出滑点的限价吃单成交，按Taker计费
+func orderLiquidity(order *ActiveOrder) string {
+	if order.isProtective() {
+		return LiquidityTaker
+	}
+	return LiquidityMaker
+}
@@ -0,0 +1,122 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// FlattenManager 日终清仓管理器 - 在每日指定时间撤销所有挂单并平掉两个交易所的仓位
+type FlattenManager struct {
+	hedgeStrategy   *DynamicHedgeStrategy
+	orderManager    *OrderManager
+	positionManager *PositionManager
+	logger          *zap.Logger
+
+	// 最近一次完成清仓的窗口开始时间，避免同一窗口内重复撤单
+	lastFlattenWindow time.Time
+}
+
+// NewFlattenManager 创建日终清仓管理器
+func NewFlattenManager(hedgeStrategy *DynamicHedgeStrategy) *FlattenManager {
+	return &FlattenManager{
+		hedgeStrategy:   hedgeStrategy,
+		orderManager:    hedgeStrategy.orderManager,
+		positionManager: hedgeStrategy.positionManager,
+		logger:          hedgeStrategy.logger.Named("flatten-manager"),
+	}
+}
+
+// parseClock 解析 "HH:MM" 格式的时间，返回当天的分钟数
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %q, expected HH:MM: %w", value, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// InFlattenWindow 检查当前时间是否处于清仓窗口 [FlattenTime, FlattenResumeTime)，按交易时间窗口的时区计算
+func (fm *FlattenManager) InFlattenWindow(config *DynamicHedgeConfig, now time.Time) bool {
+	now = now.In(config.sessionLocation())
+
+	start, err := parseClock(config.FlattenTime)
+	if err != nil {
+		fm.logger.Error("Invalid flatten time", zap.Error(err))
+		return false
+	}
+	end, err := parseClock(config.FlattenResumeTime)
+	if err != nil {
+		fm.logger.Error("Invalid flatten resume time", zap.Error(err))
+		return false
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	// 窗口跨越午夜
+	return minute >= start || minute < end
+}
+
+// windowStart 返回当前清仓窗口的开始时间
+func (fm *FlattenManager) windowStart(config *DynamicHedgeConfig, now time.Time) time.Time {
+	now = now.In(config.sessionLocation())
+	start, _ := parseClock(config.FlattenTime)
+	y, m, d := now.Date()
+	ws := time.Date(y, m, d, start/60, start%60, 0, 0, now.Location())
+	if ws.After(now) {
+		ws = ws.AddDate(0, 0, -1)
+	}
+	return ws
+}
+
+// ExecuteFlatten 执行日终清仓：撤销所有挂单，并以市价平掉两个交易所的全部仓位
+func (fm *FlattenManager) ExecuteFlatten(ctx context.Context, config *DynamicHedgeConfig) error {
+	now := time.Now()
+	window := fm.windowStart(config, now)
+
+	if fm.lastFlattenWindow.Equal(window) && fm.hedgeStrategy.allPositionsZero() {
+		// 本窗口已完成清仓，等待恢复时间
+		return nil
+	}
+
+	fm.logger.Warn("Executing end-of-cycle flatten",
+		zap.String("flatten_time", config.FlattenTime),
+		zap.String("resume_time", config.FlattenResumeTime),
+	)
+
+	cancelled, err := fm.CancelAllOrders(ctx)
+	if err != nil {
+		fm.logger.Error("Failed to cancel all orders during flatten", zap.Error(err))
+	}
+
+	if err := fm.hedgeStrategy.closingManager.ExecuteEmergencyClosing(ctx, config); err != nil {
+		return fmt.Errorf("failed to flatten positions: %w", err)
+	}
+
+	fm.lastFlattenWindow = window
+
+	fm.logger.Info("End-of-cycle flatten completed",
+		zap.Int("cancelled_orders", cancelled),
+		zap.Time("window_start", window),
+	)
+
+	return nil
+}
+
+// CancelAllOrders 撤销所有被监控的活跃订单：保护单按订单组撤销，Maker单由订单监控撤销，
+// 撤单时确认最终成交量并先完成对冲，避免按过期的成交量记录撤单
+func (fm *FlattenManager) CancelAllOrders(ctx context.Context) (int, error) {
+	// 保护单可能以OCO订单组挂出，需要按订单组撤销
+	cancelled, lastErr := fm.hedgeStrategy.protectionManager.CancelAll(ctx)
+
+	makers, err := fm.hedgeStrategy.orderMonitor.CancelWorkingOrders(ctx, "FLATTEN")
+	if err != nil {
+		lastErr = err
+	}
+
+	return cancelled + makers, lastErr
+}
@@ -0,0 +1,315 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// binanceFundingIntervalHours Binance永续合约资金费结算周期 (小时)，Lighter按小时结算
+const binanceFundingIntervalHours = 8
+
+// FundingArbStrategy 资金费率套利策略
+//
+// 同时监控两个交易所的资金费率，在费率较高的一侧做空、较低的一侧做多，
+// 保持Delta中性并收取资金费差；费率差反转时平掉两侧仓位。
+type FundingArbStrategy struct {
+	lifecycle
+	lighterStrategy *LighterStrategy
+	binanceStrategy *BinanceStrategy
+	config          *FundingArbConfig
+	logger          *zap.Logger
+
+	positions map[string]*FundingArbPosition // symbol -> 持仓
+	mu        sync.RWMutex
+}
+
+// FundingArbConfig 资金费率套利配置
+type FundingArbConfig struct {
+	Symbols       []string      // 监控币种 (BTC, ETH)
+	OrderSize     float64       // 每侧下单规模 (USDT/USDC)
+	Leverage      int           // Lighter杠杆倍数
+	SpreadPercent float64       // Binance挂单价差百分比
+	MinRateDiff   float64       // 开仓所需的最小费率差 (按小时折算)
+	CheckInterval time.Duration // 费率检查间隔
+}
+
+// FundingArbPosition 资金费率套利持仓
+type FundingArbPosition struct {
+	Symbol       string    `json:"symbol"`
+	LighterSide  string    `json:"lighter_side"` // BUY / SELL
+	BinanceSide  string    `json:"binance_side"` // BUY / SELL
+	Size         float64   `json:"size"`         // 每侧规模 (USDT/USDC)
+	OpenRateDiff float64   `json:"open_rate_diff"`
+	OpenedAt     time.Time `json:"opened_at"`
+
+	Opening *BinanceLeg `json:"opening,omitempty"` // 开仓的Binance订单未完全成交
+	Closing *BinanceLeg `json:"closing,omitempty"` // 平仓的Binance订单未完全成交，成交后删除持仓
+}
+
+// FundingRates 两个交易所的资金费率 (按小时折算)
+type FundingRates struct {
+	Lighter float64
+	Binance float64
+}
+
+// Diff 费率差 (Lighter - Binance)
+func (r FundingRates) Diff() float64 {
+	return r.Lighter - r.Binance
+}
+
+func NewFundingArbStrategy(lighterStrategy *LighterStrategy, binanceStrategy *BinanceStrategy, config *FundingArbConfig) *FundingArbStrategy {
+	return &FundingArbStrategy{
+		lifecycle:       newLifecycle(StrategyFundingArb),
+		lighterStrategy: lighterStrategy,
+		binanceStrategy: binanceStrategy,
+		config:          config,
+		logger:          logger.Named("funding-arb-strategy"),
+		positions:       make(map[string]*FundingArbPosition),
+	}
+}
+
+// Start 在后台运行资金费率套利，直到ctx取消或 Stop
+func (s *FundingArbStrategy) Start(ctx context.Context) error {
+	return s.start(ctx, func(ctx context.Context) error {
+		return s.run(ctx, s.config)
+	})
+}
+
+// Stop 停止策略
+func (s *FundingArbStrategy) Stop() {
+	s.stop()
+}
+
+// Status 返回运行状态
+func (s *FundingArbStrategy) Status() Status {
+	return s.status()
+}
+
+// run 运行资金费率套利，阻塞直到ctx取消
+func (s *FundingArbStrategy) run(ctx context.Context, config *FundingArbConfig) error {
+	s.logger.Info("Starting funding rate arbitrage strategy",
+		zap.Strings("symbols", config.Symbols),
+		zap.Float64("order_size", config.OrderSize),
+		zap.Int("leverage", config.Leverage),
+		zap.Float64("min_rate_diff", config.MinRateDiff),
+		zap.Duration("check_interval", config.CheckInterval),
+	)
+
+	ticker := time.NewTicker(config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.checkFunding(ctx, config); err != nil {
+			s.logger.Error("Funding check failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logOpenPositions()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetPositions 获取当前持仓
+func (s *FundingArbStrategy) GetPositions() map[string]*FundingArbPosition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	positions := make(map[string]*FundingArbPosition, len(s.positions))
+	for symbol, pos := range s.positions {
+		posCopy := *pos
+		posCopy.Opening = pos.Opening.clone()
+		posCopy.Closing = pos.Closing.clone()
+		positions[symbol] = &posCopy
+	}
+	return positions
+}
+
+// checkFunding 检查各币种费率差并开/平仓
+func (s *FundingArbStrategy) checkFunding(ctx context.Context, config *FundingArbConfig) error {
+	lighterRates, err := s.lighterStrategy.client.GetFundingRates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get lighter funding rates: %w", err)
+	}
+
+	for _, symbol := range config.Symbols {
+		lighterRate, ok := lighterRates[symbol]
+		if !ok {
+			s.logger.Warn("No Lighter funding rate for symbol", zap.String("symbol", symbol))
+			continue
+		}
+
+		binanceRate, err := s.binanceStrategy.client.GetFundingRate(ctx, s.binanceStrategy.pair(symbol))
+		if err != nil {
+			s.logger.Warn("Failed to get Binance funding rate", zap.String("symbol", symbol), zap.Error(err))
+			continue
+		}
+
+		rates := FundingRates{
+			Lighter: lighterRate,
+			Binance: binanceRate / binanceFundingIntervalHours,
+		}
+
+		s.logger.Debug("Funding rates",
+			zap.String("symbol", symbol),
+			zap.Float64("lighter_hourly", rates.Lighter),
+			zap.Float64("binance_hourly", rates.Binance),
+			zap.Float64("diff", rates.Diff()),
+		)
+
+		if err := s.evaluate(ctx, config, symbol, rates); err != nil {
+			s.logger.Error("Funding arbitrage action failed", zap.String("symbol", symbol), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// evaluate 根据费率差决定开仓或平仓
+func (s *FundingArbStrategy) evaluate(ctx context.Context, config *FundingArbConfig, symbol string, rates FundingRates) error {
+	diff := rates.Diff()
+
+	s.mu.RLock()
+	pos, hasPosition := s.positions[symbol]
+	s.mu.RUnlock()
+
+	if hasPosition && (pos.Opening != nil || pos.Closing != nil) {
+		return s.trackPending(ctx, config, pos)
+	}
+
+	if hasPosition {
+		// 费率差反转：原先收取资金费的一侧开始支付，平仓
+		if diff*pos.OpenRateDiff < 0 {
+			s.logger.Info("Funding differential flipped, closing position",
+				zap.String("symbol", symbol),
+				zap.Float64("open_rate_diff", pos.OpenRateDiff),
+				zap.Float64("current_rate_diff", diff),
+			)
+			return s.closePosition(ctx, config, pos)
+		}
+		return nil
+	}
+
+	if math.Abs(diff) < config.MinRateDiff {
+		return nil
+	}
+
+	// Lighter费率更高：多头付费给空头，在Lighter做空、Binance做多；反之亦然
+	lighterSide, binanceSide := "SELL", "BUY"
+	if diff < 0 {
+		lighterSide, binanceSide = "BUY", "SELL"
+	}
+
+	s.logger.Info("Funding differential above threshold, opening position",
+		zap.String("symbol", symbol),
+		zap.Float64("rate_diff", diff),
+		zap.String("lighter_side", lighterSide),
+		zap.String("binance_side", binanceSide),
+	)
+
+	orderID, err := placeCrossVenueLegs(ctx, s.lighterStrategy, s.binanceStrategy, symbol, lighterSide, binanceSide, config.OrderSize, config.Leverage, config.SpreadPercent)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.positions[symbol] = &FundingArbPosition{
+		Symbol:       symbol,
+		LighterSide:  lighterSide,
+		BinanceSide:  binanceSide,
+		Size:         config.OrderSize,
+		OpenRateDiff: diff,
+		OpenedAt:     time.Now(),
+		Opening:      &BinanceLeg{OrderID: orderID, Side: binanceSide, Size: config.OrderSize},
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// closePosition 反向下单平掉两侧仓位，Binance平仓单完全成交后才删除持仓
+func (s *FundingArbStrategy) closePosition(ctx context.Context, config *FundingArbConfig, pos *FundingArbPosition) error {
+	binanceSide := oppositeSide(pos.BinanceSide)
+	orderID, err := placeCrossVenueLegs(ctx, s.lighterStrategy, s.binanceStrategy, pos.Symbol, oppositeSide(pos.LighterSide), binanceSide, pos.Size, config.Leverage, config.SpreadPercent)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	pos.Closing = &BinanceLeg{OrderID: orderID, Side: binanceSide, Size: pos.Size}
+	s.mu.Unlock()
+
+	s.logger.Info("Funding arbitrage position closing, waiting for binance fill",
+		zap.String("symbol", pos.Symbol),
+		zap.Int64("binance_order_id", orderID),
+	)
+
+	return nil
+}
+
+// trackPending 跟踪开仓或平仓中未完全成交的Binance订单，平仓单成交后删除持仓
+func (s *FundingArbStrategy) trackPending(ctx context.Context, config *FundingArbConfig, pos *FundingArbPosition) error {
+	s.mu.RLock()
+	closing := pos.Closing != nil
+	leg := pos.Opening
+	if closing {
+		leg = pos.Closing
+	}
+	legCopy := *leg
+	s.mu.RUnlock()
+
+	filled, err := trackBinanceLeg(ctx, s.binanceStrategy, pos.Symbol, &legCopy, config.SpreadPercent)
+
+	s.mu.Lock()
+	*leg = legCopy
+	if filled {
+		if closing {
+			delete(s.positions, pos.Symbol)
+		} else {
+			pos.Opening = nil
+		}
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if !filled {
+		return nil
+	}
+
+	if closing {
+		s.logger.Info("Funding arbitrage position closed",
+			zap.String("symbol", pos.Symbol),
+			zap.Duration("held_for", time.Since(pos.OpenedAt)),
+		)
+	} else {
+		s.logger.Info("Funding arbitrage position opened",
+			zap.String("symbol", pos.Symbol),
+			zap.Int64("binance_order_id", legCopy.OrderID),
+		)
+	}
+	return nil
+}
+
+// logOpenPositions 退出时输出未平仓位
+func (s *FundingArbStrategy) logOpenPositions() {
+	for symbol, pos := range s.GetPositions() {
+		s.logger.Warn("Funding arbitrage position still open on shutdown",
+			zap.String("symbol", symbol),
+			zap.String("lighter_side", pos.LighterSide),
+			zap.String("binance_side", pos.BinanceSide),
+			zap.Float64("size", pos.Size),
+			zap.Time("opened_at", pos.OpenedAt),
+		)
+	}
+}
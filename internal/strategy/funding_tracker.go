@@ -0,0 +1,113 @@
+package strategy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+)
+
+// FundingTracker 资金费估算：按 FundingAccrualInterval 以当前持仓价值和资金费率累计两个交易所的资金费，
+// 计入盈亏归因。Lighter按小时费率，Binance合约按8小时费率折算为小时；现货和杠杆市场没有资金费
+type FundingTracker struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+
+	lastAccrual time.Time
+}
+
+// NewFundingTracker 创建资金费估算
+func NewFundingTracker(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *FundingTracker {
+	return &FundingTracker{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("funding-tracker"),
+	}
+}
+
+// Run 按 FundingAccrualInterval 累计资金费，阻塞直到ctx取消或stop关闭
+func (ft *FundingTracker) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(ft.config.FundingAccrualInterval)
+	defer ticker.Stop()
+
+	ft.lastAccrual = time.Now()
+	ft.logger.Info("Funding tracker started", zap.Duration("interval", ft.config.FundingAccrualInterval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			ft.accrue(ctx)
+		}
+	}
+}
+
+// accrue 按距上次累计的时长估算资金费：多头在费率为正时支付，空头收取。
+// 查询费率失败时该交易所本周期不累计
+func (ft *FundingTracker) accrue(ctx context.Context) {
+	now := time.Now()
+	hours := now.Sub(ft.lastAccrual).Hours()
+	ft.lastAccrual = now
+
+	pm := ft.hedgeStrategy.positionManager
+
+	if positions := openPositions(pm.positionsSnapshot("lighter")); len(positions) > 0 {
+		rates, err := ft.hedgeStrategy.lighterStrategy.client.GetFundingRates(ctx)
+		if err != nil {
+			ft.logger.Warn("Failed to get Lighter funding rates", zap.Error(err))
+		} else {
+			for symbol, pos := range positions {
+				rate, ok := rates[symbol]
+				if !ok {
+					continue
+				}
+				ft.record(pm, "lighter", symbol, pos, rate, hours)
+			}
+		}
+	}
+
+	client := ft.hedgeStrategy.binanceStrategy.client
+	if client.Market() != binance.MarketFutures {
+		return
+	}
+	for symbol, pos := range openPositions(pm.positionsSnapshot("binance")) {
+		rate, err := client.GetFundingRate(ctx, ft.hedgeStrategy.binanceStrategy.pair(symbol))
+		if err != nil {
+			ft.logger.Warn("Failed to get Binance funding rate", zap.String("symbol", symbol), zap.Error(err))
+			continue
+		}
+		ft.record(pm, "binance", symbol, pos, rate/binanceFundingIntervalHours, hours)
+	}
+}
+
+// record 按小时费率累计一个仓位的资金费
+func (ft *FundingTracker) record(pm *PositionManager, exchange, symbol string, pos Position, hourlyRate, hours float64) {
+	amount := -pos.Value * hourlyRate * hours
+	pm.AccrueFunding(symbol, amount)
+
+	ft.logger.Debug("Funding accrued",
+		zap.String("exchange", exchange),
+		zap.String("symbol", symbol),
+		zap.Float64("position_value", pos.Value),
+		zap.Float64("hourly_rate", hourlyRate),
+		zap.Float64("hours", hours),
+		zap.Float64("amount", amount),
+	)
+}
+
+// openPositions 过滤掉数量为0的仓位
+func openPositions(positions map[string]Position) map[string]Position {
+	open := make(map[string]Position)
+	for symbol, pos := range positions {
+		if pos.Size != 0 {
+			open[symbol] = pos
+		}
+	}
+	return open
+}
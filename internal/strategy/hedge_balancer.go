@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
@@ -35,7 +36,7 @@ func NewHedgeBalancer(hedgeStrategy *DynamicHedgeStrategy) *HedgeBalancer {
 
 // PositionImbalance 仓位不平衡信息
 type PositionImbalance struct {
-	Symbol           string  `json:"symbol"`            // BTC 或 ETH
+	Symbol           string  `json:"symbol"`            // 币种符号
 	LighterPosition  float64 `json:"lighter_position"`  // Lighter仓位大小
 	BinancePosition  float64 `json:"binance_position"`  // Binance仓位大小
 	ExpectedBalance  float64 `json:"expected_balance"`  // 期望的平衡值
@@ -60,20 +61,14 @@ func (hb *HedgeBalancer) CheckHedgeBalance() (*HedgeBalanceStatus, error) {
 		TotalImbalanceValue: 0,
 	}
 
-	// 检查BTC仓位平衡
-	btcImbalance := hb.checkSymbolBalance("BTC", lighterPositions, binancePositions)
-	if btcImbalance.NeedsAdjustment {
-		status.IsBalanced = false
-		status.Imbalances = append(status.Imbalances, btcImbalance)
-		status.TotalImbalanceValue += math.Abs(btcImbalance.AdjustmentAmount)
-	}
-
-	// 检查ETH仓位平衡
-	ethImbalance := hb.checkSymbolBalance("ETH", lighterPositions, binancePositions)
-	if ethImbalance.NeedsAdjustment {
-		status.IsBalanced = false
-		status.Imbalances = append(status.Imbalances, ethImbalance)
-		status.TotalImbalanceValue += math.Abs(ethImbalance.AdjustmentAmount)
+	// 逐个币种检查仓位平衡
+	for _, spec := range hb.hedgeStrategy.symbols.Specs() {
+		imbalance := hb.checkSymbolBalance(spec, lighterPositions, binancePositions)
+		if imbalance.NeedsAdjustment {
+			status.IsBalanced = false
+			status.Imbalances = append(status.Imbalances, imbalance)
+			status.TotalImbalanceValue += math.Abs(imbalance.AdjustmentAmount)
+		}
 	}
 
 	hb.logger.Info("Hedge balance check completed",
@@ -87,9 +82,11 @@ func (hb *HedgeBalancer) CheckHedgeBalance() (*HedgeBalanceStatus, error) {
 
 // checkSymbolBalance 检查单个币种的仓位平衡
 func (hb *HedgeBalancer) checkSymbolBalance(
-	symbol string,
+	spec SymbolSpec,
 	lighterPositions, binancePositions *ExchangePositions,
 ) *PositionImbalance {
+	symbol := spec.Symbol
+
 	// 获取仓位信息
 	lighterPos := hb.getPositionValue(lighterPositions, symbol)
 	binancePos := hb.getPositionValue(binancePositions, symbol)
@@ -100,9 +97,7 @@ func (hb *HedgeBalancer) checkSymbolBalance(
 		BinancePosition: binancePos,
 	}
 
-	// 对冲策略：Lighter和Binance应该是相反的仓位
-	// Lighter: BTC多头 + ETH空头
-	// Binance: BTC空头 + ETH多头
+	// 对冲策略：Lighter和Binance应该是相反的仓位，方向由币种配置的 lighter_side 决定
 	// 理想情况下：abs(lighter_position) = abs(binance_position)
 
 	expectedBalance := (math.Abs(lighterPos) + math.Abs(binancePos)) / 2
@@ -116,8 +111,12 @@ func (hb *HedgeBalancer) checkSymbolBalance(
 		imbalance.ImbalancePercent = math.Abs(actualImbalance) / expectedBalance * 100
 	}
 
-	// 判断是否需要调整
-	imbalance.NeedsAdjustment = imbalance.ImbalancePercent > hb.tolerancePercent &&
+	// 判断是否需要调整 (币种单独配置的容差优先)
+	tolerance := hb.tolerancePercent
+	if spec.BalanceTolerance > 0 {
+		tolerance = spec.BalanceTolerance
+	}
+	imbalance.NeedsAdjustment = imbalance.ImbalancePercent > tolerance &&
 		math.Abs(actualImbalance) > hb.minAdjustAmount
 
 	if imbalance.NeedsAdjustment {
@@ -125,23 +124,11 @@ func (hb *HedgeBalancer) checkSymbolBalance(
 		imbalance.AdjustmentAmount = math.Abs(actualImbalance) / 2 // 各调整一半
 
 		if math.Abs(lighterPos) > math.Abs(binancePos) {
-			// Lighter仓位过大，需要减少Lighter或增加Binance
-			if symbol == "BTC" {
-				// BTC: Lighter应该是多头，Binance应该是空头
-				imbalance.AdjustmentSide = "BINANCE_INCREASE_SHORT"
-			} else {
-				// ETH: Lighter应该是空头，Binance应该是多头
-				imbalance.AdjustmentSide = "BINANCE_INCREASE_LONG"
-			}
+			// Lighter仓位过大，需要沿Binance侧方向增加Binance仓位
+			imbalance.AdjustmentSide = "BINANCE_INCREASE_" + positionDirection(spec.BinanceSide())
 		} else {
-			// Binance仓位过大，需要减少Binance或增加Lighter
-			if symbol == "BTC" {
-				// BTC: 增加Lighter多头
-				imbalance.AdjustmentSide = "LIGHTER_INCREASE_LONG"
-			} else {
-				// ETH: 增加Lighter空头
-				imbalance.AdjustmentSide = "LIGHTER_INCREASE_SHORT"
-			}
+			// Binance仓位过大，需要沿Lighter侧方向增加Lighter仓位
+			imbalance.AdjustmentSide = "LIGHTER_INCREASE_" + positionDirection(spec.LighterSide)
 		}
 	}
 
@@ -152,6 +139,7 @@ func (hb *HedgeBalancer) checkSymbolBalance(
 		zap.Float64("expected_balance", expectedBalance),
 		zap.Float64("actual_imbalance", actualImbalance),
 		zap.Float64("imbalance_percent", imbalance.ImbalancePercent),
+		zap.Float64("tolerance_percent", tolerance),
 		zap.Bool("needs_adjustment", imbalance.NeedsAdjustment),
 		zap.String("adjustment_side", imbalance.AdjustmentSide),
 		zap.Float64("adjustment_amount", imbalance.AdjustmentAmount),
@@ -160,9 +148,15 @@ func (hb *HedgeBalancer) checkSymbolBalance(
 	return imbalance
 }
 
-// getPositionValue 获取指定币种的仓位价值
+// getPositionValue 获取指定币种的仓位价值。启用聚合价格时两边按同一参考价格折算，
+// 避免交易所之间的价差被误判为仓位不平衡
 func (hb *HedgeBalancer) getPositionValue(positions *ExchangePositions, symbol string) float64 {
 	if pos, exists := positions.Positions[symbol]; exists {
+		if feed := hb.hedgeStrategy.priceFeed; feed != nil {
+			if p, err := feed.Last(symbol); err == nil {
+				return pos.Size * p.Median
+			}
+		}
 		return pos.Value // 仓位价值（正数多头，负数空头）
 	}
 	return 0
@@ -193,27 +187,62 @@ func (hb *HedgeBalancer) ExecuteBalanceAdjustment(
 		zap.Float64("total_imbalance_value", status.TotalImbalanceValue),
 	)
 
+	var lighterOrders []marketOrder
 	for _, imbalance := range status.Imbalances {
-		if err := hb.adjustSymbolBalance(ctx, config, imbalance); err != nil {
+		hb.hedgeStrategy.bus.Publish("hedge-balancer", EventHedgeImbalance, map[string]interface{}{
+			"cycle_id":          hb.hedgeStrategy.cycles.Current(imbalance.Symbol),
+			"symbol":            imbalance.Symbol,
+			"adjustment_side":   imbalance.AdjustmentSide,
+			"adjustment_amount": imbalance.AdjustmentAmount,
+		})
+		order, err := hb.adjustSymbolBalance(ctx, config, imbalance)
+		if err != nil {
 			hb.logger.Error("Failed to adjust symbol balance",
 				zap.String("symbol", imbalance.Symbol),
 				zap.Error(err),
 			)
 			return fmt.Errorf("failed to adjust %s balance: %w", imbalance.Symbol, err)
 		}
+		if order != nil {
+			lighterOrders = append(lighterOrders, *order)
+		}
+	}
+
+	// 各币种的Lighter调整以一批市价单提交
+	if len(lighterOrders) > 0 {
+		// 部分提交失败时已提交的订单照常关联交易周期
+		txs, err := hb.hedgeStrategy.lighterStrategy.placeMarketOrders(ctx, lighterOrders)
+		for i, tx := range txs {
+			if i < len(lighterOrders) && tx != nil {
+				hb.hedgeStrategy.cycles.Link(hb.hedgeStrategy.cycles.Current(lighterOrders[i].Symbol), tx.GetTxHash())
+			}
+		}
+		if err != nil {
+			hb.logger.Error("Failed to place Lighter adjustment orders",
+				zap.Int("orders", len(lighterOrders)),
+				zap.Int("submitted", len(txs)),
+				zap.Error(err),
+			)
+			return fmt.Errorf("failed to adjust Lighter balance: %w", err)
+		}
 	}
 
 	hb.logger.Info("Balance adjustment completed successfully")
+	hb.hedgeStrategy.bus.Publish("hedge-balancer", EventBalanceAdjusted, map[string]interface{}{
+		"imbalances_count":      len(status.Imbalances),
+		"total_imbalance_value": status.TotalImbalanceValue,
+	})
 	return nil
 }
 
-// adjustSymbolBalance 调整单个币种的平衡
+// adjustSymbolBalance 调整单个币种的平衡：Binance调整直接下单，Lighter调整返回待批量提交的订单
 func (hb *HedgeBalancer) adjustSymbolBalance(
 	ctx context.Context,
 	config *DynamicHedgeConfig,
 	imbalance *PositionImbalance,
-) error {
+) (*marketOrder, error) {
 	hb.logger.Info("Adjusting symbol balance",
+		zap.String("cycle_id", hb.hedgeStrategy.cycles.Current(imbalance.Symbol)),
 		zap.String("symbol", imbalance.Symbol),
 		zap.String("adjustment_side", imbalance.AdjustmentSide),
 		zap.Float64("adjustment_amount", imbalance.AdjustmentAmount),
@@ -221,94 +250,74 @@ func (hb *HedgeBalancer) adjustSymbolBalance(
 
 	switch imbalance.AdjustmentSide {
 	case "BINANCE_INCREASE_SHORT":
-		return hb.increaseBinanceShort(ctx, imbalance.Symbol, imbalance.AdjustmentAmount, config)
+		return nil, hb.increaseBinancePosition(ctx, imbalance.Symbol, "SELL", imbalance.AdjustmentAmount, config)
 	case "BINANCE_INCREASE_LONG":
-		return hb.increaseBinanceLong(ctx, imbalance.Symbol, imbalance.AdjustmentAmount, config)
+		return nil, hb.increaseBinancePosition(ctx, imbalance.Symbol, "BUY", imbalance.AdjustmentAmount, config)
 	case "LIGHTER_INCREASE_LONG":
-		return hb.increaseLighterLong(ctx, imbalance.Symbol, imbalance.AdjustmentAmount, config)
+		return hb.increaseLighterPosition(imbalance.Symbol, "BUY", imbalance.AdjustmentAmount)
 	case "LIGHTER_INCREASE_SHORT":
-		return hb.increaseLighterShort(ctx, imbalance.Symbol, imbalance.AdjustmentAmount, config)
+		return hb.increaseLighterPosition(imbalance.Symbol, "SELL", imbalance.AdjustmentAmount)
 	default:
-		return fmt.Errorf("unknown adjustment side: %s", imbalance.AdjustmentSide)
+		return nil, fmt.Errorf("unknown adjustment side: %s", imbalance.AdjustmentSide)
 	}
 }
 
-// increaseBinanceShort 增加Binance空头仓位
-func (hb *HedgeBalancer) increaseBinanceShort(ctx context.Context, symbol string, amount float64, config *DynamicHedgeConfig) error {
-	hb.logger.Info("Increasing Binance short position",
+// increaseBinancePosition 沿配置方向增加Binance仓位
+func (hb *HedgeBalancer) increaseBinancePosition(ctx context.Context, symbol, side string, amount float64, config *DynamicHedgeConfig) error {
+	hb.logger.Info("Increasing Binance position",
 		zap.String("symbol", symbol),
+		zap.String("side", side),
 		zap.Float64("amount", amount),
 	)
 
-	switch symbol {
-	case "BTC":
-		_, err := hb.hedgeStrategy.binanceStrategy.client.PlaceBTCShort(ctx, amount, config.SpreadPercent)
-		return err
-	case "ETH":
-		return fmt.Errorf("ETH short not supported in this adjustment - ETH should be long on Binance")
-	default:
-		return fmt.Errorf("unsupported symbol for Binance short: %s", symbol)
+	spec, err := hb.hedgeStrategy.symbols.Get(symbol)
+	if err != nil {
+		return fmt.Errorf("unsupported symbol for Binance adjustment: %w", err)
+	}
+	if side != spec.BinanceSide() {
+		return fmt.Errorf("%s %s not supported in this adjustment - %s should be %s on Binance",
+			symbol, side, symbol, spec.BinanceSide())
 	}
-}
-
-// increaseBinanceLong 增加Binance多头仓位
-func (hb *HedgeBalancer) increaseBinanceLong(ctx context.Context, symbol string, amount float64, config *DynamicHedgeConfig) error {
-	hb.logger.Info("Increasing Binance long position",
-		zap.String("symbol", symbol),
-		zap.Float64("amount", amount),
-	)
 
-	switch symbol {
-	case "ETH":
-		_, err := hb.hedgeStrategy.binanceStrategy.client.PlaceETHLong(ctx, amount, config.SpreadPercent)
+	id, err := hb.hedgeStrategy.binanceStrategy.placeMakerOrder(ctx, symbol, side, amount, hb.hedgeStrategy.spreadPercent(config, symbol))
+	if err != nil {
 		return err
-	case "BTC":
-		return fmt.Errorf("BTC long not supported in this adjustment - BTC should be short on Binance")
-	default:
-		return fmt.Errorf("unsupported symbol for Binance long: %s", symbol)
 	}
+	hb.hedgeStrategy.cycles.Link(hb.hedgeStrategy.cycles.ID(symbol), strconv.FormatInt(id, 10))
+	return nil
 }
 
-// increaseLighterLong 增加Lighter多头仓位
-func (hb *HedgeBalancer) increaseLighterLong(ctx context.Context, symbol string, amount float64, config *DynamicHedgeConfig) error {
-	hb.logger.Info("Increasing Lighter long position",
+// increaseLighterPosition 沿配置方向增加Lighter仓位，返回待提交的市价单
+func (hb *HedgeBalancer) increaseLighterPosition(symbol, side string, amount float64) (*marketOrder, error) {
+	hb.logger.Info("Increasing Lighter position",
 		zap.String("symbol", symbol),
+		zap.String("side", side),
 		zap.Float64("amount", amount),
 	)
 
-	usdtAmount := int64(amount)
-	leverage := 3 // 固定3倍杠杆
-
-	switch symbol {
-	case "BTC":
-		_, err := hb.hedgeStrategy.lighterStrategy.client.PlaceBTCLong(ctx, usdtAmount, leverage)
-		return err
-	case "ETH":
-		return fmt.Errorf("ETH long not supported in this adjustment - ETH should be short on Lighter")
-	default:
-		return fmt.Errorf("unsupported symbol for Lighter long: %s", symbol)
+	spec, err := hb.hedgeStrategy.symbols.Get(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported symbol for Lighter adjustment: %w", err)
+	}
+	if side != spec.LighterSide {
+		return nil, fmt.Errorf("%s %s not supported in this adjustment - %s should be %s on Lighter",
+			symbol, side, symbol, spec.LighterSide)
 	}
-}
-
-// increaseLighterShort 增加Lighter空头仓位
-func (hb *HedgeBalancer) increaseLighterShort(ctx context.Context, symbol string, amount float64, config *DynamicHedgeConfig) error {
-	hb.logger.Info("Increasing Lighter short position",
-		zap.String("symbol", symbol),
-		zap.Float64("amount", amount),
-	)
 
-	usdtAmount := int64(amount)
-	leverage := 3 // 固定3倍杠杆
+	return &marketOrder{
+		Symbol:     symbol,
+		Side:       side,
+		USDTAmount: int64(hb.hedgeStrategy.lighterNotional(symbol, amount)),
+		Leverage:   hb.hedgeStrategy.lighterLeverage(symbol),
+	}, nil
+}
 
-	switch symbol {
-	case "ETH":
-		_, err := hb.hedgeStrategy.lighterStrategy.client.PlaceETHShort(ctx, usdtAmount, leverage)
-		return err
-	case "BTC":
-		return fmt.Errorf("BTC short not supported in this adjustment - BTC should be long on Lighter")
-	default:
-		return fmt.Errorf("unsupported symbol for Lighter short: %s", symbol)
+// positionDirection 将下单方向转换为仓位方向
+func positionDirection(side string) string {
+	if side == "SELL" {
+		return "SHORT"
 	}
+	return "LONG"
 }
 
 // GetBalanceRecommendation 获取平衡建议
@@ -0,0 +1,66 @@
+package strategy
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// orderCheck 本轮检查的订单及查询结果
+type orderCheck struct {
+	order  *ActiveOrder
+	change *statusChange // 状态变化，nil为无变化
+	err    error         // 查询失败的原因
+}
+
+// statusChange 订单状态变化
+type statusChange struct {
+	oldStatus string
+	newStatus string
+}
+
+// unhedgedNotional 状态变化后等待对冲的成交金额：完全成交为订单金额减已对冲量，部分成交为累计成交减已对冲量
+func (c *orderCheck) unhedgedNotional() float64 {
+	if c.err != nil || c.change == nil {
+		return 0
+	}
+	switch c.change.newStatus {
+	case "FILLED":
+		return c.order.Size - c.order.HedgedSize
+	case "PARTIAL":
+		return c.order.FilledSize - c.order.HedgedSize
+	}
+	return 0
+}
+
+// prioritize 对冲队列排序：多笔订单同时成交时按未对冲金额从大到小处理，最大的敞口最先对冲；
+// 金额相同时先处理挂单更久的订单。没有待对冲成交的订单 (只检查超时和追价) 排在最后
+func (om *OrderMonitor) prioritize(checks []*orderCheck) {
+	notional := make(map[*orderCheck]float64, len(checks))
+	pending := 0
+	for _, c := range checks {
+		notional[c] = c.unhedgedNotional()
+		if notional[c] > 0 {
+			pending++
+		}
+	}
+
+	sort.SliceStable(checks, func(i, j int) bool {
+		a, b := notional[checks[i]], notional[checks[j]]
+		if a != b {
+			return a > b
+		}
+		return checks[i].order.CreatedAt.Before(checks[j].order.CreatedAt)
+	})
+
+	if pending > 1 {
+		queue := make([]string, 0, pending)
+		for _, c := range checks[:pending] {
+			queue = append(queue, c.order.ID)
+		}
+		om.logger.Info("Multiple fills detected, hedging largest exposure first",
+			zap.Strings("queue", queue),
+			zap.Float64("largest_notional", notional[checks[0]]),
+		)
+	}
+}
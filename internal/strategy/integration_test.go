@@ -0,0 +1,362 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/killswitch"
+)
+
+const testPrice = 50000.0
+
+// testHedge 接入模拟交易所的动态对冲策略
+type testHedge struct {
+	*DynamicHedgeStrategy
+	binance *mockBinance
+	lighter *mockLighter
+	kill    *killswitch.Switch // 两个模拟交易所共用的紧急停止开关
+	config  *DynamicHedgeConfig
+}
+
+// newTestHedge 创建接入模拟交易所的动态对冲策略：单个币种BTC，Binance买入开仓，Lighter卖出对冲，每次100 USDC
+func newTestHedge(t *testing.T) *testHedge {
+	t.Helper()
+
+	symbols := NewSymbolUniverse([]SymbolSpec{{
+		Symbol:             "BTC",
+		BinancePair:        "BTCUSDC",
+		LighterMarketIndex: 1,
+		LighterSide:        "SELL",
+		OrderSize:          100,
+		Leverage:           3,
+	}})
+	mb := newMockBinance(binance.MarketFutures, map[string]float64{"BTCUSDC": testPrice})
+	ml := newMockLighter(map[uint8]float64{1: testPrice})
+	kill := killswitch.New()
+	mb.kill, ml.kill = kill, kill
+
+	config := &DynamicHedgeConfig{
+		OrderSize:     100,
+		MaxLeverage:   3,
+		SpreadPercent: 0.1,
+	}
+	s := NewDynamicHedgeStrategy(
+		NewLighterStrategy(ml, symbols, nil),
+		NewBinanceStrategy(mb, symbols, nil),
+		config,
+	)
+	return &testHedge{DynamicHedgeStrategy: s, binance: mb, lighter: ml, kill: kill, config: config}
+}
+
+// check 执行一轮订单检查，对冲失败的订单不等待重试间隔
+func (h *testHedge) check(t *testing.T) {
+	t.Helper()
+	h.orderMonitor.mu.Lock()
+	clear(h.orderMonitor.hedgeRetries)
+	h.orderMonitor.mu.Unlock()
+
+	if err := h.orderMonitor.checkActiveOrders(context.Background()); err != nil {
+		t.Fatalf("checkActiveOrders: %v", err)
+	}
+}
+
+// onlyOrder 唯一的活跃订单及其Binance订单ID
+func (h *testHedge) onlyOrder(t *testing.T) (*ActiveOrder, int64) {
+	t.Helper()
+	orders := h.orderManager.GetActiveOrders()
+	if len(orders) != 1 {
+		t.Fatalf("active orders = %d, want 1", len(orders))
+	}
+	for _, order := range orders {
+		id, err := strconv.ParseInt(order.ID, 10, 64)
+		if err != nil {
+			t.Fatalf("order id %q: %v", order.ID, err)
+		}
+		return order, id
+	}
+	return nil, 0
+}
+
+// lighterHedged Lighter按方向累计的成交金额 (USDT)，由订单的基础资产数量按价格换算
+func (h *testHedge) lighterHedged() (buy, sell int64) {
+	for _, o := range h.lighter.orders() {
+		if o.IsAsk == 1 {
+			sell += h.lighter.notional(o.MarketIndex, o.BaseAmount)
+		} else {
+			buy += h.lighter.notional(o.MarketIndex, o.BaseAmount)
+		}
+	}
+	return buy, sell
+}
+
+func TestOpeningFillHedgeClosingCycle(t *testing.T) {
+	h := newTestHedge(t)
+	ctx := context.Background()
+
+	// 开仓：Binance挂买入Maker单
+	volume, err := h.openingManager.ExecuteOpeningLogic(ctx, h.config)
+	if err != nil {
+		t.Fatalf("ExecuteOpeningLogic: %v", err)
+	}
+	if volume != 100 {
+		t.Fatalf("opening volume = %v, want 100", volume)
+	}
+	order, id := h.onlyOrder(t)
+	if order.Side != "BUY" || order.Role != OrderRoleOpen {
+		t.Fatalf("opening order side=%s role=%s, want BUY %s", order.Side, order.Role, OrderRoleOpen)
+	}
+
+	// 未成交时不对冲
+	h.check(t)
+	if n := len(h.lighter.orders()); n != 0 {
+		t.Fatalf("lighter orders before fill = %d, want 0", n)
+	}
+
+	// 成交后在Lighter卖出对冲，订单移出监控
+	h.binance.fill(id, math.MaxFloat64)
+	h.check(t)
+	if buy, sell := h.lighterHedged(); buy != 0 || sell != 100 {
+		t.Fatalf("lighter hedged buy=%d sell=%d, want 0/100", buy, sell)
+	}
+	// 100 USDT / 50000 = 0.002 BTC，杠杆不影响下单数量
+	if orders := h.lighter.orders(); len(orders) != 1 || orders[0].BaseAmount != 200 {
+		t.Fatalf("lighter hedge orders = %+v, want one order of base amount 200", orders)
+	}
+	if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+		t.Fatalf("active orders after hedge = %d, want 0", n)
+	}
+	if pos := h.positionManager.GetBinancePositions().Positions["BTC"]; pos == nil || pos.Size <= 0 {
+		t.Fatalf("binance position after opening = %+v, want long", pos)
+	}
+	if pos := h.positionManager.GetLighterPositions().Positions["BTC"]; pos == nil || pos.Size >= 0 {
+		t.Fatalf("lighter position after opening = %+v, want short", pos)
+	}
+
+	// 平仓：Binance挂卖出Maker单，成交后Lighter买入平掉对冲仓位
+	if _, err := h.closingManager.ExecuteClosingLogic(ctx, h.config); err != nil {
+		t.Fatalf("ExecuteClosingLogic: %v", err)
+	}
+	order, id = h.onlyOrder(t)
+	if order.Side != "SELL" || order.Role != OrderRoleClose {
+		t.Fatalf("closing order side=%s role=%s, want SELL %s", order.Side, order.Role, OrderRoleClose)
+	}
+
+	h.binance.fill(id, math.MaxFloat64)
+	h.check(t)
+	buy, sell := h.lighterHedged()
+	if buy == 0 || buy != sell {
+		t.Fatalf("lighter hedged buy=%d sell=%d, want equal and non-zero", buy, sell)
+	}
+	if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+		t.Fatalf("active orders after closing = %d, want 0", n)
+	}
+	if got := h.lighter.position(1); got != 0 {
+		t.Fatalf("lighter position after closing = %v, want 0", got)
+	}
+}
+
+func TestHedgeRetriedAfterLighterError(t *testing.T) {
+	h := newTestHedge(t)
+	ctx := context.Background()
+
+	if _, err := h.openingManager.ExecuteOpeningLogic(ctx, h.config); err != nil {
+		t.Fatalf("ExecuteOpeningLogic: %v", err)
+	}
+	_, id := h.onlyOrder(t)
+
+	h.lighter.failNext("PlaceMarketOrders", errors.New("lighter unavailable"))
+	h.binance.fill(id, math.MaxFloat64)
+	h.check(t)
+
+	// 对冲失败：订单保留为已成交，等待重试
+	order, _ := h.onlyOrder(t)
+	if order.Status != "FILLED" || order.HedgedSize != 0 {
+		t.Fatalf("order after failed hedge status=%s hedged=%v, want FILLED 0", order.Status, order.HedgedSize)
+	}
+	if n := len(h.lighter.orders()); n != 0 {
+		t.Fatalf("lighter orders after failed hedge = %d, want 0", n)
+	}
+	queries := h.binance.count("GetOrder")
+
+	// 重试成功后订单移出监控，不再查询交易所
+	h.check(t)
+	if _, sell := h.lighterHedged(); sell != 100 {
+		t.Fatalf("lighter hedged sell = %d, want 100", sell)
+	}
+	if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+		t.Fatalf("active orders after retry = %d, want 0", n)
+	}
+	if got := h.binance.count("GetOrder"); got != queries {
+		t.Fatalf("GetOrder calls during retry = %d, want %d", got, queries)
+	}
+
+	// 之后的检查不会重复对冲
+	h.check(t)
+	if n := len(h.lighter.orders()); n != 1 {
+		t.Fatalf("lighter orders = %d, want 1", n)
+	}
+}
+
+func TestOpeningFailsWhenBinanceRejects(t *testing.T) {
+	h := newTestHedge(t)
+
+	h.binance.failNext("PlaceMakerOrder", errors.New("insufficient balance"))
+	if _, err := h.openingManager.ExecuteOpeningLogic(context.Background(), h.config); err == nil {
+		t.Fatal("ExecuteOpeningLogic succeeded, want error")
+	}
+	if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+		t.Fatalf("active orders = %d, want 0", n)
+	}
+	if n := len(h.lighter.orders()); n != 0 {
+		t.Fatalf("lighter orders = %d, want 0", n)
+	}
+}
+
+func TestFastHedgeRecordsDelayBreach(t *testing.T) {
+	h := newTestHedge(t)
+	ctx := context.Background()
+
+	fastConfig := NewDefaultFastExecutionConfig()
+	fastConfig.MaxExecutionDelay = 10 * time.Millisecond
+	fastConfig.EnablePriceProtection = false
+	fastConfig.EnableRetry = false
+	h.fastExecutionManager.UpdateConfig(fastConfig)
+	h.orderMonitor.SetFastExecutionManager(h.fastExecutionManager)
+
+	if _, err := h.openingManager.ExecuteOpeningLogic(ctx, h.config); err != nil {
+		t.Fatalf("ExecuteOpeningLogic: %v", err)
+	}
+	_, id := h.onlyOrder(t)
+
+	h.lighter.delay("PlaceShort", 30*time.Millisecond)
+	h.binance.fill(id, math.MaxFloat64)
+	h.check(t)
+
+	stats := h.fastExecutionManager.GetExecutionStats()
+	if stats.SuccessfulExecutions != 1 || stats.DelayBreaches != 1 {
+		t.Fatalf("executions=%d breaches=%d, want 1/1", stats.SuccessfulExecutions, stats.DelayBreaches)
+	}
+	if stats.MaxDelay < 30*time.Millisecond {
+		t.Fatalf("max delay = %v, want >= 30ms", stats.MaxDelay)
+	}
+}
+
+func TestCrossVenueRevertsLighterLegOnBinanceFailure(t *testing.T) {
+	h := newTestHedge(t)
+
+	h.binance.failNext("PlaceMakerOrder", errors.New("binance unavailable"))
+	_, err := placeCrossVenueLegs(context.Background(), h.lighterStrategy, h.binanceStrategy, "BTC", "SELL", "BUY", 100, 3, 0.1)
+	if err == nil {
+		t.Fatal("placeCrossVenueLegs succeeded, want error")
+	}
+
+	orders := h.lighter.orders()
+	if len(orders) != 2 {
+		t.Fatalf("lighter orders = %d, want 2 (leg and revert)", len(orders))
+	}
+	if orders[1].IsAsk == orders[0].IsAsk {
+		t.Fatalf("revert order has the same side as the leg")
+	}
+	if got := h.lighter.position(1); got != 0 {
+		t.Fatalf("lighter position after revert = %v, want 0", got)
+	}
+}
+
+func TestCrossVenueRejectsFractionalSize(t *testing.T) {
+	h := newTestHedge(t)
+
+	if _, err := placeCrossVenueLegs(context.Background(), h.lighterStrategy, h.binanceStrategy, "BTC", "SELL", "BUY", 100.5, 3, 0.1); err == nil {
+		t.Fatal("placeCrossVenueLegs accepted a fractional size")
+	}
+	if n := len(h.lighter.orders()) + len(h.binance.orderIDs()); n != 0 {
+		t.Fatalf("orders placed = %d, want 0", n)
+	}
+}
+
+func TestFastHedgeWithUnknownFillPrice(t *testing.T) {
+	h := newTestHedge(t)
+	ctx := context.Background()
+
+	fastConfig := NewDefaultFastExecutionConfig()
+	fastConfig.EnableRetry = false
+	h.fastExecutionManager.UpdateConfig(fastConfig)
+	h.orderMonitor.SetFastExecutionManager(h.fastExecutionManager)
+
+	if _, err := h.openingManager.ExecuteOpeningLogic(ctx, h.config); err != nil {
+		t.Fatalf("ExecuteOpeningLogic: %v", err)
+	}
+	_, id := h.onlyOrder(t)
+	h.binance.fill(id, math.MaxFloat64)
+	h.check(t)
+
+	// Lighter市价单没有成交回报：成交价未知，不计入MAE和滑点
+	stats := h.fastExecutionManager.GetExecutionStats()
+	if stats.SuccessfulExecutions != 1 || stats.MaxAdversePercent != 0 {
+		t.Fatalf("executions=%d max adverse=%v, want 1/0", stats.SuccessfulExecutions, stats.MaxAdversePercent)
+	}
+	h.positionManager.mu.RLock()
+	attribution := h.positionManager.attributionSnapshot()["BTC"]
+	h.positionManager.mu.RUnlock()
+	if attribution == nil {
+		t.Fatal("no attribution recorded for BTC")
+	}
+	if attribution.HedgeSlippage != 0 {
+		t.Fatalf("hedge slippage = %v, want 0 for unknown fill price", attribution.HedgeSlippage)
+	}
+	makerPrice := testPrice * (1 - h.config.SpreadPercent/100)
+	wantEdge := 100 / makerPrice * (testPrice - makerPrice)
+	if math.Abs(attribution.OpeningEdge-wantEdge) > 1e-9 {
+		t.Fatalf("opening edge = %v, want %v", attribution.OpeningEdge, wantEdge)
+	}
+
+	// 仓位按对冲前的参考价近似计入
+	pos := h.positionManager.GetLighterPositions().Positions["BTC"]
+	if pos == nil || math.Abs(pos.EntryPrice-testPrice) > 1e-9 {
+		t.Fatalf("lighter position = %+v, want entry price %v", pos, testPrice)
+	}
+}
+
+func TestKillSwitchStillHedgesFillsAndFlattens(t *testing.T) {
+	h := newTestHedge(t)
+	ctx := context.Background()
+
+	if _, err := h.openingManager.ExecuteOpeningLogic(ctx, h.config); err != nil {
+		t.Fatalf("ExecuteOpeningLogic: %v", err)
+	}
+	_, id := h.onlyOrder(t)
+
+	// 紧急停止前刚部分成交：撤单时成交部分仍然对冲
+	h.binance.fillNotional(id, 40)
+	h.kill.Engage("test")
+	if n, err := h.CancelWorkingOrders(ctx, "KILL_SWITCH"); err != nil || n != 1 {
+		t.Fatalf("CancelWorkingOrders = %d, %v, want 1, nil", n, err)
+	}
+	if _, sell := h.lighterHedged(); sell != 40 {
+		t.Fatalf("lighter hedged after kill = %d, want 40", sell)
+	}
+	if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+		t.Fatalf("active orders after kill = %d, want 0", n)
+	}
+
+	// 新开仓单被拒绝
+	h.openingManager.ExecuteOpeningLogic(ctx, h.config)
+	if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+		t.Fatalf("active orders after opening under kill switch = %d, want 0", n)
+	}
+
+	// 紧急平仓仍然放行
+	if err := h.closingManager.ExecuteEmergencyClosing(ctx, h.config); err != nil {
+		t.Fatalf("ExecuteEmergencyClosing: %v", err)
+	}
+	if got := h.lighter.position(1); got != 0 {
+		t.Fatalf("lighter position after flatten = %v, want 0", got)
+	}
+	if n := h.binance.count("PlaceMarketOrders"); n != 1 {
+		t.Fatalf("binance market orders = %d, want 1", n)
+	}
+}
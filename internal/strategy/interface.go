@@ -0,0 +1,165 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// Strategy 统一的策略生命周期接口。配置在构造时以各策略的类型化配置传入，
+// Start 在后台启动策略并立即返回，Stop 停止策略并等待退出
+type Strategy interface {
+	Start(ctx context.Context) error
+	Stop()
+	Status() Status
+	// Done 策略结束 (自行完成、出错或被停止) 时关闭
+	Done() <-chan struct{}
+}
+
+var (
+	_ Strategy = (*LighterStrategy)(nil)
+	_ Strategy = (*BinanceStrategy)(nil)
+	_ Strategy = (*ArbitrageStrategy)(nil)
+	_ Strategy = (*DynamicHedgeStrategy)(nil)
+	_ Strategy = (*FundingArbStrategy)(nil)
+	_ Strategy = (*BasisStrategy)(nil)
+	_ Strategy = (*MarketMakingStrategy)(nil)
+)
+
+// Status 策略运行状态
+type Status struct {
+	Type      StrategyType `json:"type"`
+	Running   bool         `json:"running"`
+	Phase     string       `json:"phase,omitempty"`
+	StartedAt time.Time    `json:"started_at"`
+	Err       error        `json:"-"` // 策略异常结束的原因
+}
+
+// StrategyType 定义策略类型
+type StrategyType string
+
+const (
+	StrategyLighter      StrategyType = "lighter"
+	StrategyBinance      StrategyType = "binance"
+	StrategyArbitrage    StrategyType = "arbitrage"
+	StrategyDynamicHedge StrategyType = "dynamic_hedge"
+	StrategyFundingArb   StrategyType = "funding_arb"
+	StrategyBasis        StrategyType = "basis"
+	StrategyMarketMaking StrategyType = "market_making"
+)
+
+// GetStrategyName 获取策略名称
+func (s StrategyType) String() string {
+	return string(s)
+}
+
+// lifecycle 在后台goroutine中运行策略主体，供一次性和轮询类策略实现 Strategy
+type lifecycle struct {
+	strategyType StrategyType
+
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	done      chan struct{}
+	running   bool
+	startedAt time.Time
+	err       error
+}
+
+func newLifecycle(strategyType StrategyType) lifecycle {
+	return lifecycle{strategyType: strategyType, done: make(chan struct{})}
+}
+
+// start 在后台运行 run，ctx取消或 stop 时结束。同一策略只能启动一次
+func (l *lifecycle) start(ctx context.Context, run func(ctx context.Context) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cancel != nil {
+		return fmt.Errorf("%s strategy already started", l.strategyType)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	l.cancel = cancel
+	l.running = true
+	l.startedAt = time.Now()
+
+	go func() {
+		err := runRecovered(ctx, l.strategyType, run)
+
+		l.mu.Lock()
+		l.running = false
+		// 被取消不视为异常结束
+		if err != nil && !errors.Is(err, context.Canceled) {
+			l.err = err
+		}
+		l.mu.Unlock()
+
+		cancel()
+		close(l.done)
+	}()
+
+	return nil
+}
+
+// runRecovered 运行策略主体，panic时记录堆栈并转为错误，策略以 FAILED 结束
+func runRecovered(ctx context.Context, strategyType StrategyType, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, stack := unwrapPanic(r)
+			logger.Named(strategyType.String()+"-strategy").Error("Recovered from panic, strategy failed",
+				zap.Any("panic", value),
+				zap.ByteString("stack", stack),
+			)
+			err = fmt.Errorf("panic: %v", value)
+		}
+	}()
+	return run(ctx)
+}
+
+// stop 取消策略并等待退出，未启动时直接返回
+func (l *lifecycle) stop() {
+	l.mu.Lock()
+	cancel := l.cancel
+	l.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-l.done
+}
+
+// status 返回运行状态
+func (l *lifecycle) status() Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	phase := "INITIALIZED"
+	switch {
+	case l.running:
+		phase = "RUNNING"
+	case l.err != nil:
+		phase = "FAILED"
+	case l.cancel != nil:
+		phase = "STOPPED"
+	}
+
+	return Status{
+		Type:      l.strategyType,
+		Running:   l.running,
+		Phase:     phase,
+		StartedAt: l.startedAt,
+		Err:       l.err,
+	}
+}
+
+// Done 策略结束时关闭
+func (l *lifecycle) Done() <-chan struct{} {
+	return l.done
+}
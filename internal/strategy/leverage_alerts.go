@@ -0,0 +1,77 @@
+package strategy
+
+import (
+	"go.uber.org/zap"
+)
+
+// EventLeverageThreshold 交易所杠杆率越过 MaxLeverage 的预警档位
+const EventLeverageThreshold = "LEVERAGE_THRESHOLD"
+
+// leverageAlertPercents 杠杆预警档位 (占 MaxLeverage 的百分比，升序)，100% 时停止开仓
+var leverageAlertPercents = []float64{50, 80, 100}
+
+// leverageHysteresisPercent 杠杆回落到档位以下该百分点后才视为回到下一档，避免在档位附近反复通知
+const leverageHysteresisPercent = 5.0
+
+// checkLeverageThresholds 按交易所杠杆率占 MaxLeverage 的比例更新预警档位，
+// 档位变化时发布 LEVERAGE_THRESHOLD 事件 (上升立即发布，下降需低于档位 leverageHysteresisPercent)
+func (rm *RiskManager) checkLeverageThresholds(status *RiskStatus) {
+	if rm.config == nil || rm.config.MaxLeverage <= 0 {
+		return
+	}
+
+	for _, venue := range []struct {
+		exchange string
+		leverage float64
+	}{
+		{"lighter", status.LighterLeverage},
+		{"binance", status.BinanceLeverage},
+	} {
+		percent := venue.leverage / rm.config.MaxLeverage * 100
+
+		rm.mu.Lock()
+		if rm.leverageLevels == nil {
+			rm.leverageLevels = make(map[string]int)
+		}
+		previous := rm.leverageLevels[venue.exchange]
+		level := previous
+		for level < len(leverageAlertPercents) && percent >= leverageAlertPercents[level] {
+			level++
+		}
+		for level > 0 && percent < leverageAlertPercents[level-1]-leverageHysteresisPercent {
+			level--
+		}
+		rm.leverageLevels[venue.exchange] = level
+		rm.mu.Unlock()
+
+		if level == previous {
+			continue
+		}
+
+		// 上升时为越过的最高档位，下降时为跌破的最低档位
+		rising := level > previous
+		var threshold float64
+		if rising {
+			threshold = leverageAlertPercents[level-1]
+		} else {
+			threshold = leverageAlertPercents[level]
+		}
+
+		rm.logger.Warn("Leverage crossed alert threshold",
+			zap.String("exchange", venue.exchange),
+			zap.Float64("leverage", venue.leverage),
+			zap.Float64("max_leverage", rm.config.MaxLeverage),
+			zap.Float64("percent_of_max", percent),
+			zap.Float64("threshold_percent", threshold),
+			zap.Bool("rising", rising),
+		)
+		rm.bus.Publish("risk-manager", EventLeverageThreshold, map[string]interface{}{
+			"exchange":          venue.exchange,
+			"leverage":          venue.leverage,
+			"max_leverage":      rm.config.MaxLeverage,
+			"percent_of_max":    percent,
+			"threshold_percent": threshold,
+			"rising":            rising,
+		})
+	}
+}
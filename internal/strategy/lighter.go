@@ -0,0 +1,197 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/lighter"
+	"cs-projects-backpack/pkg/logger"
+
+	"github.com/elliottech/lighter-go/types/txtypes"
+)
+
+type LighterStrategy struct {
+	lifecycle
+	client  LighterClient
+	symbols *SymbolUniverse
+	config  *LighterConfig // 作为其他策略的一腿时为nil
+	logger  *zap.Logger
+}
+
+type LighterConfig struct {
+	USDTAmount int64 // 每次交易的USDT数量
+	Leverage   int   // 杠杆倍数
+}
+
+// NewLighterStrategy 创建Lighter策略，作为其他策略的一腿时config传nil
+func NewLighterStrategy(client LighterClient, symbols *SymbolUniverse, config *LighterConfig) *LighterStrategy {
+	return &LighterStrategy{
+		lifecycle: newLifecycle(StrategyLighter),
+		client:    client,
+		symbols:   symbols,
+		config:    config,
+		logger:    logger.Named("lighter-strategy"),
+	}
+}
+
+// Start 在后台按配置依次下单，全部完成后结束
+func (s *LighterStrategy) Start(ctx context.Context) error {
+	if s.config == nil {
+		return fmt.Errorf("lighter strategy has no config")
+	}
+	return s.start(ctx, func(ctx context.Context) error {
+		return s.ExecutePairs(ctx, s.config)
+	})
+}
+
+// Stop 停止策略
+func (s *LighterStrategy) Stop() {
+	s.stop()
+}
+
+// Status 返回运行状态
+func (s *LighterStrategy) Status() Status {
+	return s.status()
+}
+
+// ExecutePairs 按配置的币种依次在Lighter下市价单
+func (s *LighterStrategy) ExecutePairs(ctx context.Context, config *LighterConfig) error {
+	s.logger.Info("Starting Lighter pair trading strategy",
+		zap.Int("symbols", len(s.symbols.Specs())),
+		zap.Int64("usdt_amount", config.USDTAmount),
+		zap.Int("leverage", config.Leverage),
+	)
+
+	for _, spec := range s.symbols.Specs() {
+		s.logger.Info("Placing order on Lighter",
+			zap.String("symbol", spec.Symbol),
+			zap.String("side", spec.LighterSide),
+			zap.Int64("usdt_amount", config.USDTAmount),
+			zap.Int("leverage", config.Leverage),
+		)
+		tx, err := s.placeMarketOrder(ctx, spec.Symbol, spec.LighterSide, config.USDTAmount, config.Leverage)
+		if err != nil {
+			s.logger.Error("Lighter order failed", zap.String("symbol", spec.Symbol), zap.Error(err))
+			return fmt.Errorf("lighter %s %s下单失败: %w", spec.Symbol, spec.LighterSide, err)
+		}
+		s.logger.Info("Lighter order successful",
+			zap.String("symbol", spec.Symbol),
+			zap.String("side", spec.LighterSide),
+			zap.String("tx_hash", tx.GetTxHash()),
+		)
+	}
+
+	s.logger.Info("Lighter pair trading completed successfully")
+
+	return nil
+}
+
+// marketIndex 将内部币种符号映射为Lighter市场索引
+func (s *LighterStrategy) marketIndex(symbol string) (uint8, error) {
+	return s.symbols.LighterMarketIndex(symbol)
+}
+
+// leverage 币种配置的Lighter杠杆倍数，未配置时使用3倍
+func (s *LighterStrategy) leverage(symbol string) int {
+	if spec, err := s.symbols.Get(symbol); err == nil && spec.Leverage > 0 {
+		return spec.Leverage
+	}
+	return 3
+}
+
+// lastPrice 按内部币种符号获取最新成交价
+func (s *LighterStrategy) lastPrice(ctx context.Context, symbol string) (float64, error) {
+	marketIndex, err := s.marketIndex(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return s.client.GetLastPrice(ctx, marketIndex)
+}
+
+// markIndexPrice 按内部币种符号获取标记价格和指数价格
+func (s *LighterStrategy) markIndexPrice(ctx context.Context, symbol string) (mark, index float64, err error) {
+	marketIndex, err := s.marketIndex(symbol)
+	if err != nil {
+		return 0, 0, err
+	}
+	return s.client.GetMarkIndexPrice(ctx, marketIndex)
+}
+
+// depthNotional 按内部币种符号获取距最优价 withinPercent 范围内的盘口名义金额
+func (s *LighterStrategy) depthNotional(ctx context.Context, symbol, side string, withinPercent float64) (float64, error) {
+	marketIndex, err := s.marketIndex(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return s.client.GetDepthNotional(ctx, marketIndex, side, withinPercent)
+}
+
+// closePositions 按内部币种符号以一批只减仓市价单平掉实际持仓，返回 symbol -> 已平仓持仓，没有持仓的币种不在结果中。
+// 部分提交失败时返回已提交的部分和错误
+func (s *LighterStrategy) closePositions(ctx context.Context, symbols []string) (map[string]lighter.ClosedPosition, error) {
+	bySymbol := make(map[uint8]string, len(symbols))
+	marketIndexes := make([]uint8, 0, len(symbols))
+	for _, symbol := range symbols {
+		marketIndex, err := s.marketIndex(symbol)
+		if err != nil {
+			return nil, err
+		}
+		bySymbol[marketIndex] = symbol
+		marketIndexes = append(marketIndexes, marketIndex)
+	}
+
+	closed, err := s.client.ClosePositions(ctx, marketIndexes)
+
+	result := make(map[string]lighter.ClosedPosition, len(closed))
+	for _, c := range closed {
+		result[bySymbol[c.Position.MarketIndex]] = c
+	}
+	return result, err
+}
+
+// placeMarketOrder 按内部币种符号下市价单，side为BUY/SELL
+func (s *LighterStrategy) placeMarketOrder(ctx context.Context, symbol, side string, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error) {
+	marketIndex, err := s.marketIndex(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if side == "SELL" {
+		return s.client.PlaceShort(ctx, marketIndex, usdtAmount, leverage)
+	}
+	return s.client.PlaceLong(ctx, marketIndex, usdtAmount, leverage)
+}
+
+// marketOrder 批量市价单中的一笔订单 (内部币种符号)
+type marketOrder struct {
+	Symbol     string
+	Side       string // BUY, SELL
+	USDTAmount int64
+	Leverage   int
+}
+
+// placeMarketOrders 按内部币种符号批量下市价单，一次提交，返回的交易与 orders 的前缀一一对应
+// (部分提交失败时同时返回已提交的交易和错误)
+func (s *LighterStrategy) placeMarketOrders(ctx context.Context, orders []marketOrder) ([]*txtypes.L2CreateOrderTxInfo, error) {
+	reqs := make([]*lighter.MarketOrderRequest, len(orders))
+	for i, o := range orders {
+		marketIndex, err := s.marketIndex(o.Symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		var isAsk uint8
+		if o.Side == "SELL" {
+			isAsk = 1
+		}
+		reqs[i] = &lighter.MarketOrderRequest{
+			MarketIndex: marketIndex,
+			USDTAmount:  o.USDTAmount,
+			Leverage:    o.Leverage,
+			IsAsk:       isAsk,
+		}
+	}
+	return s.client.PlaceMarketOrders(ctx, reqs)
+}
@@ -0,0 +1,192 @@
+package strategy
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LiquidationRisk 单个仓位的强平风险
+type LiquidationRisk struct {
+	Exchange         string    `json:"exchange"`
+	Symbol           string    `json:"symbol"`
+	Size             float64   `json:"size"`
+	MarkPrice        float64   `json:"mark_price"`
+	LiquidationPrice float64   `json:"liquidation_price"`
+	DistancePercent  float64   `json:"distance_percent"` // 标记价格距强平价格的百分比，越过强平价时为负
+	DeRisk           bool      `json:"derisk"`           // 是否进入减仓缓冲区
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// LiquidationMonitor 强平价监控：每个策略周期按交易所返回的强平价格计算各仓位标记价格到强平价的距离，
+// 进入 LiquidationAlertPercent 缓冲区时告警并发布 LIQUIDATION_WARNING 事件；
+// 进入 LiquidationDeRiskPercent 缓冲区时按平仓流程减仓该币种，两个交易所同时减仓保持对冲
+type LiquidationMonitor struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+
+	mu    sync.RWMutex
+	risks map[string]*LiquidationRisk // exchange/symbol -> 缓冲区内的仓位
+}
+
+// NewLiquidationMonitor 创建强平价监控
+func NewLiquidationMonitor(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *LiquidationMonitor {
+	return &LiquidationMonitor{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("liquidation-monitor"),
+		risks:         make(map[string]*LiquidationRisk),
+	}
+}
+
+// liquidationDistance 标记价格距强平价格的百分比：多头强平价在下方，空头在上方
+func liquidationDistance(size, markPrice, liquidationPrice float64) float64 {
+	if size > 0 {
+		return (markPrice - liquidationPrice) / markPrice * 100
+	}
+	return (liquidationPrice - markPrice) / markPrice * 100
+}
+
+// Check 检查全部仓位，仓位新进入告警缓冲区时告警，返回需要减仓的币种。
+// 交易所未提供强平价格或标记价格的仓位不参与检查
+func (lm *LiquidationMonitor) Check() []string {
+	now := time.Now()
+	risks := make(map[string]*LiquidationRisk)
+	deRisk := make(map[string]bool)
+
+	for _, exchange := range []string{"lighter", "binance"} {
+		for symbol, pos := range lm.hedgeStrategy.positionManager.positionsSnapshot(exchange) {
+			if pos.Size == 0 || pos.MarkPrice <= 0 || pos.LiquidationPrice <= 0 {
+				continue
+			}
+
+			distance := liquidationDistance(pos.Size, pos.MarkPrice, pos.LiquidationPrice)
+			if distance > lm.config.LiquidationAlertPercent {
+				continue
+			}
+
+			risk := &LiquidationRisk{
+				Exchange:         exchange,
+				Symbol:           symbol,
+				Size:             pos.Size,
+				MarkPrice:        pos.MarkPrice,
+				LiquidationPrice: pos.LiquidationPrice,
+				DistancePercent:  distance,
+				DeRisk:           lm.config.LiquidationDeRiskPercent > 0 && distance <= lm.config.LiquidationDeRiskPercent,
+				UpdatedAt:        now,
+			}
+			risks[exchange+"/"+symbol] = risk
+			if risk.DeRisk {
+				deRisk[symbol] = true
+			}
+		}
+	}
+
+	lm.mu.Lock()
+	previous := lm.risks
+	lm.risks = risks
+	lm.mu.Unlock()
+
+	for key, risk := range risks {
+		if old, ok := previous[key]; ok && old.DeRisk == risk.DeRisk {
+			continue
+		}
+		lm.logger.Error("Position approaching liquidation price",
+			zap.String("exchange", risk.Exchange),
+			zap.String("symbol", risk.Symbol),
+			zap.Float64("size", risk.Size),
+			zap.Float64("mark_price", risk.MarkPrice),
+			zap.Float64("liquidation_price", risk.LiquidationPrice),
+			zap.Float64("distance_percent", risk.DistancePercent),
+			zap.Bool("derisk", risk.DeRisk),
+		)
+		lm.hedgeStrategy.bus.Publish("liquidation-monitor", EventLiquidationWarning, map[string]interface{}{
+			"exchange":          risk.Exchange,
+			"symbol":            risk.Symbol,
+			"size":              risk.Size,
+			"mark_price":        risk.MarkPrice,
+			"liquidation_price": risk.LiquidationPrice,
+			"distance_percent":  risk.DistancePercent,
+			"derisk":            risk.DeRisk,
+		})
+	}
+	for key, risk := range previous {
+		if _, ok := risks[key]; !ok {
+			lm.logger.Info("Position moved away from liquidation price",
+				zap.String("exchange", risk.Exchange),
+				zap.String("symbol", risk.Symbol),
+			)
+		}
+	}
+
+	symbols := make([]string, 0, len(deRisk))
+	for symbol := range deRisk {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// DeRisk 减仓接近强平价的币种：Binance侧按平仓流程挂一笔平仓单，成交后Lighter侧由订单监控对冲平仓。
+// 已有进行中订单的币种跳过，返回本轮平仓下单总金额
+func (lm *LiquidationMonitor) DeRisk(ctx context.Context, symbols []string) (float64, error) {
+	var volume float64
+	var lastErr error
+	for _, symbol := range symbols {
+		spec, err := lm.hedgeStrategy.symbols.Get(symbol)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if lm.hedgeStrategy.symbolBusy(symbol) {
+			continue
+		}
+
+		pos, ok := lm.hedgeStrategy.positionManager.positionsSnapshot("binance")[symbol]
+		if !ok || pos.Size == 0 {
+			lm.logger.Warn("No Binance position to de-risk against", zap.String("symbol", symbol))
+			continue
+		}
+
+		lm.logger.Warn("De-risking position near liquidation", zap.String("symbol", symbol))
+		closeSize, err := lm.hedgeStrategy.closingManager.closeSymbol(ctx, lm.config, spec, &pos)
+		if err != nil {
+			lm.logger.Error("Failed to de-risk position", zap.String("symbol", symbol), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		volume += closeSize
+	}
+	return volume, lastErr
+}
+
+// Risks 返回最近一次检查时处于告警缓冲区的仓位
+func (lm *LiquidationMonitor) Risks() []LiquidationRisk {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+
+	risks := make([]LiquidationRisk, 0, len(lm.risks))
+	for _, risk := range lm.risks {
+		risks = append(risks, *risk)
+	}
+	sort.Slice(risks, func(i, j int) bool {
+		return risks[i].DistancePercent < risks[j].DistancePercent
+	})
+	return risks
+}
+
+// positionsSnapshot 复制交易所的仓位，避免遍历时与仓位同步并发
+func (pm *PositionManager) positionsSnapshot(exchange string) map[string]Position {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	positions := pm.exchangePositions(exchange)
+	if positions == nil {
+		return nil
+	}
+	return copyPositions(positions.Positions)
+}
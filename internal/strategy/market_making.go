@@ -0,0 +1,332 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// MarketMakingStrategy 库存感知做市策略
+//
+// 在Binance围绕中间价双边挂Maker单，成交产生的库存按净敞口偏移报价 (库存越多越倾向卖出)，
+// 达到最大库存时停止该方向报价；净敞口超过卸载阈值时在Lighter用市价单对冲。
+type MarketMakingStrategy struct {
+	lifecycle
+	lighterStrategy *LighterStrategy
+	binanceStrategy *BinanceStrategy
+	config          *MarketMakingConfig
+	logger          *zap.Logger
+
+	inventory map[string]*MarketMakingInventory // symbol -> 库存
+	mu        sync.RWMutex
+}
+
+// MarketMakingConfig 做市配置
+type MarketMakingConfig struct {
+	Symbols          []string      // 做市币种
+	QuoteSize        float64       // 每侧挂单金额 (USDC)
+	SpreadPercent    float64       // 报价距中间价的百分比
+	MaxInventory     float64       // 最大净库存 (USDC名义)，超过后停止同方向报价
+	SkewPercent      float64       // 满库存时报价整体偏移的百分比
+	OffloadThreshold float64       // 净库存超过该名义值时在Lighter对冲
+	Leverage         int           // Lighter杠杆倍数
+	RefreshInterval  time.Duration // 报价刷新间隔
+}
+
+// MarketMakingInventory 单个币种的做市库存
+type MarketMakingInventory struct {
+	Symbol     string  `json:"symbol"`
+	BinanceQty float64 `json:"binance_qty"` // Binance成交累积的数量 (正数多头)
+	LighterQty float64 `json:"lighter_qty"` // Lighter对冲累积的数量
+	Fills      int     `json:"fills"`
+
+	bid *mmQuote
+	ask *mmQuote
+}
+
+// NetQty 净库存数量
+func (inv *MarketMakingInventory) NetQty() float64 {
+	return inv.BinanceQty + inv.LighterQty
+}
+
+// mmQuote 挂单中的报价
+type mmQuote struct {
+	orderID int64
+	side    string
+	seenQty float64 // 已计入库存的成交数量
+}
+
+func NewMarketMakingStrategy(lighterStrategy *LighterStrategy, binanceStrategy *BinanceStrategy, config *MarketMakingConfig) *MarketMakingStrategy {
+	return &MarketMakingStrategy{
+		lifecycle:       newLifecycle(StrategyMarketMaking),
+		lighterStrategy: lighterStrategy,
+		binanceStrategy: binanceStrategy,
+		config:          config,
+		logger:          logger.Named("market-making-strategy"),
+		inventory:       make(map[string]*MarketMakingInventory),
+	}
+}
+
+// Start 在后台运行做市策略，直到ctx取消或 Stop
+func (s *MarketMakingStrategy) Start(ctx context.Context) error {
+	return s.start(ctx, func(ctx context.Context) error {
+		return s.run(ctx, s.config)
+	})
+}
+
+// Stop 停止策略
+func (s *MarketMakingStrategy) Stop() {
+	s.stop()
+}
+
+// Status 返回运行状态
+func (s *MarketMakingStrategy) Status() Status {
+	return s.status()
+}
+
+// run 运行做市策略，阻塞直到ctx取消
+func (s *MarketMakingStrategy) run(ctx context.Context, config *MarketMakingConfig) error {
+	s.logger.Info("Starting inventory-aware market making strategy",
+		zap.Strings("symbols", config.Symbols),
+		zap.Float64("quote_size", config.QuoteSize),
+		zap.Float64("spread_percent", config.SpreadPercent),
+		zap.Float64("max_inventory", config.MaxInventory),
+		zap.Float64("skew_percent", config.SkewPercent),
+		zap.Float64("offload_threshold", config.OffloadThreshold),
+		zap.Duration("refresh_interval", config.RefreshInterval),
+	)
+
+	for _, symbol := range config.Symbols {
+		s.inventory[symbol] = &MarketMakingInventory{Symbol: symbol}
+	}
+
+	ticker := time.NewTicker(config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, symbol := range config.Symbols {
+			if err := s.refresh(ctx, config, symbol); err != nil {
+				s.logger.Error("Market making cycle failed", zap.String("symbol", symbol), zap.Error(err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			s.cancelAllQuotes()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetInventory 获取当前库存
+func (s *MarketMakingStrategy) GetInventory() map[string]MarketMakingInventory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]MarketMakingInventory, len(s.inventory))
+	for symbol, inv := range s.inventory {
+		result[symbol] = MarketMakingInventory{
+			Symbol:     inv.Symbol,
+			BinanceQty: inv.BinanceQty,
+			LighterQty: inv.LighterQty,
+			Fills:      inv.Fills,
+		}
+	}
+	return result
+}
+
+// refresh 单个币种的一轮做市：同步成交、卸载库存、重新报价
+func (s *MarketMakingStrategy) refresh(ctx context.Context, config *MarketMakingConfig, symbol string) error {
+	pair := s.binanceStrategy.pair(symbol)
+
+	s.mu.Lock()
+	inv := s.inventory[symbol]
+	s.mu.Unlock()
+
+	// 1. 撤掉旧报价并计入成交
+	for _, q := range []**mmQuote{&inv.bid, &inv.ask} {
+		if *q == nil {
+			continue
+		}
+		if err := s.retireQuote(ctx, pair, inv, *q); err != nil {
+			return err
+		}
+		*q = nil
+	}
+
+	mid, err := s.binanceStrategy.client.GetCurrentPrice(ctx, pair)
+	if err != nil {
+		return fmt.Errorf("failed to get mid price: %w", err)
+	}
+
+	// 2. 净敞口超过阈值时在Lighter对冲
+	s.mu.RLock()
+	netNotional := inv.NetQty() * mid
+	s.mu.RUnlock()
+
+	if config.OffloadThreshold > 0 && math.Abs(netNotional) > config.OffloadThreshold {
+		if err := s.offload(ctx, config, inv, netNotional, mid); err != nil {
+			s.logger.Error("Failed to offload inventory on Lighter", zap.String("symbol", symbol), zap.Error(err))
+		} else {
+			s.mu.RLock()
+			netNotional = inv.NetQty() * mid
+			s.mu.RUnlock()
+		}
+	}
+
+	// 3. 按库存偏移报价
+	skew := 0.0
+	if config.MaxInventory > 0 {
+		skew = math.Max(-1, math.Min(1, netNotional/config.MaxInventory)) * config.SkewPercent
+	}
+	bidPrice := mid * (1 - (config.SpreadPercent+skew)/100)
+	askPrice := mid * (1 + (config.SpreadPercent-skew)/100)
+
+	s.logger.Debug("Requoting",
+		zap.String("symbol", symbol),
+		zap.Float64("mid", mid),
+		zap.Float64("net_notional", netNotional),
+		zap.Float64("skew_percent", skew),
+		zap.Float64("bid", bidPrice),
+		zap.Float64("ask", askPrice),
+	)
+
+	if config.MaxInventory <= 0 || netNotional < config.MaxInventory {
+		order, err := s.binanceStrategy.client.PlaceLimitOrderAt(ctx, pair, "BUY", config.QuoteSize, bidPrice)
+		if err != nil {
+			return fmt.Errorf("failed to place bid: %w", err)
+		}
+		inv.bid = &mmQuote{orderID: order.OrderID, side: "BUY"}
+	}
+
+	if config.MaxInventory <= 0 || netNotional > -config.MaxInventory {
+		order, err := s.binanceStrategy.client.PlaceLimitOrderAt(ctx, pair, "SELL", config.QuoteSize, askPrice)
+		if err != nil {
+			return fmt.Errorf("failed to place ask: %w", err)
+		}
+		inv.ask = &mmQuote{orderID: order.OrderID, side: "SELL"}
+	}
+
+	return nil
+}
+
+// retireQuote 撤销报价并把撤单前的成交计入库存
+func (s *MarketMakingStrategy) retireQuote(ctx context.Context, pair string, inv *MarketMakingInventory, q *mmQuote) error {
+	// 撤单失败通常是已完全成交，以随后的查询结果为准
+	if err := s.binanceStrategy.client.CancelOrder(ctx, pair, q.orderID); err != nil {
+		s.logger.Debug("Cancel quote failed", zap.Int64("order_id", q.orderID), zap.Error(err))
+	}
+
+	status, err := s.binanceStrategy.client.GetOrder(ctx, pair, q.orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get quote status: %w", err)
+	}
+
+	filled := status.ExecutedQty - q.seenQty
+	if filled <= 0 {
+		return nil
+	}
+	q.seenQty = status.ExecutedQty
+
+	if q.side == "SELL" {
+		filled = -filled
+	}
+
+	s.mu.Lock()
+	inv.BinanceQty += filled
+	inv.Fills++
+	s.mu.Unlock()
+
+	s.logger.Info("Quote filled",
+		zap.String("symbol", inv.Symbol),
+		zap.String("side", q.side),
+		zap.Int64("order_id", q.orderID),
+		zap.Float64("qty", math.Abs(filled)),
+		zap.Float64("price", status.Price),
+	)
+
+	return nil
+}
+
+// offload 在Lighter反向市价成交以降低净库存，交易所接受提交后才计入库存
+func (s *MarketMakingStrategy) offload(ctx context.Context, config *MarketMakingConfig, inv *MarketMakingInventory, netNotional, mid float64) error {
+	// 净多头 -> Lighter卖出；净空头 -> Lighter买入
+	side := "BUY"
+	if netNotional > 0 {
+		side = "SELL"
+	}
+
+	amount := math.Abs(netNotional)
+	usdtAmount := int64(amount)
+	if usdtAmount <= 0 {
+		return nil
+	}
+
+	txs, err := s.lighterStrategy.placeMarketOrders(ctx, []marketOrder{{
+		Symbol:     inv.Symbol,
+		Side:       side,
+		USDTAmount: usdtAmount,
+		Leverage:   config.Leverage,
+	}})
+	if err != nil {
+		return err
+	}
+	tx := txs[0]
+
+	qty := float64(usdtAmount) / mid
+	if side == "SELL" {
+		qty = -qty
+	}
+
+	s.mu.Lock()
+	inv.LighterQty += qty
+	s.mu.Unlock()
+
+	s.logger.Info("Inventory offloaded on Lighter",
+		zap.String("symbol", inv.Symbol),
+		zap.String("side", side),
+		zap.Int64("notional", usdtAmount),
+		zap.String("tx_hash", tx.GetTxHash()),
+	)
+
+	return nil
+}
+
+// cancelAllQuotes 退出时撤销所有挂单
+func (s *MarketMakingStrategy) cancelAllQuotes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for symbol, inv := range s.inventory {
+		for _, q := range []*mmQuote{inv.bid, inv.ask} {
+			if q == nil {
+				continue
+			}
+			if err := s.binanceStrategy.client.CancelOrder(ctx, s.binanceStrategy.pair(symbol), q.orderID); err != nil {
+				s.logger.Warn("Failed to cancel quote on shutdown",
+					zap.String("symbol", symbol),
+					zap.Int64("order_id", q.orderID),
+					zap.Error(err),
+				)
+			}
+		}
+
+		s.logger.Info("Final market making inventory",
+			zap.String("symbol", symbol),
+			zap.Float64("binance_qty", inv.BinanceQty),
+			zap.Float64("lighter_qty", inv.LighterQty),
+			zap.Float64("net_qty", inv.NetQty()),
+			zap.Int("fills", inv.Fills),
+		)
+	}
+}
@@ -0,0 +1,707 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elliottech/lighter-go/types/txtypes"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/killswitch"
+	"cs-projects-backpack/pkg/lighter"
+	"cs-projects-backpack/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "strategy-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if _, err := logger.Initialize(&config.LoggingConfig{Level: "error", Output: filepath.Join(dir, "test.log")}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// errMockUnsupported 模拟交易所未实现的操作
+var errMockUnsupported = errors.New("not supported by mock exchange")
+
+// mockScript 按方法名注入的延迟和错误，记录调用次数
+type mockScript struct {
+	mu      sync.Mutex
+	errs    map[string][]error
+	latency map[string]time.Duration
+	calls   map[string]int
+
+	kill *killswitch.Switch // 与真实客户端一样在下单前检查 (nil为不启用)
+}
+
+func newMockScript() mockScript {
+	return mockScript{
+		errs:    make(map[string][]error),
+		latency: make(map[string]time.Duration),
+		calls:   make(map[string]int),
+	}
+}
+
+// failNext 方法接下来的调用依次返回 errs (nil 为成功)，用完后恢复正常
+func (s *mockScript) failNext(method string, errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs[method] = append(s.errs[method], errs...)
+}
+
+// delay 方法每次调用前等待 d
+func (s *mockScript) delay(method string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency[method] = d
+}
+
+// count 方法被调用的次数
+func (s *mockScript) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// call 记录一次调用，按脚本等待并返回注入的错误
+func (s *mockScript) call(ctx context.Context, method string) error {
+	s.mu.Lock()
+	s.calls[method]++
+	d := s.latency[method]
+	var err error
+	if q := s.errs[method]; len(q) > 0 {
+		err, s.errs[method] = q[0], q[1:]
+	}
+	s.mu.Unlock()
+
+	if d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// callOrder 同 call，用于下单：先按紧急停止开关检查，被拒绝的下单不计入调用次数
+func (s *mockScript) callOrder(ctx context.Context, method string, reduceOnly bool) error {
+	if err := s.kill.AllowOrder(ctx, reduceOnly); err != nil {
+		return err
+	}
+	return s.call(ctx, method)
+}
+
+// mockBinanceOrder 模拟Binance订单
+type mockBinanceOrder struct {
+	pair   string
+	side   string
+	qty    float64
+	status binance.OrderStatus
+}
+
+// mockBinance 内存模拟的Binance：限价单挂出后由测试按脚本成交 (fill)，市价单立即按最新价格成交
+type mockBinance struct {
+	mockScript
+
+	market string
+	equity float64
+
+	mu           sync.Mutex
+	prices       map[string]float64 // 交易对 -> 最新价格
+	nextID       int64
+	orders       map[int64]*mockBinanceOrder
+	marketOrders []binance.MarketOrderRequest
+	spot         float64 // 现货钱包余额 (划转和提现累计)
+}
+
+func newMockBinance(market string, prices map[string]float64) *mockBinance {
+	return &mockBinance{
+		mockScript: newMockScript(),
+		market:     market,
+		equity:     10000,
+		prices:     prices,
+		nextID:     1000,
+		orders:     make(map[int64]*mockBinanceOrder),
+	}
+}
+
+// fill 按数量成交挂单，累计成交达到下单数量时完全成交
+func (m *mockBinance) fill(orderID int64, qty float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	o, ok := m.orders[orderID]
+	if !ok {
+		panic(fmt.Sprintf("mock binance: unknown order %d", orderID))
+	}
+	o.status.ExecutedQty = min(o.status.ExecutedQty+qty, o.qty)
+	o.status.Status = "PARTIALLY_FILLED"
+	if o.status.ExecutedQty >= o.qty {
+		o.status.Status = "FILLED"
+	}
+}
+
+// fillNotional 按金额 (USDC) 成交挂单
+func (m *mockBinance) fillNotional(orderID int64, notional float64) {
+	m.mu.Lock()
+	price := m.orders[orderID].status.Price
+	m.mu.Unlock()
+	m.fill(orderID, notional/price)
+}
+
+// expire 交易所侧撤销挂单 (如过期)，已成交部分保留
+func (m *mockBinance) expire(orderID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orders[orderID].status.Status = "EXPIRED"
+}
+
+// order 订单当前状态
+func (m *mockBinance) order(orderID int64) (mockBinanceOrder, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.orders[orderID]
+	if !ok {
+		return mockBinanceOrder{}, false
+	}
+	return *o, true
+}
+
+// orderIDs 全部订单ID，按下单顺序
+func (m *mockBinance) orderIDs() []int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]int64, 0, len(m.orders))
+	for id := range m.orders {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (m *mockBinance) price(pair string) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.prices[pair]
+	if !ok {
+		return 0, fmt.Errorf("mock binance: no price for %s", pair)
+	}
+	return p, nil
+}
+
+func (m *mockBinance) addOrder(pair, side string, qty, price float64) *binance.OrderStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	o := &mockBinanceOrder{
+		pair: pair,
+		side: side,
+		qty:  qty,
+		status: binance.OrderStatus{
+			OrderID: m.nextID,
+			Status:  "NEW",
+			Price:   price,
+		},
+	}
+	m.orders[o.status.OrderID] = o
+	status := o.status
+	return &status
+}
+
+func (m *mockBinance) Market() string           { return m.market }
+func (m *mockBinance) HasPositions() bool       { return m.market == binance.MarketFutures }
+func (m *mockBinance) SupportsOCO() bool        { return false }
+func (m *mockBinance) PollingSlowdown() float64 { return 1 }
+func (m *mockBinance) GetBookTicker(string) (binance.BookTicker, bool) {
+	return binance.BookTicker{}, false
+}
+
+func (m *mockBinance) GetCurrentPrice(ctx context.Context, symbol string) (float64, error) {
+	if err := m.call(ctx, "GetCurrentPrice"); err != nil {
+		return 0, err
+	}
+	return m.price(symbol)
+}
+
+func (m *mockBinance) GetUSDCRate(ctx context.Context) (float64, error) {
+	return 1, m.call(ctx, "GetUSDCRate")
+}
+
+func (m *mockBinance) GetMarkIndexPrice(ctx context.Context, symbol string) (mark, index float64, err error) {
+	if err := m.call(ctx, "GetMarkIndexPrice"); err != nil {
+		return 0, 0, err
+	}
+	p, err := m.price(symbol)
+	return p, p, err
+}
+
+func (m *mockBinance) GetDepthNotional(ctx context.Context, symbol, side string, withinPercent float64) (float64, error) {
+	return 1e9, m.call(ctx, "GetDepthNotional")
+}
+
+func (m *mockBinance) EstimateMarketFill(ctx context.Context, symbol, side string, quantity float64) (best, avg float64, err error) {
+	if err := m.call(ctx, "EstimateMarketFill"); err != nil {
+		return 0, 0, err
+	}
+	p, err := m.price(symbol)
+	return p, p, err
+}
+
+func (m *mockBinance) GetFundingRate(ctx context.Context, symbol string) (float64, error) {
+	return 0, m.call(ctx, "GetFundingRate")
+}
+
+func (m *mockBinance) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]binance.Kline, error) {
+	return nil, m.call(ctx, "GetKlines")
+}
+
+func (m *mockBinance) GetQuoteVolume(ctx context.Context, symbol string, start, end time.Time) (float64, error) {
+	return 0, m.call(ctx, "GetQuoteVolume")
+}
+
+func (m *mockBinance) GetAccountEquity(ctx context.Context) (float64, error) {
+	return m.equity, m.call(ctx, "GetAccountEquity")
+}
+
+func (m *mockBinance) GetFreeCollateral(ctx context.Context, symbol, side string) (free, leverage float64, err error) {
+	return m.equity, 1, m.call(ctx, "GetFreeCollateral")
+}
+
+func (m *mockBinance) GetBaseBalances(ctx context.Context) (map[string]float64, error) {
+	return map[string]float64{}, m.call(ctx, "GetBaseBalances")
+}
+
+func (m *mockBinance) GetSpotFreeBalance(ctx context.Context, asset string) (float64, error) {
+	return m.equity, m.call(ctx, "GetSpotFreeBalance")
+}
+
+func (m *mockBinance) GetFreeStablecoins(ctx context.Context) (float64, error) {
+	return m.equity, m.call(ctx, "GetFreeStablecoins")
+}
+
+func (m *mockBinance) GetPositions(ctx context.Context) (map[string]binance.Position, error) {
+	return map[string]binance.Position{}, m.call(ctx, "GetPositions")
+}
+
+func (m *mockBinance) GetOpenOrders(ctx context.Context, symbol string) ([]binance.OpenOrder, error) {
+	if err := m.call(ctx, "GetOpenOrders"); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var open []binance.OpenOrder
+	for _, o := range m.orders {
+		if o.pair != symbol || (o.status.Status != "NEW" && o.status.Status != "PARTIALLY_FILLED") {
+			continue
+		}
+		open = append(open, binance.OpenOrder{
+			OrderID:     o.status.OrderID,
+			ListID:      -1,
+			Symbol:      o.pair,
+			Side:        o.side,
+			Type:        "LIMIT",
+			Price:       o.status.Price,
+			Quantity:    o.qty,
+			ExecutedQty: o.status.ExecutedQty,
+		})
+	}
+	return open, nil
+}
+
+// PlaceMakerOrder 按最新价格加减价差挂单 (买单低于、卖单高于最新价)
+func (m *mockBinance) PlaceMakerOrder(ctx context.Context, symbol, side string, usdcAmount float64, spreadPercent float64) (*binance.OrderStatus, error) {
+	if err := m.callOrder(ctx, "PlaceMakerOrder", false); err != nil {
+		return nil, err
+	}
+	p, err := m.price(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if side == "BUY" {
+		p *= 1 - spreadPercent/100
+	} else {
+		p *= 1 + spreadPercent/100
+	}
+	return m.addOrder(symbol, side, usdcAmount/p, p), nil
+}
+
+func (m *mockBinance) PlaceLimitOrderAt(ctx context.Context, symbol, side string, usdcAmount, price float64) (*binance.OrderStatus, error) {
+	if err := m.callOrder(ctx, "PlaceLimitOrderAt", false); err != nil {
+		return nil, err
+	}
+	return m.addOrder(symbol, side, usdcAmount/price, price), nil
+}
+
+func (m *mockBinance) PlaceMarketOrders(ctx context.Context, reqs []binance.MarketOrderRequest) []binance.BatchOrderResult {
+	results := make([]binance.BatchOrderResult, len(reqs))
+	for i, req := range reqs {
+		results[i].Request = req
+		if err := m.callOrder(ctx, "PlaceMarketOrders", req.ReduceOnly); err != nil {
+			results[i].Err = err
+			continue
+		}
+		p, err := m.price(req.Symbol)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		status := m.addOrder(req.Symbol, req.Side, req.Quantity, p)
+		m.fill(status.OrderID, req.Quantity)
+		status.Status, status.ExecutedQty = "FILLED", req.Quantity
+		results[i].Order = status
+
+		m.mu.Lock()
+		m.marketOrders = append(m.marketOrders, req)
+		m.mu.Unlock()
+	}
+	return results
+}
+
+func (m *mockBinance) PlaceStopOrder(ctx context.Context, symbol, side, kind string, quantity, stopPrice, limitPrice float64) (*binance.OrderStatus, error) {
+	return nil, errMockUnsupported
+}
+
+func (m *mockBinance) PlaceOCOOrder(ctx context.Context, symbol, side string, quantity, takeProfitPrice, stopPrice, stopLimitPrice float64) (*binance.OCOStatus, error) {
+	return nil, errMockUnsupported
+}
+
+func (m *mockBinance) GetOrder(ctx context.Context, symbol string, orderID int64) (*binance.OrderStatus, error) {
+	if err := m.call(ctx, "GetOrder"); err != nil {
+		return nil, err
+	}
+	o, ok := m.order(orderID)
+	if !ok {
+		return nil, fmt.Errorf("mock binance: unknown order %d", orderID)
+	}
+	return &o.status, nil
+}
+
+func (m *mockBinance) CancelOrder(ctx context.Context, symbol string, orderID int64) error {
+	if err := m.call(ctx, "CancelOrder"); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.orders[orderID]
+	if !ok || (o.status.Status != "NEW" && o.status.Status != "PARTIALLY_FILLED") {
+		return fmt.Errorf("mock binance: order %d is not open", orderID)
+	}
+	o.status.Status = "CANCELED"
+	return nil
+}
+
+func (m *mockBinance) CancelOrders(ctx context.Context, symbol string, orderIDs []int64) ([]int64, error) {
+	var done []int64
+	var lastErr error
+	for _, id := range orderIDs {
+		if err := m.CancelOrder(ctx, symbol, id); err != nil {
+			lastErr = err
+			continue
+		}
+		done = append(done, id)
+	}
+	return done, lastErr
+}
+
+func (m *mockBinance) CancelOCOOrder(ctx context.Context, symbol string, orderListID int64) error {
+	return errMockUnsupported
+}
+
+// TransferToFutures 现货钱包划转到合约钱包，现货余额按划转累计
+func (m *mockBinance) TransferToFutures(ctx context.Context, asset string, amount float64) (int64, error) {
+	if err := m.callOrder(ctx, "TransferToFutures", false); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spot -= amount
+	m.nextID++
+	return m.nextID, nil
+}
+
+func (m *mockBinance) TransferToSpot(ctx context.Context, asset string, amount float64) (int64, error) {
+	if err := m.callOrder(ctx, "TransferToSpot", false); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spot += amount
+	m.nextID++
+	return m.nextID, nil
+}
+
+// Withdraw 从现货钱包提现
+func (m *mockBinance) Withdraw(ctx context.Context, asset, network, address string, amount float64) (string, error) {
+	if err := m.callOrder(ctx, "Withdraw", false); err != nil {
+		return "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spot -= amount
+	m.nextID++
+	return fmt.Sprintf("withdraw-%d", m.nextID), nil
+}
+
+// spotBalance 从合约钱包划转到现货钱包、尚未提现或转回的金额
+func (m *mockBinance) spotBalance() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.spot
+}
+
+// mockLighterSizeDecimals 模拟市场的数量精度：BaseAmount = 币数量 * 10^5
+const mockLighterSizeDecimals = 5
+
+// mockLighter 内存模拟的Lighter：市价单提交即按最新价格成交。
+// 与真实客户端一样按价格将USDT金额换算为基础资产数量 (杠杆不影响数量)，持仓按基础资产数量累计
+type mockLighter struct {
+	mockScript
+
+	equity float64
+
+	mu        sync.Mutex
+	prices    map[uint8]float64
+	submitted []lighter.MarketOrderRequest // BaseAmount 为实际成交的基础资产数量
+	base      map[uint8]int64              // 市场索引 -> 持仓基础资产数量 (正数做多，负数做空)
+	nonce     int64
+}
+
+func newMockLighter(prices map[uint8]float64) *mockLighter {
+	return &mockLighter{
+		mockScript: newMockScript(),
+		equity:     10000,
+		prices:     prices,
+		base:       make(map[uint8]int64),
+	}
+}
+
+// orders 已提交的市价单，BaseAmount 为换算后的基础资产数量
+func (m *mockLighter) orders() []lighter.MarketOrderRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]lighter.MarketOrderRequest(nil), m.submitted...)
+}
+
+// notional 按市场当前价格将基础资产数量换算为USDT金额 (四舍五入)
+func (m *mockLighter) notional(marketIndex uint8, baseAmount int64) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(math.Round(float64(baseAmount) / math.Pow10(mockLighterSizeDecimals) * m.prices[marketIndex]))
+}
+
+// position 市场的持仓金额 (正数做多，负数做空)
+func (m *mockLighter) position(marketIndex uint8) float64 {
+	m.mu.Lock()
+	base := m.base[marketIndex]
+	m.mu.Unlock()
+	return float64(m.notional(marketIndex, base))
+}
+
+// submit 提交一批市价单，method 决定注入的延迟和错误；失败时整批都不成交
+func (m *mockLighter) submit(ctx context.Context, method string, reqs []*lighter.MarketOrderRequest) ([]*txtypes.L2CreateOrderTxInfo, error) {
+	reduceOnly := true
+	for _, req := range reqs {
+		reduceOnly = reduceOnly && req.ReduceOnly == 1
+	}
+	if err := m.callOrder(ctx, method, reduceOnly); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 先换算整批订单的数量，任一订单无法下单时整批都不成交
+	amounts := make([]int64, len(reqs))
+	for i, req := range reqs {
+		amounts[i] = req.BaseAmount
+		if amounts[i] > 0 {
+			continue
+		}
+		price, ok := m.prices[req.MarketIndex]
+		if !ok {
+			return nil, fmt.Errorf("mock lighter: no price for market %d", req.MarketIndex)
+		}
+		amounts[i] = int64(math.Floor(float64(req.USDTAmount)/price*math.Pow10(mockLighterSizeDecimals) + 1e-9))
+		if amounts[i] <= 0 {
+			return nil, fmt.Errorf("mock lighter: notional %d too small for market %d", req.USDTAmount, req.MarketIndex)
+		}
+	}
+
+	txs := make([]*txtypes.L2CreateOrderTxInfo, len(reqs))
+	for i, req := range reqs {
+		amount := amounts[i]
+		if req.IsAsk == 1 {
+			m.base[req.MarketIndex] -= amount
+		} else {
+			m.base[req.MarketIndex] += amount
+		}
+		submitted := *req
+		submitted.BaseAmount = amount
+		m.submitted = append(m.submitted, submitted)
+
+		m.nonce++
+		txs[i] = &txtypes.L2CreateOrderTxInfo{
+			OrderInfo: &txtypes.OrderInfo{
+				MarketIndex:      req.MarketIndex,
+				ClientOrderIndex: m.nonce,
+				BaseAmount:       amount,
+				Price:            txtypes.NilOrderPrice,
+				IsAsk:            req.IsAsk,
+				ReduceOnly:       req.ReduceOnly,
+			},
+			Nonce:      m.nonce,
+			SignedHash: fmt.Sprintf("0xmock%d", m.nonce),
+		}
+	}
+	return txs, nil
+}
+
+func (m *mockLighter) GetLastPrice(ctx context.Context, marketIndex uint8) (float64, error) {
+	if err := m.call(ctx, "GetLastPrice"); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.prices[marketIndex]
+	if !ok {
+		return 0, fmt.Errorf("mock lighter: no price for market %d", marketIndex)
+	}
+	return p, nil
+}
+
+func (m *mockLighter) GetMarkIndexPrice(ctx context.Context, marketIndex uint8) (mark, index float64, err error) {
+	p, err := m.GetLastPrice(ctx, marketIndex)
+	return p, p, err
+}
+
+func (m *mockLighter) GetDepthNotional(ctx context.Context, marketIndex uint8, side string, withinPercent float64) (float64, error) {
+	return 1e9, m.call(ctx, "GetDepthNotional")
+}
+
+func (m *mockLighter) EstimateMarketFill(ctx context.Context, marketIndex uint8, side string, quantity float64) (best, avg float64, err error) {
+	p, err := m.GetLastPrice(ctx, marketIndex)
+	return p, p, err
+}
+
+func (m *mockLighter) GetFundingRates(ctx context.Context) (map[string]float64, error) {
+	return map[string]float64{}, m.call(ctx, "GetFundingRates")
+}
+
+func (m *mockLighter) GetAccountEquity(ctx context.Context) (float64, error) {
+	return m.equity, m.call(ctx, "GetAccountEquity")
+}
+
+func (m *mockLighter) GetAvailableBalance(ctx context.Context) (float64, error) {
+	return m.equity, m.call(ctx, "GetAvailableBalance")
+}
+
+func (m *mockLighter) GetPositions(ctx context.Context) ([]lighter.Position, error) {
+	if err := m.call(ctx, "GetPositions"); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var positions []lighter.Position
+	for idx, base := range m.base {
+		if base == 0 {
+			continue
+		}
+		price := m.prices[idx]
+		size := float64(base) / math.Pow10(mockLighterSizeDecimals)
+		positions = append(positions, lighter.Position{
+			MarketIndex: idx,
+			Size:        size,
+			EntryPrice:  price,
+			Value:       size * price,
+		})
+	}
+	return positions, nil
+}
+
+func (m *mockLighter) GetOpenOrders(ctx context.Context, marketIndex uint8) ([]lighter.OpenOrder, error) {
+	return nil, m.call(ctx, "GetOpenOrders")
+}
+
+func (m *mockLighter) PlaceLong(ctx context.Context, marketIndex uint8, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error) {
+	txs, err := m.submit(ctx, "PlaceLong", []*lighter.MarketOrderRequest{{MarketIndex: marketIndex, USDTAmount: usdtAmount, Leverage: leverage}})
+	if err != nil {
+		return nil, err
+	}
+	return txs[0], nil
+}
+
+func (m *mockLighter) PlaceShort(ctx context.Context, marketIndex uint8, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error) {
+	txs, err := m.submit(ctx, "PlaceShort", []*lighter.MarketOrderRequest{{MarketIndex: marketIndex, USDTAmount: usdtAmount, Leverage: leverage, IsAsk: 1}})
+	if err != nil {
+		return nil, err
+	}
+	return txs[0], nil
+}
+
+func (m *mockLighter) PlaceMarketOrder(ctx context.Context, req *lighter.MarketOrderRequest) (*txtypes.L2CreateOrderTxInfo, error) {
+	txs, err := m.submit(ctx, "PlaceMarketOrder", []*lighter.MarketOrderRequest{req})
+	if err != nil {
+		return nil, err
+	}
+	return txs[0], nil
+}
+
+func (m *mockLighter) PlaceMarketOrders(ctx context.Context, reqs []*lighter.MarketOrderRequest) ([]*txtypes.L2CreateOrderTxInfo, error) {
+	return m.submit(ctx, "PlaceMarketOrders", reqs)
+}
+
+func (m *mockLighter) ClosePositions(ctx context.Context, marketIndexes []uint8) ([]lighter.ClosedPosition, error) {
+	if err := m.call(ctx, "ClosePositions"); err != nil {
+		return nil, err
+	}
+
+	var reqs []*lighter.MarketOrderRequest
+	var closed []lighter.ClosedPosition
+	for _, idx := range marketIndexes {
+		m.mu.Lock()
+		base, price := m.base[idx], m.prices[idx]
+		m.mu.Unlock()
+		if base == 0 {
+			continue
+		}
+		req := &lighter.MarketOrderRequest{MarketIndex: idx, BaseAmount: base, ReduceOnly: 1}
+		if base > 0 {
+			req.IsAsk = 1
+		} else {
+			req.BaseAmount = -base
+		}
+		size := float64(base) / math.Pow10(mockLighterSizeDecimals)
+		reqs = append(reqs, req)
+		closed = append(closed, lighter.ClosedPosition{Position: lighter.Position{MarketIndex: idx, Size: size, Value: size * price}})
+	}
+
+	txs, err := m.submit(ctx, "ClosePositions.submit", reqs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range closed {
+		closed[i].Tx = txs[i]
+	}
+	return closed, nil
+}
+
+var (
+	_ BinanceClient = (*mockBinance)(nil)
+	_ LighterClient = (*mockLighter)(nil)
+)
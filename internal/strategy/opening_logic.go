@@ -0,0 +1,419 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+)
+
+// OpeningManager 开仓管理器
+type OpeningManager struct {
+	hedgeStrategy   *DynamicHedgeStrategy
+	positionManager *PositionManager
+	orderManager    *OrderManager
+	orderMonitor    *OrderMonitor
+	logger          *zap.Logger
+}
+
+// NewOpeningManager 创建开仓管理器
+func NewOpeningManager(hedgeStrategy *DynamicHedgeStrategy) *OpeningManager {
+	return &OpeningManager{
+		hedgeStrategy:   hedgeStrategy,
+		positionManager: hedgeStrategy.positionManager,
+		orderManager:    hedgeStrategy.orderManager,
+		orderMonitor:    hedgeStrategy.orderMonitor,
+		logger:          hedgeStrategy.logger.Named("opening-manager"),
+	}
+}
+
+// ExecuteOpeningLogic 执行开仓逻辑：所有空闲且未达杠杆上限的币种并发开仓，返回本轮下单总金额
+func (om *OpeningManager) ExecuteOpeningLogic(ctx context.Context, config *DynamicHedgeConfig) (float64, error) {
+	om.logger.Debug("Starting opening logic execution")
+
+	// 1. 筛选可开仓的币种：没有进行中的订单，启用价差触发时价差有利，且未达到该币种的杠杆和名义金额上限。
+	// 本轮已选中的币种金额计入交易所名义金额上限，避免并发开仓合计超限。
+	var targets []SymbolSpec
+	var pending float64
+	for _, spec := range om.hedgeStrategy.symbols.Specs() {
+		if om.hedgeStrategy.symbolBusy(spec.Symbol) {
+			om.logger.Debug("Symbol has pending orders, skipping", zap.String("symbol", spec.Symbol))
+			continue
+		}
+
+		if sm := om.hedgeStrategy.spreadMonitor; sm != nil && !sm.Favorable(spec.Symbol) {
+			om.logger.Debug("Spread not favorable, skipping", zap.String("symbol", spec.Symbol))
+			continue
+		}
+
+		if risk := om.hedgeStrategy.riskManager.CheckSymbolRisk(om.positionManager, spec, pending); !risk.Allowed {
+			om.logger.Info("Symbol risk limit reached, skipping",
+				zap.String("symbol", spec.Symbol),
+				zap.Float64("leverage", risk.Leverage),
+				zap.Float64("notional", risk.Notional),
+				zap.String("reason", risk.Reason),
+			)
+			continue
+		}
+
+		targets = append(targets, spec)
+		pending += spec.OrderSize
+	}
+
+	if len(targets) == 0 {
+		om.logger.Debug("No symbols available for opening")
+		return 0, nil
+	}
+
+	// 2. 各币种并发执行开仓流程：先Binance挂Maker单，成交后Lighter按配置方向下Taker单
+	var mu sync.Mutex
+	var volume float64
+	err := runPerSymbol(targets, func(spec SymbolSpec) error {
+		size, err := om.executeOpeningSequence(ctx, config, spec)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		volume += size
+		mu.Unlock()
+		return nil
+	})
+
+	return volume, err
+}
+
+// ensurePosition 确保仓位结构存在
+func (om *OpeningManager) ensurePosition(positions *ExchangePositions, symbol string) *Position {
+	if pos, exists := positions.Positions[symbol]; exists {
+		return pos
+	}
+
+	// 如果不存在，创建空仓位
+	newPos := &Position{
+		Symbol:   symbol,
+		Size:     0,
+		Value:    0,
+		Leverage: 0,
+	}
+	positions.Positions[symbol] = newPos
+	return newPos
+}
+
+// executeOpeningSequence 执行开仓序列，返回下单金额 (按流动性限额后可能为0)
+func (om *OpeningManager) executeOpeningSequence(
+	ctx context.Context,
+	config *DynamicHedgeConfig,
+	spec SymbolSpec,
+) (float64, error) {
+	symbol, binanceSide := spec.Symbol, spec.BinanceSide()
+
+	if config.EnableDivergenceGuard && om.divergenceTooHigh(ctx, config, spec) {
+		return 0, nil
+	}
+
+	orderSize := om.liquidityLimitedSize(ctx, config, spec)
+	if orderSize <= 0 {
+		return 0, nil
+	}
+
+	om.logger.Info("Executing opening sequence",
+		zap.String("symbol", symbol),
+		zap.String("binance_side", binanceSide),
+		zap.String("lighter_side", spec.LighterSide),
+		zap.Float64("order_size", orderSize),
+	)
+
+	// 在Binance下Maker限价单并加入监控 (订单过大时按TWAP分片)
+	err := om.hedgeStrategy.placeMakerOrder(ctx, config, symbol, binanceSide, OrderRoleOpen, orderSize,
+		func(ctx context.Context, size float64) (string, error) {
+			return om.placeBinanceMakerOrder(ctx, symbol, binanceSide, size, config)
+		})
+	if err != nil {
+		return 0, fmt.Errorf("failed to place Binance maker order: %w", err)
+	}
+
+	// 注意：Lighter的Taker单会在Binance订单成交时自动触发（通过OrderMonitor）
+
+	return orderSize, nil
+}
+
+// divergenceTooHigh 永续合约腿 (Lighter，以及合约市场的Binance) 标记价格偏离指数价格超过
+// MaxMarkIndexDivergence 时暂停该币种开仓，避免在逼空/踩踏行情中开仓。获取价格失败时不阻塞开仓
+func (om *OpeningManager) divergenceTooHigh(ctx context.Context, config *DynamicHedgeConfig, spec SymbolSpec) bool {
+	type leg struct {
+		venue string
+		fetch func() (float64, float64, error)
+	}
+	legs := []leg{{"lighter", func() (float64, float64, error) {
+		return om.hedgeStrategy.lighterStrategy.markIndexPrice(ctx, spec.Symbol)
+	}}}
+	if om.hedgeStrategy.binanceStrategy.client.Market() == binance.MarketFutures {
+		legs = append(legs, leg{"binance", func() (float64, float64, error) {
+			return om.hedgeStrategy.binanceStrategy.client.GetMarkIndexPrice(ctx, om.hedgeStrategy.binanceStrategy.pair(spec.Symbol))
+		}})
+	}
+
+	for _, l := range legs {
+		mark, index, err := l.fetch()
+		if err != nil {
+			om.logger.Warn("Failed to get mark/index price, skipping divergence check",
+				zap.String("symbol", spec.Symbol),
+				zap.String("venue", l.venue),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		divergence := math.Abs(mark-index) / index * 100
+		if divergence > config.MaxMarkIndexDivergence {
+			om.logger.Warn("Mark/index divergence too high, pausing opening",
+				zap.String("symbol", spec.Symbol),
+				zap.String("venue", l.venue),
+				zap.Float64("mark_price", mark),
+				zap.Float64("index_price", index),
+				zap.Float64("divergence_percent", divergence),
+				zap.Float64("max_divergence_percent", config.MaxMarkIndexDivergence),
+			)
+			return true
+		}
+	}
+
+	return false
+}
+
+// liquidityLimitedSize 按Lighter盘口深度限制开仓金额：Maker单成交后Lighter以市价单对冲，
+// 订单金额不超过对冲方向可见深度的 MaxLiquidityRatio，降低Taker滑点。
+// 限额后低于 MinOrderSize 时返回0，跳过本轮开仓；获取深度失败时按原金额下单
+func (om *OpeningManager) liquidityLimitedSize(ctx context.Context, config *DynamicHedgeConfig, spec SymbolSpec) float64 {
+	if !config.EnableLiquiditySizing {
+		return spec.OrderSize
+	}
+
+	depth, err := om.hedgeStrategy.lighterStrategy.depthNotional(ctx, spec.Symbol, spec.LighterSide, config.LiquidityDepthPercent)
+	if err != nil {
+		om.logger.Warn("Failed to get Lighter book depth, using configured order size",
+			zap.String("symbol", spec.Symbol),
+			zap.Error(err),
+		)
+		return spec.OrderSize
+	}
+
+	size := math.Min(spec.OrderSize, depth*config.MaxLiquidityRatio)
+	if size < spec.OrderSize {
+		om.logger.Info("Order size limited by Lighter liquidity",
+			zap.String("symbol", spec.Symbol),
+			zap.String("lighter_side", spec.LighterSide),
+			zap.Float64("order_size", spec.OrderSize),
+			zap.Float64("depth_notional", depth),
+			zap.Float64("limited_size", size),
+		)
+	}
+
+	if size < config.MinOrderSize {
+		om.logger.Info("Lighter liquidity too thin, skipping opening",
+			zap.String("symbol", spec.Symbol),
+			zap.Float64("depth_notional", depth),
+			zap.Float64("limited_size", size),
+			zap.Float64("min_order_size", config.MinOrderSize),
+		)
+		return 0
+	}
+
+	return size
+}
+
+// placeBinanceMakerOrder 在Binance下Maker限价单
+func (om *OpeningManager) placeBinanceMakerOrder(
+	ctx context.Context,
+	symbol, side string,
+	size float64,
+	config *DynamicHedgeConfig,
+) (string, error) {
+	spreadPercent := om.hedgeStrategy.spreadPercent(config, symbol)
+
+	om.logger.Info("Placing Binance maker order",
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("usdc_amount", size),
+		zap.Float64("spread_percent", spreadPercent),
+	)
+
+	orderID, err := om.hedgeStrategy.binanceStrategy.placeMakerOrder(ctx, symbol, side, size, spreadPercent)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", orderID), nil
+}
+
+// PlaceLighterTakerOrder 在Lighter下Taker市价单（由OrderMonitor调用）
+func (om *OpeningManager) PlaceLighterTakerOrder(
+	ctx context.Context,
+	symbol, side string,
+	size float64,
+) error {
+	om.logger.Info("Placing Lighter taker order",
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("usdt_amount", size),
+	)
+
+	// 将USDC金额按USDC/USDT汇率转换为USDT金额
+	usdtAmount := int64(om.hedgeStrategy.lighterNotional(symbol, size))
+	leverage := om.hedgeStrategy.lighterLeverage(symbol)
+
+	_, err := om.hedgeStrategy.lighterStrategy.placeMarketOrder(ctx, symbol, side, usdtAmount, leverage)
+	return err
+}
+
+// CheckOpeningConditions 检查开仓条件
+func (om *OpeningManager) CheckOpeningConditions(ctx context.Context, config *DynamicHedgeConfig) (bool, string) {
+	// 1. 检查杠杆率限制
+	riskStatus := om.hedgeStrategy.riskManager.CheckRisk(om.positionManager)
+	if riskStatus.MaxLeverage >= config.MaxLeverage {
+		return false, fmt.Sprintf("leverage too high: %.2fx >= %.2fx",
+			riskStatus.MaxLeverage, config.MaxLeverage)
+	}
+
+	// 2. 检查是否有未完成的订单
+	pending := 0
+	for _, order := range om.orderManager.GetActiveOrders() {
+		if !order.isProtective() {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return false, fmt.Sprintf("has %d active orders", pending)
+	}
+
+	// 3. 检查账户余额
+	if config.EnableBalanceCheck {
+		if ok, reason := om.CheckBalance(ctx, config); !ok {
+			return false, reason
+		}
+	}
+
+	return true, "all conditions met"
+}
+
+// CheckBalance 检查两个交易所的可用保证金能否覆盖本轮开仓：空闲币种并发开仓，
+// 所需保证金 (下单金额 / 杠杆) 按交易所累计，Binance按下单方向取可用余额和杠杆 (见 GetFreeCollateral)，
+// Lighter按币种下单杠杆。Binance合约钱包不足且启用自动划转时先从现货钱包补足 (见 topUpFutures)。
+// 任一交易所不足时返回false和原因；查询失败时同样返回false，避免在余额未知时开仓
+func (om *OpeningManager) CheckBalance(ctx context.Context, config *DynamicHedgeConfig) (bool, string) {
+	var lighterRequired, binanceRequired float64
+	for _, spec := range om.hedgeStrategy.symbols.Specs() {
+		if om.hedgeStrategy.symbolBusy(spec.Symbol) {
+			continue
+		}
+
+		lighterRequired += spec.OrderSize / float64(om.hedgeStrategy.lighterLeverage(spec.Symbol))
+
+		free, leverage, err := om.hedgeStrategy.binanceStrategy.client.GetFreeCollateral(ctx,
+			om.hedgeStrategy.binanceStrategy.pair(spec.Symbol), spec.BinanceSide())
+		if err != nil {
+			return false, fmt.Sprintf("failed to get Binance free collateral: %v", err)
+		}
+		binanceRequired += spec.OrderSize / leverage
+		if free < binanceRequired && config.EnableAutoTransfer {
+			free += om.topUpFutures(ctx, config, binanceRequired-free)
+		}
+		if free < binanceRequired {
+			return false, fmt.Sprintf("binance free collateral %.2f < required %.2f (%s %s at %.0fx)",
+				free, binanceRequired, spec.Symbol, spec.BinanceSide(), leverage)
+		}
+	}
+
+	if lighterRequired == 0 {
+		return true, "no idle symbols"
+	}
+
+	available, err := om.hedgeStrategy.lighterStrategy.client.GetAvailableBalance(ctx)
+	if err != nil {
+		return false, fmt.Sprintf("failed to get Lighter available balance: %v", err)
+	}
+	if available < lighterRequired {
+		return false, fmt.Sprintf("lighter available balance %.2f < required %.2f", available, lighterRequired)
+	}
+
+	return true, "balance sufficient"
+}
+
+// topUpFutures 从Binance现货钱包划转 AutoTransferAsset 到合约钱包补足保证金缺口，返回实际划转金额。
+// 划转金额不少于 AutoTransferMinAmount、不超过 AutoTransferMaxAmount 和现货可用余额；失败时记录日志并返回0
+func (om *OpeningManager) topUpFutures(ctx context.Context, config *DynamicHedgeConfig, shortfall float64) float64 {
+	client := om.hedgeStrategy.binanceStrategy.client
+	if client.Market() != binance.MarketFutures {
+		return 0
+	}
+
+	spotFree, err := client.GetSpotFreeBalance(ctx, config.AutoTransferAsset)
+	if err != nil {
+		om.logger.Warn("Failed to get Binance spot balance for transfer", zap.Error(err))
+		return 0
+	}
+
+	amount := math.Max(shortfall, config.AutoTransferMinAmount)
+	if config.AutoTransferMaxAmount > 0 {
+		amount = math.Min(amount, config.AutoTransferMaxAmount)
+	}
+	amount = math.Floor(math.Min(amount, spotFree)*100) / 100
+	if amount <= 0 {
+		om.logger.Warn("No spot balance available to top up futures wallet",
+			zap.String("asset", config.AutoTransferAsset),
+			zap.Float64("shortfall", shortfall),
+			zap.Float64("spot_free", spotFree),
+		)
+		return 0
+	}
+
+	tranID, err := client.TransferToFutures(ctx, config.AutoTransferAsset, amount)
+	if err != nil {
+		om.logger.Error("Failed to top up futures wallet", zap.Float64("amount", amount), zap.Error(err))
+		return 0
+	}
+
+	om.logger.Info("Topped up futures wallet from spot",
+		zap.String("asset", config.AutoTransferAsset),
+		zap.Float64("amount", amount),
+		zap.Float64("shortfall", shortfall),
+		zap.Int64("tran_id", tranID),
+	)
+	om.hedgeStrategy.emitEvent(EventWalletTransfer, map[string]interface{}{
+		"exchange":  "binance",
+		"asset":     config.AutoTransferAsset,
+		"amount":    amount,
+		"shortfall": shortfall,
+		"tran_id":   tranID,
+	})
+	return amount
+}
+
+// GetOptimalOrderSize 获取最优订单大小
+func (om *OpeningManager) GetOptimalOrderSize(config *DynamicHedgeConfig, symbol string) float64 {
+	// 基础订单大小
+	baseSize := config.OrderSize
+
+	// TODO: 根据当前仓位情况动态调整订单大小
+	// 例如：如果某个币种仓位已经很大，可以减少订单大小
+
+	currentPositions := om.positionManager.GetBinancePositions()
+	if pos, exists := currentPositions.Positions[symbol]; exists {
+		positionRatio := math.Abs(pos.Size) / (baseSize * 10) // 假设最大仓位是10倍基础大小
+		if positionRatio > 0.8 {
+			// 如果仓位已经很大，减少订单大小
+			baseSize *= (1 - positionRatio)
+		}
+	}
+
+	om.logger.Debug("Calculated optimal order size",
+		zap.String("symbol", symbol),
+		zap.Float64("base_size", config.OrderSize),
+		zap.Float64("optimal_size", baseSize),
+	)
+
+	return baseSize
+}
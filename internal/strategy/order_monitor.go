@@ -0,0 +1,1255 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/eventbus"
+	"cs-projects-backpack/pkg/journal"
+	"cs-projects-backpack/pkg/killswitch"
+	"cs-projects-backpack/pkg/logger"
+)
+
+// OrderMonitor 订单监控器
+type OrderMonitor struct {
+	orderManager         *OrderManager
+	positionManager      *PositionManager
+	lighterStrategy      *LighterStrategy
+	binanceStrategy      *BinanceStrategy
+	fastExecutionManager *FastExecutionManager
+	protectionManager    *ProtectionManager
+	journal              *journal.Journal
+	bus                  *eventbus.Bus
+	cycles               *CycleTracker
+	logger               *zap.Logger
+
+	// 监控状态
+	isRunning bool
+	stopChan  chan struct{}
+	checkNow  chan struct{} // 请求立即检查一次活跃订单 (见 TriggerCheck)
+
+	// 监控循环，看门狗发现卡住时取消并重新启动 (见 Restart)
+	runCtx     context.Context
+	loopMu     sync.Mutex
+	loopCancel context.CancelFunc
+	heartbeat  heartbeat
+
+	panicHandler PanicHandler // 检查周期panic时调用 (nil为只记录日志)
+
+	mu      sync.RWMutex
+	cycleMu sync.Mutex // 串行化订单检查周期，关闭时撤单与进行中的对冲互斥
+
+	// 配置
+	checkInterval time.Duration
+	maxOrderAge   time.Duration // Maker单最长挂单时间，超时撤单 (0为不限制)
+	repriceStale  bool          // 超时撤单后是否重挂剩余金额
+	reprice       RepriceFunc   // 撤单后按最新价格重挂剩余金额
+
+	// 追价配置：市场价偏离挂单价超过阈值时撤单重挂
+	chaseThreshold float64              // 偏离阈值 (%)，0为不追价
+	chaseInterval  time.Duration        // 同一币种两次追价检查的最小间隔
+	maxChases      int                  // 单笔订单 (含重挂后的订单) 最多重挂次数
+	lastChaseCheck map[string]time.Time // symbol -> 最近一次追价检查时间
+
+	// 保护单不需要高频查询，按 protectiveCheckInterval 降频检查以节省接口权重
+	lastProtectiveCheck time.Time
+
+	// 已完全成交或撤销但对冲失败的订单最近一次重试时间 (order ID -> 时间)
+	hedgeRetries map[string]time.Time
+}
+
+// protectiveCheckInterval 止损/止盈保护单的状态检查间隔
+const protectiveCheckInterval = 2 * time.Second
+
+// hedgeRetryInterval 对冲失败的已成交订单重试对冲的最小间隔
+const hedgeRetryInterval = 2 * time.Second
+
+// RepriceFunc 按最新价格重新挂出订单的剩余金额，返回新订单ID
+type RepriceFunc func(ctx context.Context, order *ActiveOrder, remaining float64) (string, error)
+
+// OrderEvent 订单事件
+type OrderEvent struct {
+	Type      string       `json:"type"` // FILLED, PARTIAL_FILLED, CANCELLED
+	Order     *ActiveOrder `json:"order"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// NewOrderMonitor 创建订单监控器
+func NewOrderMonitor(
+	orderManager *OrderManager,
+	positionManager *PositionManager,
+	lighterStrategy *LighterStrategy,
+	binanceStrategy *BinanceStrategy,
+) *OrderMonitor {
+	return &OrderMonitor{
+		orderManager:    orderManager,
+		positionManager: positionManager,
+		lighterStrategy: lighterStrategy,
+		binanceStrategy: binanceStrategy,
+		logger:          logger.Named("order-monitor"),
+		stopChan:        make(chan struct{}),
+		checkNow:        make(chan struct{}, 1),
+		checkInterval:   200 * time.Millisecond, // 默认高频检查
+		hedgeRetries:    make(map[string]time.Time),
+	}
+}
+
+// SetEventBus 设置事件总线，成交、撤单和对冲结果发布到总线
+func (om *OrderMonitor) SetEventBus(bus *eventbus.Bus) {
+	om.bus = bus
+}
+
+// SetCycleTracker 设置对冲周期管理，对冲交易关联到Maker单所属的周期
+func (om *OrderMonitor) SetCycleTracker(ct *CycleTracker) {
+	om.cycles = ct
+}
+
+// publish 发布订单事件
+func (om *OrderMonitor) publish(eventType string, fields map[string]interface{}) {
+	om.bus.Publish("order-monitor", eventType, fields)
+}
+
+// SetFastExecutionManager 设置快速执行管理器
+func (om *OrderMonitor) SetFastExecutionManager(fem *FastExecutionManager) {
+	om.fastExecutionManager = fem
+}
+
+// SetProtectionManager 设置止损止盈管理器
+func (om *OrderMonitor) SetProtectionManager(pm *ProtectionManager) {
+	om.protectionManager = pm
+}
+
+// SetRepriceFunc 设置撤单后重挂订单的方法
+func (om *OrderMonitor) SetRepriceFunc(fn RepriceFunc) {
+	om.reprice = fn
+}
+
+// SetStaleOrderPolicy 设置Maker单超时策略：挂单超过 maxAge 未成交则撤单，reprice 为true时按最新价格重挂剩余金额
+func (om *OrderMonitor) SetStaleOrderPolicy(maxAge time.Duration, reprice bool) {
+	om.maxOrderAge = maxAge
+	om.repriceStale = reprice
+	om.logger.Info("Stale order policy updated",
+		zap.Duration("max_order_age", maxAge),
+		zap.Bool("reprice", reprice),
+	)
+}
+
+// SetChasePolicy 设置追价策略：市场价偏离挂单价超过 thresholdPercent 时撤单并按最新最优价重挂，
+// 同一币种每 interval 最多检查一次，单笔订单最多重挂 maxChases 次
+func (om *OrderMonitor) SetChasePolicy(thresholdPercent float64, interval time.Duration, maxChases int) {
+	om.chaseThreshold = thresholdPercent
+	om.chaseInterval = interval
+	om.maxChases = maxChases
+	om.lastChaseCheck = make(map[string]time.Time)
+	om.logger.Info("Order chase policy updated",
+		zap.Float64("threshold_percent", thresholdPercent),
+		zap.Duration("interval", interval),
+		zap.Int("max_chases", maxChases),
+	)
+}
+
+// SetTradeJournal 设置成交日志
+func (om *OrderMonitor) SetTradeJournal(j *journal.Journal) {
+	om.journal = j
+}
+
+// recordJournal 将成交写入成交日志（未启用时忽略）
+func (om *OrderMonitor) recordJournal(entry *journal.Entry) {
+	if om.journal == nil {
+		return
+	}
+	if err := om.journal.Record(entry); err != nil {
+		om.logger.Error("Failed to record trade to journal",
+			zap.String("order_id", entry.OrderID),
+			zap.Error(err),
+		)
+	}
+}
+
+// SetCheckInterval 设置检查间隔
+func (om *OrderMonitor) SetCheckInterval(interval time.Duration) {
+	om.checkInterval = interval
+	om.logger.Info("Order monitor check interval updated",
+		zap.Duration("interval", interval),
+	)
+}
+
+// Start 启动订单监控
+func (om *OrderMonitor) Start(ctx context.Context) error {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if om.isRunning {
+		return fmt.Errorf("order monitor is already running")
+	}
+
+	om.isRunning = true
+	om.logger.Info("Starting order monitor")
+
+	// 启动监控循环
+	om.runCtx = ctx
+	om.startLoop()
+
+	return nil
+}
+
+// startLoop 以 runCtx 的子上下文启动监控循环
+func (om *OrderMonitor) startLoop() {
+	om.loopMu.Lock()
+	defer om.loopMu.Unlock()
+
+	ctx, cancel := context.WithCancel(om.runCtx)
+	om.loopCancel = cancel
+	om.heartbeat.beat()
+	go om.monitorLoop(ctx)
+}
+
+// Restart 取消卡住的监控循环 (中断进行中的请求) 并启动新的循环，未启动时不处理
+func (om *OrderMonitor) Restart() {
+	om.loopMu.Lock()
+	cancel := om.loopCancel
+	om.loopMu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	om.logger.Warn("Restarting order monitor loop")
+	om.startLoop()
+}
+
+// HeartbeatAge 距监控循环最近一次完成检查的时长
+func (om *OrderMonitor) HeartbeatAge() time.Duration {
+	return om.heartbeat.age()
+}
+
+// Stop 停止订单监控
+func (om *OrderMonitor) Stop() {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if !om.isRunning {
+		return
+	}
+
+	om.logger.Info("Stopping order monitor")
+	close(om.stopChan)
+	om.isRunning = false
+}
+
+// monitorLoop 监控循环
+func (om *OrderMonitor) monitorLoop(ctx context.Context) {
+	ticker := time.NewTicker(om.checkInterval) // 使用可配置的检查间隔
+	defer ticker.Stop()
+
+	om.logger.Info("Order monitor loop started",
+		zap.Duration("check_interval", om.checkInterval),
+		zap.Bool("fast_execution_enabled", om.fastExecutionManager != nil),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			om.logger.Info("Context cancelled, stopping order monitor")
+			return
+		case <-om.stopChan:
+			om.logger.Info("Stop signal received, stopping order monitor")
+			return
+		case <-ticker.C:
+			om.runCheck(ctx)
+		case <-om.checkNow:
+			om.runCheck(ctx)
+		}
+		om.heartbeat.beat()
+
+		// 请求权重接近上限时放慢检查
+		ticker.Reset(om.binanceStrategy.pollInterval(om.checkInterval))
+	}
+}
+
+// SetPanicHandler 设置检查周期panic时的处理函数
+func (om *OrderMonitor) SetPanicHandler(fn PanicHandler) {
+	om.panicHandler = fn
+}
+
+// runCheck 执行一次活跃订单检查，panic时恢复并交给 panicHandler，监控循环继续运行
+func (om *OrderMonitor) runCheck(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, stack := unwrapPanic(r)
+			if om.panicHandler != nil {
+				om.panicHandler("order-monitor", value, stack)
+				return
+			}
+			om.logger.Error("Recovered from panic in order check", zap.Any("panic", value), zap.ByteString("stack", stack))
+		}
+	}()
+
+	if err := om.checkActiveOrders(ctx); err != nil {
+		om.logger.Error("Error checking active orders", zap.Error(err))
+	}
+}
+
+// TriggerCheck 请求监控循环立即检查一次活跃订单，已有待处理的请求时合并
+func (om *OrderMonitor) TriggerCheck() {
+	select {
+	case om.checkNow <- struct{}{}:
+	default:
+	}
+}
+
+// checkActiveOrders 检查活跃订单状态
+func (om *OrderMonitor) checkActiveOrders(ctx context.Context) error {
+	om.cycleMu.Lock()
+	defer om.cycleMu.Unlock()
+
+	activeOrders := om.orderManager.GetActiveOrders()
+
+	checkProtective := time.Since(om.lastProtectiveCheck) >= protectiveCheckInterval
+	if checkProtective {
+		om.lastProtectiveCheck = time.Now()
+	}
+
+	om.mu.Lock()
+	for id := range om.hedgeRetries {
+		if _, ok := activeOrders[id]; !ok {
+			delete(om.hedgeRetries, id)
+		}
+	}
+	om.mu.Unlock()
+
+	checks := make([]*orderCheck, 0, len(activeOrders))
+	for _, order := range activeOrders {
+		if order.isProtective() && !checkProtective {
+			continue
+		}
+		checks = append(checks, &orderCheck{order: order})
+	}
+
+	// 先查询全部订单的状态，再按优先级处理：未对冲金额最大的成交最先对冲
+	om.runWorkers(len(checks), func(i int) {
+		checks[i].change, checks[i].err = om.checkOrderStatus(ctx, checks[i].order)
+	})
+	om.prioritize(checks)
+	om.runWorkers(len(checks), func(i int) {
+		om.processOrder(ctx, checks[i])
+	})
+	return nil
+}
+
+// runWorkers 按并发数限制执行 fn(0..n-1)，按下标顺序启动，等待全部完成后返回
+func (om *OrderMonitor) runWorkers(n int, fn func(i int)) {
+	sem := make(chan struct{}, om.concurrency())
+	panics := make([]*goroutinePanic, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			defer repanic(&panics[i])
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	// worker的panic在检查周期中重新抛出，由 runCheck 恢复
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+}
+
+// concurrency 同时处理的订单数：启用快速执行的并发执行时为 MaxConcurrentOrders，否则逐个处理
+func (om *OrderMonitor) concurrency() int {
+	if om.fastExecutionManager == nil {
+		return 1
+	}
+	return om.fastExecutionManager.Concurrency()
+}
+
+// processOrder 处理单个订单的状态变化 (成交后对冲)，之后按需超时撤单或追价
+func (om *OrderMonitor) processOrder(ctx context.Context, check *orderCheck) {
+	order := check.order
+	err := check.err
+	if err == nil && check.change != nil {
+		if err = om.handleOrderStatusChange(ctx, order, check.change.oldStatus, check.change.newStatus); err != nil {
+			err = fmt.Errorf("failed to handle order status change: %w", err)
+		}
+	}
+	if err != nil {
+		om.logger.Error("Error checking order status",
+			zap.String("order_id", order.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if om.isStale(order) {
+		if err := om.replaceOrder(ctx, order, "STALE", om.repriceStale); err != nil {
+			om.logger.Error("Error cancelling stale order",
+				zap.String("order_id", order.ID),
+				zap.Error(err),
+			)
+		}
+		return
+	}
+
+	if om.shouldChase(ctx, order) {
+		if err := om.replaceOrder(ctx, order, "CHASE", true); err != nil {
+			om.logger.Error("Error chasing order",
+				zap.String("order_id", order.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// WorkingOrders 未完全成交的Maker单数量
+func (om *OrderMonitor) WorkingOrders() int {
+	count := 0
+	for _, order := range om.orderManager.GetActiveOrders() {
+		if isWorkingMaker(order) {
+			count++
+		}
+	}
+	return count
+}
+
+// WaitIdle 等待进行中的订单检查 (含对冲) 完成
+func (om *OrderMonitor) WaitIdle() {
+	om.cycleMu.Lock()
+	defer om.cycleMu.Unlock()
+}
+
+// CancelWorkingOrders 撤销全部未完全成交的Maker单，撤单前新增的成交先完成对冲，返回撤单数量
+func (om *OrderMonitor) CancelWorkingOrders(ctx context.Context, reason string) (int, error) {
+	om.cycleMu.Lock()
+	defer om.cycleMu.Unlock()
+
+	var lastErr error
+	cancelled := 0
+	for _, order := range om.orderManager.GetActiveOrders() {
+		if !isWorkingMaker(order) {
+			continue
+		}
+		if err := om.replaceOrder(ctx, order, reason, false); err != nil {
+			om.logger.Error("Error cancelling maker order",
+				zap.String("order_id", order.ID),
+				zap.Error(err),
+			)
+			lastErr = err
+			continue
+		}
+		cancelled++
+	}
+	return cancelled, lastErr
+}
+
+// isWorkingMaker 是否为未完全成交的Binance Maker单 (保护单除外)
+func isWorkingMaker(order *ActiveOrder) bool {
+	if order.Exchange != "binance" || order.isProtective() {
+		return false
+	}
+	return order.Status == "PENDING" || order.Status == "PARTIAL"
+}
+
+// isStale 未成交的Binance Maker单挂单时间是否超过上限 (保护单不受限制)
+func (om *OrderMonitor) isStale(order *ActiveOrder) bool {
+	if om.maxOrderAge <= 0 || !isWorkingMaker(order) {
+		return false
+	}
+	return time.Since(order.CreatedAt) > om.maxOrderAge
+}
+
+// shouldChase 市场价是否已向远离挂单价的方向偏离超过阈值：买单价格低于市价、卖单价格高于市价。
+// 同一币种按 chaseInterval 限频查询价格
+func (om *OrderMonitor) shouldChase(ctx context.Context, order *ActiveOrder) bool {
+	if om.chaseThreshold <= 0 || om.reprice == nil || !isWorkingMaker(order) || order.Price <= 0 {
+		return false
+	}
+	if om.maxChases > 0 && order.Chases >= om.maxChases {
+		return false
+	}
+	om.mu.Lock()
+	if time.Since(om.lastChaseCheck[order.Symbol]) < om.chaseInterval {
+		om.mu.Unlock()
+		return false
+	}
+	om.lastChaseCheck[order.Symbol] = time.Now()
+	om.mu.Unlock()
+
+	price, err := om.binanceStrategy.client.GetCurrentPrice(ctx, om.binanceStrategy.pair(order.Symbol))
+	if err != nil {
+		om.logger.Warn("Failed to get price for order chasing", zap.String("symbol", order.Symbol), zap.Error(err))
+		return false
+	}
+
+	drift := (price - order.Price) / order.Price * 100
+	if order.Side == "SELL" {
+		drift = -drift
+	}
+
+	if drift <= om.chaseThreshold {
+		return false
+	}
+
+	om.logger.Info("Market moved away from resting order",
+		zap.String("order_id", order.ID),
+		zap.String("symbol", order.Symbol),
+		zap.String("side", order.Side),
+		zap.Float64("order_price", order.Price),
+		zap.Float64("market_price", price),
+		zap.Float64("drift_percent", drift),
+		zap.Int("chases", order.Chases),
+	)
+	return true
+}
+
+// replaceOrder 撤销Maker单：撤单前新增的成交先完成对冲，reprice 为true时按最新价格重挂剩余金额
+func (om *OrderMonitor) replaceOrder(ctx context.Context, order *ActiveOrder, reason string, reprice bool) error {
+	om.logger.Warn("Cancelling maker order",
+		zap.String("order_id", order.ID),
+		zap.String("reason", reason),
+		zap.String("symbol", order.Symbol),
+		zap.String("side", order.Side),
+		zap.Duration("age", time.Since(order.CreatedAt)),
+		zap.Float64("filled_size", order.FilledSize),
+	)
+
+	orderID, err := strconv.ParseInt(order.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid binance order id %s: %w", order.ID, err)
+	}
+
+	// 撤单失败通常是订单刚好成交，交由下一轮状态检查处理
+	if err := om.binanceStrategy.client.CancelOrder(ctx, om.binanceStrategy.pair(order.Symbol), orderID); err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	_, filledSize, err := om.getBinanceOrderStatus(ctx, order)
+	if err != nil {
+		// 无法确认最终成交，保留上次检查的成交量
+		om.logger.Warn("Failed to confirm cancelled order status", zap.String("order_id", order.ID), zap.Error(err))
+		filledSize = order.FilledSize
+	}
+
+	// 先记录撤单和最终成交量，对冲失败时订单保留在活跃列表中，由下一轮检查重试对冲
+	om.orderManager.UpdateOrderStatus(order.ID, "CANCELLED", filledSize)
+	order.Status = "CANCELLED"
+	order.FilledSize = filledSize
+	if err := om.handleOrderCancelled(ctx, order); err != nil {
+		return fmt.Errorf("failed to hedge fills before cancel: %w", err)
+	}
+
+	remaining := order.Size - filledSize
+	if !reprice || om.reprice == nil || remaining <= 0 {
+		return nil
+	}
+
+	newID, err := om.reprice(ctx, order, remaining)
+	if err != nil {
+		return fmt.Errorf("failed to reprice order: %w", err)
+	}
+
+	om.logger.Info("Maker order repriced",
+		zap.String("old_order_id", order.ID),
+		zap.String("new_order_id", newID),
+		zap.String("reason", reason),
+		zap.Float64("remaining", remaining),
+	)
+	return nil
+}
+
+// checkOrderStatus 查询单个订单状态并更新，返回状态变化 (无变化时为nil)，由 processOrder 处理
+func (om *OrderMonitor) checkOrderStatus(ctx context.Context, order *ActiveOrder) (*statusChange, error) {
+	// 已完全成交或撤销但成交尚未全部对冲的订单不再查询交易所，按 hedgeRetryInterval 重新处理以重试对冲
+	if isTerminalStatus(order.Status) {
+		om.mu.Lock()
+		defer om.mu.Unlock()
+		if time.Since(om.hedgeRetries[order.ID]) < hedgeRetryInterval {
+			return nil, nil
+		}
+		om.hedgeRetries[order.ID] = time.Now()
+		om.logger.Warn("Retrying hedge for unhedged order",
+			zap.String("order_id", order.ID),
+			zap.String("status", order.Status),
+			zap.Float64("filled_size", order.FilledSize),
+			zap.Float64("hedged_size", order.HedgedSize),
+		)
+		return &statusChange{oldStatus: order.Status, newStatus: order.Status}, nil
+	}
+
+	var newStatus string
+	var filledSize float64
+	var err error
+
+	// 根据交易所查询订单状态
+	switch order.Exchange {
+	case "binance":
+		newStatus, filledSize, err = om.getBinanceOrderStatus(ctx, order)
+	case "lighter":
+		newStatus, filledSize, err = om.getLighterOrderStatus(ctx, order)
+	default:
+		return nil, fmt.Errorf("unknown exchange: %s", order.Exchange)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status: %w", err)
+	}
+
+	// 检查状态是否有变化
+	if newStatus == order.Status && filledSize == order.FilledSize {
+		return nil, nil
+	}
+
+	oldStatus := order.Status
+	oldFilledSize := order.FilledSize
+
+	// 更新订单状态 (order 为本轮检查的副本，同步更新供后续处理使用)
+	om.orderManager.UpdateOrderStatus(order.ID, newStatus, filledSize)
+	order.Status = newStatus
+	order.FilledSize = filledSize
+
+	om.logger.Info("Order status updated",
+		zap.String("order_id", order.ID),
+		zap.String("old_status", oldStatus),
+		zap.String("new_status", newStatus),
+		zap.Float64("old_filled", oldFilledSize),
+		zap.Float64("new_filled", filledSize),
+	)
+
+	return &statusChange{oldStatus: oldStatus, newStatus: newStatus}, nil
+}
+
+// handleOrderStatusChange 处理订单状态变化
+func (om *OrderMonitor) handleOrderStatusChange(ctx context.Context, order *ActiveOrder, oldStatus, newStatus string) error {
+	switch newStatus {
+	case "FILLED":
+		return om.handleOrderFilled(ctx, order)
+	case "PARTIAL":
+		return om.handleOrderPartialFilled(ctx, order)
+	case "CANCELLED":
+		return om.handleOrderCancelled(ctx, order)
+	}
+
+	return nil
+}
+
+// handleOrderFilled 处理订单完全成交：部分成交时已对冲的部分不再重复对冲和计入仓位，只处理剩余成交量
+func (om *OrderMonitor) handleOrderFilled(ctx context.Context, order *ActiveOrder) error {
+	startTime := time.Now()
+
+	om.logger.Info("Order fully filled, executing hedge trade",
+		zap.String("order_id", order.ID),
+		zap.String("cycle_id", order.CycleID),
+		zap.String("exchange", order.Exchange),
+		zap.String("symbol", order.Symbol),
+		zap.String("side", order.Side),
+		zap.Float64("size", order.Size),
+		zap.Float64("hedged_size", order.HedgedSize),
+	)
+
+	fill := order
+	if order.HedgedSize > 0 {
+		remaining := *order
+		remaining.Size = order.Size - order.HedgedSize
+		fill = &remaining
+	}
+	if fill.Size > 0 {
+		// 对冲失败时订单保留在活跃列表中，下一轮检查重试
+		if err := om.hedgeFill(ctx, fill, startTime); err != nil {
+			return err
+		}
+		om.orderManager.AddHedgedSize(order.ID, fill.Size)
+		order.HedgedSize += fill.Size
+	}
+	om.orderManager.RemoveOrder(order.ID)
+
+	return om.handleProtection(ctx, order)
+}
+
+// hedgeFill 记录Maker成交、执行对冲并更新仓位，order.Size 为本次需要对冲的成交量
+func (om *OrderMonitor) hedgeFill(ctx context.Context, order *ActiveOrder, startTime time.Time) error {
+	// 已成交的部分必须对冲，紧急停止后仍然放行
+	ctx = killswitch.Exempt(ctx)
+
+	reason := "MAKER_FILL"
+	if order.isProtective() {
+		reason = order.Role
+	}
+
+	// 之前对冲失败时已记录的成交不重复记录，只记录新增的部分
+	if size := om.orderManager.RecordFill(order.ID, order.Size); size > 0 {
+		om.recordJournal(&journal.Entry{
+			Venue:   order.Exchange,
+			Symbol:  order.Symbol,
+			Side:    order.Side,
+			Size:    size,
+			Price:   order.Price,
+			Fee:     om.positionManager.Fee(order.Exchange, orderLiquidity(order), size),
+			OrderID: order.ID,
+			CycleID: order.CycleID,
+			Reason:  reason,
+		})
+		om.publish(EventOrderFilled, map[string]interface{}{
+			"order_id": order.ID,
+			"cycle_id": order.CycleID,
+			"exchange": order.Exchange,
+			"symbol":   order.Symbol,
+			"side":     order.Side,
+			"size":     size,
+			"price":    order.Price,
+			"reason":   reason,
+		})
+	}
+
+	// 使用快速执行管理器进行对冲交易
+	if om.fastExecutionManager != nil {
+		execCtx, err := om.fastExecutionManager.ExecuteFastHedge(
+			ctx,
+			order.ID,
+			order.Symbol,
+			order.Side,
+			order.Size,
+			order.Price,
+		)
+
+		if err != nil {
+			om.logger.Error("Fast hedge execution failed",
+				zap.String("order_id", order.ID),
+				zap.Duration("total_delay", time.Since(startTime)),
+				zap.Error(err),
+			)
+			om.publishHedgeFailed(order, err)
+			return err
+		}
+
+		om.logger.Info("Fast hedge execution completed",
+			zap.String("order_id", order.ID),
+			zap.String("cycle_id", order.CycleID),
+			zap.String("hedge_tx_hash", execCtx.HedgeTxHash),
+			zap.Duration("detection_to_execution", execCtx.TotalDelay),
+			zap.Float64("execution_price", execCtx.ExecutionPrice),
+			zap.Float64("adverse_percent", execCtx.AdversePercent),
+			zap.Bool("success", execCtx.Success),
+		)
+
+		om.recordJournal(&journal.Entry{
+			Venue:     "lighter",
+			Symbol:    order.Symbol,
+			Side:      execCtx.HedgeSide,
+			Size:      order.Size,
+			Price:     execCtx.ExecutionPrice,
+			Fee:       om.positionManager.Fee("lighter", LiquidityTaker, order.Size),
+			OrderID:   execCtx.HedgeTxHash,
+			HedgeLink: order.ID,
+			CycleID:   order.CycleID,
+			LatencyMs: execCtx.TotalDelay.Milliseconds(),
+			Reason:    "HEDGE",
+			Adverse:   execCtx.AdverseExcursion,
+		})
+		om.cycles.Link(order.CycleID, execCtx.HedgeTxHash)
+
+		// 对冲成交价未知时仓位按对冲前的参考价 (未获取时按Maker成交价) 近似计入，成交日志和归因仍按未知处理
+		fillPrice := execCtx.ExecutionPrice
+		if fillPrice <= 0 {
+			fillPrice = execCtx.MarketPrice
+		}
+		if fillPrice <= 0 {
+			fillPrice = order.Price
+		}
+		om.positionManager.ApplyFill("lighter", order.Symbol, execCtx.HedgeSide, LiquidityTaker, order.Size, fillPrice)
+		closing := order.Role != "" && order.Role != OrderRoleOpen
+		om.positionManager.RecordHedge(order.Symbol, order.Side, closing, order.Size, order.Price, execCtx.MarketPrice, execCtx.ExecutionPrice)
+		om.publish(EventHedgeExecuted, map[string]interface{}{
+			"order_id":          order.ID,
+			"cycle_id":          order.CycleID,
+			"exchange":          "lighter",
+			"symbol":            order.Symbol,
+			"side":              execCtx.HedgeSide,
+			"size":              order.Size,
+			"price":             execCtx.ExecutionPrice,
+			"latency_ms":        execCtx.TotalDelay.Milliseconds(),
+			"adverse_percent":   execCtx.AdversePercent,
+			"adverse_excursion": execCtx.AdverseExcursion,
+		})
+		if om.fastExecutionManager.IsDelayExcessive(execCtx.TotalDelay) {
+			om.publishDelayExceeded(order, execCtx)
+		}
+	} else {
+		// 降级到传统执行方式
+		if err := om.executeHedgeTrade(ctx, order); err != nil {
+			om.logger.Error("Failed to execute hedge trade",
+				zap.String("order_id", order.ID),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	// 更新仓位信息
+	return om.updatePositionsAfterTrade(order)
+}
+
+// handleProtection 开仓单成交后挂保护单；保护单成交后撤销同组的另一张
+func (om *OrderMonitor) handleProtection(ctx context.Context, order *ActiveOrder) error {
+	if om.protectionManager == nil {
+		return nil
+	}
+
+	if order.isProtective() {
+		return om.protectionManager.OnTriggered(ctx, order)
+	}
+	return om.protectionManager.Attach(ctx, order)
+}
+
+// handleOrderPartialFilled 处理订单部分成交：FilledSize 为累计成交量，只对冲超出已对冲量的部分。
+// 启用快速执行时按预执行配置决定是否提前对冲，成交比例未达到阈值时等待。
+// 对冲失败时已对冲量不变，差额在下一次成交更新或完全成交时一并对冲
+func (om *OrderMonitor) handleOrderPartialFilled(ctx context.Context, order *ActiveOrder) error {
+	startTime := time.Now()
+
+	delta := order.FilledSize - order.HedgedSize
+	if delta <= 0 {
+		return nil
+	}
+	if om.fastExecutionManager != nil && !om.fastExecutionManager.ShouldPreExecute(order.FilledSize, order.Size) {
+		om.logger.Debug("Partial fill below pre-execution threshold, waiting",
+			zap.String("order_id", order.ID),
+			zap.Float64("filled_size", order.FilledSize),
+			zap.Float64("size", order.Size),
+		)
+		return nil
+	}
+
+	om.logger.Info("Order partially filled, executing partial hedge",
+		zap.String("order_id", order.ID),
+		zap.Float64("filled_size", order.FilledSize),
+		zap.Float64("hedged_size", order.HedgedSize),
+		zap.Float64("delta", delta),
+		zap.Float64("remaining_size", order.Size-order.FilledSize),
+	)
+
+	// 为新成交部分执行对冲
+	hedgeOrder := &ActiveOrder{
+		ID:       order.ID,
+		Exchange: order.Exchange,
+		Symbol:   order.Symbol,
+		Side:     order.Side,
+		Size:     delta, // 只对冲新成交的部分
+		Price:    order.Price,
+		Role:     order.Role,
+		CycleID:  order.CycleID,
+	}
+
+	if err := om.hedgeFill(ctx, hedgeOrder, startTime); err != nil {
+		om.logger.Error("Failed to execute partial hedge trade",
+			zap.String("order_id", order.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	om.orderManager.AddHedgedSize(order.ID, delta)
+	order.HedgedSize += delta
+	return nil
+}
+
+// handleOrderCancelled 处理订单取消：撤单前未对冲的成交先完成对冲，之后才移出活跃订单。
+// 对冲失败时订单保留，下一轮检查重试
+func (om *OrderMonitor) handleOrderCancelled(ctx context.Context, order *ActiveOrder) error {
+	om.logger.Warn("Order cancelled",
+		zap.String("order_id", order.ID),
+		zap.String("exchange", order.Exchange),
+		zap.Float64("filled_size", order.FilledSize),
+		zap.Float64("hedged_size", order.HedgedSize),
+	)
+
+	if delta := order.FilledSize - order.HedgedSize; delta > 0 {
+		hedgeOrder := &ActiveOrder{
+			ID:       order.ID,
+			Exchange: order.Exchange,
+			Symbol:   order.Symbol,
+			Side:     order.Side,
+			Size:     delta,
+			Price:    order.Price,
+			Role:     order.Role,
+			CycleID:  order.CycleID,
+		}
+		if err := om.hedgeFill(ctx, hedgeOrder, time.Now()); err != nil {
+			om.logger.Error("Failed to hedge fills of cancelled order",
+				zap.String("order_id", order.ID),
+				zap.Float64("delta", delta),
+				zap.Error(err),
+			)
+			return err
+		}
+		om.orderManager.AddHedgedSize(order.ID, delta)
+		order.HedgedSize += delta
+	}
+
+	// 从活跃订单中移除
+	om.orderManager.RemoveOrder(order.ID)
+
+	om.publish(EventOrderCancelled, map[string]interface{}{
+		"order_id":    order.ID,
+		"exchange":    order.Exchange,
+		"symbol":      order.Symbol,
+		"filled_size": order.FilledSize,
+	})
+
+	return nil
+}
+
+// executeHedgeTrade 执行对冲交易
+func (om *OrderMonitor) executeHedgeTrade(ctx context.Context, order *ActiveOrder) error {
+	// 确定对冲方向和交易所：在另一个交易所反向下单
+	hedgeExchange := "binance"
+	if order.Exchange == "binance" {
+		hedgeExchange = "lighter"
+	}
+	hedgeSide := oppositeSide(order.Side)
+
+	om.logger.Info("Executing hedge trade",
+		zap.String("original_exchange", order.Exchange),
+		zap.String("hedge_exchange", hedgeExchange),
+		zap.String("symbol", order.Symbol),
+		zap.String("hedge_side", hedgeSide),
+		zap.Float64("size", order.Size),
+	)
+
+	// 执行对冲交易 (使用市价单快速成交)
+	startTime := time.Now()
+	var err error
+	switch hedgeExchange {
+	case "lighter":
+		err = om.executeLighterHedge(ctx, order.Symbol, hedgeSide, order.Size)
+	case "binance":
+		err = om.executeBinanceHedge(ctx, order.Symbol, hedgeSide, order.Size)
+	default:
+		return fmt.Errorf("unknown hedge exchange: %s", hedgeExchange)
+	}
+	if err != nil {
+		om.publishHedgeFailed(order, err)
+		return err
+	}
+
+	om.recordJournal(&journal.Entry{
+		Venue:     hedgeExchange,
+		Symbol:    order.Symbol,
+		Side:      hedgeSide,
+		Size:      order.Size,
+		Fee:       om.positionManager.Fee(hedgeExchange, LiquidityTaker, order.Size),
+		HedgeLink: order.ID,
+		CycleID:   order.CycleID,
+		LatencyMs: time.Since(startTime).Milliseconds(),
+		Reason:    "HEDGE",
+	})
+
+	// 市价对冲的成交价暂无回报，按原订单价格近似计入仓位
+	om.positionManager.ApplyFill(hedgeExchange, order.Symbol, hedgeSide, LiquidityTaker, order.Size, order.Price)
+	om.publish(EventHedgeExecuted, map[string]interface{}{
+		"order_id":   order.ID,
+		"cycle_id":   order.CycleID,
+		"exchange":   hedgeExchange,
+		"symbol":     order.Symbol,
+		"side":       hedgeSide,
+		"size":       order.Size,
+		"price":      order.Price,
+		"latency_ms": time.Since(startTime).Milliseconds(),
+	})
+
+	return nil
+}
+
+// publishHedgeFailed 发布对冲失败事件
+func (om *OrderMonitor) publishHedgeFailed(order *ActiveOrder, err error) {
+	om.publish(EventHedgeFailed, map[string]interface{}{
+		"order_id": order.ID,
+		"cycle_id": order.CycleID,
+		"exchange": order.Exchange,
+		"symbol":   order.Symbol,
+		"side":     order.Side,
+		"size":     order.Size,
+		"error":    err.Error(),
+	})
+}
+
+// publishDelayExceeded 发布执行延迟超限事件，包含Maker单和对冲单的详情
+func (om *OrderMonitor) publishDelayExceeded(order *ActiveOrder, execCtx *ExecutionContext) {
+	om.publish(EventDelayExceeded, map[string]interface{}{
+		"order_id":        order.ID,
+		"cycle_id":        order.CycleID,
+		"exchange":        order.Exchange,
+		"symbol":          order.Symbol,
+		"side":            order.Side,
+		"size":            order.Size,
+		"price":           order.Price,
+		"hedge_side":      execCtx.HedgeSide,
+		"hedge_price":     execCtx.ExecutionPrice,
+		"hedge_tx_hash":   execCtx.HedgeTxHash,
+		"latency_ms":      execCtx.TotalDelay.Milliseconds(),
+		"max_delay_ms":    om.fastExecutionManager.MaxExecutionDelay().Milliseconds(),
+		"adverse_percent": execCtx.AdversePercent,
+	})
+}
+
+// executeLighterHedge 在Lighter下市价单对冲，size 为成交金额 (按整数USDT下单)，交易所接受提交后返回
+func (om *OrderMonitor) executeLighterHedge(ctx context.Context, symbol, side string, size float64) error {
+	usdtAmount := int64(math.Round(size))
+	if usdtAmount <= 0 {
+		return fmt.Errorf("lighter hedge size %.4f for %s is below 1 USDT", size, symbol)
+	}
+
+	txs, err := om.lighterStrategy.placeMarketOrders(ctx, []marketOrder{{
+		Symbol:     symbol,
+		Side:       side,
+		USDTAmount: usdtAmount,
+		Leverage:   om.lighterStrategy.leverage(symbol),
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to place %s %s on Lighter: %w", symbol, side, err)
+	}
+
+	om.logger.Info("Lighter hedge submitted",
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Int64("usdt_amount", usdtAmount),
+		zap.String("tx_hash", txs[0].GetTxHash()),
+	)
+	return nil
+}
+
+// executeBinanceHedge 在Binance按最新价格换算数量下市价单对冲，size 为成交金额
+func (om *OrderMonitor) executeBinanceHedge(ctx context.Context, symbol, side string, size float64) error {
+	pair := om.binanceStrategy.pair(symbol)
+	price, err := om.binanceStrategy.client.GetCurrentPrice(ctx, pair)
+	if err != nil {
+		return fmt.Errorf("failed to get %s price for hedge: %w", pair, err)
+	}
+	if price <= 0 {
+		return fmt.Errorf("invalid %s price for hedge: %f", pair, price)
+	}
+
+	result := om.binanceStrategy.client.PlaceMarketOrders(ctx, []binance.MarketOrderRequest{{
+		Symbol:   pair,
+		Side:     side,
+		Quantity: size / price,
+	}})[0]
+	if result.Err != nil {
+		return fmt.Errorf("failed to place %s %s on Binance: %w", pair, side, result.Err)
+	}
+
+	om.logger.Info("Binance hedge placed",
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("size", size),
+		zap.Int64("order_id", result.Order.OrderID),
+	)
+	return nil
+}
+
+// updatePositionsAfterTrade 交易后更新仓位
+func (om *OrderMonitor) updatePositionsAfterTrade(order *ActiveOrder) error {
+	om.logger.Debug("Updating positions after trade",
+		zap.String("symbol", order.Symbol),
+		zap.Float64("size", order.Size),
+	)
+
+	realized := om.positionManager.ApplyFill(order.Exchange, order.Symbol, order.Side, orderLiquidity(order), order.Size, order.Price)
+	if realized != 0 {
+		om.logger.Info("Realized PnL on position reduction",
+			zap.String("exchange", order.Exchange),
+			zap.String("symbol", order.Symbol),
+			zap.Float64("realized_pnl", realized),
+		)
+	}
+
+	om.positionManager.CalculateTotalLeverage()
+	return nil
+}
+
+// getBinanceOrderStatus 获取Binance订单状态
+func (om *OrderMonitor) getBinanceOrderStatus(ctx context.Context, order *ActiveOrder) (string, float64, error) {
+	orderID, err := strconv.ParseInt(order.ID, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid binance order id %s: %w", order.ID, err)
+	}
+
+	status, err := om.binanceStrategy.client.GetOrder(ctx, om.binanceStrategy.pair(order.Symbol), orderID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// 下单时只返回订单ID，首次查询时补充挂单价格
+	if order.Price == 0 && status.Price > 0 {
+		om.orderManager.UpdateOrderPrice(order.ID, status.Price)
+		order.Price = status.Price
+	}
+
+	// 成交数量换算为金额，与订单 Size 口径一致
+	return binanceOrderState(status.Status), status.ExecutedQty * status.Price, nil
+}
+
+// binanceOrderState 将Binance订单状态映射为 PENDING, PARTIAL, FILLED, CANCELLED
+func binanceOrderState(status string) string {
+	switch status {
+	case "PARTIALLY_FILLED":
+		return "PARTIAL"
+	case "FILLED":
+		return "FILLED"
+	case "CANCELED", "EXPIRED", "EXPIRED_IN_MATCH", "REJECTED":
+		return "CANCELLED"
+	default:
+		return "PENDING"
+	}
+}
+
+// isTerminalStatus 订单是否已完全成交或撤销
+func isTerminalStatus(status string) bool {
+	return status == "FILLED" || status == "CANCELLED"
+}
+
+// getLighterOrderStatus 获取Lighter订单状态
+func (om *OrderMonitor) getLighterOrderStatus(ctx context.Context, order *ActiveOrder) (string, float64, error) {
+	// TODO: 实现Lighter订单状态查询
+	return "PENDING", 0, nil
+}
+
+// 订单管理器方法实现
+
+// AddOrder 添加订单到监控
+func (om *OrderManager) AddOrder(order *ActiveOrder) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	om.activeOrders[order.ID] = order
+	om.persist()
+	om.logger.Info("Added order to monitoring",
+		zap.String("order_id", order.ID),
+		zap.String("exchange", order.Exchange),
+		zap.String("symbol", order.Symbol),
+	)
+}
+
+// GetActiveOrders 获取所有活跃订单
+func (om *OrderManager) GetActiveOrders() map[string]*ActiveOrder {
+	om.mu.RLock()
+	defer om.mu.RUnlock()
+
+	// 返回副本，调用方 (含并发处理订单的worker) 修改副本不影响管理器中的订单
+	orders := make(map[string]*ActiveOrder, len(om.activeOrders))
+	for id, order := range om.activeOrders {
+		orderCopy := *order
+		orders[id] = &orderCopy
+	}
+
+	return orders
+}
+
+// HasActiveOrders 指定币种是否有未完成订单
+func (om *OrderManager) HasActiveOrders(symbol string) bool {
+	om.mu.RLock()
+	defer om.mu.RUnlock()
+
+	for _, order := range om.activeOrders {
+		// 保护单在仓位存续期间一直挂着，不阻塞该币种的开平仓
+		if order.Symbol == symbol && !order.isProtective() {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateOrderStatus 更新订单状态。完全成交或已撤销的订单仍保留 (含持久化状态)，
+// 成交全部对冲后由 RemoveOrder 移除，对冲失败时下一轮检查重试
+func (om *OrderManager) UpdateOrderStatus(orderID, status string, filledSize float64) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if order, exists := om.activeOrders[orderID]; exists {
+		order.Status = status
+		order.FilledSize = filledSize
+		order.UpdatedAt = time.Now()
+		om.persist()
+	}
+}
+
+// AddHedgedSize 累加订单的已对冲成交量
+func (om *OrderManager) AddHedgedSize(orderID string, size float64) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if order, exists := om.activeOrders[orderID]; exists {
+		order.HedgedSize += size
+		om.persist()
+	}
+}
+
+// RecordFill 登记订单待对冲的成交量 size (FilledSize - HedgedSize) 已记入成交日志，返回其中新增、需要记录的部分。
+// 对冲失败时已对冲量不变，重试时同一成交不会再次记录；不在监控中的订单全部需要记录
+func (om *OrderManager) RecordFill(orderID string, size float64) float64 {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	order, exists := om.activeOrders[orderID]
+	if !exists {
+		return size
+	}
+	fresh := size - math.Max(order.RecordedSize-order.HedgedSize, 0)
+	if fresh <= positionEpsilon {
+		return 0
+	}
+	order.RecordedSize = order.HedgedSize + size
+	om.persist()
+	return fresh
+}
+
+// UpdateOrderPrice 更新订单挂单价格
+func (om *OrderManager) UpdateOrderPrice(orderID string, price float64) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if order, exists := om.activeOrders[orderID]; exists {
+		order.Price = price
+		om.persist()
+	}
+}
+
+// RemoveOrder 移除订单
+func (om *OrderManager) RemoveOrder(orderID string) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	delete(om.activeOrders, orderID)
+	om.persist()
+	om.logger.Debug("Removed order from monitoring", zap.String("order_id", orderID))
+}
+
+// SetStore 设置活跃订单持久化，之后每次订单变化都会保存
+func (om *OrderManager) SetStore(store *OrderStore) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.store = store
+}
+
+// persist 保存全部活跃订单 (调用方需持有锁)，失败只记录日志，不影响交易
+func (om *OrderManager) persist() {
+	if om.store == nil {
+		return
+	}
+	if err := om.store.Save(om.activeOrders); err != nil {
+		om.logger.Error("Failed to persist active orders", zap.Error(err))
+	}
+}
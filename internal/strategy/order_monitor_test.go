@@ -0,0 +1,212 @@
+package strategy
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cs-projects-backpack/pkg/journal"
+)
+
+// fillStep 订单检查前交易所侧的一次变化
+type fillStep struct {
+	notional  float64 // 新成交金额 (USDC)
+	fill      bool    // 剩余部分全部成交
+	expire    bool    // 交易所撤销订单 (在 notional 成交之后)
+	hedgeFail bool    // 本轮对冲下单失败 (下一步检查时重试)
+	hedged    int64   // 本轮检查后Lighter累计对冲金额 (USDT)
+}
+
+func TestPartialFillsHedgedOnce(t *testing.T) {
+	tests := []struct {
+		name         string
+		preExecution bool // 启用快速执行 (部分成交达到 50% 后才对冲)
+		steps        []fillStep
+		hedges       []int64 // Lighter对冲单金额，按下单顺序
+	}{
+		{
+			name: "partials then fill",
+			steps: []fillStep{
+				{notional: 20, hedged: 20},
+				{notional: 30, hedged: 50},
+				{fill: true, hedged: 100},
+			},
+			hedges: []int64{20, 30, 50},
+		},
+		{
+			name: "partials then cancel with late fill",
+			steps: []fillStep{
+				{notional: 20, hedged: 20},
+				{notional: 30, hedged: 50},
+				{notional: 10, expire: true, hedged: 60},
+			},
+			hedges: []int64{20, 30, 10},
+		},
+		{
+			name: "partial then cancel without new fill",
+			steps: []fillStep{
+				{notional: 40, hedged: 40},
+				{expire: true, hedged: 40},
+			},
+			hedges: []int64{40},
+		},
+		{
+			name:         "pre-execution waits for threshold then fill",
+			preExecution: true,
+			steps: []fillStep{
+				{notional: 20, hedged: 0},
+				{notional: 40, hedged: 60},
+				{notional: 10, hedged: 70},
+				{fill: true, hedged: 100},
+			},
+			hedges: []int64{60, 10, 30},
+		},
+		{
+			name:         "pre-execution below threshold then cancel",
+			preExecution: true,
+			steps: []fillStep{
+				{notional: 30, hedged: 0},
+				{expire: true, hedged: 30},
+			},
+			hedges: []int64{30},
+		},
+		{
+			name: "failed partial hedge caught up by next fill",
+			steps: []fillStep{
+				{notional: 20, hedgeFail: true, hedged: 0},
+				{notional: 30, hedged: 50},
+				{fill: true, hedged: 100},
+			},
+			hedges: []int64{50, 50},
+		},
+		{
+			name: "failed final hedge retried",
+			steps: []fillStep{
+				{notional: 50, hedged: 50},
+				{fill: true, hedgeFail: true, hedged: 50},
+				{hedged: 100},
+			},
+			hedges: []int64{50, 50},
+		},
+		{
+			name: "failed hedge of cancelled order retried",
+			steps: []fillStep{
+				{notional: 30, hedged: 30},
+				{notional: 20, expire: true, hedgeFail: true, hedged: 30},
+				{hedged: 50},
+			},
+			hedges: []int64{30, 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHedge(t)
+			trades, err := journal.Open(filepath.Join(t.TempDir(), "trades.jsonl"))
+			if err != nil {
+				t.Fatalf("journal.Open: %v", err)
+			}
+			defer trades.Close()
+			h.SetTradeJournal(trades)
+			if tt.preExecution {
+				fastConfig := NewDefaultFastExecutionConfig()
+				fastConfig.EnablePriceProtection = false
+				fastConfig.EnableRetry = false
+				h.fastExecutionManager.UpdateConfig(fastConfig)
+				h.orderMonitor.SetFastExecutionManager(h.fastExecutionManager)
+			}
+
+			if _, err := h.openingManager.ExecuteOpeningLogic(t.Context(), h.config); err != nil {
+				t.Fatalf("ExecuteOpeningLogic: %v", err)
+			}
+			_, id := h.onlyOrder(t)
+
+			for i, step := range tt.steps {
+				if step.notional > 0 {
+					h.binance.fillNotional(id, step.notional)
+				}
+				if step.fill {
+					h.binance.fill(id, math.MaxFloat64)
+				}
+				if step.expire {
+					h.binance.expire(id)
+				}
+				if step.hedgeFail {
+					h.lighter.failNext("PlaceMarketOrders", errors.New("lighter unavailable"))
+					h.lighter.failNext("PlaceShort", errors.New("lighter unavailable"))
+				}
+
+				h.check(t)
+				if _, sell := h.lighterHedged(); sell != step.hedged {
+					t.Fatalf("step %d: lighter hedged = %d, want %d", i, sell, step.hedged)
+				}
+				if step.hedgeFail {
+					continue
+				}
+
+				// 再检查一轮确认没有变化时不会重复对冲
+				h.check(t)
+				if _, sell := h.lighterHedged(); sell != step.hedged {
+					t.Fatalf("step %d: lighter hedged after recheck = %d, want %d", i, sell, step.hedged)
+				}
+			}
+
+			var hedges []int64
+			for _, o := range h.lighter.orders() {
+				hedges = append(hedges, h.lighter.notional(o.MarketIndex, o.BaseAmount))
+			}
+			if !slices.Equal(hedges, tt.hedges) {
+				t.Fatalf("lighter hedges = %v, want %v", hedges, tt.hedges)
+			}
+
+			// 终态订单的成交全部对冲后移出监控
+			if n := len(h.orderManager.GetActiveOrders()); n != 0 {
+				t.Fatalf("active orders = %d, want 0", n)
+			}
+			o, _ := h.binance.order(id)
+			filled := int64(math.Round(o.status.ExecutedQty * o.status.Price))
+			if _, sell := h.lighterHedged(); sell != filled {
+				t.Fatalf("lighter hedged = %d, binance filled = %d", sell, filled)
+			}
+
+			// 对冲失败重试时Maker成交只记录一次
+			entries, err := journal.ReadAll(trades.Path())
+			if err != nil {
+				t.Fatalf("journal.ReadAll: %v", err)
+			}
+			var recorded float64
+			for _, e := range entries {
+				if e.Reason == "MAKER_FILL" {
+					recorded += e.Size
+				}
+			}
+			if int64(math.Round(recorded)) != filled {
+				t.Fatalf("journal maker fills = %v, binance filled = %d", recorded, filled)
+			}
+		})
+	}
+}
+
+func TestPartialFillHedgedSizeTracksFilledSize(t *testing.T) {
+	h := newTestHedge(t)
+
+	if _, err := h.openingManager.ExecuteOpeningLogic(t.Context(), h.config); err != nil {
+		t.Fatalf("ExecuteOpeningLogic: %v", err)
+	}
+	_, id := h.onlyOrder(t)
+
+	for _, notional := range []float64{10, 25, 15} {
+		h.binance.fillNotional(id, notional)
+		h.check(t)
+
+		order, _ := h.onlyOrder(t)
+		if order.Status != "PARTIAL" {
+			t.Fatalf("status = %s, want PARTIAL", order.Status)
+		}
+		if math.Abs(order.HedgedSize-order.FilledSize) > 1e-9 {
+			t.Fatalf("hedged size = %v, filled size = %v", order.HedgedSize, order.FilledSize)
+		}
+	}
+}
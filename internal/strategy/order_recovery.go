@@ -0,0 +1,172 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+)
+
+// 启动时交易所上未记录挂单的处理方式
+const (
+	UntrackedOrderCancel = "cancel" // 撤单
+	UntrackedOrderAdopt  = "adopt"  // Binance限价单加入监控，成交后照常对冲
+	UntrackedOrderIgnore = "ignore" // 仅告警
+)
+
+// SetOrderStore 设置活跃订单持久化，Start 时据此恢复上次运行留下的订单
+func (s *DynamicHedgeStrategy) SetOrderStore(store *OrderStore) {
+	s.orderStore = store
+}
+
+// recoverOrders 启动时恢复订单监控：上次保存的订单全部重新加入监控，停机期间成交的订单由订单监控补做对冲；
+// 两个交易所上不在保存记录中的挂单按 UntrackedOrderAction 撤单、接管或告警
+func (s *DynamicHedgeStrategy) recoverOrders(ctx context.Context, config *DynamicHedgeConfig) error {
+	saved, err := s.orderStore.Load()
+	if err != nil {
+		return err
+	}
+
+	untracked, err := s.recoverBinanceOrders(ctx, config, saved)
+	if err != nil {
+		return err
+	}
+	untracked += s.checkLighterOrders(ctx)
+
+	// 保存的订单在Store之前加入监控，避免恢复过程中覆盖状态文件
+	for _, order := range saved {
+		s.cycles.Resume(order.Symbol, order.CycleID)
+		s.orderManager.AddOrder(order)
+	}
+	s.orderManager.SetStore(s.orderStore)
+
+	s.logger.Info("Order recovery completed",
+		zap.Int("restored", len(saved)),
+		zap.Int("untracked", untracked),
+		zap.String("untracked_action", config.UntrackedOrderAction),
+	)
+	return nil
+}
+
+// recoverBinanceOrders 核对Binance各交易对的挂单，返回未记录的挂单数量
+func (s *DynamicHedgeStrategy) recoverBinanceOrders(ctx context.Context, config *DynamicHedgeConfig, saved map[string]*ActiveOrder) (int, error) {
+	client := s.binanceStrategy.client
+	untracked := 0
+
+	for _, spec := range s.symbols.Specs() {
+		pair := s.binanceStrategy.pair(spec.Symbol)
+		orders, err := client.GetOpenOrders(ctx, pair)
+		if err != nil {
+			return 0, err
+		}
+
+		cancelledLists := make(map[int64]bool)
+		for _, o := range orders {
+			if order, ok := saved[strconv.FormatInt(o.OrderID, 10)]; ok && order.Exchange == "binance" {
+				continue
+			}
+			untracked++
+
+			fields := []zap.Field{
+				zap.String("symbol", pair),
+				zap.Int64("order_id", o.OrderID),
+				zap.String("side", o.Side),
+				zap.String("type", o.Type),
+				zap.Float64("price", o.Price),
+				zap.Float64("quantity", o.Quantity),
+				zap.Float64("executed_qty", o.ExecutedQty),
+			}
+
+			switch {
+			case config.UntrackedOrderAction == UntrackedOrderCancel && o.ListID >= 0:
+				if cancelledLists[o.ListID] {
+					continue
+				}
+				if err := client.CancelOCOOrder(ctx, pair, o.ListID); err != nil {
+					return 0, fmt.Errorf("failed to cancel untracked OCO order %d: %w", o.ListID, err)
+				}
+				cancelledLists[o.ListID] = true
+				s.logger.Warn("Cancelled untracked Binance OCO order", append(fields, zap.Int64("list_id", o.ListID))...)
+			case config.UntrackedOrderAction == UntrackedOrderCancel:
+				if err := client.CancelOrder(ctx, pair, o.OrderID); err != nil {
+					return 0, fmt.Errorf("failed to cancel untracked order %d: %w", o.OrderID, err)
+				}
+				s.logger.Warn("Cancelled untracked Binance order", fields...)
+			case config.UntrackedOrderAction == UntrackedOrderAdopt && isPlainLimit(o):
+				s.adoptBinanceOrder(spec, o)
+				s.logger.Warn("Adopted untracked Binance order", fields...)
+			default:
+				s.logger.Warn("Untracked Binance order left open", fields...)
+			}
+		}
+	}
+
+	return untracked, nil
+}
+
+// isPlainLimit 是否为普通限价单 (条件单和OCO订单不接管)
+func isPlainLimit(o binance.OpenOrder) bool {
+	return o.ListID < 0 && (o.Type == "LIMIT" || o.Type == "LIMIT_MAKER")
+}
+
+// adoptBinanceOrder 接管未记录的Binance限价单：与策略开仓方向相同视为开仓单，否则视为平仓单，
+// 按挂单价把数量换算为金额，与订单 Size 口径一致
+func (s *DynamicHedgeStrategy) adoptBinanceOrder(spec SymbolSpec, o binance.OpenOrder) {
+	role := OrderRoleClose
+	if o.Side == spec.BinanceSide() {
+		role = OrderRoleOpen
+	}
+	status := "PENDING"
+	if o.ExecutedQty > 0 {
+		status = "PARTIAL"
+	}
+
+	s.orderManager.AddOrder(&ActiveOrder{
+		ID:         strconv.FormatInt(o.OrderID, 10),
+		Exchange:   "binance",
+		Symbol:     spec.Symbol,
+		Side:       o.Side,
+		Size:       o.Quantity * o.Price,
+		Price:      o.Price,
+		Status:     status,
+		FilledSize: o.ExecutedQty * o.Price,
+		HedgedSize: o.ExecutedQty * o.Price, // 接管前的成交是否已对冲无从得知，交由对冲平衡检查处理
+		Role:       role,
+		CycleID:    s.cycles.ID(spec.Symbol),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	})
+}
+
+// checkLighterOrders 检查Lighter各市场的挂单，返回挂单数量。策略在Lighter只下即时成交的市价单，
+// 挂单都不是本策略留下的，无法接管也不自动撤单，仅告警。查询失败不影响启动
+func (s *DynamicHedgeStrategy) checkLighterOrders(ctx context.Context) int {
+	count := 0
+	for _, spec := range s.symbols.Specs() {
+		orders, err := s.lighterStrategy.client.GetOpenOrders(ctx, spec.LighterMarketIndex)
+		if err != nil {
+			s.logger.Warn("Failed to check Lighter open orders",
+				zap.String("symbol", spec.Symbol),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for _, o := range orders {
+			count++
+			s.logger.Warn("Untracked Lighter order left open, cancel it manually if unintended",
+				zap.String("symbol", spec.Symbol),
+				zap.Int64("order_index", o.OrderIndex),
+				zap.String("side", o.Side),
+				zap.String("type", o.Type),
+				zap.Float64("price", o.Price),
+				zap.Float64("remaining_size", o.RemainingSize),
+			)
+		}
+	}
+	return count
+}
@@ -0,0 +1,72 @@
+package strategy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// OrderStore 活跃订单持久化：订单变化时把全部活跃订单写入JSON文件，进程重启后据此恢复监控
+type OrderStore struct {
+	path   string
+	mu     sync.Mutex
+	logger *zap.Logger
+}
+
+// OpenOrderStore 打开订单状态文件，目录不存在时创建
+func OpenOrderStore(path string) (*OrderStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create order state directory: %w", err)
+	}
+
+	log := logger.Named("order-store")
+	log.Info("Order state store opened", zap.String("path", path))
+
+	return &OrderStore{path: path, logger: log}, nil
+}
+
+// Load 读取上次保存的活跃订单，文件不存在时返回空
+func (s *OrderStore) Load() (map[string]*ActiveOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders := make(map[string]*ActiveOrder)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return orders, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read order state %s: %w", s.path, err)
+	}
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return nil, fmt.Errorf("failed to parse order state %s: %w", s.path, err)
+	}
+	return orders, nil
+}
+
+// Save 覆盖保存全部活跃订单。先写临时文件再重命名，进程中途退出不会留下不完整的文件
+func (s *OrderStore) Save(orders map[string]*ActiveOrder) error {
+	data, err := json.MarshalIndent(orders, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal order state: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write order state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace order state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package strategy
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+)
+
+// EventPanicRecovered 后台goroutine发生panic，已恢复并暂停开新仓
+const EventPanicRecovered = "PANIC_RECOVERED"
+
+// PanicHandler 处理已恢复的panic，stack 为发生panic的goroutine的堆栈
+type PanicHandler func(component string, recovered interface{}, stack []byte)
+
+// goroutinePanic 子goroutine中恢复的panic，带上原始堆栈在调用方goroutine中重新panic
+type goroutinePanic struct {
+	value interface{}
+	stack []byte
+}
+
+func (p *goroutinePanic) String() string {
+	return fmt.Sprint(p.value)
+}
+
+// repanic 在子goroutine中 defer 调用：恢复panic并保存堆栈到 *dst，由等待方在自己的goroutine中重新panic
+func repanic(dst **goroutinePanic) {
+	if r := recover(); r != nil {
+		if p, ok := r.(*goroutinePanic); ok {
+			*dst = p
+			return
+		}
+		*dst = &goroutinePanic{value: r, stack: debug.Stack()}
+	}
+}
+
+// unwrapPanic 取出panic的原始值和堆栈
+func unwrapPanic(r interface{}) (interface{}, []byte) {
+	if p, ok := r.(*goroutinePanic); ok {
+		return p.value, p.stack
+	}
+	return r, debug.Stack()
+}
+
+// recoverPanic 在goroutine或周期入口 defer 调用：恢复panic，记录堆栈，发布告警并暂停开新仓。
+// 已有订单的监控和对冲照常进行，确认问题后通过管理API恢复开仓
+func (s *DynamicHedgeStrategy) recoverPanic(component string) {
+	if r := recover(); r != nil {
+		value, stack := unwrapPanic(r)
+		s.handlePanic(component, value, stack)
+	}
+}
+
+// handlePanic 处理已恢复的panic，实现 PanicHandler
+func (s *DynamicHedgeStrategy) handlePanic(component string, recovered interface{}, stack []byte) {
+	s.logger.Error("Recovered from panic, pausing opening",
+		zap.String("component", component),
+		zap.Any("panic", recovered),
+		zap.ByteString("stack", stack),
+	)
+	s.bus.Publish(component, EventPanicRecovered, map[string]interface{}{
+		"component": component,
+		"panic":     fmt.Sprint(recovered),
+	})
+	s.Pause()
+}
+
+// goSafe 在策略的子系统组中运行 fn，panic时按 recoverPanic 处理，goroutine随后结束。
+// fn 返回错误时通过 Failed 上报，引擎按退出流程统一收尾；Stop 等待组内全部goroutine退出
+func (s *DynamicHedgeStrategy) goSafe(component string, fn func() error) {
+	s.group.Go(func() error {
+		defer s.recoverPanic(component)
+
+		if err := fn(); err != nil {
+			err = fmt.Errorf("%s: %w", component, err)
+			s.fail(err)
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,244 @@
+package strategy
+
+import (
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PnLSummary 盈亏汇总
+type PnLSummary struct {
+	RealizedPnL   float64              `json:"realized_pnl"`   // 已实现盈亏 (未扣手续费)
+	UnrealizedPnL float64              `json:"unrealized_pnl"` // 未实现盈亏 (按标记价格)
+	Fees          float64              `json:"fees"`           // 已支付手续费
+	TotalPnL      float64              `json:"total_pnl"`      // 总盈亏 = 已实现 + 未实现 - 手续费
+	Exchanges     map[string]*VenuePnL `json:"exchanges"`      // exchange -> 盈亏
+
+	// symbol -> 盈亏归因 (资金费为估算值，不计入 TotalPnL)
+	Attribution map[string]*PnLAttribution `json:"attribution,omitempty"`
+}
+
+// VenuePnL 单个交易所的盈亏
+type VenuePnL struct {
+	RealizedPnL   float64 `json:"realized_pnl"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+	Fees          float64 `json:"fees"`
+}
+
+// positionEpsilon 同步仓位时视为0的数量误差 (浮点相减残差)
+const positionEpsilon = 1e-9
+
+// SetFeeSchedule 设置手续费率
+func (pm *PositionManager) SetFeeSchedule(fees FeeSchedule) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.fees = fees
+}
+
+// Fee 按当前手续费率计算一笔成交的手续费
+func (pm *PositionManager) Fee(exchange, liquidity string, value float64) float64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.fees.Fee(exchange, liquidity, value)
+}
+
+// ApplyFill 按成交更新仓位的数量、开仓均价、已实现盈亏和手续费，返回本次实现的盈亏 (未扣手续费)。
+// value 为成交金额 (USDT/USDC)，side 为 BUY/SELL，liquidity 为 MAKER/TAKER。
+func (pm *PositionManager) ApplyFill(exchange, symbol, side, liquidity string, value, price float64) float64 {
+	if value <= 0 || price <= 0 {
+		return 0
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	positions := pm.exchangePositions(exchange)
+	if positions == nil {
+		return 0
+	}
+
+	pos, ok := positions.Positions[symbol]
+	if !ok {
+		pos = &Position{Symbol: symbol}
+		positions.Positions[symbol] = pos
+	}
+
+	qty := value / price
+	if side == "SELL" {
+		qty = -qty
+	}
+
+	fee := pm.fees.Fee(exchange, liquidity, value)
+	pos.Fees += fee
+	pm.volumes.Record(exchange, value, time.Now())
+
+	var realized float64
+	if pos.Size == 0 || (pos.Size > 0) == (qty > 0) {
+		// 开仓或加仓：更新加权平均开仓价
+		total := pos.Size + qty
+		pos.EntryPrice = (pos.EntryPrice*math.Abs(pos.Size) + price*math.Abs(qty)) / math.Abs(total)
+		pos.Size = total
+	} else {
+		// 减仓：按开仓均价结算已实现盈亏
+		closed := math.Min(math.Abs(qty), math.Abs(pos.Size))
+		if pos.Size > 0 {
+			realized = closed * (price - pos.EntryPrice)
+		} else {
+			realized = closed * (pos.EntryPrice - price)
+		}
+		pos.RealizedPnL += realized
+
+		remaining := pos.Size + qty
+		switch {
+		case remaining == 0:
+			pos.EntryPrice = 0
+		case (remaining > 0) != (pos.Size > 0):
+			// 反手：剩余部分以成交价开新仓
+			pos.EntryPrice = price
+		}
+		pos.Size = remaining
+	}
+
+	if pos.MarkPrice == 0 {
+		pos.MarkPrice = price
+	}
+	pos.markToMarket()
+	positions.UpdatedAt = time.Now()
+
+	pm.logger.Debug("Applied fill to position",
+		zap.String("exchange", exchange),
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("value", value),
+		zap.Float64("price", price),
+		zap.Float64("size", pos.Size),
+		zap.Float64("entry_price", pos.EntryPrice),
+		zap.Float64("realized", realized),
+		zap.Float64("fee", fee),
+	)
+
+	return realized
+}
+
+// UpdateMarkPrice 更新两个交易所中该币种的标记价格并重新计算未实现盈亏
+func (pm *PositionManager) UpdateMarkPrice(symbol string, price float64) {
+	if price <= 0 {
+		return
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	for _, positions := range []*ExchangePositions{pm.lighterPositions, pm.binancePositions} {
+		if pos, ok := positions.Positions[symbol]; ok {
+			pos.MarkPrice = price
+			pos.markToMarket()
+		}
+	}
+}
+
+// SyncPosition 用交易所返回的持仓覆盖本地仓位 (已实现盈亏和手续费保留本地累计值)。
+// entryPrice 为0时保留本地开仓均价，markPrice 为0时保留上一次的标记价格，liquidationPrice 为0表示交易所未提供
+func (pm *PositionManager) SyncPosition(exchange, symbol string, size, entryPrice, markPrice, liquidationPrice float64) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	positions := pm.exchangePositions(exchange)
+	if positions == nil {
+		return
+	}
+
+	pos, ok := positions.Positions[symbol]
+	if !ok {
+		if size == 0 {
+			return
+		}
+		pos = &Position{Symbol: symbol}
+		positions.Positions[symbol] = pos
+	}
+
+	pos.Size = size
+	switch {
+	case size == 0:
+		pos.EntryPrice = 0
+	case entryPrice > 0:
+		pos.EntryPrice = entryPrice
+	case pos.EntryPrice == 0:
+		// 交易所未提供开仓均价且本地无成交记录，以当前价格作为成本
+		pos.EntryPrice = markPrice
+	}
+	if markPrice > 0 {
+		pos.MarkPrice = markPrice
+	}
+	pos.LiquidationPrice = 0
+	if size != 0 {
+		pos.LiquidationPrice = liquidationPrice
+	}
+	pos.markToMarket()
+	positions.UpdatedAt = time.Now()
+}
+
+// GetPnL 获取盈亏汇总
+func (pm *PositionManager) GetPnL() *PnLSummary {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	summary := &PnLSummary{
+		Exchanges: make(map[string]*VenuePnL),
+	}
+
+	for _, positions := range []*ExchangePositions{pm.lighterPositions, pm.binancePositions} {
+		venue := &VenuePnL{}
+		for _, pos := range positions.Positions {
+			venue.RealizedPnL += pos.RealizedPnL
+			venue.UnrealizedPnL += pos.UnrealizedPnL
+			venue.Fees += pos.Fees
+		}
+		summary.Exchanges[positions.Exchange] = venue
+		summary.RealizedPnL += venue.RealizedPnL
+		summary.UnrealizedPnL += venue.UnrealizedPnL
+		summary.Fees += venue.Fees
+	}
+	summary.TotalPnL = summary.RealizedPnL + summary.UnrealizedPnL - summary.Fees
+	summary.Attribution = pm.attributionSnapshot()
+
+	return summary
+}
+
+// GetSymbols 获取当前有仓位的币种
+func (pm *PositionManager) GetSymbols() []string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, positions := range []*ExchangePositions{pm.lighterPositions, pm.binancePositions} {
+		for symbol, pos := range positions.Positions {
+			if pos.Size != 0 && !seen[symbol] {
+				seen[symbol] = true
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	return symbols
+}
+
+// exchangePositions 根据交易所名称获取仓位 (调用方需持有锁)
+func (pm *PositionManager) exchangePositions(exchange string) *ExchangePositions {
+	switch exchange {
+	case "lighter":
+		return pm.lighterPositions
+	case "binance":
+		return pm.binancePositions
+	default:
+		pm.logger.Warn("Unknown exchange for position update", zap.String("exchange", exchange))
+		return nil
+	}
+}
+
+// markToMarket 按标记价格重算仓位价值和未实现盈亏
+func (p *Position) markToMarket() {
+	p.Value = p.Size * p.MarkPrice
+	p.UnrealizedPnL = p.Size * (p.MarkPrice - p.EntryPrice)
+}
@@ -0,0 +1,264 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+)
+
+// ProtectionManager 止损止盈管理器：开仓单成交后在Binance挂止损和止盈条件单，
+// 保护单成交时由OrderMonitor在Lighter对冲平仓，并撤销同组的另一张保护单。
+// 现货和杠杆市场以OCO订单挂出，两张保护单由交易所保证只成交一张
+type ProtectionManager struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	orderManager  *OrderManager
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+}
+
+// NewProtectionManager 创建止损止盈管理器
+func NewProtectionManager(hedgeStrategy *DynamicHedgeStrategy) *ProtectionManager {
+	return &ProtectionManager{
+		hedgeStrategy: hedgeStrategy,
+		orderManager:  hedgeStrategy.orderManager,
+		logger:        hedgeStrategy.logger.Named("protection-manager"),
+	}
+}
+
+// protectivePrices 计算保护单的触发价：多头止损在下方、止盈在上方，空头相反
+func protectivePrices(entrySide string, entryPrice, stopLossPercent, takeProfitPercent float64) (stopLoss, takeProfit float64) {
+	if entrySide == "BUY" {
+		return entryPrice * (1 - stopLossPercent/100), entryPrice * (1 + takeProfitPercent/100)
+	}
+	return entryPrice * (1 + stopLossPercent/100), entryPrice * (1 - takeProfitPercent/100)
+}
+
+// Attach 为已成交的开仓单挂止损和止盈保护单
+func (pm *ProtectionManager) Attach(ctx context.Context, order *ActiveOrder) error {
+	if order.Exchange != "binance" || order.Role != OrderRoleOpen {
+		return nil
+	}
+	if order.Price <= 0 {
+		return fmt.Errorf("missing fill price for order %s", order.ID)
+	}
+
+	stopLoss, takeProfit := protectivePrices(order.Side, order.Price, pm.config.StopLossPercent, pm.config.TakeProfitPercent)
+	side := oppositeSide(order.Side)
+	quantity := order.Size / order.Price
+
+	pm.logger.Info("Attaching protective orders",
+		zap.String("parent_id", order.ID),
+		zap.String("symbol", order.Symbol),
+		zap.String("side", side),
+		zap.Float64("entry_price", order.Price),
+		zap.Float64("stop_loss", stopLoss),
+		zap.Float64("take_profit", takeProfit),
+	)
+
+	if pm.config.StopLossPercent > 0 && pm.config.TakeProfitPercent > 0 && pm.hedgeStrategy.binanceStrategy.client.SupportsOCO() {
+		return pm.placeOCO(ctx, order, side, quantity, stopLoss, takeProfit)
+	}
+
+	var lastErr error
+	if stopLoss > 0 && pm.config.StopLossPercent > 0 {
+		if err := pm.place(ctx, order, side, OrderRoleStopLoss, quantity, stopLoss); err != nil {
+			lastErr = err
+		}
+	}
+	if takeProfit > 0 && pm.config.TakeProfitPercent > 0 {
+		if err := pm.place(ctx, order, side, OrderRoleTakeProfit, quantity, takeProfit); err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// stopLimitPrice 止损触发后的限价：在触发价基础上让出最大滑点，保证能够成交
+func (pm *ProtectionManager) stopLimitPrice(side string, stopPrice float64) float64 {
+	if side == "BUY" {
+		return stopPrice * (1 + pm.config.MaxSlippagePercent/100)
+	}
+	return stopPrice * (1 - pm.config.MaxSlippagePercent/100)
+}
+
+// placeOCO 以OCO订单同时挂止盈和止损，两腿加入监控
+func (pm *ProtectionManager) placeOCO(ctx context.Context, parent *ActiveOrder, side string, quantity, stopLoss, takeProfit float64) error {
+	status, err := pm.hedgeStrategy.binanceStrategy.client.PlaceOCOOrder(ctx,
+		pm.hedgeStrategy.binanceStrategy.pair(parent.Symbol), side, quantity, takeProfit, stopLoss, pm.stopLimitPrice(side, stopLoss))
+	if err != nil {
+		pm.logger.Error("Failed to place OCO protective order",
+			zap.String("parent_id", parent.ID),
+			zap.String("symbol", parent.Symbol),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	listID := strconv.FormatInt(status.OrderListID, 10)
+	pm.track(parent, side, OrderRoleTakeProfit, status.TakeProfitID, takeProfit, listID)
+	pm.track(parent, side, OrderRoleStopLoss, status.StopLossID, stopLoss, listID)
+	return nil
+}
+
+// place 下一张保护单并加入监控。触发后以限价单成交
+func (pm *ProtectionManager) place(ctx context.Context, parent *ActiveOrder, side, role string, quantity, stopPrice float64) error {
+	limitPrice := pm.stopLimitPrice(side, stopPrice)
+
+	kind := binance.StopKindStopLoss
+	if role == OrderRoleTakeProfit {
+		kind = binance.StopKindTakeProfit
+	}
+
+	status, err := pm.hedgeStrategy.binanceStrategy.client.PlaceStopOrder(ctx,
+		pm.hedgeStrategy.binanceStrategy.pair(parent.Symbol), side, kind, quantity, stopPrice, limitPrice)
+	if err != nil {
+		pm.logger.Error("Failed to place protective order",
+			zap.String("parent_id", parent.ID),
+			zap.String("symbol", parent.Symbol),
+			zap.String("role", role),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	pm.track(parent, side, role, status.OrderID, stopPrice, "")
+	return nil
+}
+
+// track 将保护单加入订单监控，Price 记录触发价
+func (pm *ProtectionManager) track(parent *ActiveOrder, side, role string, orderID int64, price float64, listID string) {
+	pm.orderManager.AddOrder(&ActiveOrder{
+		ID:        strconv.FormatInt(orderID, 10),
+		Exchange:  "binance",
+		Symbol:    parent.Symbol,
+		Side:      side,
+		Size:      parent.Size,
+		Price:     price,
+		Status:    "PENDING",
+		Role:      role,
+		ParentID:  parent.ID,
+		ListID:    listID,
+		CycleID:   parent.CycleID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+}
+
+// OnTriggered 保护单成交后撤销同组的另一张保护单
+func (pm *ProtectionManager) OnTriggered(ctx context.Context, order *ActiveOrder) error {
+	pm.logger.Warn("Protective order triggered",
+		zap.String("order_id", order.ID),
+		zap.String("parent_id", order.ParentID),
+		zap.String("list_id", order.ListID),
+		zap.String("symbol", order.Symbol),
+		zap.String("role", order.Role),
+	)
+
+	// OCO的另一腿已由交易所撤销，停止监控即可
+	if order.ListID != "" {
+		for _, o := range pm.orderManager.GetActiveOrders() {
+			if o.ListID == order.ListID && o.ID != order.ID {
+				pm.orderManager.RemoveOrder(o.ID)
+			}
+		}
+		return nil
+	}
+
+	_, err := pm.cancel(ctx, func(o *ActiveOrder) bool {
+		return o.ParentID == order.ParentID && o.ID != order.ID
+	})
+	return err
+}
+
+// CancelForSymbol 撤销币种的全部保护单 (正常平仓前调用，释放被占用的余额)，返回撤单数量
+func (pm *ProtectionManager) CancelForSymbol(ctx context.Context, symbol string) (int, error) {
+	return pm.cancel(ctx, func(o *ActiveOrder) bool {
+		return o.Symbol == symbol
+	})
+}
+
+// CancelAll 撤销全部保护单，返回撤单数量
+func (pm *ProtectionManager) CancelAll(ctx context.Context) (int, error) {
+	return pm.cancel(ctx, func(*ActiveOrder) bool { return true })
+}
+
+// cancel 撤销满足条件的保护单
+func (pm *ProtectionManager) cancel(ctx context.Context, match func(o *ActiveOrder) bool) (int, error) {
+	var lastErr error
+	cancelled := 0
+	for _, order := range pm.orderManager.GetActiveOrders() {
+		if !order.isProtective() || isTerminalStatus(order.Status) || !match(order) {
+			continue
+		}
+
+		if order.ListID != "" {
+			n, err := pm.cancelList(ctx, order)
+			if err != nil {
+				lastErr = err
+			}
+			cancelled += n
+			continue
+		}
+
+		orderID, err := strconv.ParseInt(order.ID, 10, 64)
+		if err != nil {
+			lastErr = fmt.Errorf("invalid binance order id %s: %w", order.ID, err)
+			continue
+		}
+
+		if err := pm.hedgeStrategy.binanceStrategy.client.CancelOrder(ctx, pm.hedgeStrategy.binanceStrategy.pair(order.Symbol), orderID); err != nil {
+			pm.logger.Error("Failed to cancel protective order",
+				zap.String("order_id", order.ID),
+				zap.String("symbol", order.Symbol),
+				zap.Error(err),
+			)
+			lastErr = err
+			continue
+		}
+
+		pm.orderManager.UpdateOrderStatus(order.ID, "CANCELLED", order.FilledSize)
+		cancelled++
+	}
+
+	if cancelled > 0 {
+		pm.logger.Info("Cancelled protective orders", zap.Int("count", cancelled))
+	}
+
+	return cancelled, lastErr
+}
+
+// cancelList 撤销OCO订单组并停止监控两腿，返回撤单数量。另一腿在同一轮遍历中再次出现时已标记为撤销，直接跳过
+func (pm *ProtectionManager) cancelList(ctx context.Context, order *ActiveOrder) (int, error) {
+	if current, tracked := pm.orderManager.GetActiveOrders()[order.ID]; !tracked || isTerminalStatus(current.Status) {
+		return 0, nil
+	}
+
+	listID, err := strconv.ParseInt(order.ListID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid binance order list id %s: %w", order.ListID, err)
+	}
+
+	if err := pm.hedgeStrategy.binanceStrategy.client.CancelOCOOrder(ctx, pm.hedgeStrategy.binanceStrategy.pair(order.Symbol), listID); err != nil {
+		pm.logger.Error("Failed to cancel OCO protective order",
+			zap.String("list_id", order.ListID),
+			zap.String("symbol", order.Symbol),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, o := range pm.orderManager.GetActiveOrders() {
+		if o.ListID == order.ListID {
+			pm.orderManager.UpdateOrderStatus(o.ID, "CANCELLED", o.FilledSize)
+			cancelled++
+		}
+	}
+	return cancelled, nil
+}
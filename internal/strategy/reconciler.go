@@ -0,0 +1,173 @@
+package strategy
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 仓位差异严重程度
+const (
+	DiscrepancyWarning  = "WARNING"  // 超过容差，按配置修正本地仓位
+	DiscrepancyCritical = "CRITICAL" // 超过暂停阈值，暂停开新仓等待人工处理
+)
+
+// PositionDiscrepancy 本地仓位与交易所持仓的差异
+type PositionDiscrepancy struct {
+	Exchange     string    `json:"exchange"`
+	Symbol       string    `json:"symbol"`
+	LocalSize    float64   `json:"local_size"`
+	ExchangeSize float64   `json:"exchange_size"`
+	Notional     float64   `json:"notional"` // 差异数量按标记价格折算的金额
+	Severity     string    `json:"severity"` // WARNING, CRITICAL
+	Corrected    bool      `json:"corrected"`
+	FirstSeenAt  time.Time `json:"first_seen_at"`
+	ConfirmedAt  time.Time `json:"confirmed_at,omitempty"`
+}
+
+// Reconciler 仓位对账：每次同步交易所持仓前与本地仓位 (上次同步 + 此后订单监控记录的成交) 核对。
+// 差异在有进行中订单的币种上视为尚未记录的成交；否则需连续两次同步都出现才确认，
+// 确认后记录日志并发布 POSITION_DISCREPANCY 事件，按配置修正本地仓位，超过暂停阈值时暂停开新仓
+type Reconciler struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+
+	mu            sync.Mutex
+	synced        map[string]bool                 // 已完成首次同步的交易所
+	discrepancies map[string]*PositionDiscrepancy // exchange/symbol -> 未消除的差异
+}
+
+// NewReconciler 创建仓位对账
+func NewReconciler(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *Reconciler {
+	return &Reconciler{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("reconciler"),
+		synced:        make(map[string]bool),
+		discrepancies: make(map[string]*PositionDiscrepancy),
+	}
+}
+
+// MarkSynced 交易所完成一轮同步。首次同步前本地没有仓位，不参与对账
+func (r *Reconciler) MarkSynced(exchange string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.synced[exchange] = true
+}
+
+// Reconcile 核对交易所持仓与本地仓位，返回是否用交易所持仓覆盖本地仓位
+func (r *Reconciler) Reconcile(exchange, symbol string, exchangeSize, markPrice float64) bool {
+	local := r.hedgeStrategy.positionManager.positionsSnapshot(exchange)[symbol]
+	if markPrice <= 0 {
+		markPrice = local.MarkPrice
+	}
+	key := exchange + "/" + symbol
+	notional := math.Abs(exchangeSize-local.Size) * markPrice
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.synced[exchange] || notional < r.config.ReconcileToleranceNotional {
+		if d, ok := r.discrepancies[key]; ok {
+			delete(r.discrepancies, key)
+			r.logger.Info("Position discrepancy resolved",
+				zap.String("exchange", exchange),
+				zap.String("symbol", symbol),
+				zap.Bool("corrected", d.Corrected),
+			)
+		}
+		return true
+	}
+
+	// 进行中的订单可能已在交易所成交、尚未被订单监控记录
+	if r.hedgeStrategy.symbolBusy(symbol) {
+		r.logger.Debug("Position differs while orders are in flight",
+			zap.String("exchange", exchange),
+			zap.String("symbol", symbol),
+			zap.Float64("local_size", local.Size),
+			zap.Float64("exchange_size", exchangeSize),
+		)
+		return true
+	}
+
+	d, ok := r.discrepancies[key]
+	if !ok {
+		// 首次出现的差异可能来自同步与成交记录的时间差，保留本地仓位等待下一次同步确认
+		r.discrepancies[key] = &PositionDiscrepancy{
+			Exchange:     exchange,
+			Symbol:       symbol,
+			LocalSize:    local.Size,
+			ExchangeSize: exchangeSize,
+			Notional:     notional,
+			FirstSeenAt:  time.Now(),
+		}
+		return false
+	}
+
+	d.LocalSize = local.Size
+	d.ExchangeSize = exchangeSize
+	d.Notional = notional
+	d.Corrected = r.config.ReconcileAutoCorrect
+
+	severity := DiscrepancyWarning
+	if r.config.ReconcileHaltNotional > 0 && notional >= r.config.ReconcileHaltNotional {
+		severity = DiscrepancyCritical
+	}
+	if d.ConfirmedAt.IsZero() || d.Severity != severity {
+		d.ConfirmedAt = time.Now()
+		d.Severity = severity
+		r.report(d)
+	}
+
+	if severity == DiscrepancyCritical {
+		r.hedgeStrategy.Pause()
+	}
+	return d.Corrected
+}
+
+// report 记录确认的差异并发布事件
+func (r *Reconciler) report(d *PositionDiscrepancy) {
+	fields := []zap.Field{
+		zap.String("exchange", d.Exchange),
+		zap.String("symbol", d.Symbol),
+		zap.Float64("local_size", d.LocalSize),
+		zap.Float64("exchange_size", d.ExchangeSize),
+		zap.Float64("notional", d.Notional),
+		zap.String("severity", d.Severity),
+		zap.Bool("auto_correct", d.Corrected),
+	}
+	if d.Severity == DiscrepancyCritical {
+		r.logger.Error("Critical position discrepancy, pausing opening", fields...)
+	} else {
+		r.logger.Warn("Position discrepancy confirmed", fields...)
+	}
+
+	r.hedgeStrategy.bus.Publish("reconciler", EventPositionDiscrepancy, map[string]interface{}{
+		"exchange":      d.Exchange,
+		"symbol":        d.Symbol,
+		"local_size":    d.LocalSize,
+		"exchange_size": d.ExchangeSize,
+		"notional":      d.Notional,
+		"severity":      d.Severity,
+		"corrected":     d.Corrected,
+	})
+}
+
+// Discrepancies 返回尚未消除的仓位差异
+func (r *Reconciler) Discrepancies() []PositionDiscrepancy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]PositionDiscrepancy, 0, len(r.discrepancies))
+	for _, d := range r.discrepancies {
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Notional > result[j].Notional
+	})
+	return result
+}
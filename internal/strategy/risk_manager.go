@@ -0,0 +1,579 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RiskAction 风险行动类型
+type RiskAction string
+
+const (
+	RiskActionContinueOpening RiskAction = "CONTINUE_OPENING" // 继续开仓
+	RiskActionStopOpening     RiskAction = "STOP_OPENING"     // 停止开仓
+	RiskActionStartClosing    RiskAction = "START_CLOSING"    // 开始平仓
+	RiskActionEmergencyClose  RiskAction = "EMERGENCY_CLOSE"  // 紧急平仓
+)
+
+// 触发风控的指标
+const (
+	RiskTriggerLeverage = "leverage"
+	RiskTriggerDrawdown = "drawdown"
+	RiskTriggerEquity   = "equity" // 账户权益未知，杠杆率无法计算
+)
+
+// String 返回风险行动的字符串表示
+func (ra RiskAction) String() string {
+	return string(ra)
+}
+
+// RiskStatus 风险状态
+type RiskStatus struct {
+	Action          RiskAction `json:"action"`            // 风险行动
+	LighterLeverage float64    `json:"lighter_leverage"`  // Lighter杠杆率
+	BinanceLeverage float64    `json:"binance_leverage"`  // Binance杠杆率
+	MaxLeverage     float64    `json:"max_leverage"`      // 当前最高杠杆率
+	Reason          string     `json:"reason"`            // 风控原因
+	Trigger         string     `json:"trigger,omitempty"` // 触发风控的指标: leverage, drawdown, equity
+	Timestamp       time.Time  `json:"timestamp"`
+
+	Equity          float64 `json:"equity"`           // 两个交易所实测账户权益合计 (0为未知)
+	HighWaterMark   float64 `json:"high_water_mark"`  // 权益高点
+	DrawdownPercent float64 `json:"drawdown_percent"` // 从高点回撤百分比
+
+	SymbolLeverage map[string]float64 `json:"symbol_leverage"` // 各币种杠杆率
+	SymbolNotional map[string]float64 `json:"symbol_notional"` // 各币种持仓名义金额 (两个交易所中较大者)
+	VenueNotional  map[string]float64 `json:"venue_notional"`  // 各交易所持仓名义金额合计
+	BlockedSymbols []string           `json:"blocked_symbols"` // 达到单币种杠杆或名义金额上限、停止开仓的币种
+}
+
+// SymbolRisk 单币种开仓前的风控检查结果
+type SymbolRisk struct {
+	Leverage float64 // 两个交易所中较高的杠杆率
+	Notional float64 // 两个交易所中较大的持仓名义金额
+	Allowed  bool    // 是否允许继续开仓
+	Reason   string  // 不允许开仓的原因
+}
+
+// CheckRisk 检查风险状态，风控行动变化时发布 RISK_ACTION_CHANGED 事件，
+// 交易所杠杆率越过预警档位时发布 LEVERAGE_THRESHOLD 事件
+func (rm *RiskManager) CheckRisk(pm *PositionManager) *RiskStatus {
+	status := rm.checkRisk(pm)
+	rm.checkLeverageThresholds(status)
+
+	rm.mu.Lock()
+	previous := rm.lastAction
+	rm.lastAction = status.Action
+	rm.mu.Unlock()
+
+	if previous != status.Action {
+		rm.bus.Publish("risk-manager", EventRiskActionChanged, map[string]interface{}{
+			"old_action":       string(previous),
+			"new_action":       string(status.Action),
+			"reason":           status.Reason,
+			"trigger":          status.Trigger,
+			"max_leverage":     status.MaxLeverage,
+			"drawdown_percent": status.DrawdownPercent,
+		})
+	}
+
+	return status
+}
+
+// checkRisk 按杠杆率、回撤和平仓阶段计算风控行动
+func (rm *RiskManager) checkRisk(pm *PositionManager) *RiskStatus {
+	now := time.Now()
+
+	lighterPositions := pm.GetLighterPositions()
+	binancePositions := pm.GetBinancePositions()
+
+	lighterLeverage := lighterPositions.Leverage
+	binanceLeverage := binancePositions.Leverage
+	maxLeverage := max(lighterLeverage, binanceLeverage)
+
+	rm.logger.Debug("Risk check",
+		zap.Float64("lighter_leverage", lighterLeverage),
+		zap.Float64("binance_leverage", binanceLeverage),
+		zap.Float64("max_leverage", maxLeverage),
+	)
+
+	status := &RiskStatus{
+		LighterLeverage: lighterLeverage,
+		BinanceLeverage: binanceLeverage,
+		MaxLeverage:     maxLeverage,
+		Timestamp:       now,
+		SymbolLeverage:  make(map[string]float64),
+		SymbolNotional:  make(map[string]float64),
+		VenueNotional: map[string]float64{
+			lighterPositions.Exchange: pm.GetVenueNotional(lighterPositions.Exchange),
+			binancePositions.Exchange: pm.GetVenueNotional(binancePositions.Exchange),
+		},
+	}
+
+	// 单币种杠杆/名义金额上限只限制该币种开仓，不影响整体风控行动
+	if rm.symbols != nil {
+		for _, spec := range rm.symbols.Specs() {
+			risk := rm.CheckSymbolRisk(pm, spec, 0)
+			status.SymbolLeverage[spec.Symbol] = risk.Leverage
+			status.SymbolNotional[spec.Symbol] = risk.Notional
+			if !risk.Allowed {
+				status.BlockedSymbols = append(status.BlockedSymbols, spec.Symbol)
+			}
+		}
+	}
+
+	status.Equity, status.HighWaterMark, status.DrawdownPercent = rm.updateDrawdown(pm)
+
+	// 1. 检查紧急平仓条件 (5倍杠杆)
+	if maxLeverage >= rm.config.EmergencyLeverage {
+		status.Action = RiskActionEmergencyClose
+		status.Reason = "Leverage exceeded emergency threshold"
+		status.Trigger = RiskTriggerLeverage
+		rm.logger.Error("Emergency close triggered",
+			zap.Float64("max_leverage", maxLeverage),
+			zap.Float64("emergency_threshold", rm.config.EmergencyLeverage),
+		)
+		return status
+	}
+
+	// 2. 检查紧急平仓回撤
+	if rm.config.EmergencyDrawdownPercent > 0 && status.DrawdownPercent >= rm.config.EmergencyDrawdownPercent {
+		status.Action = RiskActionEmergencyClose
+		status.Reason = "Drawdown exceeded emergency threshold"
+		status.Trigger = RiskTriggerDrawdown
+		rm.logger.Error("Emergency close triggered by drawdown",
+			zap.Float64("equity", status.Equity),
+			zap.Float64("high_water_mark", status.HighWaterMark),
+			zap.Float64("drawdown_percent", status.DrawdownPercent),
+			zap.Float64("emergency_threshold", rm.config.EmergencyDrawdownPercent),
+		)
+		return status
+	}
+
+	// 3. 平仓阶段持续到仓位全部为0，期间杠杆或回撤回落也不恢复开仓
+	if trigger, closing := rm.closingTrigger(); closing {
+		if !rm.allPositionsZero(pm) {
+			status.Action = RiskActionStartClosing
+			status.Reason = "Closing phase in progress"
+			status.Trigger = trigger
+			return status
+		}
+		rm.finishClosing()
+	}
+
+	// 4. 账户权益未知时杠杆率无法计算，停止开仓直到获取到有效权益
+	if lighterPositions.LeverageUnknown || binancePositions.LeverageUnknown {
+		status.Action = RiskActionStopOpening
+		status.Reason = "Account equity unknown, leverage cannot be computed"
+		status.Trigger = RiskTriggerEquity
+		rm.logger.Error("Stop opening: account equity unknown",
+			zap.Bool("lighter_equity_unknown", lighterPositions.LeverageUnknown),
+			zap.Bool("binance_equity_unknown", binancePositions.LeverageUnknown),
+		)
+		return status
+	}
+
+	// 5. 检查停止开仓条件 (3倍杠杆)
+	if maxLeverage >= rm.config.MaxLeverage {
+		status.Action = RiskActionStopOpening
+		status.Reason = "Leverage exceeded max threshold"
+		status.Trigger = RiskTriggerLeverage
+		rm.logger.Warn("Stop opening triggered",
+			zap.Float64("max_leverage", maxLeverage),
+			zap.Float64("max_threshold", rm.config.MaxLeverage),
+		)
+
+		rm.checkStopDuration(status, now)
+		return status
+	}
+
+	// 6. 检查停止开仓回撤
+	if rm.config.MaxDrawdownPercent > 0 && status.DrawdownPercent >= rm.config.MaxDrawdownPercent {
+		status.Action = RiskActionStopOpening
+		status.Reason = "Drawdown exceeded max threshold"
+		status.Trigger = RiskTriggerDrawdown
+		rm.logger.Warn("Stop opening triggered by drawdown",
+			zap.Float64("equity", status.Equity),
+			zap.Float64("high_water_mark", status.HighWaterMark),
+			zap.Float64("drawdown_percent", status.DrawdownPercent),
+			zap.Float64("max_threshold", rm.config.MaxDrawdownPercent),
+		)
+		rm.checkStopDuration(status, now)
+		return status
+	}
+
+	// 7. 检查是否有仓位需要平仓 (仓位为0后重新开始)
+	if rm.allPositionsZero(pm) {
+		status.Action = RiskActionContinueOpening
+		status.Reason = "All positions are zero, ready to open new positions"
+		rm.logger.Info("Ready to continue opening positions")
+		return status
+	}
+
+	// 8. 正常开仓状态
+	status.Action = RiskActionContinueOpening
+	status.Reason = "Normal trading conditions"
+	return status
+}
+
+// updateDrawdown 按两个交易所实测的账户权益合计更新权益高点，返回权益、高点和回撤百分比。
+// 权益高点未恢复时以 StartingEquity (0为首次获取的权益) 为初始值；有交易所权益未知时不计算回撤
+func (rm *RiskManager) updateDrawdown(pm *PositionManager) (equity, highWaterMark, drawdownPercent float64) {
+	equity, ok := pm.TotalEquity()
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if !ok {
+		return 0, rm.highWaterMark, 0
+	}
+
+	if rm.highWaterMark <= 0 {
+		rm.highWaterMark = rm.config.StartingEquity
+	}
+	if equity > rm.highWaterMark {
+		rm.highWaterMark = equity
+	}
+	if rm.highWaterMark > 0 {
+		drawdownPercent = (rm.highWaterMark - equity) / rm.highWaterMark * 100
+	}
+
+	return equity, rm.highWaterMark, drawdownPercent
+}
+
+// HighWaterMark 当前的权益高点 (0为尚未获取到权益)
+func (rm *RiskManager) HighWaterMark() float64 {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return rm.highWaterMark
+}
+
+// RestoreHighWaterMark 恢复上次保存的权益高点，重启后回撤仍从历史高点计算
+func (rm *RiskManager) RestoreHighWaterMark(hwm float64) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if hwm > rm.highWaterMark {
+		rm.highWaterMark = hwm
+	}
+}
+
+// CheckSymbolRisk 检查单个币种是否还能再开一笔 spec.OrderSize 的仓位。
+// pendingNotional 为本轮已准许、尚未反映到仓位中的开仓金额，计入交易所名义金额上限。
+func (rm *RiskManager) CheckSymbolRisk(pm *PositionManager, spec SymbolSpec, pendingNotional float64) SymbolRisk {
+	risk := SymbolRisk{
+		Leverage: pm.GetSymbolLeverage(spec.Symbol),
+		Notional: pm.GetSymbolNotional(spec.Symbol),
+		Allowed:  true,
+	}
+
+	switch {
+	case spec.MaxLeverage > 0 && risk.Leverage >= spec.MaxLeverage:
+		risk.Allowed = false
+		risk.Reason = fmt.Sprintf("leverage %.2f reached symbol limit %.2f", risk.Leverage, spec.MaxLeverage)
+	case spec.MaxNotional > 0 && risk.Notional+spec.OrderSize > spec.MaxNotional:
+		risk.Allowed = false
+		risk.Reason = fmt.Sprintf("notional %.2f + order %.2f exceeds symbol limit %.2f", risk.Notional, spec.OrderSize, spec.MaxNotional)
+	case rm.config != nil && rm.config.MaxVenueNotional > 0:
+		venue := max(pm.GetVenueNotional("lighter"), pm.GetVenueNotional("binance"))
+		if venue+pendingNotional+spec.OrderSize > rm.config.MaxVenueNotional {
+			risk.Allowed = false
+			risk.Reason = fmt.Sprintf("venue notional %.2f + pending %.2f + order %.2f exceeds venue limit %.2f",
+				venue, pendingNotional, spec.OrderSize, rm.config.MaxVenueNotional)
+		}
+	}
+
+	return risk
+}
+
+// SetLastStopTime 设置停止开仓的开始时间 (零值表示已恢复开仓)
+func (rm *RiskManager) SetLastStopTime(t time.Time) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.lastStopTime = t
+}
+
+// checkStopDuration 停止开仓 (杠杆或回撤超限) 持续超过 StopDuration 时进入平仓阶段
+func (rm *RiskManager) checkStopDuration(status *RiskStatus, now time.Time) {
+	if !rm.shouldStartClosing(now) {
+		return
+	}
+	status.Action = RiskActionStartClosing
+	status.Reason = "Stop duration exceeded, starting to close positions"
+	rm.startClosing(status.Trigger)
+	rm.logger.Info("Starting closing phase",
+		zap.String("trigger", status.Trigger),
+		zap.Duration("time_since_stop", now.Sub(rm.getLastStopTime())),
+		zap.Duration("stop_duration", rm.config.StopDuration),
+	)
+}
+
+// shouldStartClosing 停止开仓持续时间超过 StopDuration 时开始平仓
+func (rm *RiskManager) shouldStartClosing(now time.Time) bool {
+	stopTime := rm.getLastStopTime()
+	if stopTime.IsZero() {
+		return false
+	}
+	return now.Sub(stopTime) >= rm.config.StopDuration
+}
+
+// getLastStopTime 获取上次停止开仓时间
+func (rm *RiskManager) getLastStopTime() time.Time {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return rm.lastStopTime
+}
+
+// startClosing 进入平仓阶段，trigger 为触发平仓的指标
+func (rm *RiskManager) startClosing(trigger string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.closing = true
+	rm.closingBy = trigger
+}
+
+// closingTrigger 是否处于平仓阶段及触发平仓的指标
+func (rm *RiskManager) closingTrigger() (string, bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return rm.closingBy, rm.closing
+}
+
+// finishClosing 仓位全部平掉后结束平仓阶段，重新计时
+func (rm *RiskManager) finishClosing() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.closing = false
+	rm.lastStopTime = time.Time{}
+	rm.logger.Info("Closing phase finished, all positions are zero")
+}
+
+// allPositionsZero 检查是否所有仓位都为0
+func (rm *RiskManager) allPositionsZero(pm *PositionManager) bool {
+	lighterPositions := pm.GetLighterPositions()
+	binancePositions := pm.GetBinancePositions()
+
+	// 检查Lighter仓位
+	for _, pos := range lighterPositions.Positions {
+		if pos.Size != 0 {
+			return false
+		}
+	}
+
+	// 检查Binance仓位
+	for _, pos := range binancePositions.Positions {
+		if pos.Size != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetPositionSummary 获取仓位摘要
+func (pm *PositionManager) GetPositionSummary() map[string]interface{} {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	return map[string]interface{}{
+		"lighter": map[string]interface{}{
+			"exchange":         pm.lighterPositions.Exchange,
+			"leverage":         pm.lighterPositions.Leverage,
+			"equity":           pm.lighterPositions.Equity,
+			"leverage_unknown": pm.lighterPositions.LeverageUnknown,
+			"positions":        copyPositions(pm.lighterPositions.Positions),
+			"updated_at":       pm.lighterPositions.UpdatedAt,
+		},
+		"binance": map[string]interface{}{
+			"exchange":         pm.binancePositions.Exchange,
+			"leverage":         pm.binancePositions.Leverage,
+			"equity":           pm.binancePositions.Equity,
+			"leverage_unknown": pm.binancePositions.LeverageUnknown,
+			"positions":        copyPositions(pm.binancePositions.Positions),
+			"updated_at":       pm.binancePositions.UpdatedAt,
+		},
+	}
+}
+
+// copyPositions 复制仓位，避免调用方读取时与更新并发
+func copyPositions(positions map[string]*Position) map[string]Position {
+	result := make(map[string]Position, len(positions))
+	for symbol, pos := range positions {
+		result[symbol] = *pos
+	}
+	return result
+}
+
+// GetLighterPositions 获取Lighter仓位
+func (pm *PositionManager) GetLighterPositions() *ExchangePositions {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.lighterPositions
+}
+
+// GetBinancePositions 获取Binance仓位
+func (pm *PositionManager) GetBinancePositions() *ExchangePositions {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.binancePositions
+}
+
+// UpdateLighterPosition 更新Lighter仓位
+func (pm *PositionManager) UpdateLighterPosition(symbol string, position *Position) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.lighterPositions.Positions == nil {
+		pm.lighterPositions.Positions = make(map[string]*Position)
+	}
+
+	pm.lighterPositions.Positions[symbol] = position
+	pm.lighterPositions.UpdatedAt = time.Now()
+
+	pm.logger.Debug("Updated Lighter position",
+		zap.String("symbol", symbol),
+		zap.Float64("size", position.Size),
+		zap.Float64("value", position.Value),
+		zap.Float64("leverage", position.Leverage),
+	)
+}
+
+// UpdateBinancePosition 更新Binance仓位
+func (pm *PositionManager) UpdateBinancePosition(symbol string, position *Position) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.binancePositions.Positions == nil {
+		pm.binancePositions.Positions = make(map[string]*Position)
+	}
+
+	pm.binancePositions.Positions[symbol] = position
+	pm.binancePositions.UpdatedAt = time.Now()
+
+	pm.logger.Debug("Updated Binance position",
+		zap.String("symbol", symbol),
+		zap.Float64("size", position.Size),
+		zap.Float64("value", position.Value),
+		zap.Float64("leverage", position.Leverage),
+	)
+}
+
+// UpdateEquity 更新交易所账户权益并重新计算杠杆率
+func (pm *PositionManager) UpdateEquity(exchange string, equity float64) {
+	pm.mu.Lock()
+	positions := pm.exchangePositions(exchange)
+	if positions == nil {
+		pm.mu.Unlock()
+		return
+	}
+	positions.Equity = equity
+	pm.mu.Unlock()
+
+	pm.CalculateTotalLeverage()
+}
+
+// CalculateTotalLeverage 按账户权益计算各交易所总杠杆率和单币种杠杆率，
+// 未获取到权益或权益非正时杠杆率未知 (LeverageUnknown)，风控停止开仓
+func (pm *PositionManager) CalculateTotalLeverage() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	for _, positions := range []*ExchangePositions{pm.lighterPositions, pm.binancePositions} {
+		equity := positions.Equity
+		positions.LeverageUnknown = equity <= 0
+
+		var totalValue float64
+		for _, pos := range positions.Positions {
+			totalValue += math.Abs(pos.Value)
+			pos.Leverage = 0
+			if !positions.LeverageUnknown {
+				pos.Leverage = math.Abs(pos.Value) / equity
+			}
+		}
+		positions.Leverage = 0
+		if !positions.LeverageUnknown {
+			positions.Leverage = totalValue / equity
+		}
+	}
+
+	pm.logger.Debug("Calculated total leverage",
+		zap.Float64("lighter_leverage", pm.lighterPositions.Leverage),
+		zap.Float64("lighter_equity", pm.lighterPositions.Equity),
+		zap.Float64("binance_leverage", pm.binancePositions.Leverage),
+		zap.Float64("binance_equity", pm.binancePositions.Equity),
+	)
+}
+
+// TotalEquity 两个交易所实测账户权益合计，有交易所尚未获取到有效权益时返回false
+func (pm *PositionManager) TotalEquity() (float64, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	if pm.lighterPositions.LeverageUnknown || pm.binancePositions.LeverageUnknown {
+		return 0, false
+	}
+	return pm.lighterPositions.Equity + pm.binancePositions.Equity, true
+}
+
+// LeverageUnknown 是否有交易所尚未获取到有效权益、杠杆率无法计算
+func (pm *PositionManager) LeverageUnknown() bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.lighterPositions.LeverageUnknown || pm.binancePositions.LeverageUnknown
+}
+
+// GetSymbolLeverage 获取单个币种在两个交易所中较高的杠杆率
+func (pm *PositionManager) GetSymbolLeverage(symbol string) float64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	var leverage float64
+	if pos, ok := pm.lighterPositions.Positions[symbol]; ok {
+		leverage = pos.Leverage
+	}
+	if pos, ok := pm.binancePositions.Positions[symbol]; ok {
+		leverage = max(leverage, pos.Leverage)
+	}
+	return leverage
+}
+
+// GetSymbolNotional 获取单个币种在两个交易所中较大的持仓名义金额
+func (pm *PositionManager) GetSymbolNotional(symbol string) float64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	var notional float64
+	if pos, ok := pm.lighterPositions.Positions[symbol]; ok {
+		notional = math.Abs(pos.Value)
+	}
+	if pos, ok := pm.binancePositions.Positions[symbol]; ok {
+		notional = max(notional, math.Abs(pos.Value))
+	}
+	return notional
+}
+
+// GetVenueNotional 获取单个交易所全部币种的持仓名义金额合计
+func (pm *PositionManager) GetVenueNotional(exchange string) float64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	positions := pm.exchangePositions(exchange)
+	if positions == nil {
+		return 0
+	}
+
+	var notional float64
+	for _, pos := range positions.Positions {
+		notional += math.Abs(pos.Value)
+	}
+	return notional
+}
+
+// max 返回两个float64中的最大值
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,150 @@
+package strategy
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRiskStopsOpeningWhileEquityUnknown(t *testing.T) {
+	pm := NewPositionManager()
+	rm := NewRiskManager(nil)
+	rm.config = &DynamicHedgeConfig{MaxLeverage: 3, EmergencyLeverage: 5}
+
+	pm.UpdateLighterPosition("BTC", &Position{Symbol: "BTC", Size: -0.01, Value: -500})
+	pm.UpdateBinancePosition("BTC", &Position{Symbol: "BTC", Size: 0.01, Value: 500})
+
+	// 两个交易所都未获取到权益
+	pm.CalculateTotalLeverage()
+	status := rm.checkRisk(pm)
+	if status.Action != RiskActionStopOpening || status.Trigger != RiskTriggerEquity {
+		t.Fatalf("action=%s trigger=%s, want %s/%s", status.Action, status.Trigger, RiskActionStopOpening, RiskTriggerEquity)
+	}
+
+	// 只有一个交易所获取到权益
+	pm.UpdateEquity("lighter", 1000)
+	if status := rm.checkRisk(pm); status.Trigger != RiskTriggerEquity {
+		t.Fatalf("trigger with binance equity unknown = %s, want %s", status.Trigger, RiskTriggerEquity)
+	}
+
+	// 权益非正同样视为未知
+	pm.UpdateEquity("binance", -10)
+	if status := rm.checkRisk(pm); status.Trigger != RiskTriggerEquity {
+		t.Fatalf("trigger with non-positive equity = %s, want %s", status.Trigger, RiskTriggerEquity)
+	}
+
+	pm.UpdateEquity("binance", 1000)
+	status = rm.checkRisk(pm)
+	if status.Action != RiskActionContinueOpening {
+		t.Fatalf("action with known equity = %s, want %s", status.Action, RiskActionContinueOpening)
+	}
+	if status.MaxLeverage != 0.5 {
+		t.Fatalf("max leverage = %v, want 0.5", status.MaxLeverage)
+	}
+}
+
+func TestDrawdownUsesMeasuredEquity(t *testing.T) {
+	pm := NewPositionManager()
+	rm := NewRiskManager(nil)
+	rm.config = &DynamicHedgeConfig{MaxLeverage: 3, EmergencyLeverage: 5, MaxDrawdownPercent: 5, EmergencyDrawdownPercent: 10}
+
+	// 权益未知时不计算回撤
+	if equity, _, drawdown := rm.updateDrawdown(pm); equity != 0 || drawdown != 0 {
+		t.Fatalf("equity=%v drawdown=%v with unknown equity, want 0/0", equity, drawdown)
+	}
+
+	pm.UpdateEquity("lighter", 1000)
+	pm.UpdateEquity("binance", 1000)
+	if equity, hwm, drawdown := rm.updateDrawdown(pm); equity != 2000 || hwm != 2000 || drawdown != 0 {
+		t.Fatalf("equity=%v hwm=%v drawdown=%v, want 2000/2000/0", equity, hwm, drawdown)
+	}
+
+	// 本地盈亏不影响回撤，只按交易所实测权益计算
+	pm.UpdateEquity("binance", 880)
+	status := rm.checkRisk(pm)
+	if status.Equity != 1880 || status.HighWaterMark != 2000 || status.DrawdownPercent != 6 {
+		t.Fatalf("equity=%v hwm=%v drawdown=%v, want 1880/2000/6", status.Equity, status.HighWaterMark, status.DrawdownPercent)
+	}
+	if status.Action != RiskActionStopOpening || status.Trigger != RiskTriggerDrawdown {
+		t.Fatalf("action=%s trigger=%s, want %s/%s", status.Action, status.Trigger, RiskActionStopOpening, RiskTriggerDrawdown)
+	}
+}
+
+func TestHighWaterMarkPersistedWithStats(t *testing.T) {
+	store, err := OpenStatsStore(filepath.Join(t.TempDir(), "stats.json"))
+	if err != nil {
+		t.Fatalf("OpenStatsStore: %v", err)
+	}
+
+	h := newTestHedge(t)
+	h.riskManager.config = h.config
+	h.SetStatsStore(store)
+	h.positionManager.UpdateEquity("lighter", 1500)
+	h.positionManager.UpdateEquity("binance", 1500)
+	h.riskManager.updateDrawdown(h.positionManager)
+	h.saveStats()
+
+	// 重启后权益低于保存的高点，回撤从历史高点计算
+	restarted := newTestHedge(t)
+	restarted.riskManager.config = restarted.config
+	restarted.SetStatsStore(store)
+	restarted.restoreStats()
+	restarted.positionManager.UpdateEquity("lighter", 1400)
+	restarted.positionManager.UpdateEquity("binance", 1300)
+	_, hwm, drawdown := restarted.riskManager.updateDrawdown(restarted.positionManager)
+	if hwm != 3000 || drawdown != 10 {
+		t.Fatalf("hwm=%v drawdown=%v after restart, want 3000/10", hwm, drawdown)
+	}
+}
+
+func TestStopDurationStartsClosingForEachTrigger(t *testing.T) {
+	tests := []struct {
+		name    string
+		equity  float64 // Binance权益 (Lighter固定1000)，从2000的高点回落
+		value   float64 // 每个交易所的持仓名义金额
+		trigger string
+	}{
+		{name: "leverage", equity: 1000, value: 3500, trigger: RiskTriggerLeverage},
+		{name: "drawdown", equity: 880, value: 500, trigger: RiskTriggerDrawdown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := NewPositionManager()
+			rm := NewRiskManager(nil)
+			rm.config = &DynamicHedgeConfig{
+				MaxLeverage:              3,
+				EmergencyLeverage:        5,
+				StopDuration:             10 * time.Minute,
+				StartingEquity:           2000,
+				MaxDrawdownPercent:       5,
+				EmergencyDrawdownPercent: 10,
+			}
+			pm.UpdateLighterPosition("BTC", &Position{Symbol: "BTC", Size: -1, Value: -tt.value})
+			pm.UpdateBinancePosition("BTC", &Position{Symbol: "BTC", Size: 1, Value: tt.value})
+			pm.UpdateEquity("lighter", 1000)
+			pm.UpdateEquity("binance", tt.equity)
+
+			// 停止开仓未满 StopDuration
+			rm.SetLastStopTime(time.Now().Add(-time.Minute))
+			status := rm.checkRisk(pm)
+			if status.Action != RiskActionStopOpening || status.Trigger != tt.trigger {
+				t.Fatalf("action=%s trigger=%s, want %s/%s", status.Action, status.Trigger, RiskActionStopOpening, tt.trigger)
+			}
+
+			// 超过 StopDuration 后进入平仓阶段
+			rm.SetLastStopTime(time.Now().Add(-11 * time.Minute))
+			status = rm.checkRisk(pm)
+			if status.Action != RiskActionStartClosing || status.Trigger != tt.trigger {
+				t.Fatalf("action=%s trigger=%s, want %s/%s", status.Action, status.Trigger, RiskActionStartClosing, tt.trigger)
+			}
+
+			// 指标回落后仍继续平仓，直到仓位全部为0
+			pm.UpdateEquity("binance", 2000)
+			status = rm.checkRisk(pm)
+			if status.Action != RiskActionStartClosing || status.Trigger != tt.trigger {
+				t.Fatalf("after recovery action=%s trigger=%s, want %s/%s", status.Action, status.Trigger, RiskActionStartClosing, tt.trigger)
+			}
+		})
+	}
+}
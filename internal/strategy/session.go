@@ -0,0 +1,101 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TradingWindow 允许开新仓的时间窗口，窗口外只管理和平掉已有仓位
+type TradingWindow struct {
+	Days  []time.Weekday // 窗口开始所在的星期，空为每天
+	Start int            // 开始时间 (当天分钟数)
+	End   int            // 结束时间 (当天分钟数)，小于开始时间时跨越午夜，等于开始时间时为全天
+}
+
+// weekdays 星期名称 (三字母缩写或全称，不区分大小写)
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseTradingWindow 解析交易时间窗口，start/end 为 "HH:MM"
+func ParseTradingWindow(days []string, start, end string) (TradingWindow, error) {
+	var w TradingWindow
+	for _, day := range days {
+		wd, ok := weekdays[strings.ToLower(strings.TrimSpace(day))]
+		if !ok {
+			return w, fmt.Errorf("invalid weekday %q", day)
+		}
+		w.Days = append(w.Days, wd)
+	}
+
+	var err error
+	if w.Start, err = parseClock(start); err != nil {
+		return w, err
+	}
+	if w.End, err = parseClock(end); err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+// Contains 检查时间是否处于窗口内 (now 已转换到窗口时区)
+func (w TradingWindow) Contains(now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+
+	switch {
+	case w.Start == w.End:
+		return w.onDay(now.Weekday())
+	case w.Start < w.End:
+		return minute >= w.Start && minute < w.End && w.onDay(now.Weekday())
+	case minute >= w.Start:
+		return w.onDay(now.Weekday())
+	case minute < w.End:
+		// 跨越午夜的窗口按开始当天的星期判断
+		return w.onDay(now.AddDate(0, 0, -1).Weekday())
+	}
+	return false
+}
+
+// onDay 窗口是否适用于该星期
+func (w TradingWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionLocation 交易时间窗口和日终清仓的时区，未配置时为本地时区
+func (c *DynamicHedgeConfig) sessionLocation() *time.Location {
+	if c.SessionLocation == nil {
+		return time.Local
+	}
+	return c.SessionLocation
+}
+
+// inTradingSession 当前是否允许开新仓，未配置交易时间窗口时始终允许
+func (s *DynamicHedgeStrategy) inTradingSession(config *DynamicHedgeConfig, now time.Time) bool {
+	if len(config.TradingWindows) == 0 {
+		return true
+	}
+
+	now = now.In(config.sessionLocation())
+
+	for _, w := range config.TradingWindows {
+		if w.Contains(now) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+package strategy
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ShadowParams 影子模式的参数组，0表示与实盘配置相同
+type ShadowParams struct {
+	SpreadPercent       float64       `json:"spread_percent"`        // Binance挂单价差 (%)
+	OrderSize           float64       `json:"order_size"`            // 每次下单金额 (USDC)
+	MaxPositionNotional float64       `json:"max_position_notional"` // 每个币种虚拟仓位名义金额上限，达到后转为平仓
+	TradingInterval     time.Duration `json:"trading_interval"`      // 同一币种两次下单的最小间隔
+	MaxOrderAge         time.Duration `json:"max_order_age"`         // 虚拟挂单最长挂单时间，超时撤单 (0为不限制)
+}
+
+// ShadowReport 影子模式与实盘在同一时间段内的对比
+type ShadowReport struct {
+	StartedAt time.Time    `json:"started_at"`
+	Params    ShadowParams `json:"params"` // 影子参数 (OrderSize 为0时按各币种实盘下单金额)
+
+	Orders   int     `json:"orders"`    // 虚拟挂单次数
+	Fills    int     `json:"fills"`     // 虚拟成交次数
+	Cancels  int     `json:"cancels"`   // 超时撤单次数
+	FillRate float64 `json:"fill_rate"` // 成交率 (%)
+	Volume   float64 `json:"volume"`    // Binance虚拟成交金额 (USDC)
+
+	PnL       *PnLSummary        `json:"pnl"`       // 虚拟仓位盈亏 (按实盘手续费率)
+	Positions map[string]float64 `json:"positions"` // 币种 -> Binance虚拟仓位价值
+
+	Live ShadowLiveComparison `json:"live"` // 同一时间段的实盘表现
+}
+
+// ShadowLiveComparison 影子模式启动后的实盘增量
+type ShadowLiveComparison struct {
+	Volume   float64 `json:"volume"`    // 实盘下单金额 (交易统计口径)
+	Trades   int     `json:"trades"`    // 实盘交易次数
+	TotalPnL float64 `json:"total_pnl"` // 实盘总盈亏变化 (已扣手续费)
+}
+
+// shadowOrder 虚拟挂单
+type shadowOrder struct {
+	side     string
+	price    float64
+	size     float64
+	placedAt time.Time
+}
+
+// shadowSymbol 币种的虚拟挂单和开平仓阶段
+type shadowSymbol struct {
+	order     *shadowOrder
+	closing   bool // 虚拟仓位达到上限后平仓，平完后重新开仓
+	lastOrder time.Time
+}
+
+// ShadowTrader 影子模式：与实盘并行，按另一组参数计算挂单决策，并用Binance最优挂单价模拟成交
+// (买单在卖一价不高于挂单价时成交，卖单在买一价不低于挂单价时成交)，成交后按Lighter最新成交价虚拟对冲。
+// 只读取行情，不下单，用于切换参数前与实盘对比
+type ShadowTrader struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	params        ShadowParams
+	logger        *zap.Logger
+
+	positions *PositionManager // 虚拟仓位，与实盘仓位分开核算
+
+	mu        sync.RWMutex
+	symbols   map[string]*shadowSymbol
+	startedAt time.Time
+	orders    int
+	fills     int
+	cancels   int
+	volume    float64
+	liveBase  TradingStats // 启动时的实盘统计，用于计算实盘增量
+	livePnL   float64
+}
+
+// NewShadowTrader 创建影子模式，未配置的参数使用实盘配置
+func NewShadowTrader(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *ShadowTrader {
+	params := config.ShadowParams
+	if params.TradingInterval == 0 {
+		params.TradingInterval = config.TradingInterval
+	}
+	if params.MaxOrderAge == 0 {
+		params.MaxOrderAge = config.MaxOrderAge
+	}
+
+	positions := NewPositionManager()
+	positions.SetFeeSchedule(config.Fees)
+
+	return &ShadowTrader{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		params:        params,
+		logger:        hedgeStrategy.logger.Named("shadow"),
+		positions:     positions,
+		symbols:       make(map[string]*shadowSymbol),
+	}
+}
+
+// Run 按 MonitorInterval 执行虚拟决策，阻塞直到ctx取消或stop关闭
+func (st *ShadowTrader) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(st.config.MonitorInterval)
+	defer ticker.Stop()
+
+	st.mu.Lock()
+	st.startedAt = time.Now()
+	st.liveBase = *st.hedgeStrategy.statsManager.GetStats()
+	st.livePnL = st.hedgeStrategy.positionManager.GetPnL().TotalPnL
+	st.mu.Unlock()
+
+	st.logger.Info("Shadow mode started, no orders will be placed",
+		zap.Float64("spread_percent", st.params.SpreadPercent),
+		zap.Float64("order_size", st.params.OrderSize),
+		zap.Float64("max_position_notional", st.params.MaxPositionNotional),
+		zap.Duration("trading_interval", st.params.TradingInterval),
+		zap.Duration("max_order_age", st.params.MaxOrderAge),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			_ = runPerSymbol(st.hedgeStrategy.symbols.Specs(), func(spec SymbolSpec) error {
+				st.step(ctx, spec)
+				return nil
+			})
+		}
+	}
+}
+
+// step 处理币种的一个周期：有虚拟挂单时检查成交或超时，否则按开平仓阶段挂新单
+func (st *ShadowTrader) step(ctx context.Context, spec SymbolSpec) {
+	bid, ask, err := st.quote(ctx, spec.Symbol)
+	if err != nil {
+		st.logger.Debug("Failed to get Binance quote", zap.String("symbol", spec.Symbol), zap.Error(err))
+		return
+	}
+	mid := (bid + ask) / 2
+	st.positions.UpdateMarkPrice(spec.Symbol, mid)
+
+	st.mu.Lock()
+	state, ok := st.symbols[spec.Symbol]
+	if !ok {
+		state = &shadowSymbol{}
+		st.symbols[spec.Symbol] = state
+	}
+	order := state.order
+	st.mu.Unlock()
+
+	if order != nil {
+		st.checkOrder(ctx, spec, state, order, bid, ask)
+		return
+	}
+	st.placeOrder(spec, state, mid)
+}
+
+// checkOrder 市场价格穿过虚拟挂单价时按挂单价成交，超过 MaxOrderAge 时撤单
+func (st *ShadowTrader) checkOrder(ctx context.Context, spec SymbolSpec, state *shadowSymbol, order *shadowOrder, bid, ask float64) {
+	filled := (order.side == "BUY" && ask <= order.price) || (order.side == "SELL" && bid >= order.price)
+	if !filled {
+		if st.params.MaxOrderAge > 0 && time.Since(order.placedAt) > st.params.MaxOrderAge {
+			st.mu.Lock()
+			state.order = nil
+			st.cancels++
+			st.mu.Unlock()
+			st.logger.Debug("Shadow order expired", zap.String("symbol", spec.Symbol), zap.String("side", order.side))
+		}
+		return
+	}
+
+	hedgePrice, err := st.hedgeStrategy.lighterStrategy.lastPrice(ctx, spec.Symbol)
+	if err != nil {
+		// 下一周期重试对冲价格，虚拟挂单保持成交状态
+		st.logger.Debug("Failed to get Lighter price for shadow hedge", zap.String("symbol", spec.Symbol), zap.Error(err))
+		return
+	}
+
+	st.positions.ApplyFill("binance", spec.Symbol, order.side, LiquidityMaker, order.size, order.price)
+	st.positions.ApplyFill("lighter", spec.Symbol, oppositeSide(order.side), LiquidityTaker, order.size, hedgePrice)
+
+	st.mu.Lock()
+	state.order = nil
+	st.fills++
+	st.volume += order.size
+	st.mu.Unlock()
+
+	st.logger.Info("Shadow order filled",
+		zap.String("symbol", spec.Symbol),
+		zap.String("side", order.side),
+		zap.Float64("size", order.size),
+		zap.Float64("price", order.price),
+		zap.Float64("hedge_price", hedgePrice),
+	)
+}
+
+// placeOrder 按阶段挂新的虚拟单：开仓阶段按币种配置方向加仓，虚拟仓位达到上限后反向平仓，平完后重新开仓
+func (st *ShadowTrader) placeOrder(spec SymbolSpec, state *shadowSymbol, mid float64) {
+	if !state.lastOrder.IsZero() && time.Since(state.lastOrder) < st.params.TradingInterval {
+		return
+	}
+
+	size := st.params.OrderSize
+	if size <= 0 {
+		size = spec.OrderSize
+	}
+
+	var value float64
+	if pos, ok := st.positions.positionsSnapshot("binance")[spec.Symbol]; ok {
+		value = pos.Size * mid
+	}
+
+	side := spec.BinanceSide()
+	switch {
+	case state.closing && math.Abs(value) < size*0.01:
+		state.closing = false
+	case !state.closing && math.Abs(value)+size > st.params.MaxPositionNotional:
+		state.closing = true
+	}
+	if state.closing {
+		side = "SELL"
+		if value < 0 {
+			side = "BUY"
+		}
+		size = math.Min(size, math.Abs(value))
+	}
+	if size <= 0 {
+		return
+	}
+
+	spread := st.params.SpreadPercent
+	if spread <= 0 {
+		spread = st.hedgeStrategy.spreadPercent(st.config, spec.Symbol)
+	}
+	price := mid * (1 - spread/100)
+	if side == "SELL" {
+		price = mid * (1 + spread/100)
+	}
+
+	st.mu.Lock()
+	state.order = &shadowOrder{side: side, price: price, size: size, placedAt: time.Now()}
+	state.lastOrder = time.Now()
+	st.orders++
+	st.mu.Unlock()
+
+	st.logger.Debug("Shadow order placed",
+		zap.String("symbol", spec.Symbol),
+		zap.String("side", side),
+		zap.Float64("size", size),
+		zap.Float64("price", price),
+		zap.Bool("closing", state.closing),
+	)
+}
+
+// quote 获取Binance买一卖一价，未启用最优挂单价推送时以最新价格作为买一和卖一
+func (st *ShadowTrader) quote(ctx context.Context, symbol string) (bid, ask float64, err error) {
+	client := st.hedgeStrategy.binanceStrategy.client
+	pair := st.hedgeStrategy.binanceStrategy.pair(symbol)
+	if t, ok := client.GetBookTicker(pair); ok {
+		return t.BidPrice, t.AskPrice, nil
+	}
+	price, err := client.GetCurrentPrice(ctx, pair)
+	if err != nil {
+		return 0, 0, err
+	}
+	return price, price, nil
+}
+
+// Report 返回影子模式与实盘的对比
+func (st *ShadowTrader) Report() *ShadowReport {
+	live := st.hedgeStrategy.statsManager.GetStats()
+	livePnL := st.hedgeStrategy.positionManager.GetPnL().TotalPnL
+
+	positions := make(map[string]float64)
+	for symbol, pos := range st.positions.positionsSnapshot("binance") {
+		positions[symbol] = pos.Value
+	}
+
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	report := &ShadowReport{
+		StartedAt: st.startedAt,
+		Params:    st.params,
+		Orders:    st.orders,
+		Fills:     st.fills,
+		Cancels:   st.cancels,
+		Volume:    st.volume,
+		PnL:       st.positions.GetPnL(),
+		Positions: positions,
+		Live: ShadowLiveComparison{
+			Volume:   live.TotalVolume - st.liveBase.TotalVolume,
+			Trades:   live.TotalTrades - st.liveBase.TotalTrades,
+			TotalPnL: livePnL - st.livePnL,
+		},
+	}
+	if st.orders > 0 {
+		report.FillRate = float64(st.fills) / float64(st.orders) * 100
+	}
+	return report
+}
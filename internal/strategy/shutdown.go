@@ -0,0 +1,107 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 关闭模式
+const (
+	ShutdownModeNone    = "none"    // 直接退出，挂单和仓位保持不动
+	ShutdownModeDrain   = "drain"   // 等待Maker单成交并完成对冲，超时后撤销剩余挂单
+	ShutdownModeCancel  = "cancel"  // 撤销全部挂单，撤单前的成交先完成对冲
+	ShutdownModeFlatten = "flatten" // 撤销全部挂单并市价平掉两个交易所的仓位
+)
+
+// shutdownStepTimeout 撤单、平仓步骤的超时时间
+const shutdownStepTimeout = 30 * time.Second
+
+// drainPollInterval 等待Maker单成交时的检查间隔
+const drainPollInterval = time.Second
+
+// Shutdown 按关闭模式收尾，须在策略上下文取消和 Stop 之前调用，期间订单监控和对冲照常运行。
+// 先暂停开新仓，drain 模式最多等待 drainTimeout，超时后按 cancel 模式撤单；
+// flatten 模式先停止执行监控循环的周期 (等待进行中的周期结束)，由关闭流程独占撤单和平仓
+func (s *DynamicHedgeStrategy) Shutdown(mode string, drainTimeout time.Duration) error {
+	if mode == "" || mode == ShutdownModeNone {
+		return nil
+	}
+
+	s.Pause()
+	s.logger.Info("Shutting down strategy", zap.String("mode", mode), zap.Duration("drain_timeout", drainTimeout))
+
+	// 撤单和平仓期间监控循环不再下平仓单或紧急平仓，避免重复平仓
+	if mode == ShutdownModeFlatten {
+		s.quiesceCycles()
+	}
+
+	if mode == ShutdownModeDrain {
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		err := s.drain(ctx)
+		cancel()
+		if err != nil {
+			s.logger.Warn("Drain did not complete, cancelling remaining orders", zap.Error(err))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownStepTimeout)
+	defer cancel()
+
+	var lastErr error
+	cancelled, err := s.orderMonitor.CancelWorkingOrders(ctx, "SHUTDOWN")
+	if err != nil {
+		lastErr = err
+	}
+
+	// 退出后无人监控，保护单触发时将无法对冲
+	protective, err := s.protectionManager.CancelAll(ctx)
+	if err != nil {
+		lastErr = err
+	}
+
+	s.logger.Info("Open orders cancelled on shutdown",
+		zap.Int("maker_orders", cancelled),
+		zap.Int("protective_orders", protective),
+	)
+
+	if mode == ShutdownModeFlatten {
+		if err := s.closingManager.ExecuteEmergencyClosing(ctx, s.riskManager.config); err != nil {
+			return fmt.Errorf("failed to flatten positions on shutdown: %w", err)
+		}
+		s.logger.Info("Positions flattened on shutdown")
+	}
+
+	return lastErr
+}
+
+// CancelWorkingOrders 撤销全部未完全成交的Maker单，撤单前新增的成交先完成对冲，返回撤单数量 (紧急停止用)
+func (s *DynamicHedgeStrategy) CancelWorkingOrders(ctx context.Context, reason string) (int, error) {
+	return s.orderMonitor.CancelWorkingOrders(ctx, reason)
+}
+
+// drain 等待全部Maker单成交 (成交后由订单监控完成对冲)，ctx超时返回错误
+func (s *DynamicHedgeStrategy) drain(ctx context.Context) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		working := s.orderMonitor.WorkingOrders()
+		if working == 0 {
+			// 等待进行中的对冲完成
+			s.orderMonitor.WaitIdle()
+			s.logger.Info("All maker orders drained")
+			return nil
+		}
+
+		s.logger.Info("Waiting for maker orders to fill", zap.Int("working_orders", working))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d maker orders still working: %w", working, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
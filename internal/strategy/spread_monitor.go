@@ -0,0 +1,154 @@
+package strategy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SpreadQuote 币种的跨交易所价差
+type SpreadQuote struct {
+	Symbol       string    `json:"symbol"`
+	BinancePrice float64   `json:"binance_price"`
+	LighterPrice float64   `json:"lighter_price"`
+	Edge         float64   `json:"edge"`       // 按配置方向开仓的毛价差 (%)，正数表示有利
+	NetEdge      float64   `json:"net_edge"`   // 扣除往返手续费后的价差 (%)
+	Favorable    bool      `json:"favorable"`  // 净价差是否达到开仓要求
+	UpdatedAt    time.Time `json:"updated_at"` // 报价时间
+}
+
+// SpreadMonitor 跨交易所价差监控：持续计算各币种Binance与Lighter的价差，
+// 按配置方向扣除开仓和平仓手续费后价差达到 MinSpreadPercent 时通知策略立即开仓
+type SpreadMonitor struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+
+	mu     sync.RWMutex
+	quotes map[string]*SpreadQuote
+
+	signal chan struct{} // 出现有利价差时通知主循环 (容量1，不阻塞)
+}
+
+// NewSpreadMonitor 创建价差监控
+func NewSpreadMonitor(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *SpreadMonitor {
+	return &SpreadMonitor{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("spread-monitor"),
+		quotes:        make(map[string]*SpreadQuote),
+		signal:        make(chan struct{}, 1),
+	}
+}
+
+// Signals 出现有利价差时可读
+func (sm *SpreadMonitor) Signals() <-chan struct{} {
+	return sm.signal
+}
+
+// Run 按 SpreadCheckInterval 轮询价差，阻塞直到ctx取消或stop关闭
+func (sm *SpreadMonitor) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(sm.config.SpreadCheckInterval)
+	defer ticker.Stop()
+
+	sm.logger.Info("Spread monitor started",
+		zap.Duration("interval", sm.config.SpreadCheckInterval),
+		zap.Float64("min_spread_percent", sm.config.MinSpreadPercent),
+		zap.Float64("break_even_spread_percent", sm.config.Fees.BreakEvenSpreadPercent()),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			if sm.refresh(ctx) {
+				select {
+				case sm.signal <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// refresh 并发更新全部币种的报价，返回是否有币种出现有利价差
+func (sm *SpreadMonitor) refresh(ctx context.Context) bool {
+	var mu sync.Mutex
+	favorable := false
+	_ = runPerSymbol(sm.hedgeStrategy.symbols.Specs(), func(spec SymbolSpec) error {
+		quote, err := sm.quote(ctx, spec)
+		if err != nil {
+			sm.logger.Debug("Failed to update spread quote", zap.String("symbol", spec.Symbol), zap.Error(err))
+			return err
+		}
+
+		sm.mu.Lock()
+		sm.quotes[spec.Symbol] = quote
+		sm.mu.Unlock()
+
+		if quote.Favorable {
+			mu.Lock()
+			favorable = true
+			mu.Unlock()
+		}
+		return nil
+	})
+	return favorable
+}
+
+// quote 计算币种按配置方向开仓的价差：Lighter卖出、Binance买入时Lighter价格越高越有利，反之相反
+func (sm *SpreadMonitor) quote(ctx context.Context, spec SymbolSpec) (*SpreadQuote, error) {
+	binancePrice, err := sm.hedgeStrategy.binanceStrategy.client.GetCurrentPrice(ctx, sm.hedgeStrategy.binanceStrategy.pair(spec.Symbol))
+	if err != nil {
+		return nil, err
+	}
+	lighterPrice, err := sm.hedgeStrategy.lighterStrategy.lastPrice(ctx, spec.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	edge := (lighterPrice - binancePrice) / binancePrice * 100
+	if spec.LighterSide == "BUY" {
+		edge = -edge
+	}
+	netEdge := edge - sm.config.Fees.BreakEvenSpreadPercent()
+
+	return &SpreadQuote{
+		Symbol:       spec.Symbol,
+		BinancePrice: binancePrice,
+		LighterPrice: lighterPrice,
+		Edge:         edge,
+		NetEdge:      netEdge,
+		Favorable:    netEdge >= sm.config.MinSpreadPercent,
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// Favorable 币种最近的报价是否为有利价差，超过两个检查周期未更新的报价视为无效
+func (sm *SpreadMonitor) Favorable(symbol string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	quote, ok := sm.quotes[symbol]
+	if !ok || time.Since(quote.UpdatedAt) > 2*sm.config.SpreadCheckInterval {
+		return false
+	}
+	return quote.Favorable
+}
+
+// Quotes 返回全部币种的最新报价
+func (sm *SpreadMonitor) Quotes() map[string]SpreadQuote {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	quotes := make(map[string]SpreadQuote, len(sm.quotes))
+	for symbol, quote := range sm.quotes {
+		quotes[symbol] = *quote
+	}
+	return quotes
+}
@@ -0,0 +1,141 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// StatsSnapshot 交易统计、执行统计和风控状态快照
+type StatsSnapshot struct {
+	SavedAt       time.Time       `json:"saved_at"`
+	Trading       *TradingStats   `json:"trading"`
+	Execution     *ExecutionStats `json:"execution,omitempty"`
+	HighWaterMark float64         `json:"high_water_mark,omitempty"` // 回撤风控的权益高点
+}
+
+// StatsStore 统计持久化：定期和停止时把统计快照写入JSON文件，进程重启后据此恢复
+type StatsStore struct {
+	path   string
+	mu     sync.Mutex
+	logger *zap.Logger
+}
+
+// OpenStatsStore 打开统计状态文件，目录不存在时创建
+func OpenStatsStore(path string) (*StatsStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create stats state directory: %w", err)
+	}
+
+	log := logger.Named("stats-store")
+	log.Info("Stats state store opened", zap.String("path", path))
+
+	return &StatsStore{path: path, logger: log}, nil
+}
+
+// Load 读取上次保存的快照，文件不存在时返回nil
+func (s *StatsStore) Load() (*StatsSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats state %s: %w", s.path, err)
+	}
+
+	var snapshot StatsSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse stats state %s: %w", s.path, err)
+	}
+	return &snapshot, nil
+}
+
+// Save 覆盖保存快照。先写临时文件再重命名，进程中途退出不会留下不完整的文件
+func (s *StatsStore) Save(snapshot *StatsSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats state: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write stats state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace stats state: %w", err)
+	}
+	return nil
+}
+
+// SetStatsStore 设置统计持久化，Start 时恢复上次保存的统计，运行期间按 StatsSnapshotInterval 保存
+func (s *DynamicHedgeStrategy) SetStatsStore(store *StatsStore) {
+	s.statsStore = store
+}
+
+// restoreStats 恢复上次保存的交易统计、执行统计和权益高点。读取失败时记录告警，统计从零开始
+func (s *DynamicHedgeStrategy) restoreStats() {
+	snapshot, err := s.statsStore.Load()
+	if err != nil {
+		s.logger.Warn("Failed to load stats state, starting from zero", zap.Error(err))
+		return
+	}
+	if snapshot == nil {
+		return
+	}
+
+	if snapshot.Trading != nil {
+		s.statsManager.Restore(snapshot.Trading, snapshot.SavedAt)
+	}
+	if snapshot.Execution != nil {
+		s.fastExecutionManager.RestoreStats(snapshot.Execution)
+	}
+	if snapshot.HighWaterMark > 0 {
+		s.riskManager.RestoreHighWaterMark(snapshot.HighWaterMark)
+		s.logger.Info("Restored equity high-water mark", zap.Float64("high_water_mark", snapshot.HighWaterMark))
+	}
+}
+
+// runStatsSnapshots 按 StatsSnapshotInterval 保存统计快照，阻塞直到ctx取消或stop关闭
+func (s *DynamicHedgeStrategy) runStatsSnapshots(ctx context.Context, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.saveStats()
+		}
+	}
+}
+
+// saveStats 保存当前统计快照
+func (s *DynamicHedgeStrategy) saveStats() {
+	snapshot := &StatsSnapshot{
+		SavedAt:       time.Now(),
+		Trading:       s.statsManager.GetStats(),
+		Execution:     s.fastExecutionManager.GetExecutionStats(),
+		HighWaterMark: s.riskManager.HighWaterMark(),
+	}
+	if err := s.statsStore.Save(snapshot); err != nil {
+		s.logger.Warn("Failed to save stats state", zap.Error(err))
+	}
+}
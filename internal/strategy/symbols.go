@@ -0,0 +1,104 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SymbolSpec 币种在两个交易所上的映射
+type SymbolSpec struct {
+	Symbol             string // 内部币种符号，如 BTC
+	BinancePair        string // Binance交易对，如 BTCUSDC
+	LighterMarketIndex uint8  // Lighter市场索引
+	LighterSide        string // 动态对冲中Lighter侧方向: BUY, SELL
+
+	OrderSize        float64 // 动态对冲每次下单金额 (USDC)
+	Leverage         int     // Lighter下单杠杆
+	MaxLeverage      float64 // 该币种杠杆上限 (0为不单独限制)
+	MaxNotional      float64 // 该币种单个交易所持仓名义金额上限 (0为不限制)
+	SpreadPercent    float64 // Binance价差百分比 (0为使用策略配置)
+	BalanceTolerance float64 // 平衡容差百分比 (0为使用对冲平衡器设置)
+}
+
+// BinanceSide 动态对冲中Binance侧方向 (与Lighter相反)
+func (s SymbolSpec) BinanceSide() string {
+	return oppositeSide(s.LighterSide)
+}
+
+// SymbolUniverse 策略可交易的币种集合，保持配置顺序
+type SymbolUniverse struct {
+	specs    []SymbolSpec
+	bySymbol map[string]SymbolSpec
+}
+
+// NewSymbolUniverse 创建币种集合
+func NewSymbolUniverse(specs []SymbolSpec) *SymbolUniverse {
+	u := &SymbolUniverse{
+		specs:    specs,
+		bySymbol: make(map[string]SymbolSpec, len(specs)),
+	}
+	for _, spec := range specs {
+		u.bySymbol[spec.Symbol] = spec
+	}
+	return u
+}
+
+// Specs 返回全部币种配置
+func (u *SymbolUniverse) Specs() []SymbolSpec {
+	return u.specs
+}
+
+// Get 查找币种配置
+func (u *SymbolUniverse) Get(symbol string) (SymbolSpec, error) {
+	spec, ok := u.bySymbol[symbol]
+	if !ok {
+		return SymbolSpec{}, fmt.Errorf("symbol %s is not configured", symbol)
+	}
+	return spec, nil
+}
+
+// BinancePair 将内部币种符号映射为Binance交易对，未配置时按 <symbol>USDC 推断
+func (u *SymbolUniverse) BinancePair(symbol string) string {
+	if spec, ok := u.bySymbol[symbol]; ok {
+		return spec.BinancePair
+	}
+	return symbol + "USDC"
+}
+
+// LighterMarketIndex 将内部币种符号映射为Lighter市场索引
+func (u *SymbolUniverse) LighterMarketIndex(symbol string) (uint8, error) {
+	spec, err := u.Get(symbol)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported lighter symbol: %s", symbol)
+	}
+	return spec.LighterMarketIndex, nil
+}
+
+// runPerSymbol 对每个币种并发执行 fn，等待全部完成并合并错误
+func runPerSymbol(specs []SymbolSpec, fn func(spec SymbolSpec) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(specs))
+	panics := make([]*goroutinePanic, len(specs))
+
+	for i, spec := range specs {
+		wg.Add(1)
+		go func(i int, spec SymbolSpec) {
+			defer wg.Done()
+			defer repanic(&panics[i])
+			if err := fn(spec); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", spec.Symbol, err)
+			}
+		}(i, spec)
+	}
+
+	wg.Wait()
+
+	// 子goroutine的panic在调用方重新抛出，由调用方的恢复逻辑处理
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,413 @@
+package strategy
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// TradingStatsManager 交易统计管理器
+type TradingStatsManager struct {
+	stats  *TradingStats
+	mu     sync.RWMutex
+	logger *zap.Logger
+
+	// 日统计的日切边界：location 时区的 resetHour 点
+	location  *time.Location
+	resetHour int
+
+	// 日盈亏：当日盈亏 = 最新总盈亏 - 日切时的总盈亏
+	lastPnL     float64
+	dayStartPnL float64
+}
+
+// maxDailyPnLHistory 保留的日盈亏天数
+const maxDailyPnLHistory = 365
+
+// tradingDaysPerYear 年化天数，加密货币市场全年交易
+const tradingDaysPerYear = 365
+
+// TradingStats 交易统计信息
+type TradingStats struct {
+	// 日统计
+	DailyVolume    float64   `json:"daily_volume"`     // 日交易量 (USDT)
+	DailyTrades    int       `json:"daily_trades"`     // 日交易次数
+	DailyStartTime time.Time `json:"daily_start_time"` // 日统计开始时间
+
+	// 总统计
+	TotalVolume float64   `json:"total_volume"` // 总交易量
+	TotalTrades int       `json:"total_trades"` // 总交易次数
+	StartTime   time.Time `json:"start_time"`   // 策略开始时间
+
+	// 当前状态
+	LastTradeTime time.Time `json:"last_trade_time"` // 最后交易时间
+	CurrentPhase  string    `json:"current_phase"`   // 当前阶段
+	ActiveOrders  int       `json:"active_orders"`   // 活跃订单数
+
+	// 性能指标
+	AvgTradeSize   float64 `json:"avg_trade_size"`  // 平均交易大小
+	TradeFrequency float64 `json:"trade_frequency"` // 交易频率 (次/小时)
+	VolumeProgress float64 `json:"volume_progress"` // 日交易量完成进度 (%)
+
+	// 盈亏
+	RealizedPnL   float64 `json:"realized_pnl"`   // 已实现盈亏
+	UnrealizedPnL float64 `json:"unrealized_pnl"` // 未实现盈亏
+	Fees          float64 `json:"fees"`           // 已支付手续费
+	TotalPnL      float64 `json:"total_pnl"`      // 总盈亏 (已扣手续费)
+
+	// 绩效指标：按日盈亏序列计算 (含当前未结束的交易日)，无风险利率按0，天数不足时为0
+	DailyPnL        float64   `json:"daily_pnl"`                   // 当前交易日的盈亏
+	DailyPnLHistory []float64 `json:"daily_pnl_history,omitempty"` // 已结束交易日的盈亏 (按时间顺序，最多365天)
+	SharpeRatio     float64   `json:"sharpe_ratio"`                // 年化夏普比率 = 日均盈亏 / 日盈亏标准差 × √365
+	SortinoRatio    float64   `json:"sortino_ratio"`               // 年化索提诺比率 = 日均盈亏 / 下行标准差 × √365
+	WinRate         float64   `json:"win_rate"`                    // 盈利日占比 (%)
+	ProfitFactor    float64   `json:"profit_factor"`               // 盈利日合计 / 亏损日合计 (无亏损日时为0)
+
+	// 手续费等级 (exchange -> 近30天成交量和估算等级)
+	FeeTiers map[string]*FeeTierStatus `json:"fee_tiers,omitempty"`
+}
+
+// NewTradingStatsManager 创建交易统计管理器
+func NewTradingStatsManager() *TradingStatsManager {
+	now := time.Now()
+	return &TradingStatsManager{
+		stats: &TradingStats{
+			DailyStartTime: now,
+			StartTime:      now,
+			CurrentPhase:   "INITIALIZING",
+		},
+		logger:   logger.Named("trading-stats"),
+		location: time.Local,
+	}
+}
+
+// SetDayBoundary 设置日统计的日切时区和时刻 (0-23点)，如交易所按UTC 0点日切
+func (tsm *TradingStatsManager) SetDayBoundary(loc *time.Location, resetHour int) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	if loc == nil {
+		loc = time.Local
+	}
+	tsm.location = loc
+	tsm.resetHour = resetHour
+	tsm.stats.DailyStartTime = tsm.dayStart(time.Now())
+
+	tsm.logger.Info("Daily stats boundary configured",
+		zap.String("timezone", loc.String()),
+		zap.Int("reset_hour", resetHour),
+		zap.Time("daily_start_time", tsm.stats.DailyStartTime),
+	)
+}
+
+// Restore 恢复上次运行保存的统计。总统计直接恢复；日统计只在保存时与当前属于同一交易日时恢复，
+// 停机期间跨过日切则从零开始
+func (tsm *TradingStatsManager) Restore(saved *TradingStats, savedAt time.Time) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	now := time.Now()
+
+	tsm.stats.TotalVolume = saved.TotalVolume
+	tsm.stats.TotalTrades = saved.TotalTrades
+	tsm.stats.LastTradeTime = saved.LastTradeTime
+	if !saved.StartTime.IsZero() {
+		tsm.stats.StartTime = saved.StartTime
+	}
+	if tsm.stats.TotalTrades > 0 {
+		tsm.stats.AvgTradeSize = tsm.stats.TotalVolume / float64(tsm.stats.TotalTrades)
+		if hours := now.Sub(tsm.stats.StartTime).Hours(); hours > 0 {
+			tsm.stats.TradeFrequency = float64(tsm.stats.TotalTrades) / hours
+		}
+	}
+
+	// 盈亏由成交重新计算，重启时总盈亏从0开始：同一交易日时把已有的日盈亏计入日切基准，否则保存时的交易日已结束
+	tsm.stats.DailyPnLHistory = append([]float64(nil), saved.DailyPnLHistory...)
+	sameDay := tsm.isSameDay(savedAt, now)
+	if sameDay {
+		tsm.stats.DailyVolume = saved.DailyVolume
+		tsm.stats.DailyTrades = saved.DailyTrades
+		tsm.dayStartPnL = tsm.lastPnL - saved.DailyPnL
+	} else {
+		tsm.appendDailyPnL(saved.DailyPnL)
+		tsm.dayStartPnL = tsm.lastPnL
+	}
+	tsm.stats.DailyPnL = tsm.lastPnL - tsm.dayStartPnL
+	tsm.stats.DailyStartTime = tsm.dayStart(now)
+
+	tsm.logger.Info("Trading stats restored",
+		zap.Time("saved_at", savedAt),
+		zap.Bool("daily_restored", sameDay),
+		zap.Float64("daily_volume", tsm.stats.DailyVolume),
+		zap.Int("daily_trades", tsm.stats.DailyTrades),
+		zap.Float64("total_volume", tsm.stats.TotalVolume),
+		zap.Int("total_trades", tsm.stats.TotalTrades),
+		zap.Int("pnl_days", len(tsm.stats.DailyPnLHistory)),
+	)
+}
+
+// UpdatePnL 更新总盈亏 (已扣手续费)，用于计算日盈亏
+func (tsm *TradingStatsManager) UpdatePnL(totalPnL float64) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	tsm.rollover(time.Now())
+	tsm.lastPnL = totalPnL
+	tsm.stats.DailyPnL = totalPnL - tsm.dayStartPnL
+}
+
+// RecordTrade 记录交易
+func (tsm *TradingStatsManager) RecordTrade(volume float64, tradeType string) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	now := time.Now()
+	tsm.rollover(now)
+
+	// 更新统计
+	tsm.stats.DailyVolume += volume
+	tsm.stats.DailyTrades++
+	tsm.stats.TotalVolume += volume
+	tsm.stats.TotalTrades++
+	tsm.stats.LastTradeTime = now
+
+	// 计算平均交易大小
+	if tsm.stats.TotalTrades > 0 {
+		tsm.stats.AvgTradeSize = tsm.stats.TotalVolume / float64(tsm.stats.TotalTrades)
+	}
+
+	// 计算交易频率 (次/小时)
+	if duration := now.Sub(tsm.stats.StartTime); duration > 0 {
+		hours := duration.Hours()
+		if hours > 0 {
+			tsm.stats.TradeFrequency = float64(tsm.stats.TotalTrades) / hours
+		}
+	}
+
+	tsm.logger.Info("Trade recorded",
+		zap.String("type", tradeType),
+		zap.Float64("volume", volume),
+		zap.Float64("daily_volume", tsm.stats.DailyVolume),
+		zap.Int("daily_trades", tsm.stats.DailyTrades),
+	)
+}
+
+// UpdatePhase 更新当前阶段
+func (tsm *TradingStatsManager) UpdatePhase(phase string) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	oldPhase := tsm.stats.CurrentPhase
+	tsm.stats.CurrentPhase = phase
+
+	tsm.logger.Info("Phase updated",
+		zap.String("old_phase", oldPhase),
+		zap.String("new_phase", phase),
+	)
+}
+
+// UpdateActiveOrders 更新活跃订单数
+func (tsm *TradingStatsManager) UpdateActiveOrders(count int) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	tsm.stats.ActiveOrders = count
+}
+
+// UpdateVolumeProgress 更新交易量进度
+func (tsm *TradingStatsManager) UpdateVolumeProgress(target float64) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	if target > 0 {
+		tsm.stats.VolumeProgress = (tsm.stats.DailyVolume / target) * 100
+		if tsm.stats.VolumeProgress > 100 {
+			tsm.stats.VolumeProgress = 100
+		}
+	}
+}
+
+// GetStats 获取统计信息
+func (tsm *TradingStatsManager) GetStats() *TradingStats {
+	tsm.mu.RLock()
+	defer tsm.mu.RUnlock()
+
+	// 返回副本
+	statsCopy := *tsm.stats
+	statsCopy.DailyPnLHistory = append([]float64(nil), tsm.stats.DailyPnLHistory...)
+
+	series := append(append([]float64(nil), statsCopy.DailyPnLHistory...), statsCopy.DailyPnL)
+	statsCopy.SharpeRatio, statsCopy.SortinoRatio, statsCopy.WinRate, statsCopy.ProfitFactor = performanceRatios(series)
+	return &statsCopy
+}
+
+// performanceRatios 按日盈亏序列计算年化夏普比率、年化索提诺比率、胜率 (%) 和盈亏比。
+// 夏普比率至少需要2天，标准差或下行标准差为0时对应比率为0
+func performanceRatios(series []float64) (sharpe, sortino, winRate, profitFactor float64) {
+	n := float64(len(series))
+	if n == 0 {
+		return 0, 0, 0, 0
+	}
+
+	var sum, gains, losses, downside float64
+	wins := 0
+	for _, pnl := range series {
+		sum += pnl
+		switch {
+		case pnl > 0:
+			wins++
+			gains += pnl
+		case pnl < 0:
+			losses -= pnl
+			downside += pnl * pnl
+		}
+	}
+	mean := sum / n
+	winRate = float64(wins) / n * 100
+	if losses > 0 {
+		profitFactor = gains / losses
+	}
+
+	annualize := math.Sqrt(tradingDaysPerYear)
+	if downside > 0 {
+		sortino = mean / math.Sqrt(downside/n) * annualize
+	}
+	if len(series) < 2 {
+		return sharpe, sortino, winRate, profitFactor
+	}
+
+	var variance float64
+	for _, pnl := range series {
+		variance += (pnl - mean) * (pnl - mean)
+	}
+	if std := math.Sqrt(variance / (n - 1)); std > 0 {
+		sharpe = mean / std * annualize
+	}
+	return sharpe, sortino, winRate, profitFactor
+}
+
+// GetDailyStats 获取日统计
+func (tsm *TradingStatsManager) GetDailyStats() map[string]interface{} {
+	tsm.mu.RLock()
+	defer tsm.mu.RUnlock()
+
+	return map[string]interface{}{
+		"daily_volume":     tsm.stats.DailyVolume,
+		"daily_trades":     tsm.stats.DailyTrades,
+		"daily_start_time": tsm.stats.DailyStartTime,
+		"volume_progress":  tsm.stats.VolumeProgress,
+		"avg_trade_size":   tsm.stats.AvgTradeSize,
+		"trade_frequency":  tsm.stats.TradeFrequency,
+	}
+}
+
+// CheckDailyTargets 检查日目标完成情况
+func (tsm *TradingStatsManager) CheckDailyTargets(volumeTarget float64, tradesTarget int) (bool, bool) {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	// 暂停期间没有新成交，需要在检查时日切，否则达到上限后无法恢复
+	tsm.rollover(time.Now())
+
+	volumeReached := tsm.stats.DailyVolume >= volumeTarget
+	tradesReached := tsm.stats.DailyTrades >= tradesTarget
+
+	return volumeReached, tradesReached
+}
+
+// ShouldPauseTradingForDay 检查是否应该暂停交易
+func (tsm *TradingStatsManager) ShouldPauseTradingForDay(maxTrades int) bool {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	tsm.rollover(time.Now())
+
+	return tsm.stats.DailyTrades >= maxTrades
+}
+
+// GetTradeVelocity 获取交易速度 (交易/分钟)
+func (tsm *TradingStatsManager) GetTradeVelocity() float64 {
+	tsm.mu.RLock()
+	defer tsm.mu.RUnlock()
+
+	if tsm.stats.DailyTrades == 0 {
+		return 0
+	}
+
+	dayDuration := time.Since(tsm.stats.DailyStartTime)
+	if dayDuration.Minutes() == 0 {
+		return 0
+	}
+
+	return float64(tsm.stats.DailyTrades) / dayDuration.Minutes()
+}
+
+// LogStats 输出统计日志
+func (tsm *TradingStatsManager) LogStats() {
+	stats := tsm.GetStats()
+
+	tsm.logger.Info("Trading Statistics Summary",
+		zap.Float64("daily_volume", stats.DailyVolume),
+		zap.Int("daily_trades", stats.DailyTrades),
+		zap.Float64("total_volume", stats.TotalVolume),
+		zap.Int("total_trades", stats.TotalTrades),
+		zap.String("current_phase", stats.CurrentPhase),
+		zap.Int("active_orders", stats.ActiveOrders),
+		zap.Float64("avg_trade_size", stats.AvgTradeSize),
+		zap.Float64("trade_frequency", stats.TradeFrequency),
+		zap.Float64("volume_progress", stats.VolumeProgress),
+		zap.Float64("daily_pnl", stats.DailyPnL),
+	)
+}
+
+// rollover 跨过日切边界时重置日统计 (调用方持有写锁)
+func (tsm *TradingStatsManager) rollover(now time.Time) {
+	if !tsm.isSameDay(now, tsm.stats.DailyStartTime) {
+		tsm.resetDailyStats(tsm.dayStart(now))
+	}
+}
+
+// resetDailyStats 重置日统计
+func (tsm *TradingStatsManager) resetDailyStats(newStartTime time.Time) {
+	tsm.logger.Info("Resetting daily stats",
+		zap.Float64("previous_daily_volume", tsm.stats.DailyVolume),
+		zap.Int("previous_daily_trades", tsm.stats.DailyTrades),
+		zap.Float64("previous_daily_pnl", tsm.stats.DailyPnL),
+	)
+
+	tsm.appendDailyPnL(tsm.lastPnL - tsm.dayStartPnL)
+	tsm.dayStartPnL = tsm.lastPnL
+	tsm.stats.DailyPnL = 0
+	tsm.stats.DailyVolume = 0
+	tsm.stats.DailyTrades = 0
+	tsm.stats.DailyStartTime = newStartTime
+	tsm.stats.VolumeProgress = 0
+}
+
+// appendDailyPnL 记录一个已结束交易日的盈亏，超过 maxDailyPnLHistory 天时丢弃最早的
+func (tsm *TradingStatsManager) appendDailyPnL(pnl float64) {
+	tsm.stats.DailyPnLHistory = append(tsm.stats.DailyPnLHistory, pnl)
+	if extra := len(tsm.stats.DailyPnLHistory) - maxDailyPnLHistory; extra > 0 {
+		tsm.stats.DailyPnLHistory = tsm.stats.DailyPnLHistory[extra:]
+	}
+}
+
+// isSameDay 检查两个时间是否属于同一个交易日 (按日切时区和时刻划分)
+func (tsm *TradingStatsManager) isSameDay(t1, t2 time.Time) bool {
+	return tsm.dayStart(t1).Equal(tsm.dayStart(t2))
+}
+
+// dayStart 返回时间所在交易日的开始时间
+func (tsm *TradingStatsManager) dayStart(t time.Time) time.Time {
+	local := t.In(tsm.location)
+	y, m, d := local.Date()
+	start := time.Date(y, m, d, tsm.resetHour, 0, 0, 0, tsm.location)
+	if start.After(local) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
@@ -0,0 +1,98 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TWAPExecutor 时间加权平均执行器：把目标金额均匀切分为子订单，在时间窗口内定时下达
+type TWAPExecutor struct {
+	window  time.Duration
+	slices  int
+	running int32
+	logger  *zap.Logger
+}
+
+// NewTWAPExecutor 创建TWAP执行器
+func NewTWAPExecutor(window time.Duration, slices int, logger *zap.Logger) *TWAPExecutor {
+	if slices < 1 {
+		slices = 1
+	}
+	return &TWAPExecutor{
+		window: window,
+		slices: slices,
+		logger: logger.Named("twap"),
+	}
+}
+
+// IsRunning 是否有TWAP任务在执行
+func (e *TWAPExecutor) IsRunning() bool {
+	return atomic.LoadInt32(&e.running) > 0
+}
+
+// Name 执行算法名称
+func (e *TWAPExecutor) Name() string {
+	return ExecutionAlgoTWAP
+}
+
+// Execute 在时间窗口内分片下达子订单，阻塞直到全部下达、出错或ctx取消
+func (e *TWAPExecutor) Execute(ctx context.Context, symbol string, target float64, place SliceFunc) (*ExecutionResult, error) {
+	atomic.AddInt32(&e.running, 1)
+	defer atomic.AddInt32(&e.running, -1)
+
+	start := time.Now()
+	result := &ExecutionResult{Target: target}
+	sliceNotional := target / float64(e.slices)
+
+	var interval time.Duration
+	if e.slices > 1 {
+		interval = e.window / time.Duration(e.slices-1)
+	}
+
+	e.logger.Info("Starting TWAP execution",
+		zap.String("symbol", symbol),
+		zap.Float64("target", target),
+		zap.Int("slices", e.slices),
+		zap.Float64("slice_notional", sliceNotional),
+		zap.Duration("window", e.window),
+		zap.Duration("interval", interval),
+	)
+
+	for i := 0; i < e.slices; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				result.Duration = time.Since(start)
+				return result, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		if err := place(ctx, i, sliceNotional); err != nil {
+			result.Duration = time.Since(start)
+			return result, fmt.Errorf("twap slice %d/%d failed: %w", i+1, e.slices, err)
+		}
+
+		result.Executed += sliceNotional
+		result.Slices++
+
+		e.logger.Debug("TWAP slice placed",
+			zap.Int("slice", i+1),
+			zap.Int("total_slices", e.slices),
+			zap.Float64("executed", result.Executed),
+		)
+	}
+
+	result.Duration = time.Since(start)
+	e.logger.Info("TWAP execution completed",
+		zap.Float64("executed", result.Executed),
+		zap.Int("slices", result.Slices),
+		zap.Duration("duration", result.Duration),
+	)
+
+	return result, nil
+}
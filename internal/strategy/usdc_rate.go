@@ -0,0 +1,115 @@
+package strategy
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// USDCRateTracker USDC/USDT汇率跟踪器：按 USDCRateRefresh 拉取Binance USDCUSDT现货价格。
+// Binance交易对以USDC计价、Lighter以USDT计价，对冲下单金额按该汇率换算，脱锚时两边名义金额保持一致
+type USDCRateTracker struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+
+	mu        sync.Mutex
+	rate      float64
+	updatedAt time.Time
+	stale     bool // 已记录过期告警，恢复后重新记录
+}
+
+// NewUSDCRateTracker 创建USDC/USDT汇率跟踪器
+func NewUSDCRateTracker(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *USDCRateTracker {
+	return &USDCRateTracker{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("usdc-rate"),
+	}
+}
+
+// Run 立即拉取一次，之后按 USDCRateRefresh 刷新，阻塞直到ctx取消或stop关闭
+func (rt *USDCRateTracker) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(rt.config.USDCRateRefresh)
+	defer ticker.Stop()
+
+	rt.logger.Info("USDC rate tracker started", zap.Duration("refresh", rt.config.USDCRateRefresh))
+
+	for {
+		rt.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh 拉取最新汇率，失败时保留上一次结果
+func (rt *USDCRateTracker) refresh(ctx context.Context) {
+	rate, err := rt.hedgeStrategy.binanceStrategy.client.GetUSDCRate(ctx)
+	if err != nil {
+		rt.logger.Warn("Failed to update USDC rate", zap.Error(err))
+		return
+	}
+
+	rt.mu.Lock()
+	previous := rt.rate
+	rt.rate = rate
+	rt.updatedAt = time.Now()
+	rt.stale = false
+	rt.mu.Unlock()
+
+	// 偏离1:1超过0.5%时记录告警，便于发现脱锚
+	if depegged(rate) && !depegged(previous) {
+		rt.logger.Warn("USDC deviates from USDT peg, hedge sizes adjusted", zap.Float64("rate", rate))
+	} else if !depegged(rate) && depegged(previous) {
+		rt.logger.Info("USDC back near USDT peg", zap.Float64("rate", rate))
+	}
+	rt.logger.Debug("USDC rate updated", zap.Float64("rate", rate))
+}
+
+// depegged 汇率偏离1:1超过0.5%，0为尚未获取
+func depegged(rate float64) bool {
+	return rate > 0 && (rate < 0.995 || rate > 1.005)
+}
+
+// Rate 返回最新汇率，尚未获取成功或超过3个刷新周期未更新时返回 false
+func (rt *USDCRateTracker) Rate() (float64, bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.rate <= 0 {
+		return 0, false
+	}
+	if time.Since(rt.updatedAt) > 3*rt.config.USDCRateRefresh {
+		if !rt.stale {
+			rt.stale = true
+			rt.logger.Warn("USDC rate is stale, falling back to 1:1",
+				zap.Float64("last_rate", rt.rate),
+				zap.Time("updated_at", rt.updatedAt),
+			)
+		}
+		return 0, false
+	}
+	return rt.rate, true
+}
+
+// lighterNotional 将Binance侧金额换算为Lighter下单金额 (USDT)：交易对以USDC计价时乘以USDC/USDT汇率，
+// 未启用汇率或汇率不可用时按1:1
+func (s *DynamicHedgeStrategy) lighterNotional(symbol string, amount float64) float64 {
+	if s.usdcRate == nil || !strings.HasSuffix(s.symbols.BinancePair(symbol), "USDC") {
+		return amount
+	}
+	rate, ok := s.usdcRate.Rate()
+	if !ok {
+		return amount
+	}
+	return amount * rate
+}
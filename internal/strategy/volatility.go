@@ -0,0 +1,199 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+)
+
+// klineIntervals 支持的K线周期
+var klineIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"3m":  3 * time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// VolatilityEstimate 币种的波动率估计
+type VolatilityEstimate struct {
+	Symbol     string    `json:"symbol"`
+	Interval   string    `json:"interval"`    // K线周期
+	Samples    int       `json:"samples"`     // 参与计算的收益率数量
+	Realized   float64   `json:"realized"`    // 每根K线对数收益率的标准差 (%)
+	Annualized float64   `json:"annualized"`  // 年化波动率 (%)
+	ATRPercent float64   `json:"atr_percent"` // 平均真实波幅占最新收盘价的比例 (%)
+	LastPrice  float64   `json:"last_price"`  // 最新收盘价
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// VolatilityTracker 滚动波动率：按 VolatilityRefresh 拉取各币种Binance最近 VolatilityWindow 根已收盘K线，
+// 计算对数收益率标准差 (已实现波动率) 和平均真实波幅，供下单规模和风控模块使用
+type VolatilityTracker struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+
+	mu        sync.RWMutex
+	estimates map[string]*VolatilityEstimate
+}
+
+// NewVolatilityTracker 创建滚动波动率
+func NewVolatilityTracker(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *VolatilityTracker {
+	return &VolatilityTracker{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("volatility"),
+		estimates:     make(map[string]*VolatilityEstimate),
+	}
+}
+
+// Run 立即计算一次，之后按 VolatilityRefresh 刷新，阻塞直到ctx取消或stop关闭
+func (vt *VolatilityTracker) Run(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(vt.config.VolatilityRefresh)
+	defer ticker.Stop()
+
+	vt.logger.Info("Volatility tracker started",
+		zap.String("interval", vt.config.VolatilityInterval),
+		zap.Int("window", vt.config.VolatilityWindow),
+		zap.Duration("refresh", vt.config.VolatilityRefresh),
+	)
+
+	for {
+		vt.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh 并发更新全部币种的波动率，失败的币种保留上一次结果
+func (vt *VolatilityTracker) refresh(ctx context.Context) {
+	_ = runPerSymbol(vt.hedgeStrategy.symbols.Specs(), func(spec SymbolSpec) error {
+		estimate, err := vt.estimate(ctx, spec.Symbol)
+		if err != nil {
+			vt.logger.Warn("Failed to update volatility", zap.String("symbol", spec.Symbol), zap.Error(err))
+			return err
+		}
+
+		vt.mu.Lock()
+		vt.estimates[spec.Symbol] = estimate
+		vt.mu.Unlock()
+
+		vt.logger.Debug("Volatility updated",
+			zap.String("symbol", spec.Symbol),
+			zap.Float64("realized", estimate.Realized),
+			zap.Float64("annualized", estimate.Annualized),
+			zap.Float64("atr_percent", estimate.ATRPercent),
+		)
+		return nil
+	})
+}
+
+// estimate 拉取K线并计算波动率。多取一根K线用于第一个收益率，最后一根未收盘的K线不参与计算
+func (vt *VolatilityTracker) estimate(ctx context.Context, symbol string) (*VolatilityEstimate, error) {
+	klines, err := vt.hedgeStrategy.binanceStrategy.client.GetKlines(ctx,
+		vt.hedgeStrategy.binanceStrategy.pair(symbol), vt.config.VolatilityInterval, vt.config.VolatilityWindow+2)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if n := len(klines); n > 0 && klines[n-1].CloseTime.After(now) {
+		klines = klines[:n-1]
+	}
+	if len(klines) < 3 {
+		return nil, fmt.Errorf("not enough klines: %d", len(klines))
+	}
+
+	realized, atr := realizedVolatility(klines), averageTrueRange(klines)
+	last := klines[len(klines)-1].Close
+
+	periodsPerYear := float64(365*24*time.Hour) / float64(klineIntervals[vt.config.VolatilityInterval])
+
+	return &VolatilityEstimate{
+		Symbol:     symbol,
+		Interval:   vt.config.VolatilityInterval,
+		Samples:    len(klines) - 1,
+		Realized:   realized * 100,
+		Annualized: realized * math.Sqrt(periodsPerYear) * 100,
+		ATRPercent: atr / last * 100,
+		LastPrice:  last,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// realizedVolatility 相邻收盘价对数收益率的样本标准差
+func realizedVolatility(klines []binance.Kline) float64 {
+	returns := make([]float64, 0, len(klines)-1)
+	for i := 1; i < len(klines); i++ {
+		if klines[i-1].Close <= 0 || klines[i].Close <= 0 {
+			continue
+		}
+		returns = append(returns, math.Log(klines[i].Close/klines[i-1].Close))
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	return math.Sqrt(variance / float64(len(returns)-1))
+}
+
+// averageTrueRange 平均真实波幅：max(最高-最低, |最高-前收|, |最低-前收|) 的平均值，第一根K线只作为前收
+func averageTrueRange(klines []binance.Kline) float64 {
+	var sum float64
+	for i := 1; i < len(klines); i++ {
+		k, prevClose := klines[i], klines[i-1].Close
+		sum += math.Max(k.High-k.Low, math.Max(math.Abs(k.High-prevClose), math.Abs(k.Low-prevClose)))
+	}
+	return sum / float64(len(klines)-1)
+}
+
+// Get 返回币种最近一次的波动率估计，尚未计算成功时返回 false
+func (vt *VolatilityTracker) Get(symbol string) (VolatilityEstimate, bool) {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	e, ok := vt.estimates[symbol]
+	if !ok {
+		return VolatilityEstimate{}, false
+	}
+	return *e, true
+}
+
+// Estimates 返回全部币种最近一次的波动率估计
+func (vt *VolatilityTracker) Estimates() map[string]VolatilityEstimate {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	result := make(map[string]VolatilityEstimate, len(vt.estimates))
+	for symbol, e := range vt.estimates {
+		result[symbol] = *e
+	}
+	return result
+}
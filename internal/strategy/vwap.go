@@ -0,0 +1,144 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// VolumeFunc 查询币种在 [start, end) 区间内的市场成交额
+type VolumeFunc func(ctx context.Context, symbol string, start, end time.Time) (float64, error)
+
+// VWAPExecutor 成交量加权执行器：按最近市场成交量调整每笔子订单的大小，
+// 成交活跃时多下、清淡时少下，最后一笔补齐剩余金额。
+type VWAPExecutor struct {
+	window   time.Duration
+	slices   int
+	lookback time.Duration
+	volume   VolumeFunc
+	running  int32
+	logger   *zap.Logger
+}
+
+// NewVWAPExecutor 创建VWAP执行器，lookback 为计算平均成交量的回看区间
+func NewVWAPExecutor(window time.Duration, slices int, lookback time.Duration, volume VolumeFunc, logger *zap.Logger) *VWAPExecutor {
+	if slices < 1 {
+		slices = 1
+	}
+	return &VWAPExecutor{
+		window:   window,
+		slices:   slices,
+		lookback: lookback,
+		volume:   volume,
+		logger:   logger.Named("vwap"),
+	}
+}
+
+// Name 执行算法名称
+func (e *VWAPExecutor) Name() string {
+	return ExecutionAlgoVWAP
+}
+
+// IsRunning 是否有VWAP任务在执行
+func (e *VWAPExecutor) IsRunning() bool {
+	return atomic.LoadInt32(&e.running) > 0
+}
+
+// Execute 在时间窗口内按成交量节奏下达子订单，阻塞直到全部下达、出错或ctx取消
+func (e *VWAPExecutor) Execute(ctx context.Context, symbol string, target float64, place SliceFunc) (*ExecutionResult, error) {
+	atomic.AddInt32(&e.running, 1)
+	defer atomic.AddInt32(&e.running, -1)
+
+	start := time.Now()
+	result := &ExecutionResult{Target: target}
+
+	var interval time.Duration
+	if e.slices > 1 {
+		interval = e.window / time.Duration(e.slices-1)
+	}
+
+	// 成交量统计粒度为1分钟K线
+	sampleWindow := interval
+	if sampleWindow < time.Minute {
+		sampleWindow = time.Minute
+	}
+
+	// 回看区间内每个采样窗口的平均成交量
+	var avgVolume float64
+	if total, err := e.volume(ctx, symbol, start.Add(-e.lookback), start); err != nil {
+		e.logger.Warn("Failed to get lookback volume, falling back to equal slices", zap.Error(err))
+	} else if e.lookback > 0 {
+		avgVolume = total / (float64(e.lookback) / float64(sampleWindow))
+	}
+
+	e.logger.Info("Starting VWAP execution",
+		zap.String("symbol", symbol),
+		zap.Float64("target", target),
+		zap.Int("slices", e.slices),
+		zap.Duration("window", e.window),
+		zap.Duration("interval", interval),
+		zap.Float64("avg_volume", avgVolume),
+	)
+
+	for i := 0; i < e.slices; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				result.Duration = time.Since(start)
+				return result, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		remaining := target - result.Executed
+		slicesLeft := e.slices - i
+		notional := e.sliceNotional(ctx, symbol, remaining, slicesLeft, avgVolume, sampleWindow)
+
+		if err := place(ctx, i, notional); err != nil {
+			result.Duration = time.Since(start)
+			return result, fmt.Errorf("vwap slice %d/%d failed: %w", i+1, e.slices, err)
+		}
+
+		result.Executed += notional
+		result.Slices++
+
+		e.logger.Debug("VWAP slice placed",
+			zap.Int("slice", i+1),
+			zap.Int("total_slices", e.slices),
+			zap.Float64("notional", notional),
+			zap.Float64("executed", result.Executed),
+		)
+	}
+
+	result.Duration = time.Since(start)
+	e.logger.Info("VWAP execution completed",
+		zap.Float64("executed", result.Executed),
+		zap.Int("slices", result.Slices),
+		zap.Duration("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// sliceNotional 根据最近成交量与平均成交量的比例计算本次子订单金额
+func (e *VWAPExecutor) sliceNotional(ctx context.Context, symbol string, remaining float64, slicesLeft int, avgVolume float64, sampleWindow time.Duration) float64 {
+	if slicesLeft <= 1 {
+		return remaining
+	}
+	equal := remaining / float64(slicesLeft)
+	if avgVolume <= 0 {
+		return equal
+	}
+
+	now := time.Now()
+	recent, err := e.volume(ctx, symbol, now.Add(-sampleWindow), now)
+	if err != nil || recent <= 0 {
+		return equal
+	}
+
+	// 假设剩余时段按平均成交量进行，本次分配占预期剩余成交量的比例
+	return remaining * recent / (recent + avgVolume*float64(slicesLeft-1))
+}
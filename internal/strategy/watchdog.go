@@ -0,0 +1,144 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 看门狗发现循环卡住后的处理方式
+const (
+	WatchdogActionRestart = "restart" // 取消卡住的循环 (中断进行中的请求) 并重新启动，重启次数用尽后退出
+	WatchdogActionExit    = "exit"    // 直接告警并让策略异常结束
+)
+
+// EventLoopStalled 监控循环超过 WatchdogStallTimeout 没有心跳
+const EventLoopStalled = "LOOP_STALLED"
+
+// heartbeat 循环心跳，记录最近一次完成迭代的时间
+type heartbeat struct {
+	last atomic.Int64 // UnixNano
+}
+
+func (h *heartbeat) beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// age 距最近一次心跳的时长，尚未心跳时返回0
+func (h *heartbeat) age() time.Duration {
+	last := h.last.Load()
+	if last == 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, last))
+}
+
+// watchedLoop 看门狗检查的循环
+type watchedLoop struct {
+	name        string
+	age         func() time.Duration
+	restart     func()
+	restarts    int       // 连续重启次数
+	lastRestart time.Time // 最近一次重启时间
+}
+
+// Watchdog 看门狗：按 WatchdogInterval 检查主监控循环和订单监控循环的心跳，
+// 超过 WatchdogStallTimeout 没有心跳时告警，并按 WatchdogAction 重启循环或让策略异常结束
+type Watchdog struct {
+	hedgeStrategy *DynamicHedgeStrategy
+	config        *DynamicHedgeConfig
+	logger        *zap.Logger
+	loops         []*watchedLoop
+}
+
+// NewWatchdog 创建看门狗
+func NewWatchdog(hedgeStrategy *DynamicHedgeStrategy, config *DynamicHedgeConfig) *Watchdog {
+	return &Watchdog{
+		hedgeStrategy: hedgeStrategy,
+		config:        config,
+		logger:        hedgeStrategy.logger.Named("watchdog"),
+		loops: []*watchedLoop{
+			{
+				name:    "monitoring-loop",
+				age:     hedgeStrategy.loopHeartbeat.age,
+				restart: hedgeStrategy.restartMonitoringLoop,
+			},
+			{
+				name:    "order-monitor",
+				age:     hedgeStrategy.orderMonitor.HeartbeatAge,
+				restart: hedgeStrategy.orderMonitor.Restart,
+			},
+		},
+	}
+}
+
+// Run 按 WatchdogInterval 检查心跳，阻塞直到ctx取消或stop关闭，需要退出时返回循环卡住的错误
+func (w *Watchdog) Run(ctx context.Context, stop <-chan struct{}) error {
+	ticker := time.NewTicker(w.config.WatchdogInterval)
+	defer ticker.Stop()
+
+	w.logger.Info("Watchdog started",
+		zap.Duration("interval", w.config.WatchdogInterval),
+		zap.Duration("stall_timeout", w.config.WatchdogStallTimeout),
+		zap.String("action", w.config.WatchdogAction),
+		zap.Int("max_restarts", w.config.WatchdogMaxRestarts),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			if err := w.check(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// check 检查全部循环，需要退出时返回错误
+func (w *Watchdog) check() error {
+	timeout := w.config.WatchdogStallTimeout
+	for _, loop := range w.loops {
+		age := loop.age()
+		if age < timeout {
+			// 重启后连续两倍超时时间没有再卡住，重启次数清零
+			if loop.restarts > 0 && time.Since(loop.lastRestart) >= 2*timeout {
+				loop.restarts = 0
+			}
+			continue
+		}
+
+		action := w.config.WatchdogAction
+		if action == WatchdogActionRestart && loop.restarts >= w.config.WatchdogMaxRestarts {
+			action = WatchdogActionExit
+		}
+
+		w.logger.Error("Loop stalled",
+			zap.String("loop", loop.name),
+			zap.Duration("stalled_for", age),
+			zap.String("action", action),
+			zap.Int("restarts", loop.restarts),
+		)
+		w.hedgeStrategy.bus.Publish("watchdog", EventLoopStalled, map[string]interface{}{
+			"loop":           loop.name,
+			"stalled_for_ms": age.Milliseconds(),
+			"action":         action,
+			"restarts":       loop.restarts,
+		})
+
+		if action == WatchdogActionExit {
+			return fmt.Errorf("%s stalled for %s", loop.name, age.Truncate(time.Second))
+		}
+
+		loop.restart()
+		loop.restarts++
+		loop.lastRestart = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// statusRecorder 记录处理函数写出的HTTP状态码，用于审计日志
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// control 包装控制接口：同一请求方对同一接口的POST请求间隔小于 admin.control_min_interval 时返回429，
+// 每次控制请求 (含被限流的请求) 都写入审计日志
+func (s *Server) control(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next(w, r)
+			return
+		}
+
+		start := time.Now()
+		who := requester(r)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		if wait := s.throttle(who+" "+r.URL.Path, start); wait > 0 {
+			rec.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(rec, http.StatusTooManyRequests, "too many control requests, retry in "+wait.Round(time.Millisecond).String())
+		} else {
+			next(rec, r)
+		}
+
+		fields := []zap.Field{
+			zap.String("requester", who),
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.String("query", r.URL.RawQuery),
+			zap.Int("status", rec.status),
+			zap.Duration("elapsed", time.Since(start)),
+		}
+		if rec.status >= http.StatusBadRequest {
+			s.audit.Warn("Control request rejected or failed", fields...)
+		} else {
+			s.audit.Info("Control request", fields...)
+		}
+	}
+}
+
+// throttle 检查并记录请求时间，返回还需等待的时长 (0为放行)。未配置最小间隔时不限流
+func (s *Server) throttle(key string, now time.Time) time.Duration {
+	interval := s.cfg.ControlMinInterval
+	if interval <= 0 {
+		return 0
+	}
+
+	s.limitMu.Lock()
+	defer s.limitMu.Unlock()
+
+	if last, ok := s.lastControl[key]; ok {
+		if wait := interval - now.Sub(last); wait > 0 {
+			return wait
+		}
+	}
+	s.lastControl[key] = now
+	return 0
+}
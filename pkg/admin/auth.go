@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/config"
+)
+
+// requesterKey 请求上下文中令牌名称的键
+type requesterKey struct{}
+
+// authorize 校验请求头 Authorization: Bearer <token>，令牌角色满足 role 时调用 next，
+// 令牌名称写入请求上下文 (见 requester)。未配置令牌时不鉴权
+func (s *Server) authorize(role string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(s.cfg.Tokens) == 0 {
+			next(w, r)
+			return
+		}
+
+		token, ok := s.lookupToken(r)
+		if !ok {
+			s.logger.Warn("Admin API request rejected: missing or invalid token",
+				zap.String("path", r.URL.Path),
+				zap.String("remote_addr", r.RemoteAddr),
+			)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		if role == config.AdminRoleControl && token.Role != config.AdminRoleControl {
+			s.logger.Warn("Admin API request rejected: token lacks control role",
+				zap.String("path", r.URL.Path),
+				zap.String("token", token.Name),
+				zap.String("remote_addr", r.RemoteAddr),
+			)
+			writeError(w, http.StatusForbidden, "token "+token.Name+" is not allowed to use control endpoints")
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), requesterKey{}, token.Name)))
+	})
+}
+
+// lookupToken 按请求头中的令牌查找配置，逐个做常量时间比较
+func (s *Server) lookupToken(r *http.Request) (config.AdminToken, bool) {
+	header := r.Header.Get("Authorization")
+	presented, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || presented == "" {
+		return config.AdminToken{}, false
+	}
+
+	var found config.AdminToken
+	matched := false
+	for _, t := range s.cfg.Tokens {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(t.Token)) == 1 {
+			found = t
+			matched = true
+		}
+	}
+	return found, matched
+}
+
+// requester 返回请求方标识：令牌名称，未配置令牌时为客户端证书的CN (mTLS) 或远端IP
+func requester(r *http.Request) string {
+	if name, ok := r.Context().Value(requesterKey{}).(string); ok {
+		return name
+	}
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return "cert:" + r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/logger"
+)
+
+// PprofServer 性能分析服务，独立于管理API监听，只应绑定本机地址
+type PprofServer struct {
+	cfg    *config.PprofConfig
+	server *http.Server
+	logger *zap.Logger
+}
+
+// NewPprofServer 创建性能分析服务
+func NewPprofServer(cfg *config.PprofConfig) *PprofServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return &PprofServer{
+		cfg: cfg,
+		server: &http.Server{
+			Addr:              cfg.Listen,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		logger: logger.Named("pprof"),
+	}
+}
+
+// Run 设置阻塞/锁竞争采样率并启动HTTP服务，阻塞直到ctx取消，监听失败时返回错误
+func (s *PprofServer) Run(ctx context.Context) error {
+	tlsConfig, err := newTLSConfig(&s.cfg.TLS)
+	if err != nil {
+		return fmt.Errorf("pprof tls: %w", err)
+	}
+	s.server.TLSConfig = tlsConfig
+
+	runtime.SetBlockProfileRate(s.cfg.BlockProfileRate)
+	runtime.SetMutexProfileFraction(s.cfg.MutexProfileFraction)
+
+	s.logger.Info("pprof listening",
+		zap.String("addr", s.cfg.Listen),
+		zap.Bool("tls", tlsConfig != nil),
+		zap.Int("block_profile_rate", s.cfg.BlockProfileRate),
+		zap.Int("mutex_profile_fraction", s.cfg.MutexProfileFraction),
+	)
+
+	if err := serve(ctx, s.server, s.logger); err != nil {
+		return fmt.Errorf("pprof server failed: %w", err)
+	}
+	return nil
+}
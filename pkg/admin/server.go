@@ -0,0 +1,390 @@
+// Package admin 提供运行时管理HTTP API，用于查询引擎状态、仓位和盈亏，以及触发紧急停止。
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/engine"
+	"cs-projects-backpack/pkg/logger"
+	"cs-projects-backpack/pkg/metrics"
+)
+
+// shutdownTimeout 退出时等待进行中请求完成的时间
+const shutdownTimeout = 5 * time.Second
+
+// Server 管理API服务
+type Server struct {
+	cfg    *config.AdminConfig
+	engine *engine.Engine
+	server *http.Server
+	logger *zap.Logger
+	audit  *zap.Logger // 控制操作审计日志
+
+	// 控制接口限流：请求方+接口 -> 最近一次放行的时间
+	limitMu     sync.Mutex
+	lastControl map[string]time.Time
+}
+
+// NewServer 创建管理API服务
+func NewServer(cfg *config.AdminConfig, eng *engine.Engine) *Server {
+	s := &Server{
+		cfg:         cfg,
+		engine:      eng,
+		logger:      logger.Named("admin"),
+		audit:       logger.Named("admin-audit"),
+		lastControl: make(map[string]time.Time),
+	}
+
+	// 配置令牌后查询接口需要 read 或 control 角色，会改变交易状态的接口需要 control 角色
+	mux := http.NewServeMux()
+	mux.Handle("/status", s.authorize(config.AdminRoleRead, s.handleStatus))
+	mux.Handle("/stats", s.authorize(config.AdminRoleRead, s.handleStats))
+	mux.Handle("/positions", s.authorize(config.AdminRoleRead, s.handlePositions))
+	mux.Handle("/pnl", s.authorize(config.AdminRoleRead, s.handlePnL))
+	mux.Handle("/shadow", s.authorize(config.AdminRoleRead, s.handleShadow))
+	mux.Handle("/rebalance", s.authorize(config.AdminRoleRead, s.handleRebalance))
+	mux.Handle("/rebalance/resume", s.authorize(config.AdminRoleControl, s.control(s.handleRebalanceResume)))
+	mux.Handle("/cycles", s.authorize(config.AdminRoleRead, s.handleCycles))
+	mux.Handle("/emergency-close/preview", s.authorize(config.AdminRoleRead, s.handleEmergencyClosePreview))
+	mux.Handle("/hedge-balance", s.authorize(config.AdminRoleRead, s.handleHedgeBalance))
+	mux.Handle("/hedge-balance/adjust", s.authorize(config.AdminRoleControl, s.control(s.handleHedgeBalanceAdjust)))
+	mux.Handle("/kill", s.authorize(config.AdminRoleControl, s.control(s.handleKill)))
+	mux.Handle("/pause", s.authorize(config.AdminRoleControl, s.control(s.handlePause)))
+	mux.Handle("/resume", s.authorize(config.AdminRoleControl, s.control(s.handleResume)))
+	mux.Handle("/metrics", s.authorize(config.AdminRoleRead, metrics.Handler().ServeHTTP))
+
+	s.server = &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return s
+}
+
+// Run 启动HTTP服务并阻塞，ctx取消后优雅关闭并返回nil，监听失败时返回错误
+func (s *Server) Run(ctx context.Context) error {
+	tlsConfig, err := newTLSConfig(&s.cfg.TLS)
+	if err != nil {
+		return fmt.Errorf("admin API tls: %w", err)
+	}
+	s.server.TLSConfig = tlsConfig
+
+	// 不是本机地址时必须鉴权，否则任何能访问该地址的人都可以调用 /kill 等控制接口
+	if s.cfg.Unauthenticated() {
+		return fmt.Errorf("admin API listens on %s beyond localhost without authentication, configure admin.tokens or admin.tls.client_ca_file", s.cfg.Listen)
+	}
+
+	s.logger.Info("Admin API listening",
+		zap.String("addr", s.cfg.Listen),
+		zap.Bool("tls", tlsConfig != nil),
+		zap.Bool("client_cert", s.cfg.TLS.ClientCAFile != ""),
+		zap.Int("tokens", len(s.cfg.Tokens)),
+	)
+
+	if err := serve(ctx, s.server, s.logger); err != nil {
+		return fmt.Errorf("admin API server failed: %w", err)
+	}
+	return nil
+}
+
+// serve 运行HTTP服务直到ctx取消，取消后在 shutdownTimeout 内优雅关闭。设置了 TLSConfig 时以HTTPS提供服务
+func serve(ctx context.Context, server *http.Server, log *zap.Logger) error {
+	errChan := make(chan error, 1)
+	go func() {
+		if server.TLSConfig != nil {
+			errChan <- server.ListenAndServeTLS("", "")
+			return
+		}
+		errChan <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Warn("Failed to shut down HTTP server", zap.Error(err))
+	}
+	if err := <-errChan; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Warn("HTTP server stopped with error", zap.Error(err))
+	}
+	return nil
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.engine.Status())
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	status := s.engine.Status()
+	if status.Stats == nil {
+		writeError(w, http.StatusNotFound, "stats not available for strategy "+status.Strategy)
+		return
+	}
+	writeJSON(w, http.StatusOK, status.Stats)
+}
+
+func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	positions := s.engine.Positions()
+	if positions == nil {
+		writeError(w, http.StatusNotFound, "positions not available")
+		return
+	}
+	writeJSON(w, http.StatusOK, positions)
+}
+
+func (s *Server) handlePnL(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	status := s.engine.Status()
+	if status.PnL == nil {
+		writeError(w, http.StatusNotFound, "pnl not available for strategy "+status.Strategy)
+		return
+	}
+	writeJSON(w, http.StatusOK, status.PnL)
+}
+
+func (s *Server) handleShadow(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	report := s.engine.ShadowReport()
+	if report == nil {
+		writeError(w, http.StatusNotFound, "shadow mode not enabled")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (s *Server) handleRebalance(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	plan := s.engine.RebalancePlan()
+	if plan == nil {
+		writeError(w, http.StatusNotFound, "collateral rebalance not enabled or not checked yet")
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
+
+// rebalanceResumeResponse 恢复自动提现接口返回
+type rebalanceResumeResponse struct {
+	Resumed       bool   `json:"resumed"`                  // 之前处于暂停状态
+	PreviousError string `json:"previous_error,omitempty"` // 暂停前的提现失败原因
+}
+
+// handleRebalanceResume 人工核对资金位置后恢复因提现失败暂停的自动提现
+func (s *Server) handleRebalanceResume(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	s.logger.Warn("Collateral auto withdraw resume requested via admin API",
+		zap.String("requester", requester(r)),
+		zap.String("remote_addr", r.RemoteAddr),
+	)
+
+	previous, err := s.engine.ResumeRebalance("admin API (" + requester(r) + ")")
+	if errors.Is(err, engine.ErrNoDynamicHedge) || errors.Is(err, engine.ErrRebalanceDisabled) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, rebalanceResumeResponse{Resumed: previous != "", PreviousError: previous})
+}
+
+func (s *Server) handleCycles(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	cycles := s.engine.HedgeCycles()
+	if cycles == nil {
+		writeError(w, http.StatusNotFound, "hedge cycles not available for strategy "+s.engine.Status().Strategy)
+		return
+	}
+	writeJSON(w, http.StatusOK, cycles)
+}
+
+// handleEmergencyClosePreview 预览紧急平仓将发送的市价单，不下单
+func (s *Server) handleEmergencyClosePreview(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	preview, err := s.engine.EmergencyClosePreview(r.Context())
+	if errors.Is(err, engine.ErrNoDynamicHedge) {
+		writeError(w, http.StatusNotFound, "emergency close preview not available for strategy "+s.engine.Status().Strategy)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, preview)
+}
+
+// handleHedgeBalance 查询两个交易所的仓位平衡状态
+func (s *Server) handleHedgeBalance(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	status, err := s.engine.HedgeBalance()
+	if errors.Is(err, engine.ErrNoDynamicHedge) {
+		writeError(w, http.StatusNotFound, "hedge balance not available for strategy "+s.engine.Status().Strategy)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// handleHedgeBalanceAdjust 立即执行一次仓位平衡调整，返回调整后的平衡状态
+func (s *Server) handleHedgeBalanceAdjust(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	s.logger.Warn("Hedge balance adjustment requested via admin API",
+		zap.String("requester", requester(r)),
+		zap.String("remote_addr", r.RemoteAddr),
+	)
+
+	status, err := s.engine.AdjustHedgeBalance(r.Context(), "admin API ("+requester(r)+")")
+	if errors.Is(err, engine.ErrNoDynamicHedge) {
+		writeError(w, http.StatusNotFound, "hedge balance not available for strategy "+s.engine.Status().Strategy)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// killResponse 紧急停止接口返回
+type killResponse struct {
+	KillSwitch engine.KillSwitchStatus `json:"kill_switch"`
+	Cancelled  int                     `json:"cancelled"`       // 本次撤销的挂单数量
+	Error      string                  `json:"error,omitempty"` // 撤单失败原因
+}
+
+// handleKill 触发紧急停止，可通过 reason 参数说明原因
+func (s *Server) handleKill(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		reason = "admin API"
+	}
+
+	s.logger.Warn("Kill switch requested via admin API",
+		zap.String("requester", requester(r)),
+		zap.String("remote_addr", r.RemoteAddr),
+		zap.String("reason", reason),
+	)
+
+	cancelled, err := s.engine.Kill(reason)
+	resp := killResponse{
+		KillSwitch: s.engine.Status().KillSwitch,
+		Cancelled:  cancelled,
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// pauseResponse 暂停/恢复接口返回
+type pauseResponse struct {
+	Paused bool   `json:"paused"`
+	Phase  string `json:"phase"`
+}
+
+// handlePause 暂停开新仓，已有订单的监控和对冲照常进行
+func (s *Server) handlePause(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	s.logger.Warn("Pause requested via admin API",
+		zap.String("requester", requester(r)),
+		zap.String("remote_addr", r.RemoteAddr),
+	)
+
+	if err := s.engine.Pause("admin API (" + requester(r) + ")"); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	status := s.engine.Status()
+	writeJSON(w, http.StatusOK, pauseResponse{Paused: status.Paused, Phase: status.Phase})
+}
+
+// handleResume 恢复开新仓
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	s.logger.Info("Resume requested via admin API",
+		zap.String("requester", requester(r)),
+		zap.String("remote_addr", r.RemoteAddr),
+	)
+
+	if err := s.engine.Resume("admin API (" + requester(r) + ")"); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	status := s.engine.Status()
+	writeJSON(w, http.StatusOK, pauseResponse{Paused: status.Paused, Phase: status.Phase})
+}
+
+// allowMethod 校验请求方法
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
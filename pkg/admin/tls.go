@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"cs-projects-backpack/pkg/config"
+)
+
+// newTLSConfig 按配置创建HTTPS设置：加载证书文件或通过ACME自动申请证书，配置客户端CA时要求客户端证书。
+// 未启用时返回nil
+func newTLSConfig(cfg *config.ServerTLS) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	var tlsConfig *tls.Config
+	if len(cfg.AutocertDomains) > 0 {
+		if err := os.MkdirAll(cfg.AutocertCacheDir, 0700); err != nil {
+			return nil, fmt.Errorf("failed to create autocert cache directory: %w", err)
+		}
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains...),
+			Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+			Email:      cfg.AutocertEmail,
+		}
+		// 通过TLS-ALPN-01验证域名，监听端口需要以443对外提供
+		tlsConfig = m.TLSConfig()
+	} else {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls certificate: %w", err)
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+	tlsConfig.MinVersion = tls.VersionTLS12
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client_ca_file %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
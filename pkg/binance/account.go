@@ -0,0 +1,153 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"go.uber.org/zap"
+)
+
+// stablecoins 按1:1计入账户权益的稳定币
+var stablecoins = map[string]bool{
+	"USDT":  true,
+	"USDC":  true,
+	"FDUSD": true,
+}
+
+// Position 合约/杠杆持仓
+type Position struct {
+	Symbol     string  // 内部币种符号，如 BTC
+	Size       float64 // 持仓数量 (正数做多，负数做空)
+	EntryPrice float64 // 开仓均价
+	MarkPrice  float64 // 标记价格
+
+	LiquidationPrice float64 // 强平价格 (仅合约市场，0为交易所未提供)
+}
+
+// getBalances 查询现货账户余额 (可用 + 冻结)，返回 asset -> 数量，忽略为0的资产
+func (c *Client) getBalances(ctx context.Context) (map[string]float64, error) {
+	account, err := call(ctx, c, c.limiter, weightAccount, "account", func(ctx context.Context) (*binance.Account, error) {
+		return c.client.NewGetAccountService().Do(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	balances := make(map[string]float64)
+	for _, balance := range account.Balances {
+		free, err := strconv.ParseFloat(balance.Free, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s free balance: %w", balance.Asset, err)
+		}
+		locked, err := strconv.ParseFloat(balance.Locked, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s locked balance: %w", balance.Asset, err)
+		}
+		if amount := free + locked; amount != 0 {
+			balances[balance.Asset] = amount
+		}
+	}
+
+	return balances, nil
+}
+
+// GetAccountEquity 获取账户权益 (USD)。合约市场为保证金余额 (含未实现盈亏)；
+// 现货/杠杆市场中稳定币按面值计算，已配置币种按当前价格折算 (杠杆市场为扣除借款后的净值)，其他资产忽略
+func (c *Client) GetAccountEquity(ctx context.Context) (float64, error) {
+	if c.isFutures() {
+		equity, err := c.getFuturesEquity(ctx)
+		if err != nil {
+			return 0, err
+		}
+		c.logger.Debug("Fetched Binance futures account equity", zap.Float64("equity", equity))
+		return equity, nil
+	}
+
+	var balances map[string]float64
+	var err error
+	if c.isMargin() {
+		balances, err = c.getMarginNetAssets(ctx)
+	} else {
+		balances, err = c.getBalances(ctx)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var equity float64
+	for asset, amount := range balances {
+		if stablecoins[asset] {
+			equity += amount
+		}
+	}
+
+	for pair, sym := range c.symbols {
+		amount, ok := balances[sym.Symbol]
+		if !ok {
+			continue
+		}
+		price, err := c.GetCurrentPrice(ctx, pair)
+		if err != nil {
+			return 0, fmt.Errorf("failed to value %s balance: %w", sym.Symbol, err)
+		}
+		equity += amount * price
+	}
+
+	c.logger.Debug("Fetched Binance account equity",
+		zap.String("market", c.market),
+		zap.Float64("equity", equity),
+	)
+
+	return equity, nil
+}
+
+// GetBaseBalances 获取已配置币种的现货余额 (可用 + 冻结)，返回 symbol -> 数量，
+// 未持有的币种返回0
+func (c *Client) GetBaseBalances(ctx context.Context) (map[string]float64, error) {
+	balances, err := c.getBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]float64, len(c.symbols))
+	for _, sym := range c.symbols {
+		result[sym.Symbol] = balances[sym.Symbol]
+	}
+
+	c.logger.Debug("Fetched Binance base balances", zap.Int("symbols", len(result)))
+
+	return result, nil
+}
+
+// HasPositions 交易所是否提供持仓 (合约市场和杠杆市场)，现货市场只能通过余额变化推算仓位
+func (c *Client) HasPositions() bool {
+	return c.isFutures() || c.isMargin()
+}
+
+// GetPositions 获取已配置币种的持仓，返回 symbol -> 持仓，无持仓的币种不返回。
+// 合约市场为合约持仓；杠杆市场为币种净值 (借币卖出后为负)，不提供开仓均价。
+// 现货市场不可用 (见 HasPositions)
+func (c *Client) GetPositions(ctx context.Context) (map[string]Position, error) {
+	var positions map[string]Position
+	var err error
+	switch {
+	case c.isFutures():
+		positions, err = c.getFuturesPositions(ctx)
+	case c.isMargin():
+		positions, err = c.getMarginPositions(ctx)
+	default:
+		return nil, fmt.Errorf("positions are not available on %s market", c.market)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Debug("Fetched Binance positions",
+		zap.String("market", c.market),
+		zap.Int("positions", len(positions)),
+	)
+
+	return positions, nil
+}
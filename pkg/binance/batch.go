@@ -0,0 +1,202 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/killswitch"
+)
+
+// 合约批量接口单次上限
+const (
+	maxFuturesBatchOrders = 5  // 批量下单
+	maxFuturesBatchCancel = 10 // 批量撤单
+)
+
+// MarketOrderRequest 批量市价单中的一笔订单
+type MarketOrderRequest struct {
+	Symbol     string  // 交易对，如 BTCUSDC
+	Side       string  // BUY, SELL
+	Quantity   float64 // 下单数量 (币)
+	ReduceOnly bool    // 同 PlaceMarketOrder
+}
+
+// BatchOrderResult 批量下单中单笔订单的结果，Err 不为空时 Order 为 nil
+type BatchOrderResult struct {
+	Request MarketOrderRequest
+	Order   *OrderStatus
+	Err     error
+}
+
+// PlaceMarketOrders 批量下市价单，结果与 reqs 一一对应，单笔失败不影响其他订单。
+// 合约市场按每批 maxFuturesBatchOrders 笔调用批量下单接口；现货和杠杆账户没有批量下单接口，逐笔下单
+func (c *Client) PlaceMarketOrders(ctx context.Context, reqs []MarketOrderRequest) []BatchOrderResult {
+	results := make([]BatchOrderResult, len(reqs))
+	for i, req := range reqs {
+		results[i].Request = req
+	}
+
+	if !c.isFutures() {
+		for i, req := range reqs {
+			results[i].Order, results[i].Err = c.PlaceMarketOrder(ctx, req.Symbol, req.Side, req.Quantity, req.ReduceOnly)
+		}
+		return results
+	}
+
+	c.logger.Warn("Placing futures market order batch", zap.Int("orders", len(reqs)))
+
+	// 低于最小数量的订单不提交，其余按批提交
+	var pending []int
+	for i, req := range reqs {
+		if err := c.checkMinQuantity(req.Symbol, c.formatQuantity(req.Symbol, req.Quantity)); err != nil {
+			results[i].Err = err
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	for start := 0; start < len(pending); start += maxFuturesBatchOrders {
+		batch := pending[start:min(start+maxFuturesBatchOrders, len(pending))]
+		c.placeFuturesMarketBatch(ctx, reqs, batch, results)
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			c.logger.Error("Failed to place market order in batch",
+				zap.Error(r.Err),
+				zap.String("symbol", r.Request.Symbol),
+				zap.String("side", r.Request.Side),
+			)
+			continue
+		}
+		c.logger.Info("Market order placed successfully",
+			zap.Int64("order_id", r.Order.OrderID),
+			zap.String("symbol", r.Request.Symbol),
+			zap.String("status", r.Order.Status),
+			zap.Float64("executed_qty", r.Order.ExecutedQty),
+			zap.Float64("avg_price", r.Order.Price),
+		)
+	}
+
+	return results
+}
+
+// placeFuturesMarketBatch 调用一次合约批量下单接口，结果写入 results 中 batch 对应的位置
+func (c *Client) placeFuturesMarketBatch(ctx context.Context, reqs []MarketOrderRequest, batch []int, results []BatchOrderResult) {
+	reduceOnly := true
+	orders := make([]*futures.CreateOrderService, len(batch))
+	for j, i := range batch {
+		req := reqs[i]
+		reduceOnly = reduceOnly && req.ReduceOnly
+		svc := c.futuresClient.NewCreateOrderService().
+			Symbol(req.Symbol).
+			Side(futures.SideType(req.Side)).
+			PositionSide(c.positionSide(req.Symbol)).
+			Type(futures.OrderTypeMarket).
+			Quantity(c.formatQuantity(req.Symbol, req.Quantity)).
+			NewOrderResponseType(futures.NewOrderRespTypeRESULT)
+		// 双向持仓模式不接受reduceOnly，由持仓方向保证只平仓
+		if req.ReduceOnly && !c.dualSidePosition {
+			svc = svc.ReduceOnly(true)
+		}
+		orders[j] = svc
+	}
+
+	// 整批都是只减仓单时在紧急停止后仍然放行
+	if reduceOnly {
+		ctx = killswitch.Exempt(ctx)
+	}
+
+	resp, err := callOrder(ctx, c, c.futuresLimiter, weightFuturesBatchOrders, "create futures batch orders", func(ctx context.Context) (*futures.CreateBatchOrdersResponse, error) {
+		return c.futuresClient.NewCreateBatchOrdersService().OrderList(orders).Do(ctx)
+	})
+	if err == nil && resp.N != len(batch) {
+		err = fmt.Errorf("batch order response has %d results for %d orders", resp.N, len(batch))
+	}
+	if err != nil {
+		for _, i := range batch {
+			results[i].Err = fmt.Errorf("failed to place market order: %w", err)
+		}
+		return
+	}
+
+	// 成功的订单按顺序放在 Orders 中，失败的订单在 Errors 的对应位置
+	next := 0
+	for j, i := range batch {
+		if resp.Errors[j] != nil {
+			results[i].Err = fmt.Errorf("failed to place market order: %w", resp.Errors[j])
+			continue
+		}
+		o := resp.Orders[next]
+		next++
+
+		avgPrice := o.AvgPrice
+		if avgPrice == "" {
+			avgPrice = "0"
+		}
+		results[i].Order, results[i].Err = newOrderStatus(o.OrderID, string(o.Status), avgPrice, o.ExecutedQuantity)
+	}
+}
+
+// CancelOrders 撤销交易对上的多个订单，返回已撤销的订单ID。单笔失败不影响其他订单，返回最后一个错误。
+// 合约市场按每批 maxFuturesBatchCancel 笔调用批量撤单接口；现货和杠杆账户逐笔撤单
+func (c *Client) CancelOrders(ctx context.Context, symbol string, orderIDs []int64) ([]int64, error) {
+	var cancelled []int64
+	var lastErr error
+
+	if !c.isFutures() {
+		for _, id := range orderIDs {
+			if err := c.CancelOrder(ctx, symbol, id); err != nil {
+				lastErr = err
+				continue
+			}
+			cancelled = append(cancelled, id)
+		}
+		return cancelled, lastErr
+	}
+
+	c.logger.Info("Cancelling futures order batch",
+		zap.String("symbol", symbol),
+		zap.Int64s("order_ids", orderIDs),
+	)
+
+	for start := 0; start < len(orderIDs); start += maxFuturesBatchCancel {
+		batch := orderIDs[start:min(start+maxFuturesBatchCancel, len(orderIDs))]
+		resp, err := call(ctx, c, c.futuresLimiter, weightFuturesBatchCancel, "cancel futures batch orders", func(ctx context.Context) ([]*futures.CancelOrderResponse, error) {
+			return c.futuresClient.NewCancelMultipleOrdersService().Symbol(symbol).OrderIDList(batch).Do(ctx)
+		})
+		if err != nil {
+			lastErr = fmt.Errorf("failed to cancel orders %v: %w", batch, err)
+			continue
+		}
+
+		// 撤单失败的订单在结果中为错误信息，解析后没有订单ID
+		done := make(map[int64]bool, len(resp))
+		for _, o := range resp {
+			if o.OrderID != 0 {
+				done[o.OrderID] = true
+			}
+		}
+		for _, id := range batch {
+			if done[id] {
+				cancelled = append(cancelled, id)
+			} else {
+				lastErr = fmt.Errorf("failed to cancel order %d", id)
+			}
+		}
+	}
+
+	if lastErr != nil {
+		c.logger.Error("Failed to cancel some orders in batch",
+			zap.Error(lastErr),
+			zap.String("symbol", symbol),
+			zap.Int("cancelled", len(cancelled)),
+			zap.Int("orders", len(orderIDs)),
+		)
+	}
+
+	return cancelled, lastErr
+}
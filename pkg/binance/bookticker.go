@@ -0,0 +1,225 @@
+package binance
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+// 行情推送断线重连的等待时间。连接保持超过 bookTickerStableAfter 后才重置退避，
+// 避免连上即断时每秒重连
+const (
+	bookTickerMinBackoff   = time.Second
+	bookTickerMaxBackoff   = 30 * time.Second
+	bookTickerStableAfter  = time.Minute
+	bookTickerSnapshotWait = 5 * time.Second // REST快照的超时时间
+)
+
+// StreamReconnectHandler 行情推送断线重连后调用，gap 为断线到重新连上的时长
+type StreamReconnectHandler func(gap time.Duration)
+
+// BookTicker 交易对的最优挂单价
+type BookTicker struct {
+	Symbol    string    `json:"symbol"`
+	BidPrice  float64   `json:"bid_price"`
+	BidQty    float64   `json:"bid_qty"`
+	AskPrice  float64   `json:"ask_price"`
+	AskQty    float64   `json:"ask_qty"`
+	UpdatedAt time.Time `json:"updated_at"` // 收到推送的本地时间
+}
+
+// Mid 买一卖一中间价
+func (t BookTicker) Mid() float64 {
+	return (t.BidPrice + t.AskPrice) / 2
+}
+
+// bookTickerCache 按交易对缓存最新的最优挂单价
+type bookTickerCache struct {
+	maxAge time.Duration
+
+	mu      sync.RWMutex
+	tickers map[string]BookTicker
+}
+
+// SetStreamReconnectHandler 设置行情推送重连后的回调，用于在断线期间可能错过的变化后立即核对订单。
+// 需在 StartBookTicker 之前设置
+func (c *Client) SetStreamReconnectHandler(fn StreamReconnectHandler) {
+	c.onReconnect = fn
+}
+
+// StartBookTicker 订阅已配置交易对的最优挂单价推送 (bookTicker)，在内存中维护价格缓存，
+// 断线后自动重连，直到ctx取消。缓存未超过 maxAge 时 GetCurrentPrice 和 GetOptimalPrice 直接使用缓存，
+// 否则回退到REST接口
+func (c *Client) StartBookTicker(ctx context.Context, maxAge time.Duration) {
+	pairs := make([]string, 0, len(c.symbols))
+	for pair := range c.symbols {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(pairs)
+	if len(pairs) == 0 {
+		return
+	}
+
+	c.bookTickers = &bookTickerCache{
+		maxAge:  maxAge,
+		tickers: make(map[string]BookTicker),
+	}
+
+	c.logger.Info("Starting book ticker stream",
+		zap.String("market", c.market),
+		zap.Strings("symbols", pairs),
+		zap.Duration("max_age", maxAge),
+	)
+
+	go c.runBookTicker(ctx, pairs)
+}
+
+// runBookTicker 维持行情推送连接，断线后按指数退避重连。每次连上后重新订阅全部交易对，
+// 并用REST快照刷新缓存，断线期间的价格变化不必等下一条推送
+func (c *Client) runBookTicker(ctx context.Context, pairs []string) {
+	backoff := bookTickerMinBackoff
+	var disconnectedAt time.Time // 上次断线时间，首次连接前为零值
+	for {
+		doneC, stopC, err := c.serveBookTicker(pairs)
+		if err != nil {
+			c.logger.Warn("Failed to connect book ticker stream", zap.Error(err), zap.Duration("retry_in", backoff))
+		} else {
+			connectedAt := time.Now()
+			c.snapshotBookTickers(ctx, pairs)
+			if !disconnectedAt.IsZero() {
+				gap := connectedAt.Sub(disconnectedAt)
+				c.logger.Info("Book ticker stream reconnected", zap.Duration("gap", gap))
+				if c.onReconnect != nil {
+					c.onReconnect(gap)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				close(stopC)
+				<-doneC
+				return
+			case <-doneC:
+				disconnectedAt = time.Now()
+				if disconnectedAt.Sub(connectedAt) >= bookTickerStableAfter {
+					backoff = bookTickerMinBackoff
+				}
+				c.logger.Warn("Book ticker stream disconnected", zap.Duration("retry_in", backoff))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, bookTickerMaxBackoff)
+	}
+}
+
+// serveBookTicker 建立一次行情推送连接，合约市场订阅合约行情，现货和杠杆账户订阅现货行情
+func (c *Client) serveBookTicker(pairs []string) (doneC, stopC chan struct{}, err error) {
+	errHandler := func(err error) {
+		c.logger.Debug("Book ticker stream error", zap.Error(err))
+	}
+
+	if c.isFutures() {
+		return futures.WsCombinedBookTickerServe(pairs, func(e *futures.WsBookTickerEvent) {
+			c.updateBookTicker(e.Symbol, e.BestBidPrice, e.BestBidQty, e.BestAskPrice, e.BestAskQty)
+		}, errHandler)
+	}
+	return binance.WsCombinedBookTickerServe(pairs, func(e *binance.WsBookTickerEvent) {
+		c.updateBookTicker(e.Symbol, e.BestBidPrice, e.BestBidQty, e.BestAskPrice, e.BestAskQty)
+	}, errHandler)
+}
+
+// snapshotBookTickers 通过REST接口拉取已配置交易对的最优挂单价写入缓存，失败时只记录告警，
+// 缓存等待后续推送更新
+func (c *Client) snapshotBookTickers(ctx context.Context, pairs []string) {
+	ctx, cancel := context.WithTimeout(ctx, bookTickerSnapshotWait)
+	defer cancel()
+
+	wanted := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		wanted[pair] = true
+	}
+
+	updated := 0
+	if c.isFutures() {
+		tickers, err := call(ctx, c, c.futuresLimiter, weightFuturesBookTickers, "futures book tickers", func(ctx context.Context) ([]*futures.BookTicker, error) {
+			return c.futuresClient.NewListBookTickersService().Do(ctx)
+		})
+		if err != nil {
+			c.logger.Warn("Failed to snapshot book tickers", zap.Error(err))
+			return
+		}
+		for _, t := range tickers {
+			if wanted[t.Symbol] {
+				c.updateBookTicker(t.Symbol, t.BidPrice, t.BidQuantity, t.AskPrice, t.AskQuantity)
+				updated++
+			}
+		}
+	} else {
+		tickers, err := call(ctx, c, c.limiter, weightBookTickers, "book tickers", func(ctx context.Context) ([]*binance.BookTicker, error) {
+			return c.client.NewListBookTickersService().Do(ctx)
+		})
+		if err != nil {
+			c.logger.Warn("Failed to snapshot book tickers", zap.Error(err))
+			return
+		}
+		for _, t := range tickers {
+			if wanted[t.Symbol] {
+				c.updateBookTicker(t.Symbol, t.BidPrice, t.BidQuantity, t.AskPrice, t.AskQuantity)
+				updated++
+			}
+		}
+	}
+
+	c.logger.Debug("Book ticker snapshot loaded", zap.Int("symbols", updated))
+}
+
+// updateBookTicker 解析推送并更新缓存，价格无效的推送丢弃
+func (c *Client) updateBookTicker(symbol, bidPrice, bidQty, askPrice, askQty string) {
+	bid, err1 := strconv.ParseFloat(bidPrice, 64)
+	ask, err2 := strconv.ParseFloat(askPrice, 64)
+	if err1 != nil || err2 != nil || bid <= 0 || ask <= 0 {
+		return
+	}
+	bq, _ := strconv.ParseFloat(bidQty, 64)
+	aq, _ := strconv.ParseFloat(askQty, 64)
+
+	cache := c.bookTickers
+	cache.mu.Lock()
+	cache.tickers[symbol] = BookTicker{
+		Symbol:    symbol,
+		BidPrice:  bid,
+		BidQty:    bq,
+		AskPrice:  ask,
+		AskQty:    aq,
+		UpdatedAt: time.Now(),
+	}
+	cache.mu.Unlock()
+}
+
+// GetBookTicker 返回缓存的最优挂单价。未启用推送、尚未收到推送或缓存超过有效期时返回 false
+func (c *Client) GetBookTicker(symbol string) (BookTicker, bool) {
+	cache := c.bookTickers
+	if cache == nil {
+		return BookTicker{}, false
+	}
+
+	cache.mu.RLock()
+	t, ok := cache.tickers[symbol]
+	cache.mu.RUnlock()
+
+	if !ok || time.Since(t.UpdatedAt) > cache.maxAge {
+		return BookTicker{}, false
+	}
+	return t, true
+}
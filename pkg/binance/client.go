@@ -2,20 +2,56 @@ package binance
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
 	"go.uber.org/zap"
 
+	"cs-projects-backpack/pkg/breaker"
 	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/killswitch"
 	"cs-projects-backpack/pkg/logger"
+	"cs-projects-backpack/pkg/retry"
+	"cs-projects-backpack/pkg/transport"
 )
 
 type Client struct {
-	client *binance.Client
-	config *config.BinanceConfig
-	logger *zap.Logger
+	client        *binance.Client
+	futuresClient *futures.Client // U本位合约接口 (资金费率，market为futures时用于交易)
+	market        string          // 交易市场: spot, futures, margin
+	config        *config.BinanceConfig
+	symbols       map[string]config.SymbolConfig // 按交易对索引的精度配置
+	logger        *zap.Logger
+
+	filtersMu sync.RWMutex
+	filters   map[string]*SymbolFilters // exchangeInfo 下单规则，优先于配置精度
+
+	limiter        *RateLimiter       // 现货接口权重限流
+	futuresLimiter *RateLimiter       // 合约接口权重限流
+	spotWeight     *usedWeight        // 交易所报告的现货已用权重
+	futuresWeight  *usedWeight        // 交易所报告的合约已用权重
+	throttling     atomic.Bool        // 是否处于自适应降频 (见 PollingSlowdown)
+	retryPolicy    retry.Policy       // 接口重试策略
+	queryTimeout   time.Duration      // 查询和撤单的单次请求超时 (0为不限制)
+	orderTimeout   time.Duration      // 下单的单次请求超时 (0为不限制)
+	breaker        *breaker.Breaker   // 连续失败熔断 (nil为不启用)
+	killSwitch     *killswitch.Switch // 紧急停止开关 (nil为不启用)
+
+	dualSidePosition bool // 合约账户是否为双向持仓模式 (见 InitFutures)
+
+	minSpreadPercent float64 // Maker挂单价差下限 (往返手续费 + 最小利润)，0为不调整
+
+	bookTickers *bookTickerCache       // WebSocket最优挂单价缓存 (nil为不启用，见 StartBookTicker)
+	onReconnect StreamReconnectHandler // 行情推送重连后的回调 (nil为不回调)
 }
 
 type OrderRequest struct {
@@ -25,55 +61,118 @@ type OrderRequest struct {
 	Price    string // 限价单价格，空字符串表示市价单
 }
 
-const (
-	BTCUSDCSymbol = "BTCUSDC"
-	ETHUSDCSymbol = "ETHUSDC"
-)
+// OrderStatus 订单状态
+type OrderStatus struct {
+	OrderID     int64
+	Status      string // NEW, PARTIALLY_FILLED, FILLED, CANCELED, ...
+	Price       float64
+	ExecutedQty float64 // 已成交数量 (币)
+}
+
+// 未配置交易对的默认精度
+const defaultPrecision = 4
 
-func NewClient(cfg *config.BinanceConfig) (*Client, error) {
+func NewClient(cfg *config.BinanceConfig, symbols []config.SymbolConfig) (*Client, error) {
 	log := logger.Named("binance-client")
 
 	if cfg.APIKey == "" || cfg.SecretKey == "" {
 		return nil, fmt.Errorf("binance API key and secret key are required")
 	}
 
-	// 设置测试网络
-	if cfg.Testnet {
-		binance.UseTestnet = true
-		log.Info("Using Binance testnet")
+	// 设置测试网络。现货和合约SDK各有一个全局开关，决定新建客户端的REST地址和WebSocket推送地址，
+	// 按配置同时设置，避免同一进程内后创建的客户端沿用之前的设置
+	binance.UseTestnet = cfg.Testnet
+	futures.UseTestnet = cfg.Testnet
+
+	httpClient, err := transport.NewHTTPClient(&cfg.Transport, "binance")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Binance HTTP client: %w", err)
 	}
+	// WebSocket推送不经过HTTP客户端，代理通过SDK全局设置，自定义DNS和TLS设置不生效
+	binance.SetWsProxyUrl(cfg.Transport.Proxy)
+	futures.SetWsProxyUrl(cfg.Transport.Proxy)
+
+	spotWeight := newUsedWeight(MarketSpot, spotWeightLimit)
+	futuresWeight := newUsedWeight(MarketFutures, futuresWeightLimit)
 
 	client := binance.NewClient(cfg.APIKey, cfg.SecretKey)
+	client.HTTPClient = spotWeight.wrap(httpClient)
+	futuresClient := binance.NewFuturesClient(cfg.APIKey, cfg.SecretKey)
+	futuresClient.HTTPClient = futuresWeight.wrap(httpClient)
+	if cfg.SpotBaseURL != "" {
+		client.BaseURL = strings.TrimRight(cfg.SpotBaseURL, "/")
+	}
+	if cfg.FuturesBaseURL != "" {
+		futuresClient.BaseURL = strings.TrimRight(cfg.FuturesBaseURL, "/")
+	}
+	if cfg.Testnet {
+		log.Info("Using Binance testnet",
+			zap.String("spot_base_url", client.BaseURL),
+			zap.String("futures_base_url", futuresClient.BaseURL),
+		)
+	}
+
+	pairs := make(map[string]config.SymbolConfig, len(symbols))
+	for _, sym := range symbols {
+		pairs[sym.BinancePair] = sym
+	}
+
+	market := cfg.Market
+	if market == "" {
+		market = MarketSpot
+	}
+
+	if proxy, _ := transport.ProxyURL(&cfg.Transport); proxy != nil {
+		log.Info("Using proxy for Binance", zap.String("proxy", proxy.Redacted()))
+	}
 
 	log.Info("Binance client initialized",
 		zap.Bool("testnet", cfg.Testnet),
+		zap.String("market", market),
+		zap.Int("symbols", len(pairs)),
 	)
 
 	return &Client{
-		client: client,
-		config: cfg,
-		logger: log,
+		client:         client,
+		futuresClient:  futuresClient,
+		market:         market,
+		config:         cfg,
+		symbols:        pairs,
+		logger:         log,
+		limiter:        NewRateLimiter(cfg.RequestWeightPerMinute),
+		futuresLimiter: NewRateLimiter(cfg.FuturesWeightPerMinute),
+		spotWeight:     spotWeight,
+		futuresWeight:  futuresWeight,
+		retryPolicy:    retry.DefaultPolicy(),
+		queryTimeout:   retry.DefaultQueryTimeout,
+		orderTimeout:   retry.DefaultOrderTimeout,
 	}, nil
 }
 
 // PlaceLimitOrder 下限价单 (作为Maker)
-func (c *Client) PlaceLimitOrder(ctx context.Context, req *OrderRequest) (*binance.CreateOrderResponse, error) {
+func (c *Client) PlaceLimitOrder(ctx context.Context, req *OrderRequest) (*OrderStatus, error) {
 	c.logger.Info("Placing limit order",
+		zap.String("market", c.market),
 		zap.String("symbol", req.Symbol),
 		zap.String("side", string(req.Side)),
 		zap.String("quantity", req.Quantity),
 		zap.String("price", req.Price),
 	)
 
-	order, err := c.client.NewCreateOrderService().
-		Symbol(req.Symbol).
-		Side(req.Side).
-		Type(binance.OrderTypeLimit).
-		TimeInForce(binance.TimeInForceTypeGTC). // Good Till Cancelled
-		Quantity(req.Quantity).
-		Price(req.Price).
-		Do(ctx)
+	if err := c.checkOrderFilters(req.Symbol, req.Quantity, req.Price); err != nil {
+		return nil, err
+	}
 
+	var order *OrderStatus
+	var err error
+	switch {
+	case c.isFutures():
+		order, err = c.placeFuturesLimitOrder(ctx, req)
+	case c.isMargin():
+		order, err = c.placeMarginLimitOrder(ctx, req)
+	default:
+		order, err = c.placeSpotLimitOrder(ctx, req)
+	}
 	if err != nil {
 		c.logger.Error("Failed to place limit order",
 			zap.Error(err),
@@ -93,23 +192,331 @@ func (c *Client) PlaceLimitOrder(ctx context.Context, req *OrderRequest) (*binan
 	return order, nil
 }
 
-// GetCurrentPrice 获取当前价格
+// placeSpotLimitOrder 在现货市场下限价单
+func (c *Client) placeSpotLimitOrder(ctx context.Context, req *OrderRequest) (*OrderStatus, error) {
+	order, err := callOrder(ctx, c, c.limiter, weightCreateOrder, "create order", func(ctx context.Context) (*binance.CreateOrderResponse, error) {
+		return c.client.NewCreateOrderService().
+			Symbol(req.Symbol).
+			Side(req.Side).
+			Type(binance.OrderTypeLimit).
+			TimeInForce(binance.TimeInForceTypeGTC). // Good Till Cancelled
+			Quantity(req.Quantity).
+			Price(req.Price).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
+
+// CancelOrder 撤销指定订单
+func (c *Client) CancelOrder(ctx context.Context, symbol string, orderID int64) error {
+	c.logger.Info("Cancelling order",
+		zap.String("symbol", symbol),
+		zap.Int64("order_id", orderID),
+	)
+
+	var err error
+	switch {
+	case c.isFutures():
+		err = c.cancelFuturesOrder(ctx, symbol, orderID)
+	case c.isMargin():
+		err = c.cancelMarginOrder(ctx, symbol, orderID)
+	default:
+		_, err = call(ctx, c, c.limiter, weightCancelOrder, "cancel order", func(ctx context.Context) (*binance.CancelOrderResponse, error) {
+			return c.client.NewCancelOrderService().
+				Symbol(symbol).
+				OrderID(orderID).
+				Do(ctx)
+		})
+	}
+	if err != nil {
+		c.logger.Error("Failed to cancel order",
+			zap.Error(err),
+			zap.String("symbol", symbol),
+			zap.Int64("order_id", orderID),
+		)
+		return fmt.Errorf("failed to cancel order %d: %w", orderID, err)
+	}
+
+	return nil
+}
+
+// CancelAllOpenOrders 撤销交易对上的全部挂单 (包括非本进程下的订单)，返回撤单数量
+func (c *Client) CancelAllOpenOrders(ctx context.Context, symbol string) (int, error) {
+	if c.isFutures() || c.isMargin() {
+		var cancelled int
+		var err error
+		if c.isFutures() {
+			cancelled, err = c.cancelAllFuturesOrders(ctx, symbol)
+		} else {
+			cancelled, err = c.cancelAllMarginOrders(ctx, symbol)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to cancel open orders for %s: %w", symbol, err)
+		}
+		if cancelled > 0 {
+			c.logger.Warn("Cancelled all open orders",
+				zap.String("symbol", symbol),
+				zap.Int("orders", cancelled),
+			)
+		}
+		return cancelled, nil
+	}
+
+	resp, err := call(ctx, c, c.limiter, weightCancelOpenOrders, "cancel open orders", func(ctx context.Context) (*binance.CancelOpenOrdersResponse, error) {
+		return c.client.NewCancelOpenOrdersService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		var apiErr *common.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == codeUnknownOrder {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to cancel open orders for %s: %w", symbol, err)
+	}
+
+	c.logger.Warn("Cancelled all open orders",
+		zap.String("symbol", symbol),
+		zap.Int("orders", len(resp.Orders)),
+	)
+
+	return len(resp.Orders), nil
+}
+
+// GetCurrentPrice 获取当前价格。最优挂单价缓存有效时返回买一卖一中间价，否则查询最新成交价
 func (c *Client) GetCurrentPrice(ctx context.Context, symbol string) (float64, error) {
-	ticker, err := c.client.NewListPricesService().Symbol(symbol).Do(ctx)
+	if t, ok := c.GetBookTicker(symbol); ok {
+		return t.Mid(), nil
+	}
+
+	var last string
+	var err error
+	if c.isFutures() {
+		last, err = c.getFuturesPrice(ctx, symbol)
+	} else {
+		last, err = c.getSpotPrice(ctx, symbol)
+	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to get price for %s: %w", symbol, err)
 	}
 
+	price, err := strconv.ParseFloat(last, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse price: %w", err)
+	}
+
+	return price, nil
+}
+
+// GetUSDCRate 获取USDCUSDT现货最新价格 (1 USDC可兑换的USDT)，合约和杠杆市场同样使用现货价格
+func (c *Client) GetUSDCRate(ctx context.Context) (float64, error) {
+	last, err := c.getSpotPrice(ctx, "USDCUSDT")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get USDCUSDT price: %w", err)
+	}
+
+	rate, err := strconv.ParseFloat(last, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse USDCUSDT price: %w", err)
+	}
+	if rate <= 0 {
+		return 0, fmt.Errorf("invalid USDCUSDT price: %s", last)
+	}
+	return rate, nil
+}
+
+// getSpotPrice 获取现货最新价格
+func (c *Client) getSpotPrice(ctx context.Context, symbol string) (string, error) {
+	ticker, err := call(ctx, c, c.limiter, weightTickerPrice, "ticker price", func(ctx context.Context) ([]*binance.SymbolPrice, error) {
+		return c.client.NewListPricesService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
 	if len(ticker) == 0 {
-		return 0, fmt.Errorf("no price data for %s", symbol)
+		return "", fmt.Errorf("no price data for %s", symbol)
 	}
+	return ticker[0].Price, nil
+}
 
-	price, err := strconv.ParseFloat(ticker[0].Price, 64)
+// GetDepthNotional 获取距最优价 withinPercent 范围内的挂单名义金额。
+// side 为BUY时统计卖盘 (买单吃掉的流动性)，为SELL时统计买盘。
+func (c *Client) GetDepthNotional(ctx context.Context, symbol, side string, withinPercent float64) (float64, error) {
+	levels, err := c.depthLevels(ctx, symbol, side)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse price: %w", err)
+		return 0, err
 	}
 
-	return price, nil
+	best, _, err := levels[0].Parse()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse depth level: %w", err)
+	}
+
+	var notional float64
+	for i := range levels {
+		price, quantity, err := levels[i].Parse()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse depth level: %w", err)
+		}
+		if math.Abs(price-best)/best*100 > withinPercent {
+			break
+		}
+		notional += price * quantity
+	}
+
+	return notional, nil
+}
+
+// EstimateMarketFill 按当前盘口 (前100档) 估算市价单逐档成交的均价，返回最优价和成交均价。
+// side 为市价单方向，盘口深度不足以成交 quantity 时返回错误
+func (c *Client) EstimateMarketFill(ctx context.Context, symbol, side string, quantity float64) (best, avg float64, err error) {
+	levels, err := c.depthLevels(ctx, symbol, side)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var filled, cost float64
+	for i := range levels {
+		price, available, err := levels[i].Parse()
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to parse depth level: %w", err)
+		}
+		if i == 0 {
+			best = price
+		}
+		take := math.Min(available, quantity-filled)
+		filled += take
+		cost += take * price
+		if filled >= quantity {
+			return best, cost / filled, nil
+		}
+	}
+
+	return best, 0, fmt.Errorf("insufficient depth for %s: %.8f of %.8f", symbol, filled, quantity)
+}
+
+// depthLevels 获取市价单 side 方向会吃掉的盘口 (前100档，最优价在前)：BUY为卖盘，SELL为买盘
+func (c *Client) depthLevels(ctx context.Context, symbol, side string) ([]common.PriceLevel, error) {
+	var bids, asks []common.PriceLevel
+	var err error
+	if c.isFutures() {
+		bids, asks, err = c.getFuturesDepth(ctx, symbol)
+	} else {
+		var depth *binance.DepthResponse
+		depth, err = call(ctx, c, c.limiter, weightDepth100, "depth", func(ctx context.Context) (*binance.DepthResponse, error) {
+			return c.client.NewDepthService().Symbol(symbol).Limit(100).Do(ctx)
+		})
+		if err == nil {
+			bids, asks = depth.Bids, depth.Asks
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get depth for %s: %w", symbol, err)
+	}
+
+	levels := bids
+	if side == string(binance.SideTypeBuy) {
+		levels = asks
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("empty order book for %s", symbol)
+	}
+	return levels, nil
+}
+
+// GetQuoteVolume 获取 [start, end) 区间内的成交额 (计价币)，按1分钟K线累加
+func (c *Client) GetQuoteVolume(ctx context.Context, symbol string, start, end time.Time) (float64, error) {
+	var volumes []string
+	var err error
+	if c.isFutures() {
+		volumes, err = c.getFuturesQuoteVolumes(ctx, symbol, start, end)
+	} else {
+		volumes, err = c.getSpotQuoteVolumes(ctx, symbol, start, end)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get klines for %s: %w", symbol, err)
+	}
+
+	var volume float64
+	for _, quoteVolume := range volumes {
+		v, err := strconv.ParseFloat(quoteVolume, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse kline volume: %w", err)
+		}
+		volume += v
+	}
+
+	return volume, nil
+}
+
+// getSpotQuoteVolumes 获取现货1分钟K线成交额
+func (c *Client) getSpotQuoteVolumes(ctx context.Context, symbol string, start, end time.Time) ([]string, error) {
+	klines, err := call(ctx, c, c.limiter, weightKlines, "klines", func(ctx context.Context) ([]*binance.Kline, error) {
+		return c.client.NewKlinesService().
+			Symbol(symbol).
+			Interval("1m").
+			StartTime(start.UnixMilli()).
+			EndTime(end.UnixMilli()).
+			Limit(1000).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]string, 0, len(klines))
+	for _, k := range klines {
+		volumes = append(volumes, k.QuoteAssetVolume)
+	}
+	return volumes, nil
+}
+
+// GetFundingRate 获取永续合约最新资金费率 (每8小时结算一次)
+func (c *Client) GetFundingRate(ctx context.Context, symbol string) (float64, error) {
+	indexes, err := call(ctx, c, c.futuresLimiter, weightPremiumIndex, "premium index", func(ctx context.Context) ([]*futures.PremiumIndex, error) {
+		return c.futuresClient.NewPremiumIndexService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get funding rate for %s: %w", symbol, err)
+	}
+
+	if len(indexes) == 0 {
+		return 0, fmt.Errorf("no funding rate data for %s", symbol)
+	}
+
+	rate, err := strconv.ParseFloat(indexes[0].LastFundingRate, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// GetMarkIndexPrice 获取永续合约的标记价格和指数价格
+func (c *Client) GetMarkIndexPrice(ctx context.Context, symbol string) (mark, index float64, err error) {
+	indexes, err := call(ctx, c, c.futuresLimiter, weightPremiumIndex, "premium index", func(ctx context.Context) ([]*futures.PremiumIndex, error) {
+		return c.futuresClient.NewPremiumIndexService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get mark price for %s: %w", symbol, err)
+	}
+
+	if len(indexes) == 0 {
+		return 0, 0, fmt.Errorf("no mark price data for %s", symbol)
+	}
+
+	mark, err = strconv.ParseFloat(indexes[0].MarkPrice, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse mark price: %w", err)
+	}
+	index, err = strconv.ParseFloat(indexes[0].IndexPrice, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse index price: %w", err)
+	}
+
+	return mark, index, nil
 }
 
 // CalculateQuantityFromUSDC 根据USDC数量计算对应的币种数量
@@ -121,18 +528,7 @@ func (c *Client) CalculateQuantityFromUSDC(ctx context.Context, symbol string, u
 
 	quantity := usdcAmount / price
 
-	// 根据币种调整精度
-	var precision int
-	switch symbol {
-	case BTCUSDCSymbol:
-		precision = 6 // BTC通常保留6位小数
-	case ETHUSDCSymbol:
-		precision = 5 // ETH通常保留5位小数
-	default:
-		precision = 4 // 默认4位小数
-	}
-
-	quantityStr := fmt.Sprintf("%."+strconv.Itoa(precision)+"f", quantity)
+	quantityStr := c.formatQuantity(symbol, quantity)
 
 	c.logger.Debug("Calculated quantity",
 		zap.String("symbol", symbol),
@@ -144,11 +540,35 @@ func (c *Client) CalculateQuantityFromUSDC(ctx context.Context, symbol string, u
 	return quantityStr, nil
 }
 
-// GetOptimalPrice 获取最优挂单价格 (作为Maker)
+// SetMinSpreadPercent 设置Maker挂单价差下限 (%)，配置的价差低于下限时按下限挂单，0为不调整
+func (c *Client) SetMinSpreadPercent(percent float64) {
+	c.minSpreadPercent = percent
+}
+
+// GetOptimalPrice 获取最优挂单价格 (作为Maker)。设置了价差下限时，价差至少放宽到下限，
+// 保证挂单成交后不会因手续费必然亏损。最优挂单价缓存有效时买单以买一价、卖单以卖一价为基准，
+// 否则以最新成交价为基准
 func (c *Client) GetOptimalPrice(ctx context.Context, symbol string, side binance.SideType, spreadPercent float64) (string, error) {
-	currentPrice, err := c.GetCurrentPrice(ctx, symbol)
-	if err != nil {
-		return "", err
+	var currentPrice float64
+	if t, ok := c.GetBookTicker(symbol); ok {
+		currentPrice = t.AskPrice
+		if side == binance.SideTypeBuy {
+			currentPrice = t.BidPrice
+		}
+	} else {
+		var err error
+		if currentPrice, err = c.GetCurrentPrice(ctx, symbol); err != nil {
+			return "", err
+		}
+	}
+
+	if spreadPercent < c.minSpreadPercent {
+		c.logger.Debug("Widening spread to fee-adjusted minimum",
+			zap.String("symbol", symbol),
+			zap.Float64("spread_percent", spreadPercent),
+			zap.Float64("min_spread_percent", c.minSpreadPercent),
+		)
+		spreadPercent = c.minSpreadPercent
 	}
 
 	var optimalPrice float64
@@ -160,18 +580,7 @@ func (c *Client) GetOptimalPrice(ctx context.Context, symbol string, side binanc
 		optimalPrice = currentPrice * (1 + spreadPercent/100)
 	}
 
-	// 价格精度处理
-	var pricePrecision int
-	switch symbol {
-	case BTCUSDCSymbol:
-		pricePrecision = 2 // BTC/USDC 价格保留2位小数
-	case ETHUSDCSymbol:
-		pricePrecision = 2 // ETH/USDC 价格保留2位小数
-	default:
-		pricePrecision = 4 // 默认4位小数
-	}
-
-	priceStr := fmt.Sprintf("%."+strconv.Itoa(pricePrecision)+"f", optimalPrice)
+	priceStr := c.formatPrice(symbol, side, optimalPrice)
 
 	c.logger.Debug("Calculated optimal price",
 		zap.String("symbol", symbol),
@@ -184,28 +593,23 @@ func (c *Client) GetOptimalPrice(ctx context.Context, symbol string, side binanc
 	return priceStr, nil
 }
 
-// PlaceBTCShort 做空BTC (卖出BTC)
-func (c *Client) PlaceBTCShort(ctx context.Context, usdcAmount float64, spreadPercent float64) (*binance.CreateOrderResponse, error) {
-	c.logger.Info("Placing BTC short order",
-		zap.Float64("usdc_amount", usdcAmount),
-		zap.Float64("spread_percent", spreadPercent),
-	)
+// PlaceMakerOrder 按USDC金额在指定交易对挂Maker限价单，side为BUY/SELL
+func (c *Client) PlaceMakerOrder(ctx context.Context, symbol, side string, usdcAmount float64, spreadPercent float64) (*OrderStatus, error) {
+	sideType := binance.SideType(side)
 
-	// 计算数量
-	quantity, err := c.CalculateQuantityFromUSDC(ctx, BTCUSDCSymbol, usdcAmount)
+	quantity, err := c.CalculateQuantityFromUSDC(ctx, symbol, usdcAmount)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate BTC quantity: %w", err)
+		return nil, fmt.Errorf("failed to calculate %s quantity: %w", symbol, err)
 	}
 
-	// 获取最优价格 (作为Maker)
-	price, err := c.GetOptimalPrice(ctx, BTCUSDCSymbol, binance.SideTypeSell, spreadPercent)
+	price, err := c.GetOptimalPrice(ctx, symbol, sideType, spreadPercent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get optimal price: %w", err)
 	}
 
 	req := &OrderRequest{
-		Symbol:   BTCUSDCSymbol,
-		Side:     binance.SideTypeSell,
+		Symbol:   symbol,
+		Side:     sideType,
 		Quantity: quantity,
 		Price:    price,
 	}
@@ -213,31 +617,121 @@ func (c *Client) PlaceBTCShort(ctx context.Context, usdcAmount float64, spreadPe
 	return c.PlaceLimitOrder(ctx, req)
 }
 
-// PlaceETHLong 做多ETH (买入ETH)
-func (c *Client) PlaceETHLong(ctx context.Context, usdcAmount float64, spreadPercent float64) (*binance.CreateOrderResponse, error) {
-	c.logger.Info("Placing ETH long order",
-		zap.Float64("usdc_amount", usdcAmount),
-		zap.Float64("spread_percent", spreadPercent),
-	)
+// PlaceLimitOrderAt 按USDC金额在指定价格挂限价单，side为BUY/SELL
+func (c *Client) PlaceLimitOrderAt(ctx context.Context, symbol, side string, usdcAmount, price float64) (*OrderStatus, error) {
+	if price <= 0 {
+		return nil, fmt.Errorf("invalid price for %s: %f", symbol, price)
+	}
+
+	sideType := binance.SideType(side)
+	req := &OrderRequest{
+		Symbol:   symbol,
+		Side:     sideType,
+		Quantity: c.formatQuantity(symbol, usdcAmount/price),
+		Price:    c.formatPrice(symbol, sideType, price),
+	}
+
+	return c.PlaceLimitOrder(ctx, req)
+}
 
-	// 计算数量
-	quantity, err := c.CalculateQuantityFromUSDC(ctx, ETHUSDCSymbol, usdcAmount)
+// GetOrder 查询订单状态
+func (c *Client) GetOrder(ctx context.Context, symbol string, orderID int64) (*OrderStatus, error) {
+	var status *OrderStatus
+	var err error
+	switch {
+	case c.isFutures():
+		status, err = c.getFuturesOrder(ctx, symbol, orderID)
+	case c.isMargin():
+		status, err = c.getMarginOrder(ctx, symbol, orderID)
+	default:
+		var order *binance.Order
+		order, err = call(ctx, c, c.limiter, weightGetOrder, "get order", func(ctx context.Context) (*binance.Order, error) {
+			return c.client.NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(ctx)
+		})
+		if err == nil {
+			status, err = newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate ETH quantity: %w", err)
+		return nil, fmt.Errorf("failed to get order %d: %w", orderID, err)
 	}
 
-	// 获取最优价格 (作为Maker)
-	price, err := c.GetOptimalPrice(ctx, ETHUSDCSymbol, binance.SideTypeBuy, spreadPercent)
+	return status, nil
+}
+
+// newOrderStatus 解析现货/合约接口返回的订单字段
+func newOrderStatus(orderID int64, status, price, executedQty string) (*OrderStatus, error) {
+	qty, err := strconv.ParseFloat(executedQty, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get optimal price: %w", err)
+		return nil, fmt.Errorf("failed to parse executed quantity: %w", err)
 	}
 
-	req := &OrderRequest{
-		Symbol:   ETHUSDCSymbol,
-		Side:     binance.SideTypeBuy,
-		Quantity: quantity,
-		Price:    price,
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse order price: %w", err)
 	}
 
-	return c.PlaceLimitOrder(ctx, req)
+	return &OrderStatus{
+		OrderID:     orderID,
+		Status:      status,
+		Price:       p,
+		ExecutedQty: qty,
+	}, nil
+}
+
+// formatQuantity 按交易对步长向下取整并格式化下单数量，未加载exchangeInfo时使用配置精度
+func (c *Client) formatQuantity(symbol string, quantity float64) string {
+	if f, ok := c.GetSymbolFilters(symbol); ok && f.StepSize > 0 {
+		return strconv.FormatFloat(floorToStep(quantity, f.StepSize), 'f', f.quantityPrecision, 64)
+	}
+
+	precision := defaultPrecision
+	if sym, ok := c.symbols[symbol]; ok {
+		precision = sym.QuantityPrecision
+	}
+	return strconv.FormatFloat(quantity, 'f', precision, 64)
+}
+
+// formatPrice 按交易对价格步长格式化价格：买单向下、卖单向上取整，保证仍为Maker价格
+func (c *Client) formatPrice(symbol string, side binance.SideType, price float64) string {
+	if f, ok := c.GetSymbolFilters(symbol); ok && f.TickSize > 0 {
+		if side == binance.SideTypeSell {
+			price = ceilToStep(price, f.TickSize)
+		} else {
+			price = floorToStep(price, f.TickSize)
+		}
+		return strconv.FormatFloat(price, 'f', f.pricePrecision, 64)
+	}
+
+	precision := defaultPrecision
+	if sym, ok := c.symbols[symbol]; ok {
+		precision = sym.PricePrecision
+	}
+	return strconv.FormatFloat(price, 'f', precision, 64)
+}
+
+// checkOrderFilters 校验下单数量和金额满足交易所最小限制
+func (c *Client) checkOrderFilters(symbol, quantity, price string) error {
+	f, ok := c.GetSymbolFilters(symbol)
+	if !ok {
+		return nil
+	}
+
+	qty, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return fmt.Errorf("invalid quantity %q: %w", quantity, err)
+	}
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return fmt.Errorf("invalid price %q: %w", price, err)
+	}
+
+	if qty <= 0 || qty < f.MinQty {
+		return fmt.Errorf("%s quantity %s below minimum %g", symbol, quantity, f.MinQty)
+	}
+	if f.MinNotional > 0 && qty*p < f.MinNotional {
+		return fmt.Errorf("%s order notional %.4f below minimum %g", symbol, qty*p, f.MinNotional)
+	}
+
+	return nil
 }
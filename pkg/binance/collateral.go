@@ -0,0 +1,273 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+// GetFreeCollateral 获取在交易对上按 side 下单可用的保证金 (USD) 和下单杠杆，开仓前用于检查余额：
+//   - 合约市场: 可用保证金和该交易对的当前杠杆，联合保证金模式为账户可用保证金，单币保证金模式为计价币的可用余额
+//   - 杠杆市场: 下单花费的资产 (买入为计价币，卖出为基础币) 的可用余额加最大可借数量，杠杆为1
+//   - 现货市场: 下单花费的资产的可用余额，杠杆为1
+//
+// 基础币按当前价格折算为USD
+func (c *Client) GetFreeCollateral(ctx context.Context, symbol, side string) (free, leverage float64, err error) {
+	switch {
+	case c.isFutures():
+		free, leverage, err = c.getFuturesFreeCollateral(ctx, symbol)
+	case c.isMargin():
+		free, err = c.spendableValue(ctx, symbol, side, c.getMarginSpendable)
+		leverage = 1
+	default:
+		free, err = c.spendableValue(ctx, symbol, side, c.getSpotFreeBalance)
+		leverage = 1
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	c.logger.Debug("Fetched Binance free collateral",
+		zap.String("market", c.market),
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.Float64("free", free),
+		zap.Float64("leverage", leverage),
+	)
+	return free, leverage, nil
+}
+
+// getFuturesFreeCollateral 获取合约账户可用保证金和交易对的当前杠杆
+func (c *Client) getFuturesFreeCollateral(ctx context.Context, symbol string) (float64, float64, error) {
+	account, err := call(ctx, c, c.futuresLimiter, weightFuturesAccount, "futures account", func(ctx context.Context) (*futures.Account, error) {
+		return c.futuresClient.NewGetAccountService().Do(ctx)
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get futures account: %w", err)
+	}
+
+	// 单币保证金模式下账户汇总字段只统计USDT，按交易对的计价币取可用余额
+	available := account.AvailableBalance
+	if !account.MultiAssetsMargin {
+		available = "0"
+		if sym, ok := c.symbols[symbol]; ok {
+			quote := strings.TrimPrefix(symbol, sym.Symbol)
+			for _, a := range account.Assets {
+				if a.Asset == quote {
+					available = a.AvailableBalance
+				}
+			}
+		}
+	}
+	free, err := strconv.ParseFloat(available, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse available balance: %w", err)
+	}
+
+	leverage := 1.0
+	for _, p := range account.Positions {
+		if p.Symbol != symbol {
+			continue
+		}
+		if v, err := strconv.ParseFloat(p.Leverage, 64); err == nil && v > 0 {
+			leverage = v
+		}
+		break
+	}
+	return free, leverage, nil
+}
+
+// spendableValue 按 side 取下单花费的资产 (买入为计价币，卖出为基础币)，查询可用数量并折算为USD
+func (c *Client) spendableValue(ctx context.Context, symbol, side string,
+	spendable func(ctx context.Context, symbol, asset string) (float64, error)) (float64, error) {
+	sym, ok := c.symbols[symbol]
+	if !ok {
+		return 0, fmt.Errorf("symbol %s is not configured", symbol)
+	}
+
+	if side == string(binance.SideTypeBuy) {
+		return spendable(ctx, symbol, strings.TrimPrefix(symbol, sym.Symbol))
+	}
+
+	amount, err := spendable(ctx, symbol, sym.Symbol)
+	if err != nil {
+		return 0, err
+	}
+	if amount == 0 {
+		return 0, nil
+	}
+	price, err := c.GetCurrentPrice(ctx, symbol)
+	if err != nil {
+		return 0, fmt.Errorf("failed to value %s balance: %w", sym.Symbol, err)
+	}
+	return amount * price, nil
+}
+
+// getSpotFreeBalance 查询现货账户资产的可用数量 (不含冻结)
+func (c *Client) getSpotFreeBalance(ctx context.Context, _ string, asset string) (float64, error) {
+	account, err := call(ctx, c, c.limiter, weightAccount, "account", func(ctx context.Context) (*binance.Account, error) {
+		return c.client.NewGetAccountService().Do(ctx)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	for _, balance := range account.Balances {
+		if balance.Asset != asset {
+			continue
+		}
+		free, err := strconv.ParseFloat(balance.Free, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s free balance: %w", asset, err)
+		}
+		return free, nil
+	}
+	return 0, nil
+}
+
+// getMarginSpendable 查询杠杆账户资产的可用数量加最大可借数量 (下单时自动借币)
+func (c *Client) getMarginSpendable(ctx context.Context, symbol, asset string) (float64, error) {
+	var freeValue string
+	if c.config.MarginIsolated {
+		account, err := call(ctx, c, c.limiter, weightMarginAccount, "isolated margin account", func(ctx context.Context) (*binance.IsolatedMarginAccount, error) {
+			return c.client.NewGetIsolatedMarginAccountService().Symbols(symbol).Do(ctx)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get isolated margin account: %w", err)
+		}
+		for _, pair := range account.Assets {
+			switch asset {
+			case pair.BaseAsset.Asset:
+				freeValue = pair.BaseAsset.Free
+			case pair.QuoteAsset.Asset:
+				freeValue = pair.QuoteAsset.Free
+			}
+		}
+	} else {
+		account, err := call(ctx, c, c.limiter, weightMarginAccount, "margin account", func(ctx context.Context) (*binance.MarginAccount, error) {
+			return c.client.NewGetMarginAccountService().Do(ctx)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get margin account: %w", err)
+		}
+		for _, a := range account.UserAssets {
+			if a.Asset == asset {
+				freeValue = a.Free
+			}
+		}
+	}
+
+	var free float64
+	if freeValue != "" {
+		v, err := strconv.ParseFloat(freeValue, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s free balance: %w", asset, err)
+		}
+		free = v
+	}
+
+	borrowable, err := call(ctx, c, c.limiter, weightMarginMaxBorrow, "max borrowable", func(ctx context.Context) (*binance.MaxBorrowable, error) {
+		s := c.client.NewGetMaxBorrowableService().Asset(asset)
+		if c.config.MarginIsolated {
+			s = s.IsolatedSymbol(symbol)
+		}
+		return s.Do(ctx)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s max borrowable: %w", asset, err)
+	}
+	amount, err := strconv.ParseFloat(borrowable.Amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s max borrowable: %w", asset, err)
+	}
+
+	return free + amount, nil
+}
+
+// GetFreeStablecoins 获取账户可用的稳定币余额 (USD)，用于跨交易所的保证金对比：
+// 合约市场为可用保证金 (单币保证金模式下合计各稳定币的可用余额)，现货和杠杆市场为稳定币的可用余额 (不含可借数量)
+func (c *Client) GetFreeStablecoins(ctx context.Context) (float64, error) {
+	var free float64
+	add := func(asset, value string) error {
+		if !stablecoins[asset] {
+			return nil
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s free balance: %w", asset, err)
+		}
+		free += v
+		return nil
+	}
+
+	switch {
+	case c.isFutures():
+		account, err := call(ctx, c, c.futuresLimiter, weightFuturesAccount, "futures account", func(ctx context.Context) (*futures.Account, error) {
+			return c.futuresClient.NewGetAccountService().Do(ctx)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get futures account: %w", err)
+		}
+		if account.MultiAssetsMargin {
+			v, err := strconv.ParseFloat(account.AvailableBalance, 64)
+			if err != nil {
+				return 0, fmt.Errorf("failed to parse available balance: %w", err)
+			}
+			free = v
+			break
+		}
+		for _, a := range account.Assets {
+			if err := add(a.Asset, a.AvailableBalance); err != nil {
+				return 0, err
+			}
+		}
+	case c.isMargin() && c.config.MarginIsolated:
+		pairs := make([]string, 0, len(c.symbols))
+		for pair := range c.symbols {
+			pairs = append(pairs, pair)
+		}
+		account, err := call(ctx, c, c.limiter, weightMarginAccount, "isolated margin account", func(ctx context.Context) (*binance.IsolatedMarginAccount, error) {
+			return c.client.NewGetIsolatedMarginAccountService().Symbols(pairs...).Do(ctx)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get isolated margin account: %w", err)
+		}
+		for _, pair := range account.Assets {
+			if err := add(pair.QuoteAsset.Asset, pair.QuoteAsset.Free); err != nil {
+				return 0, err
+			}
+		}
+	case c.isMargin():
+		account, err := call(ctx, c, c.limiter, weightMarginAccount, "margin account", func(ctx context.Context) (*binance.MarginAccount, error) {
+			return c.client.NewGetMarginAccountService().Do(ctx)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get margin account: %w", err)
+		}
+		for _, a := range account.UserAssets {
+			if err := add(a.Asset, a.Free); err != nil {
+				return 0, err
+			}
+		}
+	default:
+		account, err := call(ctx, c, c.limiter, weightAccount, "account", func(ctx context.Context) (*binance.Account, error) {
+			return c.client.NewGetAccountService().Do(ctx)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get account: %w", err)
+		}
+		for _, balance := range account.Balances {
+			if err := add(balance.Asset, balance.Free); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	c.logger.Debug("Fetched Binance free stablecoins", zap.String("market", c.market), zap.Float64("free", free))
+	return free, nil
+}
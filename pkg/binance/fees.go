@@ -0,0 +1,61 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// CommissionRates 账户在交易对上的实际手续费率 (%)，已包含VIP等级和BNB抵扣
+type CommissionRates struct {
+	MakerPercent float64
+	TakerPercent float64
+}
+
+// GetCommissionRates 查询账户在交易对上的Maker/Taker费率。合约市场查询合约费率，
+// 现货和杠杆市场查询现货费率
+func (c *Client) GetCommissionRates(ctx context.Context, symbol string) (*CommissionRates, error) {
+	if c.isFutures() {
+		return c.getFuturesCommissionRates(ctx, symbol)
+	}
+
+	fees, err := call(ctx, c, c.limiter, weightTradeFee, "trade fee", func(ctx context.Context) ([]*binance.TradeFeeDetails, error) {
+		return c.client.NewTradeFeeService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade fee: %w", err)
+	}
+	if len(fees) == 0 {
+		return nil, fmt.Errorf("no trade fee returned for %s", symbol)
+	}
+
+	return parseCommissionRates(fees[0].MakerCommission, fees[0].TakerCommission)
+}
+
+// getFuturesCommissionRates 查询合约交易对的Maker/Taker费率
+func (c *Client) getFuturesCommissionRates(ctx context.Context, symbol string) (*CommissionRates, error) {
+	rate, err := call(ctx, c, c.futuresLimiter, weightFuturesCommission, "futures commission rate", func(ctx context.Context) (*futures.CommissionRate, error) {
+		return c.futuresClient.NewCommissionRateService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get futures commission rate: %w", err)
+	}
+
+	return parseCommissionRates(rate.MakerCommissionRate, rate.TakerCommissionRate)
+}
+
+// parseCommissionRates 解析接口返回的小数费率 (0.0002) 为百分比
+func parseCommissionRates(maker, taker string) (*CommissionRates, error) {
+	makerRate, err := strconv.ParseFloat(maker, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse maker commission: %w", err)
+	}
+	takerRate, err := strconv.ParseFloat(taker, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse taker commission: %w", err)
+	}
+	return &CommissionRates{MakerPercent: makerRate * 100, TakerPercent: takerRate * 100}, nil
+}
@@ -0,0 +1,177 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2"
+	"go.uber.org/zap"
+)
+
+// SymbolFilters 交易对下单规则，来自 exchangeInfo 的 LOT_SIZE / PRICE_FILTER / NOTIONAL
+type SymbolFilters struct {
+	StepSize    float64 `json:"step_size"`    // 数量步长
+	MinQty      float64 `json:"min_qty"`      // 最小下单数量
+	TickSize    float64 `json:"tick_size"`    // 价格步长
+	MinNotional float64 `json:"min_notional"` // 最小下单金额
+
+	quantityPrecision int
+	pricePrecision    int
+}
+
+// LoadExchangeFilters 拉取已配置交易对的 exchangeInfo 并缓存下单规则。
+// 未能加载的交易对继续使用配置中的精度。
+func (c *Client) LoadExchangeFilters(ctx context.Context) error {
+	pairs := make([]string, 0, len(c.symbols))
+	for pair := range c.symbols {
+		pairs = append(pairs, pair)
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	var filters map[string]*SymbolFilters
+	var err error
+	if c.isFutures() {
+		filters, err = c.loadFuturesFilters(ctx)
+	} else {
+		filters, err = c.loadSpotFilters(ctx, pairs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get exchange info: %w", err)
+	}
+
+	// 测试网上架的交易对比正式网少，未上架的交易对下单会被拒绝
+	for _, pair := range pairs {
+		if _, ok := filters[pair]; !ok {
+			c.logger.Warn("Pair not listed in exchange info, using configured precisions",
+				zap.String("symbol", pair),
+				zap.String("market", c.market),
+				zap.Bool("testnet", c.config.Testnet),
+			)
+		}
+	}
+
+	for symbol, f := range filters {
+		c.logger.Info("Loaded symbol filters",
+			zap.String("symbol", symbol),
+			zap.Float64("step_size", f.StepSize),
+			zap.Float64("min_qty", f.MinQty),
+			zap.Float64("tick_size", f.TickSize),
+			zap.Float64("min_notional", f.MinNotional),
+		)
+	}
+
+	c.filtersMu.Lock()
+	c.filters = filters
+	c.filtersMu.Unlock()
+
+	return nil
+}
+
+// loadSpotFilters 拉取现货 exchangeInfo 中指定交易对的下单规则
+func (c *Client) loadSpotFilters(ctx context.Context, pairs []string) (map[string]*SymbolFilters, error) {
+	info, err := call(ctx, c, c.limiter, weightExchangeInfo, "exchange info", func(ctx context.Context) (*binance.ExchangeInfo, error) {
+		return c.client.NewExchangeInfoService().Symbols(pairs...).Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make(map[string]*SymbolFilters, len(info.Symbols))
+	for i := range info.Symbols {
+		sym := &info.Symbols[i]
+		f, err := parseSymbolFilters(sym)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse filters for %s: %w", sym.Symbol, err)
+		}
+		filters[sym.Symbol] = f
+	}
+
+	return filters, nil
+}
+
+// GetSymbolFilters 获取交易对缓存的下单规则
+func (c *Client) GetSymbolFilters(symbol string) (*SymbolFilters, bool) {
+	c.filtersMu.RLock()
+	defer c.filtersMu.RUnlock()
+
+	f, ok := c.filters[symbol]
+	return f, ok
+}
+
+// parseSymbolFilters 解析 exchangeInfo 中的过滤规则
+func parseSymbolFilters(sym *binance.Symbol) (*SymbolFilters, error) {
+	f := &SymbolFilters{}
+
+	if lot := sym.LotSizeFilter(); lot != nil {
+		step, err := strconv.ParseFloat(lot.StepSize, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stepSize %q: %w", lot.StepSize, err)
+		}
+		minQty, err := strconv.ParseFloat(lot.MinQuantity, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minQty %q: %w", lot.MinQuantity, err)
+		}
+		f.StepSize, f.MinQty = step, minQty
+		f.quantityPrecision = stepPrecision(lot.StepSize)
+	}
+
+	if price := sym.PriceFilter(); price != nil {
+		tick, err := strconv.ParseFloat(price.TickSize, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tickSize %q: %w", price.TickSize, err)
+		}
+		f.TickSize = tick
+		f.pricePrecision = stepPrecision(price.TickSize)
+	}
+
+	// 新交易对使用 NOTIONAL，旧交易对仍可能返回 MIN_NOTIONAL
+	minNotional := ""
+	if notional := sym.NotionalFilter(); notional != nil {
+		minNotional = notional.MinNotional
+	} else {
+		for _, filter := range sym.Filters {
+			if filter["filterType"] == string(binance.SymbolFilterTypeMinNotional) {
+				minNotional, _ = filter["minNotional"].(string)
+			}
+		}
+	}
+	if minNotional != "" {
+		v, err := strconv.ParseFloat(minNotional, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minNotional %q: %w", minNotional, err)
+		}
+		f.MinNotional = v
+	}
+
+	return f, nil
+}
+
+// stepPrecision 根据步长字符串计算小数位，如 "0.00100000" -> 3
+func stepPrecision(step string) int {
+	i := strings.IndexByte(step, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(step[i+1:], "0"))
+}
+
+// floorToStep 按步长向下取整
+func floorToStep(value, step float64) float64 {
+	if step <= 0 {
+		return value
+	}
+	return math.Floor(value/step+1e-9) * step
+}
+
+// ceilToStep 按步长向上取整
+func ceilToStep(value, step float64) float64 {
+	if step <= 0 {
+		return value
+	}
+	return math.Ceil(value/step-1e-9) * step
+}
@@ -0,0 +1,388 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+// Binance 交易市场
+const (
+	MarketSpot    = "spot"
+	MarketFutures = "futures"
+	MarketMargin  = "margin" // 现货杠杆，自动借币卖出实现空头
+)
+
+// Market 当前交易市场: spot, futures, margin
+func (c *Client) Market() string {
+	return c.market
+}
+
+// isFutures 是否在U本位合约市场交易
+func (c *Client) isFutures() bool {
+	return c.market == MarketFutures
+}
+
+// LoadPositionMode 只查询账户持仓模式 (单向/双向)，不修改保证金模式和杠杆，下单时据此设置持仓方向。
+// 现货市场下为空操作
+func (c *Client) LoadPositionMode(ctx context.Context) error {
+	if !c.isFutures() {
+		return nil
+	}
+
+	mode, err := call(ctx, c, c.futuresLimiter, weightFuturesPositionMode, "position mode", func(ctx context.Context) (*futures.PositionMode, error) {
+		return c.futuresClient.NewGetPositionModeService().Do(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get position mode: %w", err)
+	}
+	c.dualSidePosition = mode.DualSidePosition
+
+	c.logger.Info("Binance futures position mode",
+		zap.Bool("dual_side_position", c.dualSidePosition),
+	)
+	return nil
+}
+
+// InitFutures 初始化合约交易：查询账户持仓模式，并按配置为已配置交易对设置保证金模式和杠杆，
+// 不依赖账户上次使用的设置。现货市场下为空操作
+func (c *Client) InitFutures(ctx context.Context) error {
+	if !c.isFutures() {
+		return nil
+	}
+
+	if err := c.LoadPositionMode(ctx); err != nil {
+		return err
+	}
+
+	for pair, sym := range c.symbols {
+		if c.config.FuturesMarginType != "" {
+			if err := c.SetMarginType(ctx, pair, c.config.FuturesMarginType == "isolated"); err != nil {
+				return err
+			}
+		}
+
+		leverage := c.config.FuturesLeverage
+		if sym.FuturesLeverage > 0 {
+			leverage = sym.FuturesLeverage
+		}
+		if leverage > 0 {
+			if err := c.SetLeverage(ctx, pair, leverage); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetLeverage 设置合约交易对的杠杆倍数
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	if !c.isFutures() {
+		return fmt.Errorf("leverage is only supported on futures market")
+	}
+
+	_, err := call(ctx, c, c.futuresLimiter, weightFuturesLeverage, "change leverage", func(ctx context.Context) (*futures.SymbolLeverage, error) {
+		return c.futuresClient.NewChangeLeverageService().Symbol(symbol).Leverage(leverage).Do(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set leverage for %s: %w", symbol, err)
+	}
+
+	c.logger.Info("Set Binance futures leverage",
+		zap.String("symbol", symbol),
+		zap.Int("leverage", leverage),
+	)
+	return nil
+}
+
+// SetMarginType 设置合约交易对的保证金模式：isolated 为逐仓，否则为全仓。
+// 已是目标模式时视为成功；交易对有持仓或挂单时交易所拒绝修改
+func (c *Client) SetMarginType(ctx context.Context, symbol string, isolated bool) error {
+	if !c.isFutures() {
+		return fmt.Errorf("margin type is only supported on futures market")
+	}
+
+	marginType := futures.MarginTypeCrossed
+	if isolated {
+		marginType = futures.MarginTypeIsolated
+	}
+
+	_, err := call(ctx, c, c.futuresLimiter, weightFuturesMarginType, "change margin type", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.futuresClient.NewChangeMarginTypeService().Symbol(symbol).MarginType(marginType).Do(ctx)
+	})
+	if err != nil {
+		var apiErr *common.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != codeNoNeedMargin {
+			return fmt.Errorf("failed to set margin type for %s: %w", symbol, err)
+		}
+	}
+
+	c.logger.Info("Set Binance futures margin type",
+		zap.String("symbol", symbol),
+		zap.String("margin_type", string(marginType)),
+	)
+	return nil
+}
+
+// positionSide 下单使用的持仓方向。单向持仓模式为BOTH；双向持仓模式下每个交易对固定使用
+// 动态对冲中Binance侧的方向 (与Lighter相反)，买卖都作用于同一方向的仓位
+func (c *Client) positionSide(symbol string) futures.PositionSideType {
+	if !c.dualSidePosition {
+		return futures.PositionSideTypeBoth
+	}
+	if sym, ok := c.symbols[symbol]; ok && sym.LighterSide == "BUY" {
+		return futures.PositionSideTypeShort
+	}
+	return futures.PositionSideTypeLong
+}
+
+// placeFuturesLimitOrder 在合约市场下限价单
+func (c *Client) placeFuturesLimitOrder(ctx context.Context, req *OrderRequest) (*OrderStatus, error) {
+	order, err := callOrder(ctx, c, c.futuresLimiter, weightFuturesCreateOrder, "create futures order", func(ctx context.Context) (*futures.CreateOrderResponse, error) {
+		return c.futuresClient.NewCreateOrderService().
+			Symbol(req.Symbol).
+			Side(futures.SideType(req.Side)).
+			PositionSide(c.positionSide(req.Symbol)).
+			Type(futures.OrderTypeLimit).
+			TimeInForce(futures.TimeInForceTypeGTC).
+			Quantity(req.Quantity).
+			Price(req.Price).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
+
+// cancelFuturesOrder 撤销合约订单
+func (c *Client) cancelFuturesOrder(ctx context.Context, symbol string, orderID int64) error {
+	_, err := call(ctx, c, c.futuresLimiter, weightFuturesCancelOrder, "cancel futures order", func(ctx context.Context) (*futures.CancelOrderResponse, error) {
+		return c.futuresClient.NewCancelOrderService().Symbol(symbol).OrderID(orderID).Do(ctx)
+	})
+	return err
+}
+
+// cancelAllFuturesOrders 撤销合约交易对上的全部挂单，返回撤单数量
+func (c *Client) cancelAllFuturesOrders(ctx context.Context, symbol string) (int, error) {
+	orders, err := call(ctx, c, c.futuresLimiter, weightFuturesOpenOrders, "futures open orders", func(ctx context.Context) ([]*futures.Order, error) {
+		return c.futuresClient.NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(orders) == 0 {
+		return 0, nil
+	}
+
+	_, err = call(ctx, c, c.futuresLimiter, weightFuturesCancelAll, "cancel futures open orders", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.futuresClient.NewCancelAllOpenOrdersService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(orders), nil
+}
+
+// getFuturesOrder 查询合约订单
+func (c *Client) getFuturesOrder(ctx context.Context, symbol string, orderID int64) (*OrderStatus, error) {
+	order, err := call(ctx, c, c.futuresLimiter, weightFuturesGetOrder, "get futures order", func(ctx context.Context) (*futures.Order, error) {
+		return c.futuresClient.NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
+
+// getFuturesPrice 获取合约最新价格
+func (c *Client) getFuturesPrice(ctx context.Context, symbol string) (string, error) {
+	ticker, err := call(ctx, c, c.futuresLimiter, weightFuturesTickerPrice, "futures ticker price", func(ctx context.Context) ([]*futures.SymbolPrice, error) {
+		return c.futuresClient.NewListPricesService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(ticker) == 0 {
+		return "", fmt.Errorf("no price data for %s", symbol)
+	}
+	return ticker[0].Price, nil
+}
+
+// getFuturesDepth 获取合约盘口
+func (c *Client) getFuturesDepth(ctx context.Context, symbol string) (bids, asks []common.PriceLevel, err error) {
+	depth, err := call(ctx, c, c.futuresLimiter, weightFuturesDepth100, "futures depth", func(ctx context.Context) (*futures.DepthResponse, error) {
+		return c.futuresClient.NewDepthService().Symbol(symbol).Limit(100).Do(ctx)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return depth.Bids, depth.Asks, nil
+}
+
+// getFuturesQuoteVolumes 获取合约1分钟K线成交额
+func (c *Client) getFuturesQuoteVolumes(ctx context.Context, symbol string, start, end time.Time) ([]string, error) {
+	klines, err := call(ctx, c, c.futuresLimiter, weightFuturesKlines, "futures klines", func(ctx context.Context) ([]*futures.Kline, error) {
+		return c.futuresClient.NewKlinesService().
+			Symbol(symbol).
+			Interval("1m").
+			StartTime(start.UnixMilli()).
+			EndTime(end.UnixMilli()).
+			Limit(1000).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]string, 0, len(klines))
+	for _, k := range klines {
+		volumes = append(volumes, k.QuoteAssetVolume)
+	}
+	return volumes, nil
+}
+
+// loadFuturesFilters 拉取合约 exchangeInfo 中已配置交易对的下单规则
+func (c *Client) loadFuturesFilters(ctx context.Context) (map[string]*SymbolFilters, error) {
+	info, err := call(ctx, c, c.futuresLimiter, weightFuturesExchangeInfo, "futures exchange info", func(ctx context.Context) (*futures.ExchangeInfo, error) {
+		return c.futuresClient.NewExchangeInfoService().Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make(map[string]*SymbolFilters, len(c.symbols))
+	for i := range info.Symbols {
+		sym := &info.Symbols[i]
+		if _, ok := c.symbols[sym.Symbol]; !ok {
+			continue
+		}
+
+		f, err := parseFuturesFilters(sym)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse filters for %s: %w", sym.Symbol, err)
+		}
+		filters[sym.Symbol] = f
+	}
+
+	return filters, nil
+}
+
+// parseFuturesFilters 解析合约 exchangeInfo 中的过滤规则
+func parseFuturesFilters(sym *futures.Symbol) (*SymbolFilters, error) {
+	f := &SymbolFilters{}
+
+	if lot := sym.LotSizeFilter(); lot != nil {
+		step, err := strconv.ParseFloat(lot.StepSize, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stepSize %q: %w", lot.StepSize, err)
+		}
+		minQty, err := strconv.ParseFloat(lot.MinQuantity, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minQty %q: %w", lot.MinQuantity, err)
+		}
+		f.StepSize, f.MinQty = step, minQty
+		f.quantityPrecision = stepPrecision(lot.StepSize)
+	}
+
+	if price := sym.PriceFilter(); price != nil {
+		tick, err := strconv.ParseFloat(price.TickSize, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tickSize %q: %w", price.TickSize, err)
+		}
+		f.TickSize = tick
+		f.pricePrecision = stepPrecision(price.TickSize)
+	}
+
+	if notional := sym.MinNotionalFilter(); notional != nil && notional.Notional != "" {
+		v, err := strconv.ParseFloat(notional.Notional, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notional %q: %w", notional.Notional, err)
+		}
+		f.MinNotional = v
+	}
+
+	return f, nil
+}
+
+// getFuturesEquity 获取合约账户权益 (保证金余额，含未实现盈亏)
+func (c *Client) getFuturesEquity(ctx context.Context) (float64, error) {
+	account, err := call(ctx, c, c.futuresLimiter, weightFuturesAccount, "futures account", func(ctx context.Context) (*futures.Account, error) {
+		return c.futuresClient.NewGetAccountService().Do(ctx)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get futures account: %w", err)
+	}
+
+	equity, err := strconv.ParseFloat(account.TotalMarginBalance, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse total margin balance: %w", err)
+	}
+	return equity, nil
+}
+
+// getFuturesPositions 获取已配置交易对的合约持仓，双向持仓模式下同一交易对的多空仓位合并为净仓位
+func (c *Client) getFuturesPositions(ctx context.Context) (map[string]Position, error) {
+	risks, err := call(ctx, c, c.futuresLimiter, weightFuturesPositionRisk, "futures position risk", func(ctx context.Context) ([]*futures.PositionRisk, error) {
+		return c.futuresClient.NewGetPositionRiskService().Do(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get futures positions: %w", err)
+	}
+
+	positions := make(map[string]Position, len(c.symbols))
+	for _, risk := range risks {
+		sym, ok := c.symbols[risk.Symbol]
+		if !ok {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(risk.PositionAmt, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s position amount: %w", risk.Symbol, err)
+		}
+		if amount == 0 {
+			continue
+		}
+		entryPrice, err := strconv.ParseFloat(risk.EntryPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s entry price: %w", risk.Symbol, err)
+		}
+		markPrice, err := strconv.ParseFloat(risk.MarkPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s mark price: %w", risk.Symbol, err)
+		}
+		liquidationPrice, err := strconv.ParseFloat(risk.LiquidationPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s liquidation price: %w", risk.Symbol, err)
+		}
+
+		pos, ok := positions[sym.Symbol]
+		if ok {
+			// 双向持仓模式下多空两个方向都有仓位，开仓均价按数量加权，强平价取数量较大一侧
+			if math.Abs(amount) > math.Abs(pos.Size) {
+				pos.LiquidationPrice = liquidationPrice
+			}
+			weight := math.Abs(pos.Size) + math.Abs(amount)
+			pos.EntryPrice = (pos.EntryPrice*math.Abs(pos.Size) + entryPrice*math.Abs(amount)) / weight
+			pos.Size += amount
+		} else {
+			pos = Position{Symbol: sym.Symbol, Size: amount, EntryPrice: entryPrice, LiquidationPrice: liquidationPrice}
+		}
+		pos.MarkPrice = markPrice
+		positions[sym.Symbol] = pos
+	}
+
+	return positions, nil
+}
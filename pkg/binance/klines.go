@@ -0,0 +1,108 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// Kline K线
+type Kline struct {
+	OpenTime    time.Time
+	CloseTime   time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64 // 成交量 (币)
+	QuoteVolume float64 // 成交额 (计价币)
+}
+
+// GetKlines 获取最近 limit 根K线 (按时间升序，最后一根可能尚未收盘)。
+// interval 为Binance K线周期，如 1m, 5m, 1h；合约市场取合约K线，现货和杠杆账户取现货K线
+func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error) {
+	var klines []Kline
+	var err error
+	if c.isFutures() {
+		klines, err = c.getFuturesKlines(ctx, symbol, interval, limit)
+	} else {
+		klines, err = c.getSpotKlines(ctx, symbol, interval, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s klines for %s: %w", interval, symbol, err)
+	}
+	return klines, nil
+}
+
+// getSpotKlines 获取现货K线
+func (c *Client) getSpotKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error) {
+	raw, err := call(ctx, c, c.limiter, weightKlines, "klines", func(ctx context.Context) ([]*binance.Kline, error) {
+		return c.client.NewKlinesService().Symbol(symbol).Interval(interval).Limit(limit).Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	klines := make([]Kline, 0, len(raw))
+	for _, k := range raw {
+		kline, err := newKline(k.OpenTime, k.CloseTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.QuoteAssetVolume)
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, kline)
+	}
+	return klines, nil
+}
+
+// getFuturesKlines 获取合约K线
+func (c *Client) getFuturesKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error) {
+	raw, err := call(ctx, c, c.futuresLimiter, weightFuturesKlines, "futures klines", func(ctx context.Context) ([]*futures.Kline, error) {
+		return c.futuresClient.NewKlinesService().Symbol(symbol).Interval(interval).Limit(limit).Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	klines := make([]Kline, 0, len(raw))
+	for _, k := range raw {
+		kline, err := newKline(k.OpenTime, k.CloseTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.QuoteAssetVolume)
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, kline)
+	}
+	return klines, nil
+}
+
+// newKline 解析K线的价格和成交量
+func newKline(openTime, closeTime int64, open, high, low, closePrice, volume, quoteVolume string) (Kline, error) {
+	kline := Kline{
+		OpenTime:  time.UnixMilli(openTime),
+		CloseTime: time.UnixMilli(closeTime),
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		dst   *float64
+	}{
+		{"open", open, &kline.Open},
+		{"high", high, &kline.High},
+		{"low", low, &kline.Low},
+		{"close", closePrice, &kline.Close},
+		{"volume", volume, &kline.Volume},
+		{"quote volume", quoteVolume, &kline.QuoteVolume},
+	}
+	for _, f := range fields {
+		v, err := strconv.ParseFloat(f.value, 64)
+		if err != nil {
+			return kline, fmt.Errorf("failed to parse kline %s: %w", f.name, err)
+		}
+		*f.dst = v
+	}
+	return kline, nil
+}
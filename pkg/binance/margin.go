@@ -0,0 +1,161 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// isMargin 是否在现货杠杆市场交易
+func (c *Client) isMargin() bool {
+	return c.market == MarketMargin
+}
+
+// placeMarginLimitOrder 在杠杆账户下限价单：余额不足时自动借币，成交后自动还款
+func (c *Client) placeMarginLimitOrder(ctx context.Context, req *OrderRequest) (*OrderStatus, error) {
+	order, err := callOrder(ctx, c, c.limiter, weightMarginCreateOrder, "create margin order", func(ctx context.Context) (*binance.CreateOrderResponse, error) {
+		return c.client.NewCreateMarginOrderService().
+			Symbol(req.Symbol).
+			IsIsolated(c.config.MarginIsolated).
+			Side(req.Side).
+			Type(binance.OrderTypeLimit).
+			TimeInForce(binance.TimeInForceTypeGTC).
+			Quantity(req.Quantity).
+			Price(req.Price).
+			SideEffectType(binance.SideEffectTypeAutoBorrowRepay).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
+
+// cancelMarginOrder 撤销杠杆订单
+func (c *Client) cancelMarginOrder(ctx context.Context, symbol string, orderID int64) error {
+	_, err := call(ctx, c, c.limiter, weightMarginCancelOrder, "cancel margin order", func(ctx context.Context) (*binance.CancelMarginOrderResponse, error) {
+		return c.client.NewCancelMarginOrderService().
+			Symbol(symbol).
+			IsIsolated(c.config.MarginIsolated).
+			OrderID(orderID).
+			Do(ctx)
+	})
+	return err
+}
+
+// cancelAllMarginOrders 撤销杠杆交易对上的全部挂单，返回撤单数量
+func (c *Client) cancelAllMarginOrders(ctx context.Context, symbol string) (int, error) {
+	resp, err := call(ctx, c, c.limiter, weightMarginCancelAll, "cancel margin open orders", func(ctx context.Context) ([]*binance.CancelAllMarginOrdersResponse, error) {
+		return c.client.NewCancelAllMarginOrdersService().
+			Symbol(symbol).
+			IsIsolated(c.config.MarginIsolated).
+			Do(ctx)
+	})
+	if err != nil {
+		var apiErr *common.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == codeUnknownOrder {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return len(resp), nil
+}
+
+// getMarginOrder 查询杠杆订单
+func (c *Client) getMarginOrder(ctx context.Context, symbol string, orderID int64) (*OrderStatus, error) {
+	order, err := call(ctx, c, c.limiter, weightMarginGetOrder, "get margin order", func(ctx context.Context) (*binance.Order, error) {
+		return c.client.NewGetMarginOrderService().
+			Symbol(symbol).
+			IsIsolated(c.config.MarginIsolated).
+			OrderID(orderID).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
+
+// getMarginNetAssets 查询杠杆账户各资产净值 (余额 - 借款 - 利息，借币卖出后为负)，
+// 返回 asset -> 净值。逐仓模式下合并已配置交易对的逐仓账户
+func (c *Client) getMarginNetAssets(ctx context.Context) (map[string]float64, error) {
+	netAssets := make(map[string]float64)
+	add := func(asset, netAsset string) error {
+		v, err := strconv.ParseFloat(netAsset, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s net asset: %w", asset, err)
+		}
+		if v != 0 {
+			netAssets[asset] += v
+		}
+		return nil
+	}
+
+	if !c.config.MarginIsolated {
+		account, err := call(ctx, c, c.limiter, weightMarginAccount, "margin account", func(ctx context.Context) (*binance.MarginAccount, error) {
+			return c.client.NewGetMarginAccountService().Do(ctx)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get margin account: %w", err)
+		}
+		for _, asset := range account.UserAssets {
+			if err := add(asset.Asset, asset.NetAsset); err != nil {
+				return nil, err
+			}
+		}
+		return netAssets, nil
+	}
+
+	pairs := make([]string, 0, len(c.symbols))
+	for pair := range c.symbols {
+		pairs = append(pairs, pair)
+	}
+	account, err := call(ctx, c, c.limiter, weightMarginAccount, "isolated margin account", func(ctx context.Context) (*binance.IsolatedMarginAccount, error) {
+		return c.client.NewGetIsolatedMarginAccountService().Symbols(pairs...).Do(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get isolated margin account: %w", err)
+	}
+	for _, pair := range account.Assets {
+		if err := add(pair.BaseAsset.Asset, pair.BaseAsset.NetAsset); err != nil {
+			return nil, err
+		}
+		if err := add(pair.QuoteAsset.Asset, pair.QuoteAsset.NetAsset); err != nil {
+			return nil, err
+		}
+	}
+	return netAssets, nil
+}
+
+// getMarginPositions 按已配置币种的净值获取杠杆持仓 (净值为负即借币做空)
+func (c *Client) getMarginPositions(ctx context.Context) (map[string]Position, error) {
+	netAssets, err := c.getMarginNetAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[string]Position, len(c.symbols))
+	for pair, sym := range c.symbols {
+		size, ok := netAssets[sym.Symbol]
+		if !ok {
+			continue
+		}
+		price, err := c.GetCurrentPrice(ctx, pair)
+		if err != nil {
+			return nil, err
+		}
+		positions[sym.Symbol] = Position{
+			Symbol:    sym.Symbol,
+			Size:      size,
+			MarkPrice: price,
+		}
+	}
+
+	return positions, nil
+}
@@ -0,0 +1,161 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/killswitch"
+)
+
+// PlaceMarketOrder 按币数量下市价单 (紧急平仓用)，返回的 Price 为成交均价。
+// reduceOnly 时合约单向持仓模式下为只减仓单，杠杆账户只还款不借币
+func (c *Client) PlaceMarketOrder(ctx context.Context, symbol, side string, quantity float64, reduceOnly bool) (*OrderStatus, error) {
+	sideType := binance.SideType(side)
+	qty := c.formatQuantity(symbol, quantity)
+
+	c.logger.Warn("Placing market order",
+		zap.String("market", c.market),
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.String("quantity", qty),
+		zap.Bool("reduce_only", reduceOnly),
+	)
+
+	if err := c.checkMinQuantity(symbol, qty); err != nil {
+		return nil, err
+	}
+
+	// 只减仓单在紧急停止后仍然放行
+	if reduceOnly {
+		ctx = killswitch.Exempt(ctx)
+	}
+
+	var order *OrderStatus
+	var err error
+	switch {
+	case c.isFutures():
+		order, err = c.placeFuturesMarketOrder(ctx, symbol, sideType, qty, reduceOnly)
+	case c.isMargin():
+		order, err = c.placeMarginMarketOrder(ctx, symbol, sideType, qty, reduceOnly)
+	default:
+		order, err = c.placeSpotMarketOrder(ctx, symbol, sideType, qty)
+	}
+	if err != nil {
+		c.logger.Error("Failed to place market order",
+			zap.Error(err),
+			zap.String("symbol", symbol),
+		)
+		return nil, fmt.Errorf("failed to place market order: %w", err)
+	}
+
+	c.logger.Info("Market order placed successfully",
+		zap.Int64("order_id", order.OrderID),
+		zap.String("symbol", symbol),
+		zap.String("status", order.Status),
+		zap.Float64("executed_qty", order.ExecutedQty),
+		zap.Float64("avg_price", order.Price),
+	)
+
+	return order, nil
+}
+
+// checkMinQuantity 检查下单数量不低于交易对最小数量，未加载下单规则时不检查
+func (c *Client) checkMinQuantity(symbol, qty string) error {
+	if f, ok := c.GetSymbolFilters(symbol); ok {
+		if q, _ := strconv.ParseFloat(qty, 64); q <= 0 || q < f.MinQty {
+			return fmt.Errorf("%s quantity %s below minimum %g", symbol, qty, f.MinQty)
+		}
+	}
+	return nil
+}
+
+// placeSpotMarketOrder 在现货市场下市价单
+func (c *Client) placeSpotMarketOrder(ctx context.Context, symbol string, side binance.SideType, qty string) (*OrderStatus, error) {
+	order, err := callOrder(ctx, c, c.limiter, weightCreateOrder, "create market order", func(ctx context.Context) (*binance.CreateOrderResponse, error) {
+		return c.client.NewCreateOrderService().
+			Symbol(symbol).
+			Side(side).
+			Type(binance.OrderTypeMarket).
+			Quantity(qty).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMarketOrderStatus(order.OrderID, string(order.Status), order.CummulativeQuoteQuantity, order.ExecutedQuantity)
+}
+
+// placeMarginMarketOrder 在杠杆账户下市价单：平仓时只自动还款，否则自动借币和还款
+func (c *Client) placeMarginMarketOrder(ctx context.Context, symbol string, side binance.SideType, qty string, reduceOnly bool) (*OrderStatus, error) {
+	sideEffect := binance.SideEffectTypeAutoBorrowRepay
+	if reduceOnly {
+		sideEffect = binance.SideEffectTypeAutoRepay
+	}
+
+	order, err := callOrder(ctx, c, c.limiter, weightMarginCreateOrder, "create margin market order", func(ctx context.Context) (*binance.CreateOrderResponse, error) {
+		return c.client.NewCreateMarginOrderService().
+			Symbol(symbol).
+			IsIsolated(c.config.MarginIsolated).
+			Side(side).
+			Type(binance.OrderTypeMarket).
+			Quantity(qty).
+			SideEffectType(sideEffect).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMarketOrderStatus(order.OrderID, string(order.Status), order.CummulativeQuoteQuantity, order.ExecutedQuantity)
+}
+
+// placeFuturesMarketOrder 在合约市场下市价单
+func (c *Client) placeFuturesMarketOrder(ctx context.Context, symbol string, side binance.SideType, qty string, reduceOnly bool) (*OrderStatus, error) {
+	order, err := callOrder(ctx, c, c.futuresLimiter, weightFuturesCreateOrder, "create futures market order", func(ctx context.Context) (*futures.CreateOrderResponse, error) {
+		svc := c.futuresClient.NewCreateOrderService().
+			Symbol(symbol).
+			Side(futures.SideType(side)).
+			PositionSide(c.positionSide(symbol)).
+			Type(futures.OrderTypeMarket).
+			Quantity(qty).
+			NewOrderResponseType(futures.NewOrderRespTypeRESULT)
+		// 双向持仓模式不接受reduceOnly，由持仓方向保证只平仓
+		if reduceOnly && !c.dualSidePosition {
+			svc = svc.ReduceOnly(true)
+		}
+		return svc.Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// RESULT 回报包含成交数量和均价，个别情况下仍可能为空，此时 Price 为0
+	avgPrice := order.AvgPrice
+	if avgPrice == "" {
+		avgPrice = "0"
+	}
+	return newOrderStatus(order.OrderID, string(order.Status), avgPrice, order.ExecutedQuantity)
+}
+
+// newMarketOrderStatus 解析现货/杠杆市价单回报，按成交额和成交数量计算均价
+func newMarketOrderStatus(orderID int64, status, quoteQty, executedQty string) (*OrderStatus, error) {
+	order, err := newOrderStatus(orderID, status, "0", executedQty)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := strconv.ParseFloat(quoteQty, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cumulative quote quantity: %w", err)
+	}
+	if order.ExecutedQty > 0 {
+		order.Price = quote / order.ExecutedQty
+	}
+	return order, nil
+}
@@ -0,0 +1,170 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adshao/go-binance/v2"
+	"go.uber.org/zap"
+)
+
+// OCOStatus OCO订单组：止盈腿为LIMIT_MAKER限价单，止损腿为STOP_LOSS_LIMIT条件单，
+// 任意一腿成交后交易所自动撤销另一腿
+type OCOStatus struct {
+	OrderListID  int64
+	TakeProfitID int64 // 止盈腿订单ID
+	StopLossID   int64 // 止损腿订单ID
+}
+
+// ocoLeg OCO下单回报中的单腿
+type ocoLeg struct {
+	orderID   int64
+	orderType binance.OrderType
+}
+
+// SupportsOCO 当前交易市场是否支持OCO (合约市场不支持)
+func (c *Client) SupportsOCO() bool {
+	return !c.isFutures()
+}
+
+// PlaceOCOOrder 按币数量下OCO订单：价格触及 takeProfitPrice 时止盈腿成交，
+// 触及 stopPrice 时止损腿以 stopLimitPrice 挂限价单。卖出时止盈价在上方、止损价在下方，买入时相反
+func (c *Client) PlaceOCOOrder(ctx context.Context, symbol, side string, quantity, takeProfitPrice, stopPrice, stopLimitPrice float64) (*OCOStatus, error) {
+	if !c.SupportsOCO() {
+		return nil, fmt.Errorf("OCO orders are not supported in %s market", c.market)
+	}
+	if takeProfitPrice <= 0 || stopPrice <= 0 || stopLimitPrice <= 0 {
+		return nil, fmt.Errorf("invalid OCO price for %s: take_profit=%f stop=%f stop_limit=%f",
+			symbol, takeProfitPrice, stopPrice, stopLimitPrice)
+	}
+
+	sideType := binance.SideType(side)
+	qty := c.formatQuantity(symbol, quantity)
+	price := c.formatPrice(symbol, sideType, takeProfitPrice)
+	stop := c.formatPrice(symbol, sideType, stopPrice)
+	stopLimit := c.formatPrice(symbol, sideType, stopLimitPrice)
+
+	c.logger.Info("Placing OCO order",
+		zap.String("market", c.market),
+		zap.String("symbol", symbol),
+		zap.String("side", side),
+		zap.String("quantity", qty),
+		zap.String("price", price),
+		zap.String("stop_price", stop),
+		zap.String("stop_limit_price", stopLimit),
+	)
+
+	if err := c.checkOrderFilters(symbol, qty, stopLimit); err != nil {
+		return nil, err
+	}
+
+	var listID int64
+	var legs []ocoLeg
+	var err error
+	if c.isMargin() {
+		listID, legs, err = c.placeMarginOCO(ctx, symbol, sideType, qty, price, stop, stopLimit)
+	} else {
+		listID, legs, err = c.placeSpotOCO(ctx, symbol, sideType, qty, price, stop, stopLimit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to place OCO order: %w", err)
+	}
+
+	status := &OCOStatus{OrderListID: listID}
+	for _, leg := range legs {
+		if leg.orderType == binance.OrderTypeLimitMaker {
+			status.TakeProfitID = leg.orderID
+		} else {
+			status.StopLossID = leg.orderID
+		}
+	}
+	if status.TakeProfitID == 0 || status.StopLossID == 0 {
+		return nil, fmt.Errorf("unexpected OCO response for order list %d: %d legs", listID, len(legs))
+	}
+
+	c.logger.Info("OCO order placed successfully",
+		zap.Int64("order_list_id", status.OrderListID),
+		zap.Int64("take_profit_id", status.TakeProfitID),
+		zap.Int64("stop_loss_id", status.StopLossID),
+		zap.String("symbol", symbol),
+	)
+
+	return status, nil
+}
+
+// placeSpotOCO 在现货市场下OCO订单
+func (c *Client) placeSpotOCO(ctx context.Context, symbol string, side binance.SideType, qty, price, stop, stopLimit string) (int64, []ocoLeg, error) {
+	resp, err := callOrder(ctx, c, c.limiter, weightCreateOCO, "create oco order", func(ctx context.Context) (*binance.CreateOCOResponse, error) {
+		return c.client.NewCreateOCOService().
+			Symbol(symbol).
+			Side(side).
+			Quantity(qty).
+			Price(price).
+			StopPrice(stop).
+			StopLimitPrice(stopLimit).
+			StopLimitTimeInForce(binance.TimeInForceTypeGTC).
+			Do(ctx)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	legs := make([]ocoLeg, 0, len(resp.OrderReports))
+	for _, r := range resp.OrderReports {
+		legs = append(legs, ocoLeg{orderID: r.OrderID, orderType: r.Type})
+	}
+	return resp.OrderListID, legs, nil
+}
+
+// placeMarginOCO 在杠杆账户下OCO订单，成交后自动还款
+func (c *Client) placeMarginOCO(ctx context.Context, symbol string, side binance.SideType, qty, price, stop, stopLimit string) (int64, []ocoLeg, error) {
+	resp, err := callOrder(ctx, c, c.limiter, weightMarginCreateOCO, "create margin oco order", func(ctx context.Context) (*binance.CreateMarginOCOResponse, error) {
+		return c.client.NewCreateMarginOCOService().
+			Symbol(symbol).
+			IsIsolated(c.config.MarginIsolated).
+			Side(side).
+			Quantity(qty).
+			Price(price).
+			StopPrice(stop).
+			StopLimitPrice(stopLimit).
+			StopLimitTimeInForce(binance.TimeInForceTypeGTC).
+			SideEffectType(binance.SideEffectTypeAutoRepay).
+			Do(ctx)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	legs := make([]ocoLeg, 0, len(resp.OrderReports))
+	for _, r := range resp.OrderReports {
+		legs = append(legs, ocoLeg{orderID: r.OrderID, orderType: r.Type})
+	}
+	return resp.OrderListID, legs, nil
+}
+
+// CancelOCOOrder 撤销OCO订单组，两腿同时撤销
+func (c *Client) CancelOCOOrder(ctx context.Context, symbol string, orderListID int64) error {
+	var err error
+	if c.isMargin() {
+		_, err = call(ctx, c, c.limiter, weightMarginCancelOCO, "cancel margin oco order", func(ctx context.Context) (*binance.CancelMarginOCOResponse, error) {
+			return c.client.NewCancelMarginOCOService().
+				Symbol(symbol).
+				IsIsolated(c.config.MarginIsolated).
+				OrderListID(orderListID).
+				Do(ctx)
+		})
+	} else {
+		_, err = call(ctx, c, c.limiter, weightCancelOCO, "cancel oco order", func(ctx context.Context) (*binance.CancelOCOResponse, error) {
+			return c.client.NewCancelOCOService().Symbol(symbol).OrderListID(orderListID).Do(ctx)
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to cancel order list %d: %w", orderListID, err)
+	}
+
+	c.logger.Info("OCO order cancelled",
+		zap.String("symbol", symbol),
+		zap.Int64("order_list_id", orderListID),
+	)
+	return nil
+}
@@ -0,0 +1,134 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+// OpenOrder 交易所上未完成的挂单
+type OpenOrder struct {
+	OrderID     int64
+	ListID      int64  // 所属OCO订单组ID，-1为不属于订单组 (合约市场始终为-1)
+	Symbol      string // 交易对，如 BTCUSDC
+	Side        string // BUY, SELL
+	Type        string // LIMIT, STOP_LOSS_LIMIT, TAKE_PROFIT_LIMIT, LIMIT_MAKER, STOP, ...
+	Price       float64
+	StopPrice   float64 // 条件单触发价，普通限价单为0
+	Quantity    float64 // 下单数量 (币)
+	ExecutedQty float64 // 已成交数量 (币)
+}
+
+// GetOpenOrders 获取交易对上的全部挂单 (包括非本进程下的订单)
+func (c *Client) GetOpenOrders(ctx context.Context, symbol string) ([]OpenOrder, error) {
+	var orders []OpenOrder
+	var err error
+	switch {
+	case c.isFutures():
+		orders, err = c.getFuturesOpenOrders(ctx, symbol)
+	case c.isMargin():
+		orders, err = c.getMarginOpenOrders(ctx, symbol)
+	default:
+		orders, err = c.getSpotOpenOrders(ctx, symbol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open orders for %s: %w", symbol, err)
+	}
+
+	c.logger.Debug("Fetched Binance open orders",
+		zap.String("symbol", symbol),
+		zap.Int("orders", len(orders)),
+	)
+
+	return orders, nil
+}
+
+// getSpotOpenOrders 获取现货挂单
+func (c *Client) getSpotOpenOrders(ctx context.Context, symbol string) ([]OpenOrder, error) {
+	orders, err := call(ctx, c, c.limiter, weightOpenOrders, "open orders", func(ctx context.Context) ([]*binance.Order, error) {
+		return c.client.NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newOpenOrders(orders)
+}
+
+// getMarginOpenOrders 获取杠杆账户挂单
+func (c *Client) getMarginOpenOrders(ctx context.Context, symbol string) ([]OpenOrder, error) {
+	orders, err := call(ctx, c, c.limiter, weightMarginOpenOrders, "margin open orders", func(ctx context.Context) ([]*binance.Order, error) {
+		return c.client.NewListMarginOpenOrdersService().
+			Symbol(symbol).
+			IsIsolated(c.config.MarginIsolated).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newOpenOrders(orders)
+}
+
+// getFuturesOpenOrders 获取合约挂单
+func (c *Client) getFuturesOpenOrders(ctx context.Context, symbol string) ([]OpenOrder, error) {
+	orders, err := call(ctx, c, c.futuresLimiter, weightFuturesOpenOrders, "futures open orders", func(ctx context.Context) ([]*futures.Order, error) {
+		return c.futuresClient.NewListOpenOrdersService().Symbol(symbol).Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]OpenOrder, 0, len(orders))
+	for _, o := range orders {
+		order, err := newOpenOrder(o.OrderID, -1, o.Symbol, string(o.Side), string(o.Type), o.Price, o.StopPrice, o.OrigQuantity, o.ExecutedQuantity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+	return result, nil
+}
+
+// newOpenOrders 解析现货/杠杆挂单
+func newOpenOrders(orders []*binance.Order) ([]OpenOrder, error) {
+	result := make([]OpenOrder, 0, len(orders))
+	for _, o := range orders {
+		order, err := newOpenOrder(o.OrderID, o.OrderListId, o.Symbol, string(o.Side), string(o.Type), o.Price, o.StopPrice, o.OrigQuantity, o.ExecutedQuantity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+	return result, nil
+}
+
+// newOpenOrder 解析挂单的价格和数量，空的触发价视为0
+func newOpenOrder(orderID, listID int64, symbol, side, orderType, price, stopPrice, quantity, executedQty string) (OpenOrder, error) {
+	order := OpenOrder{
+		OrderID: orderID,
+		ListID:  listID,
+		Symbol:  symbol,
+		Side:    side,
+		Type:    orderType,
+	}
+
+	var err error
+	if order.Price, err = strconv.ParseFloat(price, 64); err != nil {
+		return order, fmt.Errorf("failed to parse order %d price: %w", orderID, err)
+	}
+	if stopPrice != "" {
+		if order.StopPrice, err = strconv.ParseFloat(stopPrice, 64); err != nil {
+			return order, fmt.Errorf("failed to parse order %d stop price: %w", orderID, err)
+		}
+	}
+	if order.Quantity, err = strconv.ParseFloat(quantity, 64); err != nil {
+		return order, fmt.Errorf("failed to parse order %d quantity: %w", orderID, err)
+	}
+	if order.ExecutedQty, err = strconv.ParseFloat(executedQty, 64); err != nil {
+		return order, fmt.Errorf("failed to parse order %d executed quantity: %w", orderID, err)
+	}
+	return order, nil
+}
@@ -0,0 +1,162 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+// maxClockSkew 本机时钟与交易所时间的最大偏差，超过时签名请求会因 recvWindow (默认5s) 被拒绝
+const maxClockSkew = 5 * time.Second
+
+// Preflight 启动前检查连通性、时钟偏差、API Key和交易权限，
+// 缺少当前交易市场需要的权限时返回包含全部问题的错误
+func (c *Client) Preflight(ctx context.Context) error {
+	if err := c.checkClockSkew(ctx); err != nil {
+		return err
+	}
+
+	var problems []error
+
+	// 测试网不提供 sapi 接口，只通过账户接口检查密钥
+	if !c.config.Testnet {
+		if err := c.checkAPIPermissions(ctx); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if err := c.checkAccount(ctx); err != nil {
+		problems = append(problems, err)
+	}
+
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+
+	c.logger.Info("Binance preflight passed", zap.String("market", c.market), zap.Bool("testnet", c.config.Testnet))
+	return nil
+}
+
+// checkClockSkew 检查交易所连通性和本机时钟偏差
+func (c *Client) checkClockSkew(ctx context.Context) error {
+	var serverTime int64
+	var err error
+	if c.isFutures() {
+		serverTime, err = call(ctx, c, c.futuresLimiter, weightFuturesServerTime, "futures server time", func(ctx context.Context) (int64, error) {
+			return c.futuresClient.NewServerTimeService().Do(ctx)
+		})
+	} else {
+		serverTime, err = call(ctx, c, c.limiter, weightServerTime, "server time", func(ctx context.Context) (int64, error) {
+			return c.client.NewServerTimeService().Do(ctx)
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("binance is unreachable: %w", err)
+	}
+
+	skew := time.Since(time.UnixMilli(serverTime))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxClockSkew {
+		return fmt.Errorf("local clock is %s off Binance server time (max %s), sync the system clock", skew.Truncate(time.Millisecond), maxClockSkew)
+	}
+
+	c.logger.Info("Binance reachable", zap.Duration("clock_skew", skew))
+	return nil
+}
+
+// checkAPIPermissions 检查API Key是否开通当前交易市场需要的权限
+func (c *Client) checkAPIPermissions(ctx context.Context) error {
+	perm, err := call(ctx, c, c.limiter, weightAPIPermissions, "api permissions", func(ctx context.Context) (*binance.APIKeyPermission, error) {
+		return c.client.NewGetAPIKeyPermission().Do(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get API key permissions: %w", describeKeyError(err))
+	}
+
+	var missing []string
+	if !perm.EnableReading {
+		missing = append(missing, "Enable Reading")
+	}
+	switch c.market {
+	case MarketFutures:
+		if !perm.EnableFutures {
+			missing = append(missing, "Enable Futures")
+		}
+	case MarketMargin:
+		if !perm.EnableSpotAndMarginTrading {
+			missing = append(missing, "Enable Spot & Margin Trading")
+		}
+		if !perm.EnableMargin {
+			missing = append(missing, "Enable Margin Loan, Repay & Transfer")
+		}
+	default:
+		if !perm.EnableSpotAndMarginTrading {
+			missing = append(missing, "Enable Spot & Margin Trading")
+		}
+	}
+
+	if perm.EnableWithdrawals {
+		c.logger.Warn("Binance API key has withdrawals enabled, consider disabling it")
+	}
+	if !perm.IPRestrict {
+		c.logger.Warn("Binance API key is not restricted to trusted IPs")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("binance API key is missing permissions for %s market: %v", c.market, missing)
+	}
+	return nil
+}
+
+// checkAccount 检查签名和账户交易权限 (canTrade)
+func (c *Client) checkAccount(ctx context.Context) error {
+	var canTrade bool
+	switch c.market {
+	case MarketFutures:
+		account, err := call(ctx, c, c.futuresLimiter, weightFuturesAccount, "futures account", func(ctx context.Context) (*futures.Account, error) {
+			return c.futuresClient.NewGetAccountService().Do(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get futures account: %w", describeKeyError(err))
+		}
+		canTrade = account.CanTrade
+	default:
+		account, err := call(ctx, c, c.limiter, weightAccount, "account", func(ctx context.Context) (*binance.Account, error) {
+			return c.client.NewGetAccountService().Do(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", describeKeyError(err))
+		}
+		canTrade = account.CanTrade
+	}
+
+	if !canTrade {
+		return fmt.Errorf("binance %s account is not allowed to trade", c.market)
+	}
+	return nil
+}
+
+// describeKeyError 为API Key相关错误补充可能的原因
+func describeKeyError(err error) error {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.Code {
+	case codeInvalidSig:
+		return fmt.Errorf("%w (secret_key does not match api_key)", err)
+	case codeInvalidAPIKey:
+		return fmt.Errorf("%w (api_key is malformed)", err)
+	case codeRejectedAPIKey:
+		return fmt.Errorf("%w (invalid api_key, IP not whitelisted, or missing permission; testnet keys only work with binance.testnet)", err)
+	}
+	return err
+}
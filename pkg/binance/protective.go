@@ -0,0 +1,164 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+// 保护单类型
+const (
+	StopKindStopLoss   = "STOP_LOSS"   // 止损：价格向不利方向触及触发价
+	StopKindTakeProfit = "TAKE_PROFIT" // 止盈：价格向有利方向触及触发价
+)
+
+// StopOrderRequest 条件单请求：价格触及 StopPrice 后按 Price 挂限价单
+type StopOrderRequest struct {
+	Symbol    string
+	Side      binance.SideType
+	Kind      string // StopKindStopLoss / StopKindTakeProfit
+	Quantity  string
+	StopPrice string
+	Price     string
+}
+
+// PlaceStopOrder 按币数量下止损/止盈条件单，触发后以 limitPrice 挂限价单。
+// 合约单向持仓模式下为只减仓单，不会反向开仓
+func (c *Client) PlaceStopOrder(ctx context.Context, symbol, side, kind string, quantity, stopPrice, limitPrice float64) (*OrderStatus, error) {
+	if kind != StopKindStopLoss && kind != StopKindTakeProfit {
+		return nil, fmt.Errorf("unknown stop order kind: %s", kind)
+	}
+	if stopPrice <= 0 || limitPrice <= 0 {
+		return nil, fmt.Errorf("invalid stop order price for %s: stop=%f limit=%f", symbol, stopPrice, limitPrice)
+	}
+
+	sideType := binance.SideType(side)
+	req := &StopOrderRequest{
+		Symbol:    symbol,
+		Side:      sideType,
+		Kind:      kind,
+		Quantity:  c.formatQuantity(symbol, quantity),
+		StopPrice: c.formatPrice(symbol, sideType, stopPrice),
+		Price:     c.formatPrice(symbol, sideType, limitPrice),
+	}
+
+	c.logger.Info("Placing stop order",
+		zap.String("market", c.market),
+		zap.String("symbol", req.Symbol),
+		zap.String("side", side),
+		zap.String("kind", kind),
+		zap.String("quantity", req.Quantity),
+		zap.String("stop_price", req.StopPrice),
+		zap.String("price", req.Price),
+	)
+
+	if err := c.checkOrderFilters(req.Symbol, req.Quantity, req.Price); err != nil {
+		return nil, err
+	}
+
+	var order *OrderStatus
+	var err error
+	switch {
+	case c.isFutures():
+		order, err = c.placeFuturesStopOrder(ctx, req)
+	case c.isMargin():
+		order, err = c.placeMarginStopOrder(ctx, req)
+	default:
+		order, err = c.placeSpotStopOrder(ctx, req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to place %s order: %w", kind, err)
+	}
+
+	c.logger.Info("Stop order placed successfully",
+		zap.Int64("order_id", order.OrderID),
+		zap.String("symbol", req.Symbol),
+		zap.String("kind", kind),
+	)
+
+	return order, nil
+}
+
+// spotStopOrderType 现货/杠杆条件单类型
+func spotStopOrderType(kind string) binance.OrderType {
+	if kind == StopKindTakeProfit {
+		return binance.OrderTypeTakeProfitLimit
+	}
+	return binance.OrderTypeStopLossLimit
+}
+
+// placeSpotStopOrder 在现货市场下条件限价单
+func (c *Client) placeSpotStopOrder(ctx context.Context, req *StopOrderRequest) (*OrderStatus, error) {
+	order, err := callOrder(ctx, c, c.limiter, weightCreateOrder, "create stop order", func(ctx context.Context) (*binance.CreateOrderResponse, error) {
+		return c.client.NewCreateOrderService().
+			Symbol(req.Symbol).
+			Side(req.Side).
+			Type(spotStopOrderType(req.Kind)).
+			TimeInForce(binance.TimeInForceTypeGTC).
+			Quantity(req.Quantity).
+			Price(req.Price).
+			StopPrice(req.StopPrice).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
+
+// placeMarginStopOrder 在杠杆账户下条件限价单，成交后自动还款
+func (c *Client) placeMarginStopOrder(ctx context.Context, req *StopOrderRequest) (*OrderStatus, error) {
+	order, err := callOrder(ctx, c, c.limiter, weightMarginCreateOrder, "create margin stop order", func(ctx context.Context) (*binance.CreateOrderResponse, error) {
+		return c.client.NewCreateMarginOrderService().
+			Symbol(req.Symbol).
+			IsIsolated(c.config.MarginIsolated).
+			Side(req.Side).
+			Type(spotStopOrderType(req.Kind)).
+			TimeInForce(binance.TimeInForceTypeGTC).
+			Quantity(req.Quantity).
+			Price(req.Price).
+			StopPrice(req.StopPrice).
+			SideEffectType(binance.SideEffectTypeAutoRepay).
+			Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
+
+// placeFuturesStopOrder 在合约市场下条件限价单
+func (c *Client) placeFuturesStopOrder(ctx context.Context, req *StopOrderRequest) (*OrderStatus, error) {
+	orderType := futures.OrderTypeStop
+	if req.Kind == StopKindTakeProfit {
+		orderType = futures.OrderTypeTakeProfit
+	}
+
+	order, err := callOrder(ctx, c, c.futuresLimiter, weightFuturesCreateOrder, "create futures stop order", func(ctx context.Context) (*futures.CreateOrderResponse, error) {
+		svc := c.futuresClient.NewCreateOrderService().
+			Symbol(req.Symbol).
+			Side(futures.SideType(req.Side)).
+			PositionSide(c.positionSide(req.Symbol)).
+			Type(orderType).
+			TimeInForce(futures.TimeInForceTypeGTC).
+			Quantity(req.Quantity).
+			Price(req.Price).
+			StopPrice(req.StopPrice).
+			WorkingType(futures.WorkingTypeMarkPrice)
+		// 双向持仓模式不接受reduceOnly，由持仓方向保证只平仓
+		if !c.dualSidePosition {
+			svc = svc.ReduceOnly(true)
+		}
+		return svc.Do(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderStatus(order.OrderID, string(order.Status), order.Price, order.ExecutedQuantity)
+}
@@ -0,0 +1,190 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 现货接口请求权重 (见 Binance API 文档 "Request Weight")
+const (
+	weightCreateOrder      = 1
+	weightCancelOrder      = 1
+	weightCancelOpenOrders = 1
+	weightTickerPrice      = 2
+	weightBookTickers      = 4 // 全部交易对
+	weightDepth100         = 5
+	weightKlines           = 2
+	weightGetOrder         = 4
+	weightExchangeInfo     = 20
+	weightAccount          = 20
+	weightCreateOCO        = 1
+	weightCancelOCO        = 1
+	weightTradeFee         = 1
+	weightOpenOrders       = 6
+	weightServerTime       = 1
+	weightAPIPermissions   = 1
+
+	// 杠杆接口 (sapi) 权重，与现货共用限流器
+	weightMarginCreateOrder = 6
+	weightMarginCancelOrder = 10
+	weightMarginCancelAll   = 1
+	weightMarginGetOrder    = 10
+	weightMarginAccount     = 10
+	weightMarginCreateOCO   = 6
+	weightMarginCancelOCO   = 1
+	weightMarginOpenOrders  = 10
+	weightMarginMaxBorrow   = 50
+	weightUniversalTransfer = 1 // 另按UID计权重
+	weightWithdraw          = 1 // 另按UID计权重
+
+	// U本位合约接口单独计权重
+	weightPremiumIndex        = 1
+	weightFuturesCreateOrder  = 1
+	weightFuturesCancelOrder  = 1
+	weightFuturesOpenOrders   = 1
+	weightFuturesCancelAll    = 1
+	weightFuturesBatchOrders  = 5
+	weightFuturesBatchCancel  = 1
+	weightFuturesTickerPrice  = 1
+	weightFuturesBookTickers  = 5 // 全部交易对
+	weightFuturesDepth100     = 5
+	weightFuturesKlines       = 5
+	weightFuturesGetOrder     = 1
+	weightFuturesExchangeInfo = 1
+	weightFuturesAccount      = 5
+	weightFuturesPositionRisk = 5
+	weightFuturesPositionMode = 30
+	weightFuturesLeverage     = 1
+	weightFuturesMarginType   = 1
+	weightFuturesCommission   = 20
+	weightFuturesServerTime   = 1
+)
+
+// RateLimitStats 限流器统计
+type RateLimitStats struct {
+	Requests    uint64        `json:"requests"`     // 已放行请求数
+	TotalWeight uint64        `json:"total_weight"` // 已消耗权重
+	Throttled   uint64        `json:"throttled"`    // 需要排队等待的请求数
+	TotalWait   time.Duration `json:"total_wait"`   // 累计等待时间
+	MaxWait     time.Duration `json:"max_wait"`     // 单次最长等待时间
+	Queued      int64         `json:"queued"`       // 当前排队中的请求数
+	Available   float64       `json:"available"`    // 当前可用权重
+}
+
+// RateLimiter 按请求权重计数的令牌桶：每分钟补充 weightPerMinute 个令牌，
+// 令牌不足时请求按到达顺序排队等待，避免触发交易所IP封禁。
+type RateLimiter struct {
+	capacity   float64
+	refillRate float64 // 每秒补充的令牌数
+
+	queue      sync.Mutex // 排队锁：同一时间只有队首请求在等待令牌
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+
+	queued int64
+	stats  RateLimitStats
+}
+
+// NewRateLimiter 创建限流器，weightPerMinute 为每分钟允许的总权重
+func NewRateLimiter(weightPerMinute int) *RateLimiter {
+	capacity := float64(weightPerMinute)
+	return &RateLimiter{
+		capacity:   capacity,
+		refillRate: capacity / 60,
+		tokens:     capacity,
+		lastRefill: time.Now(),
+	}
+}
+
+// Wait 阻塞直到获得 weight 个令牌或ctx取消
+func (l *RateLimiter) Wait(ctx context.Context, weight int) error {
+	if l == nil || l.capacity <= 0 {
+		return nil
+	}
+
+	need := float64(weight)
+	if need > l.capacity {
+		return fmt.Errorf("request weight %d exceeds limiter capacity %.0f", weight, l.capacity)
+	}
+
+	atomic.AddInt64(&l.queued, 1)
+	defer atomic.AddInt64(&l.queued, -1)
+
+	l.queue.Lock()
+	defer l.queue.Unlock()
+
+	start := time.Now()
+	for {
+		wait := l.reserve(need)
+		if wait == 0 {
+			break
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	l.record(weight, time.Since(start))
+	return nil
+}
+
+// reserve 尝试扣除令牌，成功返回0，否则返回需要等待的时间
+func (l *RateLimiter) reserve(need float64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.lastRefill).Seconds() * l.refillRate
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+	l.lastRefill = now
+
+	if l.tokens >= need {
+		l.tokens -= need
+		return 0
+	}
+
+	return time.Duration((need - l.tokens) / l.refillRate * float64(time.Second))
+}
+
+// record 记录放行请求的统计
+func (l *RateLimiter) record(weight int, waited time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests++
+	l.stats.TotalWeight += uint64(weight)
+	if waited > time.Millisecond {
+		l.stats.Throttled++
+		l.stats.TotalWait += waited
+		if waited > l.stats.MaxWait {
+			l.stats.MaxWait = waited
+		}
+	}
+}
+
+// Stats 获取限流统计快照
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := l.stats
+	stats.Queued = atomic.LoadInt64(&l.queued)
+	stats.Available = l.tokens
+	return stats
+}
+
+// RateLimitStats 获取现货和合约接口的限流统计
+func (c *Client) RateLimitStats() (spot, futures RateLimitStats) {
+	return c.limiter.Stats(), c.futuresLimiter.Stats()
+}
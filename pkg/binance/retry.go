@@ -0,0 +1,137 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+
+	"cs-projects-backpack/pkg/breaker"
+	"cs-projects-backpack/pkg/killswitch"
+	"cs-projects-backpack/pkg/retry"
+)
+
+// Binance 错误码 (见 API 文档 "Error Codes")
+const (
+	codeDisconnected    = -1001 // 内部错误，无法处理请求
+	codeTooManyRequests = -1003 // 请求权重超限
+	codeUnexpectedResp  = -1006 // 撮合返回异常，订单状态未知
+	codeTimeout         = -1007 // 等待撮合超时，订单状态未知
+	codeServerBusy      = -1008 // 服务器过载，请求被拒绝
+	codeTooManyOrders   = -1015 // 下单频率超限
+	codeInvalidTime     = -1021 // 时间戳超出 recvWindow
+	codeInvalidSig      = -1022 // 签名错误 (secret_key 与 api_key 不匹配)
+	codeUnknownOrder    = -2011 // 撤单时订单不存在 (无挂单)
+	codeInvalidAPIKey   = -2014 // API Key格式错误
+	codeRejectedAPIKey  = -2015 // API Key无效、IP不在白名单或没有该操作的权限
+	codeNoNeedMargin    = -4046 // 保证金模式已是目标模式，无需修改
+)
+
+// SetRetryPolicy 设置接口重试策略
+func (c *Client) SetRetryPolicy(p retry.Policy) {
+	c.retryPolicy = p
+}
+
+// SetRequestTimeouts 设置单次请求超时，超时的请求被中断，不会阻塞对冲路径。
+// 查询和撤单超时后按重试策略重试，下单超时视为结果未知，交给订单监控处理
+func (c *Client) SetRequestTimeouts(order, query time.Duration) {
+	c.orderTimeout = order
+	c.queryTimeout = query
+}
+
+// SetCircuitBreaker 设置熔断器，连续失败达到阈值后暂停下单
+func (c *Client) SetCircuitBreaker(b *breaker.Breaker) {
+	c.breaker = b
+}
+
+// SetKillSwitch 设置紧急停止开关，触发后拒绝下单 (只减仓单和经 killswitch.Exempt 标记的对冲/平仓单除外)
+func (c *Client) SetKillSwitch(s *killswitch.Switch) {
+	c.killSwitch = s
+}
+
+// call 在限流和重试保护下执行查询/撤单等幂等请求，每次尝试都会消耗权重，单次请求受 queryTimeout 限制
+func call[T any](ctx context.Context, c *Client, limiter *RateLimiter, weight int, op string, fn func(ctx context.Context) (T, error)) (T, error) {
+	result, err := retry.DoValue(ctx, c.retryPolicy.WithRetryable(isRetryable), op, func(ctx context.Context) (T, error) {
+		if err := limiter.Wait(ctx, weight); err != nil {
+			var zero T
+			return zero, retry.Permanent(err)
+		}
+		return retry.WithTimeout(ctx, c.queryTimeout, fn)
+	})
+	c.recordResult(ctx, err)
+	return result, err
+}
+
+// callOrder 同 call，用于下单：紧急停止 (ctx 经 killswitch.Exempt 标记的除外) 或熔断期间直接拒绝，
+// 只重试确定未被受理的错误，避免重复下单。单次请求受 orderTimeout 限制
+func callOrder[T any](ctx context.Context, c *Client, limiter *RateLimiter, weight int, op string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if err := c.killSwitch.AllowOrder(ctx, false); err != nil {
+		var zero T
+		return zero, err
+	}
+	if err := c.breaker.Allow(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	result, err := retry.DoValue(ctx, c.retryPolicy.WithRetryable(isSafeToResend), op, func(ctx context.Context) (T, error) {
+		if err := limiter.Wait(ctx, weight); err != nil {
+			var zero T
+			return zero, retry.Permanent(err)
+		}
+		return retry.WithTimeout(ctx, c.orderTimeout, fn)
+	})
+	c.recordResult(ctx, err)
+	return result, err
+}
+
+// recordResult 将请求结果计入熔断器：网络错误、超时和服务端异常计为失败，
+// 参数/余额等业务错误说明交易所可用，计为成功
+func (c *Client) recordResult(ctx context.Context, err error) {
+	switch {
+	case err == nil:
+		c.breaker.Success()
+	case ctx.Err() != nil:
+		// 调用方取消，不代表交易所状态
+	case isRetryable(err):
+		c.breaker.Failure(err)
+	default:
+		c.breaker.Success()
+	}
+}
+
+// isRetryable 幂等请求的错误分类：网络错误、限频、服务端异常可重试，参数/余额等业务错误不重试
+func isRetryable(err error) bool {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return retry.IsRetryable(err)
+	}
+
+	if !apiErr.IsValid() {
+		// 非JSON响应 (通常是网关5xx)
+		return true
+	}
+
+	switch apiErr.Code {
+	case codeDisconnected, codeTooManyRequests, codeUnexpectedResp, codeTimeout,
+		codeServerBusy, codeTooManyOrders, codeInvalidTime:
+		return true
+	}
+	return false
+}
+
+// isSafeToResend 下单请求的错误分类：仅重试交易所明确拒绝或未送达的请求，
+// 超时等状态未知的错误交给订单监控处理
+func isSafeToResend(err error) bool {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return retry.IsNotSent(err)
+	}
+
+	switch apiErr.Code {
+	case codeTooManyRequests, codeServerBusy, codeTooManyOrders, codeInvalidTime:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+
+	"cs-projects-backpack/pkg/retry"
+)
+
+func TestRetryClassification(t *testing.T) {
+	apiErr := func(code int64) error {
+		return fmt.Errorf("create order: %w", &common.APIError{Code: code, Message: "test"})
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool // 查询/撤单重试
+		resend    bool // 下单重发
+	}{
+		{name: "disconnected", err: apiErr(codeDisconnected), retryable: true},
+		{name: "too many requests", err: apiErr(codeTooManyRequests), retryable: true, resend: true},
+		{name: "unexpected response", err: apiErr(codeUnexpectedResp), retryable: true},
+		{name: "matching timeout", err: apiErr(codeTimeout), retryable: true},
+		{name: "server busy", err: apiErr(codeServerBusy), retryable: true, resend: true},
+		{name: "too many orders", err: apiErr(codeTooManyOrders), retryable: true, resend: true},
+		{name: "invalid timestamp", err: apiErr(codeInvalidTime), retryable: true, resend: true},
+		{name: "insufficient balance", err: apiErr(-2010)},
+		{name: "invalid signature", err: apiErr(codeInvalidSig)},
+		{name: "gateway error page", err: &common.APIError{Response: []byte("<html>502 Bad Gateway</html>")}, retryable: true},
+		{name: "attempt timeout", err: fmt.Errorf("%w after 10s", retry.ErrTimeout), retryable: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, retryable: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, retryable: true, resend: true},
+		{name: "business error", err: errors.New("invalid quantity")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.retryable {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.retryable)
+			}
+			if got := isSafeToResend(tt.err); got != tt.resend {
+				t.Errorf("isSafeToResend(%v) = %v, want %v", tt.err, got, tt.resend)
+			}
+		})
+	}
+}
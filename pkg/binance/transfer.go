@@ -0,0 +1,84 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2"
+	"go.uber.org/zap"
+)
+
+// TransferToFutures 从现货钱包划转资产到U本位合约钱包，返回划转ID。
+// 数量按2位小数向下取整；划转属于资金操作，紧急停止或熔断期间拒绝，结果未知时不重试
+func (c *Client) TransferToFutures(ctx context.Context, asset string, amount float64) (int64, error) {
+	return c.transfer(ctx, binance.UserUniversalTransferTypeMainToUmFutures, asset, amount)
+}
+
+// TransferToSpot 从U本位合约钱包划转资产到现货钱包，返回划转ID
+func (c *Client) TransferToSpot(ctx context.Context, asset string, amount float64) (int64, error) {
+	return c.transfer(ctx, binance.UserUniversalTransferTypeUmFuturesToMain, asset, amount)
+}
+
+// transfer 执行钱包间划转 (/sapi/v1/asset/transfer)
+func (c *Client) transfer(ctx context.Context, transferType binance.UserUniversalTransferType, asset string, amount float64) (int64, error) {
+	amount = math.Floor(amount*100) / 100
+	if amount <= 0 {
+		return 0, fmt.Errorf("transfer amount must be at least 0.01 %s", asset)
+	}
+
+	resp, err := callOrder(ctx, c, c.limiter, weightUniversalTransfer, "universal transfer", func(ctx context.Context) (*binance.CreateUserUniversalTransferResponse, error) {
+		return c.client.NewUserUniversalTransferService().
+			Type(transferType).
+			Asset(asset).
+			Amount(strconv.FormatFloat(amount, 'f', 2, 64)).
+			Do(ctx)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to transfer %.2f %s (%s): %w", amount, asset, transferType, err)
+	}
+
+	c.logger.Info("Binance wallet transfer completed",
+		zap.String("type", string(transferType)),
+		zap.String("asset", asset),
+		zap.Float64("amount", amount),
+		zap.Int64("tran_id", resp.ID),
+	)
+	return resp.ID, nil
+}
+
+// GetSpotFreeBalance 获取现货钱包资产的可用数量 (不含冻结)
+func (c *Client) GetSpotFreeBalance(ctx context.Context, asset string) (float64, error) {
+	return c.getSpotFreeBalance(ctx, "", asset)
+}
+
+// Withdraw 从现货钱包提现到外部地址，返回提现ID。数量按2位小数向下取整，network 为提现网络 (如 ARBITRUM)。
+// 地址需在交易所提现白名单中；与划转相同，紧急停止或熔断期间拒绝，结果未知时不重试
+func (c *Client) Withdraw(ctx context.Context, asset, network, address string, amount float64) (string, error) {
+	amount = math.Floor(amount*100) / 100
+	if amount <= 0 {
+		return "", fmt.Errorf("withdraw amount must be at least 0.01 %s", asset)
+	}
+
+	resp, err := callOrder(ctx, c, c.limiter, weightWithdraw, "withdraw", func(ctx context.Context) (*binance.CreateWithdrawResponse, error) {
+		return c.client.NewCreateWithdrawService().
+			Coin(asset).
+			Network(network).
+			Address(address).
+			Amount(strconv.FormatFloat(amount, 'f', 2, 64)).
+			Do(ctx)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to withdraw %.2f %s via %s: %w", amount, asset, network, err)
+	}
+
+	c.logger.Info("Binance withdrawal submitted",
+		zap.String("asset", asset),
+		zap.String("network", network),
+		zap.String("address", address),
+		zap.Float64("amount", amount),
+		zap.String("withdraw_id", resp.ID),
+	)
+	return resp.ID, nil
+}
@@ -0,0 +1,218 @@
+package binance
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// 交易所每分钟请求权重上限 (按IP计算)，自适应降频按已用权重占上限的比例计算
+const (
+	spotWeightLimit    = 6000
+	futuresWeightLimit = 2400
+)
+
+// headerUsedWeight 响应头中当前分钟已用权重 (含同一IP下其他进程的请求)
+const headerUsedWeight = "X-Mbx-Used-Weight-1m"
+
+// usedWeightTTL 已用权重按分钟重置，超过该时长没有新响应时视为0
+const usedWeightTTL = time.Minute
+
+// usedWeight 从响应头解析的交易所已用权重
+type usedWeight struct {
+	api       string // spot, futures
+	limit     int64
+	used      atomic.Int64
+	updatedAt atomic.Int64 // UnixNano
+}
+
+func newUsedWeight(api string, limit int64) *usedWeight {
+	return &usedWeight{api: api, limit: limit}
+}
+
+// update 记录响应头中的已用权重，没有该响应头时不处理
+func (w *usedWeight) update(header http.Header) {
+	value := header.Get(headerUsedWeight)
+	if value == "" {
+		return
+	}
+	used, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return
+	}
+	w.used.Store(used)
+	w.updatedAt.Store(time.Now().UnixNano())
+}
+
+// current 当前分钟已用权重，数据过期时返回0
+func (w *usedWeight) current() int64 {
+	updatedAt := w.updatedAt.Load()
+	if updatedAt == 0 || time.Since(time.Unix(0, updatedAt)) > usedWeightTTL {
+		return 0
+	}
+	return w.used.Load()
+}
+
+// ratio 已用权重占上限的比例
+func (w *usedWeight) ratio() float64 {
+	return float64(w.current()) / float64(w.limit)
+}
+
+// wrap 返回记录已用权重的HTTP客户端，与 base 共用连接池和代理设置
+func (w *usedWeight) wrap(base *http.Client) *http.Client {
+	rt := base.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: &weightTransport{base: rt, weight: w},
+		Timeout:   base.Timeout,
+	}
+}
+
+// weightTransport 在响应返回时记录已用权重
+type weightTransport struct {
+	base   http.RoundTripper
+	weight *usedWeight
+}
+
+func (t *weightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		t.weight.update(resp.Header)
+	}
+	return resp, err
+}
+
+// UsedWeight 交易所报告的当前分钟已用权重 (现货、合约)
+func (c *Client) UsedWeight() (spot, futures int64) {
+	return c.spotWeight.current(), c.futuresWeight.current()
+}
+
+// PollingSlowdown 自适应降频倍数 (>=1)，监控循环按该倍数放慢轮询：
+// 现货或合约已用权重超过上限的 throttle_start_percent 后，倍数随用量线性增加，用满时为 throttle_max_slowdown
+func (c *Client) PollingSlowdown() float64 {
+	if !c.config.AdaptiveThrottle {
+		return 1
+	}
+
+	usage := c.spotWeight.ratio()
+	if r := c.futuresWeight.ratio(); r > usage {
+		usage = r
+	}
+
+	start := c.config.ThrottleStartPercent / 100
+	slowdown := 1.0
+	if usage > start {
+		progress := (usage - start) / (1 - start)
+		if progress > 1 {
+			progress = 1
+		}
+		slowdown = 1 + (c.config.ThrottleMaxSlowdown-1)*progress
+	}
+
+	// 进入和退出降频时各记录一次
+	throttling := slowdown > 1
+	if c.throttling.CompareAndSwap(!throttling, throttling) {
+		spot, futures := c.UsedWeight()
+		if throttling {
+			c.logger.Warn("Binance request weight approaching limit, slowing down polling",
+				zap.Int64("spot_used_weight", spot),
+				zap.Int64("futures_used_weight", futures),
+				zap.Float64("slowdown", slowdown),
+			)
+		} else {
+			c.logger.Info("Binance request weight back to normal, polling at full rate",
+				zap.Int64("spot_used_weight", spot),
+				zap.Int64("futures_used_weight", futures),
+			)
+		}
+	}
+
+	return slowdown
+}
+
+var (
+	usedWeightDesc = prometheus.NewDesc(
+		"binance_used_weight_1m",
+		"Request weight used in the current minute as reported by the X-MBX-USED-WEIGHT-1M header (per IP).",
+		[]string{"api"}, nil,
+	)
+	weightLimitDesc = prometheus.NewDesc(
+		"binance_weight_limit_1m",
+		"Request weight limit per minute used for adaptive throttling.",
+		[]string{"api"}, nil,
+	)
+	pollingSlowdownDesc = prometheus.NewDesc(
+		"binance_polling_slowdown",
+		"Polling interval multiplier currently applied by adaptive throttling (1 = full rate).",
+		nil, nil,
+	)
+	rateLimitRequestsDesc = prometheus.NewDesc(
+		"binance_rate_limit_requests_total",
+		"Requests let through by the local request weight limiter.",
+		[]string{"api"}, nil,
+	)
+	rateLimitQueuedDesc = prometheus.NewDesc(
+		"binance_rate_limit_queued",
+		"Requests currently waiting for request weight in the local limiter.",
+		[]string{"api"}, nil,
+	)
+	rateLimitThrottledDesc = prometheus.NewDesc(
+		"binance_rate_limit_throttled_total",
+		"Requests that had to wait for request weight in the local limiter.",
+		[]string{"api"}, nil,
+	)
+	rateLimitWaitDesc = prometheus.NewDesc(
+		"binance_rate_limit_wait_seconds_total",
+		"Total time requests spent waiting for request weight in the local limiter.",
+		[]string{"api"}, nil,
+	)
+	rateLimitMaxWaitDesc = prometheus.NewDesc(
+		"binance_rate_limit_max_wait_seconds",
+		"Longest single wait for request weight in the local limiter.",
+		[]string{"api"}, nil,
+	)
+)
+
+// weightCollector 以Prometheus指标导出已用权重、降频倍数和本地限流器统计
+type weightCollector struct {
+	client *Client
+}
+
+// Metrics 获取请求权重的Prometheus指标
+func (c *Client) Metrics() prometheus.Collector {
+	return &weightCollector{client: c}
+}
+
+func (wc *weightCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- usedWeightDesc
+	ch <- weightLimitDesc
+	ch <- pollingSlowdownDesc
+	ch <- rateLimitRequestsDesc
+	ch <- rateLimitQueuedDesc
+	ch <- rateLimitThrottledDesc
+	ch <- rateLimitWaitDesc
+	ch <- rateLimitMaxWaitDesc
+}
+
+func (wc *weightCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, w := range []*usedWeight{wc.client.spotWeight, wc.client.futuresWeight} {
+		ch <- prometheus.MustNewConstMetric(usedWeightDesc, prometheus.GaugeValue, float64(w.current()), w.api)
+		ch <- prometheus.MustNewConstMetric(weightLimitDesc, prometheus.GaugeValue, float64(w.limit), w.api)
+	}
+	ch <- prometheus.MustNewConstMetric(pollingSlowdownDesc, prometheus.GaugeValue, wc.client.PollingSlowdown())
+
+	spot, futures := wc.client.RateLimitStats()
+	for api, s := range map[string]RateLimitStats{"spot": spot, "futures": futures} {
+		ch <- prometheus.MustNewConstMetric(rateLimitRequestsDesc, prometheus.CounterValue, float64(s.Requests), api)
+		ch <- prometheus.MustNewConstMetric(rateLimitQueuedDesc, prometheus.GaugeValue, float64(s.Queued), api)
+		ch <- prometheus.MustNewConstMetric(rateLimitThrottledDesc, prometheus.CounterValue, float64(s.Throttled), api)
+		ch <- prometheus.MustNewConstMetric(rateLimitWaitDesc, prometheus.CounterValue, s.TotalWait.Seconds(), api)
+		ch <- prometheus.MustNewConstMetric(rateLimitMaxWaitDesc, prometheus.GaugeValue, s.MaxWait.Seconds(), api)
+	}
+}
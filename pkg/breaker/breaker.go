@@ -0,0 +1,164 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State 熔断器状态
+type State string
+
+const (
+	StateClosed   State = "CLOSED"    // 正常放行
+	StateOpen     State = "OPEN"      // 熔断中，拒绝下单
+	StateHalfOpen State = "HALF_OPEN" // 冷却结束，放行试探请求
+)
+
+// ErrOpen 熔断期间拒绝下单
+var ErrOpen = errors.New("circuit breaker is open")
+
+// StateChangeFunc 状态变化回调，lastErr 为触发熔断的最后一次错误
+type StateChangeFunc func(name string, from, to State, lastErr error)
+
+// Status 熔断器状态快照
+type Status struct {
+	Name      string    `json:"name"`
+	State     State     `json:"state"`
+	Failures  int       `json:"failures"`             // 当前连续失败次数
+	OpenedAt  time.Time `json:"opened_at,omitempty"`  // 最近一次熔断时间
+	ResumeAt  time.Time `json:"resume_at,omitempty"`  // 预计恢复试探时间
+	LastError string    `json:"last_error,omitempty"` // 最近一次失败原因
+}
+
+// Breaker 连续失败熔断器：连续 threshold 次失败后熔断 cooldown 时长，
+// 冷却结束后进入半开状态，下一次请求成功则恢复，失败则重新熔断
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	lastErr  error
+	onChange StateChangeFunc
+}
+
+// New 创建熔断器
+func New(name string, threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     StateClosed,
+	}
+}
+
+// OnStateChange 设置状态变化回调
+func (b *Breaker) OnStateChange(fn StateChangeFunc) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onChange = fn
+}
+
+// Allow 检查是否允许下单，熔断期间返回 ErrOpen
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	if b.state != StateOpen {
+		b.mu.Unlock()
+		return nil
+	}
+
+	resumeAt := b.openedAt.Add(b.cooldown)
+	if time.Now().Before(resumeAt) {
+		b.mu.Unlock()
+		return fmt.Errorf("%s %w until %s", b.name, ErrOpen, resumeAt.Format(time.TimeOnly))
+	}
+
+	notify := b.transition(StateHalfOpen)
+	b.mu.Unlock()
+
+	notify()
+	return nil
+}
+
+// Success 记录一次成功请求
+func (b *Breaker) Success() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.failures = 0
+	notify := func() {}
+	if b.state == StateHalfOpen {
+		notify = b.transition(StateClosed)
+	}
+	b.mu.Unlock()
+
+	notify()
+}
+
+// Failure 记录一次失败请求 (错误或超时)
+func (b *Breaker) Failure(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.failures++
+	b.lastErr = err
+	notify := func() {}
+	switch b.state {
+	case StateHalfOpen:
+		b.openedAt = time.Now()
+		notify = b.transition(StateOpen)
+	case StateClosed:
+		if b.failures >= b.threshold {
+			b.openedAt = time.Now()
+			notify = b.transition(StateOpen)
+		}
+	}
+	b.mu.Unlock()
+
+	notify()
+}
+
+// Status 获取状态快照
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := Status{
+		Name:     b.name,
+		State:    b.state,
+		Failures: b.failures,
+		OpenedAt: b.openedAt,
+	}
+	if b.state == StateOpen {
+		status.ResumeAt = b.openedAt.Add(b.cooldown)
+	}
+	if b.lastErr != nil {
+		status.LastError = b.lastErr.Error()
+	}
+	return status
+}
+
+// transition 切换状态 (调用方持有锁)，返回在锁外执行的回调
+func (b *Breaker) transition(to State) func() {
+	from := b.state
+	b.state = to
+
+	fn, name, lastErr := b.onChange, b.name, b.lastErr
+	if fn == nil || from == to {
+		return func() {}
+	}
+	return func() { fn(name, from, to, lastErr) }
+}
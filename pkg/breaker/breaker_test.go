@@ -0,0 +1,127 @@
+package breaker
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// step 对熔断器的一次操作
+type step struct {
+	op    string // allow, success, failure
+	state State  // 操作后的状态
+	open  bool   // allow 返回 ErrOpen
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	errExchange := errors.New("exchange unavailable")
+
+	tests := []struct {
+		name     string
+		cooldown time.Duration
+		steps    []step
+		changes  []State // 状态变化回调收到的目标状态
+	}{
+		{
+			name:     "failures below threshold stay closed",
+			cooldown: time.Hour,
+			steps: []step{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "allow", state: StateClosed},
+			},
+		},
+		{
+			name:     "success resets failure count",
+			cooldown: time.Hour,
+			steps: []step{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "success", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+			},
+		},
+		{
+			name:     "threshold opens and rejects during cooldown",
+			cooldown: time.Hour,
+			steps: []step{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateOpen},
+				{op: "allow", state: StateOpen, open: true},
+				{op: "allow", state: StateOpen, open: true},
+			},
+			changes: []State{StateOpen},
+		},
+		{
+			name: "half-open probe success closes",
+			steps: []step{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateOpen},
+				{op: "allow", state: StateHalfOpen},
+				{op: "success", state: StateClosed},
+				{op: "failure", state: StateClosed},
+			},
+			changes: []State{StateOpen, StateHalfOpen, StateClosed},
+		},
+		{
+			name: "half-open probe failure reopens",
+			steps: []step{
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateClosed},
+				{op: "failure", state: StateOpen},
+				{op: "allow", state: StateHalfOpen},
+				{op: "failure", state: StateOpen},
+				{op: "allow", state: StateHalfOpen},
+				{op: "success", state: StateClosed},
+			},
+			changes: []State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New("binance", 3, tt.cooldown)
+			var changes []State
+			b.OnStateChange(func(name string, from, to State, lastErr error) {
+				if to == StateOpen && !errors.Is(lastErr, errExchange) {
+					t.Errorf("opened with last error %v, want %v", lastErr, errExchange)
+				}
+				changes = append(changes, to)
+			})
+
+			for i, s := range tt.steps {
+				switch s.op {
+				case "allow":
+					err := b.Allow()
+					if open := errors.Is(err, ErrOpen); open != s.open {
+						t.Fatalf("step %d: Allow() = %v, want open %v", i, err, s.open)
+					}
+				case "success":
+					b.Success()
+				case "failure":
+					b.Failure(errExchange)
+				}
+				if state := b.Status().State; state != s.state {
+					t.Fatalf("step %d (%s): state = %s, want %s", i, s.op, state, s.state)
+				}
+			}
+
+			if !slices.Equal(changes, tt.changes) {
+				t.Fatalf("state changes = %v, want %v", changes, tt.changes)
+			}
+		})
+	}
+}
+
+func TestNilBreakerAllows(t *testing.T) {
+	var b *Breaker
+	b.Failure(errors.New("exchange unavailable"))
+	b.Success()
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow() = %v, want nil", err)
+	}
+}
@@ -3,36 +3,180 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Lighter  LighterConfig  `mapstructure:"lighter"`
-	Binance  BinanceConfig  `mapstructure:"binance"`
-	Trading  TradingConfig  `mapstructure:"trading"`
-	Strategy StrategyConfig `mapstructure:"strategy"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
-	App      AppConfig      `mapstructure:"app"`
+	Lighter        LighterConfig        `mapstructure:"lighter"`
+	Binance        BinanceConfig        `mapstructure:"binance"`
+	Symbols        []SymbolConfig       `mapstructure:"symbols"`
+	Retry          RetryConfig          `mapstructure:"retry"`
+	RequestTimeout RequestTimeoutConfig `mapstructure:"request_timeout"`
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	KillSwitch     KillSwitchConfig     `mapstructure:"kill_switch"`
+	PriceFeed      PriceFeedConfig      `mapstructure:"price_feed"`
+	Trading        TradingConfig        `mapstructure:"trading"`
+	Strategy       StrategyConfig       `mapstructure:"strategy"`
+	Logging        LoggingConfig        `mapstructure:"logging"`
+	Journal        JournalConfig        `mapstructure:"journal"`
+	EventLog       EventLogConfig       `mapstructure:"event_log"`
+	OrderState     OrderStateConfig     `mapstructure:"order_state"`
+	StatsState     StatsStateConfig     `mapstructure:"stats_state"`
+	Report         ReportConfig         `mapstructure:"report"`
+	Notify         NotifyConfig         `mapstructure:"notify"`
+	Stats          StatsConfig          `mapstructure:"stats"`
+	Fees           FeesConfig           `mapstructure:"fees"`
+	Admin          AdminConfig          `mapstructure:"admin"`
+	Pprof          PprofConfig          `mapstructure:"pprof"`
+	Shutdown       ShutdownConfig       `mapstructure:"shutdown"`
+	Preflight      PreflightConfig      `mapstructure:"preflight"`
+	App            AppConfig            `mapstructure:"app"`
+
+	// 实际加载的配置文件路径，未找到配置文件时为空
+	ConfigFile string `mapstructure:"-"`
 }
 
+// Lighter正式网和测试网的默认接口地址和链ID
+const (
+	LighterMainnetBaseURL = "https://api.lighter.xyz"
+	LighterMainnetChainID = 1
+	LighterTestnetBaseURL = "https://testnet.zklighter.elliot.ai"
+	LighterTestnetChainID = 300
+)
+
 type LighterConfig struct {
 	APIKey       string `mapstructure:"api_key"`
 	SecretKey    string `mapstructure:"secret_key"`
 	PrivateKey   string `mapstructure:"private_key"`
-	BaseURL      string `mapstructure:"base_url"`
+	BaseURL      string `mapstructure:"base_url"` // 为空时按 testnet 使用正式网或测试网地址
 	AccountIndex int64  `mapstructure:"account_index"`
 	APIKeyIndex  uint8  `mapstructure:"api_key_index"`
-	ChainID      uint32 `mapstructure:"chain_id"`
+	ChainID      uint32 `mapstructure:"chain_id"` // 为0时按 testnet 使用正式网或测试网链ID
+
+	Testnet      bool `mapstructure:"testnet"`        // 使用Lighter测试网
+	VerifyAPIKey bool `mapstructure:"verify_api_key"` // 启动时核对API公钥与私钥一致，防止混用测试网和正式网密钥
+
+	// 加密密钥文件，代替明文 private_key (与 private_key 二选一)
+	Keystore string `mapstructure:"keystore"`
+	// 输出密钥文件口令的命令 (如KMS解密)，为空时读取 LIGHTER_KEYSTORE_PASSPHRASE 或在终端输入
+	KeystorePassphraseCommand string `mapstructure:"keystore_passphrase_command"`
+
+	Transport TransportConfig `mapstructure:"transport"` // 代理、DNS和TLS设置
 }
 
 type BinanceConfig struct {
 	APIKey    string `mapstructure:"api_key"`
 	SecretKey string `mapstructure:"secret_key"`
 	Testnet   bool   `mapstructure:"testnet"`
+
+	// REST接口地址，为空时按 testnet 使用SDK默认地址 (正式网或测试网)
+	SpotBaseURL    string `mapstructure:"spot_base_url"`    // 现货和杠杆接口
+	FuturesBaseURL string `mapstructure:"futures_base_url"` // U本位合约接口
+
+	// 交易市场: spot (现货), futures (U本位永续合约), margin (现货杠杆，借币做空)
+	Market            string `mapstructure:"market"`
+	FuturesLeverage   int    `mapstructure:"futures_leverage"`    // 启动时为已配置交易对设置的合约杠杆 (0为不修改)
+	FuturesMarginType string `mapstructure:"futures_margin_type"` // 启动时为已配置交易对设置的合约保证金模式: cross, isolated (空为不修改)
+	MarginIsolated    bool   `mapstructure:"margin_isolated"`     // 杠杆市场使用逐仓账户 (默认全仓)
+
+	RequestWeightPerMinute int `mapstructure:"request_weight_per_minute"` // 现货接口每分钟权重上限 (0为不限流)
+	FuturesWeightPerMinute int `mapstructure:"futures_weight_per_minute"` // 合约接口每分钟权重上限 (0为不限流)
+
+	BookTicker       bool          `mapstructure:"book_ticker"`         // 订阅最优挂单价推送，取价和挂单定价使用内存缓存
+	BookTickerMaxAge time.Duration `mapstructure:"book_ticker_max_age"` // 缓存有效期，超过后回退到REST接口
+
+	// 自适应降频：交易所报告的已用权重 (X-MBX-USED-WEIGHT-1M) 接近上限时放慢监控轮询
+	AdaptiveThrottle     bool    `mapstructure:"adaptive_throttle"`
+	ThrottleStartPercent float64 `mapstructure:"throttle_start_percent"` // 已用权重超过上限的该百分比后开始降频
+	ThrottleMaxSlowdown  float64 `mapstructure:"throttle_max_slowdown"`  // 权重用满时轮询间隔的倍数
+
+	Transport TransportConfig `mapstructure:"transport"` // 代理、DNS和TLS设置
+}
+
+// TransportConfig 交易所客户端的网络设置，用于只能经代理访问交易所的网络环境
+type TransportConfig struct {
+	Proxy                 string   `mapstructure:"proxy"`                    // 代理地址: http://、https://、socks5:// (可带用户名密码)，为空时读取 HTTPS_PROXY 等环境变量
+	DNSServers            []string `mapstructure:"dns_servers"`              // 自定义DNS服务器 (ip 或 ip:port，默认端口53)，为空时使用系统解析
+	TLSCAFile             string   `mapstructure:"tls_ca_file"`              // 额外信任的CA证书 (PEM)，如TLS拦截代理的根证书
+	TLSServerName         string   `mapstructure:"tls_server_name"`          // 覆盖证书校验使用的服务器名 (为空时使用请求的主机名)
+	TLSMinVersion         string   `mapstructure:"tls_min_version"`          // 最低TLS版本: 1.2, 1.3 (默认1.2)
+	TLSInsecureSkipVerify bool     `mapstructure:"tls_insecure_skip_verify"` // 不校验服务端证书，仅用于排查问题
+
+	// 请求审计日志 (密钥、签名和认证令牌已隐藏)，用于排查失败订单
+	AuditLog          string `mapstructure:"audit_log"`            // 空为不记录，errors: 只记录失败请求，all: 记录全部请求
+	AuditMaxBodyBytes int    `mapstructure:"audit_max_body_bytes"` // 请求/响应体最多记录的字节数 (0为不截断)
+}
+
+// SymbolConfig 交易币种在两个交易所上的映射
+type SymbolConfig struct {
+	Symbol             string `mapstructure:"symbol"`               // 内部币种符号，如 BTC
+	BinancePair        string `mapstructure:"binance_pair"`         // Binance交易对，如 BTCUSDC
+	LighterMarketIndex uint8  `mapstructure:"lighter_market_index"` // Lighter市场索引
+	QuantityPrecision  int    `mapstructure:"quantity_precision"`   // Binance下单数量小数位 (exchangeInfo不可用时回退)
+	PricePrecision     int    `mapstructure:"price_precision"`      // Binance价格小数位 (exchangeInfo不可用时回退)
+	LighterSide        string `mapstructure:"lighter_side"`         // 动态对冲中Lighter侧方向: BUY, SELL (Binance取反)
+	FuturesLeverage    int    `mapstructure:"futures_leverage"`     // 该交易对的Binance合约杠杆 (0为使用 binance.futures_leverage)
+
+	// 动态对冲单币种参数，0表示使用全局配置
+	OrderSize        float64 `mapstructure:"order_size"`        // 每次下单金额 (默认 trading.usdc_amount)
+	Leverage         int     `mapstructure:"leverage"`          // Lighter下单杠杆 (默认 trading.leverage)
+	MaxLeverage      float64 `mapstructure:"max_leverage"`      // 该币种杠杆上限，达到后停止对该币种开仓 (0为不单独限制)
+	MaxNotional      float64 `mapstructure:"max_notional"`      // 该币种单个交易所持仓名义金额上限 (默认 strategy.max_symbol_notional)
+	SpreadPercent    float64 `mapstructure:"spread_percent"`    // Binance价差百分比 (默认 strategy.spread_percent)
+	BalanceTolerance float64 `mapstructure:"balance_tolerance"` // 平衡容差百分比 (默认 strategy.balance_tolerance)
+}
+
+// RetryConfig 交易所接口重试配置 (指数退避 + 抖动)
+type RetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // 最大尝试次数 (含首次，1为不重试)
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // 首次重试等待时间
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // 单次等待上限
+	Multiplier     float64       `mapstructure:"multiplier"`      // 退避倍数
+	Jitter         float64       `mapstructure:"jitter"`          // 抖动比例 (0-1)
+}
+
+// RequestTimeoutConfig 交易所接口单次请求超时 (0为不限制)，超时的请求被中断，不会阻塞对冲路径
+type RequestTimeoutConfig struct {
+	Order time.Duration `mapstructure:"order"` // 下单 (Lighter为提交交易)，超时视为结果未知，由订单监控确认
+	Query time.Duration `mapstructure:"query"` // 查询和撤单，超时后按 retry 重试
+}
+
+// CircuitBreakerConfig 交易所熔断配置
+type CircuitBreakerConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`           // 是否启用熔断
+	FailureThreshold int           `mapstructure:"failure_threshold"` // 连续失败次数阈值
+	Cooldown         time.Duration `mapstructure:"cooldown"`          // 熔断后暂停下单时长
+}
+
+// KillSwitchConfig 紧急停止配置：任一条件满足即撤销所有挂单并停止交易
+type KillSwitchConfig struct {
+	Engaged       bool          `mapstructure:"engaged"`        // 配置开关，运行中修改配置文件也会生效
+	SentinelFile  string        `mapstructure:"sentinel_file"`  // 哨兵文件路径，文件存在即触发 (空为不检查)
+	CheckInterval time.Duration `mapstructure:"check_interval"` // 哨兵文件和配置开关的检查间隔
+}
+
+// PriceFeedConfig 多源聚合价格配置：Binance、Lighter和外部指数取中位数
+type PriceFeedConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`          // 是否启用聚合价格
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"` // 报价刷新间隔
+	MaxAge          time.Duration `mapstructure:"max_age"`          // 报价有效期，超过视为过期
+	MinSources      int           `mapstructure:"min_sources"`      // 最少有效报价源数量
+	Coinbase        bool          `mapstructure:"coinbase"`         // 使用Coinbase现货价格作为外部指数
+	CoinbaseURL     string        `mapstructure:"coinbase_url"`     // Coinbase接口地址
+}
+
+// TradingWindowConfig 允许开新仓的时间窗口
+type TradingWindowConfig struct {
+	Days  []string `mapstructure:"days"`  // 适用的星期 (mon, tue, ...)，空为每天
+	Start string   `mapstructure:"start"` // 开始时间 (HH:MM)
+	End   string   `mapstructure:"end"`   // 结束时间 (HH:MM)，早于开始时间时跨越午夜，等于开始时间时为全天
 }
 
 type TradingConfig struct {
@@ -42,13 +186,25 @@ type TradingConfig struct {
 }
 
 type StrategyConfig struct {
-	Type              string        `mapstructure:"type"`               // 策略类型: lighter, binance, arbitrage, dynamic_hedge
+	Type              string        `mapstructure:"type"`               // 策略类型: lighter, binance, arbitrage, dynamic_hedge, funding_arb
 	SpreadPercent     float64       `mapstructure:"spread_percent"`     // Binance价差百分比
 	MonitorInterval   time.Duration `mapstructure:"monitor_interval"`   // 动态对冲监控间隔
 	MaxLeverage       float64       `mapstructure:"max_leverage"`       // 最大杠杆率 (停止开仓)
 	EmergencyLeverage float64       `mapstructure:"emergency_leverage"` // 紧急平仓杠杆率
 	StopDuration      time.Duration `mapstructure:"stop_duration"`      // 停止开仓等待时间
 
+	// 账户权益刷新间隔，杠杆率 = 持仓名义金额 / 账户权益 (0为只在权益未知时查询，权益未知时停止开仓)
+	EquityRefreshInterval time.Duration `mapstructure:"equity_refresh_interval"`
+
+	// 回撤风控配置 (权益 = 两个交易所实测账户权益合计，按 equity_refresh_interval 刷新)
+	StartingEquity           float64 `mapstructure:"starting_equity"`            // 权益高点的初始值 (USDT，0为从首次获取的权益开始)
+	MaxDrawdownPercent       float64 `mapstructure:"max_drawdown_percent"`       // 从权益高点回撤超过该比例停止开仓 (0为不启用)
+	EmergencyDrawdownPercent float64 `mapstructure:"emergency_drawdown_percent"` // 从权益高点回撤超过该比例紧急平仓 (0为不启用)
+
+	// 名义金额上限 (与杠杆无关的绝对敞口限制)
+	MaxSymbolNotional float64 `mapstructure:"max_symbol_notional"` // 单币种在单个交易所的持仓名义金额上限 (0为不限制)
+	MaxVenueNotional  float64 `mapstructure:"max_venue_notional"`  // 单个交易所全部币种持仓名义金额上限 (0为不限制)
+
 	// 持续交易配置
 	ContinuousMode  bool          `mapstructure:"continuous_mode"`  // 是否启用持续交易模式
 	TradingInterval time.Duration `mapstructure:"trading_interval"` // 交易间隔
@@ -62,12 +218,150 @@ type StrategyConfig struct {
 	MinBalanceAdjust     float64       `mapstructure:"min_balance_adjust"`     // 最小平衡调整金额
 
 	// 快速执行配置
-	EnableFastExecution  bool          `mapstructure:"enable_fast_execution"`  // 是否启用快速执行
-	FastCheckInterval    time.Duration `mapstructure:"fast_check_interval"`    // 快速检查间隔
-	MaxExecutionDelay    time.Duration `mapstructure:"max_execution_delay"`    // 最大执行延迟
-	EnablePreExecution   bool          `mapstructure:"enable_pre_execution"`   // 启用预执行
-	PartialFillThreshold float64       `mapstructure:"partial_fill_threshold"` // 部分成交阈值
-	MaxSlippagePercent   float64       `mapstructure:"max_slippage_percent"`   // 最大滑点百分比
+	EnableFastExecution       bool            `mapstructure:"enable_fast_execution"`       // 是否启用快速执行
+	FastCheckInterval         time.Duration   `mapstructure:"fast_check_interval"`         // 快速检查间隔
+	MaxExecutionDelay         time.Duration   `mapstructure:"max_execution_delay"`         // 最大执行延迟
+	EnablePreExecution        bool            `mapstructure:"enable_pre_execution"`        // 启用预执行
+	PartialFillThreshold      float64         `mapstructure:"partial_fill_threshold"`      // 部分成交阈值
+	EnableConcurrentExecution bool            `mapstructure:"enable_concurrent_execution"` // 启用并发对冲
+	MaxConcurrentOrders       int             `mapstructure:"max_concurrent_orders"`       // 同时处理的订单数上限
+	MaxSlippagePercent        float64         `mapstructure:"max_slippage_percent"`        // 最大滑点百分比
+	RejectOnSlippage          bool            `mapstructure:"reject_on_slippage"`          // 对冲滑点超限时拒绝对冲 (false为仅告警)
+	ExecutionDelayBuckets     []time.Duration `mapstructure:"execution_delay_buckets"`     // 对冲执行延迟直方图分桶上界 (升序)
+
+	// 日终清仓配置
+	EnableDailyFlatten bool   `mapstructure:"enable_daily_flatten"` // 是否启用日终清仓
+	FlattenTime        string `mapstructure:"flatten_time"`         // 每日清仓时间 (HH:MM)
+	FlattenResumeTime  string `mapstructure:"flatten_resume_time"`  // 恢复开仓时间 (HH:MM)
+
+	// 交易时间窗口：窗口外不开新仓，只管理和平掉已有仓位 (空为不限制)
+	TradingWindows  []TradingWindowConfig `mapstructure:"trading_windows"`
+	SessionTimezone string                `mapstructure:"session_timezone"` // 交易时间窗口和日终清仓的时区 (IANA名称，默认本地时区)
+
+	// 分片执行配置
+	EnableTWAP       bool          `mapstructure:"enable_twap"`        // 订单相对盘口过大时使用分片执行
+	ExecutionAlgo    string        `mapstructure:"execution_algo"`     // 分片执行算法: twap, vwap
+	VWAPLookback     time.Duration `mapstructure:"vwap_lookback"`      // VWAP平均成交量回看区间
+	TWAPWindow       time.Duration `mapstructure:"twap_window"`        // 分片执行时间窗口
+	TWAPSlices       int           `mapstructure:"twap_slices"`        // 子订单数量
+	TWAPDepthPercent float64       `mapstructure:"twap_depth_percent"` // 统计盘口深度的价格范围 (%)
+	TWAPDepthRatio   float64       `mapstructure:"twap_depth_ratio"`   // 订单超过深度该比例时启用TWAP
+
+	// 止损止盈配置
+	EnableProtectiveOrders bool    `mapstructure:"enable_protective_orders"` // 开仓成交后挂止损/止盈保护单
+	StopLossPercent        float64 `mapstructure:"stop_loss_percent"`        // 止损触发价相对开仓价的偏移 (%)
+	TakeProfitPercent      float64 `mapstructure:"take_profit_percent"`      // 止盈触发价相对开仓价的偏移 (%)
+
+	// 挂单超时配置
+	MaxOrderAge        time.Duration `mapstructure:"max_order_age"`        // Maker单最长挂单时间，超时撤单 (0为不限制)
+	RepriceStaleOrders bool          `mapstructure:"reprice_stale_orders"` // 超时撤单后按最新价格重挂剩余金额
+
+	// 追价配置
+	EnableOrderChasing    bool          `mapstructure:"enable_order_chasing"`    // 市场价远离挂单价时撤单重挂
+	ChaseThresholdPercent float64       `mapstructure:"chase_threshold_percent"` // 市场价偏离挂单价超过该比例时追价 (%)
+	ChaseInterval         time.Duration `mapstructure:"chase_interval"`          // 同一币种两次追价检查的最小间隔
+	MaxChases             int           `mapstructure:"max_chases"`              // 单笔订单最多重挂次数 (0为不限制)
+
+	// 余额检查
+	EnableBalanceCheck    bool    `mapstructure:"enable_balance_check"`     // 开仓前检查两个交易所的可用保证金，不足时跳过本轮开仓
+	EnableAutoTransfer    bool    `mapstructure:"enable_auto_transfer"`     // Binance合约钱包保证金不足时从现货钱包划转 (仅合约市场)
+	AutoTransferAsset     string  `mapstructure:"auto_transfer_asset"`      // 划转的资产
+	AutoTransferMinAmount float64 `mapstructure:"auto_transfer_min_amount"` // 单次最少划转金额
+	AutoTransferMaxAmount float64 `mapstructure:"auto_transfer_max_amount"` // 单次最多划转金额 (0为不限制)
+
+	// 跨交易所保证金再平衡
+	EnableCollateralRebalance  bool          `mapstructure:"enable_collateral_rebalance"`   // 对比两个交易所的可用保证金，偏差过大时生成再平衡计划并告警
+	RebalanceCheckInterval     time.Duration `mapstructure:"rebalance_check_interval"`      // 检查间隔
+	RebalanceThresholdPercent  float64       `mapstructure:"rebalance_threshold_percent"`   // 两边差额占合计的比例超过该值时再平衡 (%)
+	RebalanceMinAmount         float64       `mapstructure:"rebalance_min_amount"`          // 转出金额低于该值时忽略
+	RebalanceAlertInterval     time.Duration `mapstructure:"rebalance_alert_interval"`      // 计划未变时重复告警的间隔，也是两次自动提现的最小间隔
+	RebalanceAsset             string        `mapstructure:"rebalance_asset"`               // 转移的资产
+	RebalanceAutoWithdraw      bool          `mapstructure:"rebalance_auto_withdraw"`       // Binance转出方向自动提现到Lighter充值地址
+	RebalanceWithdrawNetwork   string        `mapstructure:"rebalance_withdraw_network"`    // 提现网络 (如 ARBITRUM)
+	RebalanceDepositAddress    string        `mapstructure:"rebalance_deposit_address"`     // Lighter充值地址 (需在Binance提现白名单中)
+	RebalanceMaxWithdrawAmount float64       `mapstructure:"rebalance_max_withdraw_amount"` // 单次最多自动提现金额
+
+	// USDC/USDT汇率配置
+	EnableUSDCRate  bool          `mapstructure:"enable_usdc_rate"`  // Binance交易对以USDC计价时，Lighter对冲金额按USDCUSDT实时汇率换算 (否则按1:1)
+	USDCRateRefresh time.Duration `mapstructure:"usdc_rate_refresh"` // 汇率刷新间隔
+
+	// 盈亏归因配置
+	EnableFundingAccrual   bool          `mapstructure:"enable_funding_accrual"`   // 按持仓和资金费率估算资金费，计入盈亏归因
+	FundingAccrualInterval time.Duration `mapstructure:"funding_accrual_interval"` // 资金费累计间隔
+
+	// 流动性限额配置
+	EnableLiquiditySizing bool    `mapstructure:"enable_liquidity_sizing"` // 按Lighter盘口深度限制开仓金额
+	LiquidityDepthPercent float64 `mapstructure:"liquidity_depth_percent"` // 统计盘口深度的价格范围 (%)
+	MaxLiquidityRatio     float64 `mapstructure:"max_liquidity_ratio"`     // 单笔开仓金额不超过深度的该比例
+	MinOrderSize          float64 `mapstructure:"min_order_size"`          // 限额后低于该金额时跳过本轮开仓 (USDT)
+
+	// 价差触发配置
+	EnableSpreadTrigger bool          `mapstructure:"enable_spread_trigger"` // 仅在跨交易所价差有利时开仓
+	SpreadCheckInterval time.Duration `mapstructure:"spread_check_interval"` // 价差检查间隔
+	MinSpreadPercent    float64       `mapstructure:"min_spread_percent"`    // 扣除往返手续费后的最小价差 (%)
+
+	// 标记价格偏离保护
+	EnableDivergenceGuard  bool    `mapstructure:"enable_divergence_guard"`   // 永续合约标记价格偏离指数价格过大时暂停开仓
+	MaxMarkIndexDivergence float64 `mapstructure:"max_mark_index_divergence"` // 标记价格相对指数价格的最大偏离 (%)
+
+	// 强平价监控
+	EnableLiquidationMonitor bool    `mapstructure:"enable_liquidation_monitor"` // 标记价格接近强平价格时告警
+	LiquidationAlertPercent  float64 `mapstructure:"liquidation_alert_percent"`  // 标记价格距强平价格小于该比例时告警 (%)
+	LiquidationDeRiskPercent float64 `mapstructure:"liquidation_derisk_percent"` // 标记价格距强平价格小于该比例时减仓 (%)，0为仅告警
+
+	// 仓位对账
+	EnableReconciliation       bool    `mapstructure:"enable_reconciliation"`        // 同步交易所持仓前与本地仓位核对
+	ReconcileToleranceNotional float64 `mapstructure:"reconcile_tolerance_notional"` // 差异金额低于该值时忽略 (USDT)
+	ReconcileHaltNotional      float64 `mapstructure:"reconcile_halt_notional"`      // 差异金额达到该值时暂停开新仓 (USDT，0为不暂停)
+	ReconcileAutoCorrect       bool    `mapstructure:"reconcile_auto_correct"`       // 确认差异后以交易所持仓修正本地仓位
+
+	// 滚动波动率
+	EnableVolatility   bool          `mapstructure:"enable_volatility"`   // 按Binance K线计算各币种滚动波动率
+	VolatilityInterval string        `mapstructure:"volatility_interval"` // K线周期 (1m, 3m, 5m, 15m, 30m, 1h, 2h, 4h, 1d)
+	VolatilityWindow   int           `mapstructure:"volatility_window"`   // 参与计算的K线数量
+	VolatilityRefresh  time.Duration `mapstructure:"volatility_refresh"`  // 刷新间隔
+
+	// 波动率自适应价差
+	EnableAdaptiveSpread        bool    `mapstructure:"enable_adaptive_spread"`         // 按ATR缩放Binance挂单价差 (需启用滚动波动率)
+	AdaptiveSpreadReferenceATR  float64 `mapstructure:"adaptive_spread_reference_atr"`  // 基准ATR (%)，ATR等于该值时使用配置的价差
+	AdaptiveSpreadMinMultiplier float64 `mapstructure:"adaptive_spread_min_multiplier"` // 价差缩放倍数下限
+	AdaptiveSpreadMaxMultiplier float64 `mapstructure:"adaptive_spread_max_multiplier"` // 价差缩放倍数上限
+
+	// 影子模式 (参数为0时与实盘相同)
+	EnableShadow              bool          `mapstructure:"enable_shadow"`                // 按另一组参数虚拟挂单和成交，与实盘对比
+	ShadowSpreadPercent       float64       `mapstructure:"shadow_spread_percent"`        // 影子Binance挂单价差 (%)
+	ShadowOrderSize           float64       `mapstructure:"shadow_order_size"`            // 影子每次下单金额 (USDC)
+	ShadowMaxPositionNotional float64       `mapstructure:"shadow_max_position_notional"` // 每个币种虚拟仓位名义金额上限，达到后转为平仓
+	ShadowTradingInterval     time.Duration `mapstructure:"shadow_trading_interval"`      // 同一币种两次虚拟下单的最小间隔
+	ShadowMaxOrderAge         time.Duration `mapstructure:"shadow_max_order_age"`         // 虚拟挂单超时撤单时间
+
+	// 看门狗
+	EnableWatchdog       bool          `mapstructure:"enable_watchdog"`        // 检查主监控循环和订单监控循环的心跳
+	WatchdogInterval     time.Duration `mapstructure:"watchdog_interval"`      // 检查间隔
+	WatchdogStallTimeout time.Duration `mapstructure:"watchdog_stall_timeout"` // 超过该时长没有心跳视为卡住
+	WatchdogAction       string        `mapstructure:"watchdog_action"`        // restart (重启循环), exit (告警并退出)
+	WatchdogMaxRestarts  int           `mapstructure:"watchdog_max_restarts"`  // 同一循环连续重启次数上限，用尽后退出
+
+	// 资金费率套利配置
+	FundingSymbols       []string      `mapstructure:"funding_symbols"`        // 监控币种
+	FundingMinRateDiff   float64       `mapstructure:"funding_min_rate_diff"`  // 开仓最小费率差 (按小时折算)
+	FundingCheckInterval time.Duration `mapstructure:"funding_check_interval"` // 费率检查间隔
+
+	// 期现基差配置
+	BasisSymbols           []string      `mapstructure:"basis_symbols"`            // 交易币种
+	BasisEntryAnnualized   float64       `mapstructure:"basis_entry_annualized"`   // 开仓年化基差阈值
+	BasisExitAnnualized    float64       `mapstructure:"basis_exit_annualized"`    // 平仓年化基差阈值
+	BasisConvergenceWindow time.Duration `mapstructure:"basis_convergence_window"` // 预期收敛周期 (年化换算)
+	BasisCheckInterval     time.Duration `mapstructure:"basis_check_interval"`     // 检查间隔
+
+	// 做市配置
+	MMSymbols          []string      `mapstructure:"mm_symbols"`           // 做市币种
+	MMQuoteSize        float64       `mapstructure:"mm_quote_size"`        // 每侧挂单金额 (USDC)
+	MMSpreadPercent    float64       `mapstructure:"mm_spread_percent"`    // 报价距中间价百分比
+	MMMaxInventory     float64       `mapstructure:"mm_max_inventory"`     // 最大净库存 (USDC名义)
+	MMSkewPercent      float64       `mapstructure:"mm_skew_percent"`      // 满库存时报价偏移百分比
+	MMOffloadThreshold float64       `mapstructure:"mm_offload_threshold"` // Lighter对冲阈值 (USDC名义)
+	MMRefreshInterval  time.Duration `mapstructure:"mm_refresh_interval"`  // 报价刷新间隔
 }
 
 type LoggingConfig struct {
@@ -79,6 +373,210 @@ type LoggingConfig struct {
 	Compress   bool   `mapstructure:"compress"`
 }
 
+type JournalConfig struct {
+	Enabled bool   `mapstructure:"enabled"` // 是否记录成交日志
+	Path    string `mapstructure:"path"`    // 成交日志路径 (JSON Lines, 只追加)
+}
+
+type EventLogConfig struct {
+	Enabled bool   `mapstructure:"enabled"` // 是否记录结构化事件流
+	Path    string `mapstructure:"path"`    // 事件流路径 (JSON Lines, 只追加)
+}
+
+type OrderStateConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`          // 是否保存活跃订单并在启动时恢复
+	Path            string `mapstructure:"path"`             // 订单状态文件路径 (JSON)
+	UntrackedAction string `mapstructure:"untracked_action"` // 启动时交易所上未记录挂单的处理方式: cancel, adopt, ignore
+}
+
+type StatsStateConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`  // 是否保存交易统计和执行统计并在启动时恢复
+	Path     string        `mapstructure:"path"`     // 统计状态文件路径 (JSON)
+	Interval time.Duration `mapstructure:"interval"` // 快照保存间隔
+}
+
+type ReportConfig struct {
+	Enabled  bool     `mapstructure:"enabled"`  // 是否在日切时生成日报
+	Dir      string   `mapstructure:"dir"`      // 日报输出目录
+	Formats  []string `mapstructure:"formats"`  // 输出格式: json, html
+	Timezone string   `mapstructure:"timezone"` // 日切时区 (IANA名称，默认本地时区)
+}
+
+// NotifyConfig 外部通知渠道配置
+type NotifyConfig struct {
+	SendTimeout            time.Duration   `mapstructure:"send_timeout"`             // 单条消息在单个渠道的发送超时
+	ConnectivityAlertAfter time.Duration   `mapstructure:"connectivity_alert_after"` // 交易所熔断持续打开超过该时长时发送紧急告警 (0为不告警，需启用熔断)
+	DailySummary           bool            `mapstructure:"daily_summary"`            // 交易日结束时发送运行摘要 (按 stats 的日切时区和时刻)
+	DedupWindow            time.Duration   `mapstructure:"dedup_window"`             // 相同告警的默认去重窗口 (0为不去重)
+	Rules                  []AlertRule     `mapstructure:"rules"`                    // 按事件类型覆盖严重程度、去重窗口和升级策略
+	SMTP                   SMTPConfig      `mapstructure:"smtp"`                     // 邮件
+	PagerDuty              PagerDutyConfig `mapstructure:"pagerduty"`                // PagerDuty呼叫
+	Opsgenie               OpsgenieConfig  `mapstructure:"opsgenie"`                 // Opsgenie呼叫
+}
+
+// 告警严重程度
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+	SeverityOff      = "off" // 只用于告警规则：不通知该事件
+)
+
+// AlertRule 单个事件类型的告警规则
+type AlertRule struct {
+	Event       string            `mapstructure:"event"`        // 事件类型，如 HEDGE_FAILED
+	Severity    string            `mapstructure:"severity"`     // info, warning, critical, off；为空时使用事件的默认严重程度
+	DedupWindow time.Duration     `mapstructure:"dedup_window"` // 去重窗口 (0为使用 notify.dedup_window)
+	Escalations []AlertEscalation `mapstructure:"escalations"`  // 按重复次数升级
+}
+
+// AlertEscalation 升级策略：去重窗口内同一告警第 Count 次出现时以 Severity 重新发送
+type AlertEscalation struct {
+	Count    int    `mapstructure:"count"`    // 出现次数 (至少2)
+	Severity string `mapstructure:"severity"` // 升级后的严重程度: warning, critical
+}
+
+// PagerDutyConfig PagerDuty Events API v2 配置
+type PagerDutyConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`      // 是否启用
+	RoutingKey  string `mapstructure:"routing_key"`  // 服务集成的 Integration Key
+	URL         string `mapstructure:"url"`          // Events API 地址
+	Source      string `mapstructure:"source"`       // 告警来源 (如主机名)
+	MinSeverity string `mapstructure:"min_severity"` // 接收的最低严重程度: info, warning, critical
+}
+
+// OpsgenieConfig Opsgenie Alert API 配置
+type OpsgenieConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`      // 是否启用
+	APIKey      string `mapstructure:"api_key"`      // API集成的密钥
+	URL         string `mapstructure:"url"`          // API地址 (欧洲区为 https://api.eu.opsgenie.com)
+	Source      string `mapstructure:"source"`       // 告警来源 (如主机名)
+	MinSeverity string `mapstructure:"min_severity"` // 接收的最低严重程度: info, warning, critical
+}
+
+// SMTP连接加密方式
+const (
+	SMTPSecurityStartTLS = "starttls" // 明文连接后升级为TLS (通常为587端口)
+	SMTPSecurityTLS      = "tls"      // 隐式TLS (通常为465端口)
+	SMTPSecurityNone     = "none"     // 不加密，只应用于本机邮件中继
+)
+
+// SMTPConfig 邮件通知渠道配置
+type SMTPConfig struct {
+	Enabled       bool     `mapstructure:"enabled"`        // 是否启用邮件通知
+	Host          string   `mapstructure:"host"`           // SMTP服务器
+	Port          int      `mapstructure:"port"`           // SMTP端口
+	Security      string   `mapstructure:"security"`       // 加密方式: starttls, tls, none
+	Username      string   `mapstructure:"username"`       // 登录用户名 (为空时不认证)
+	Password      string   `mapstructure:"password"`       // 登录密码
+	From          string   `mapstructure:"from"`           // 发件人地址
+	To            []string `mapstructure:"to"`             // 收件人地址
+	MinSeverity   string   `mapstructure:"min_severity"`   // 接收的最低严重程度: info (包括日报), warning, critical (只有紧急告警)
+	SubjectPrefix string   `mapstructure:"subject_prefix"` // 邮件主题前缀
+}
+
+// StatsConfig 交易统计配置
+type StatsConfig struct {
+	ResetTimezone string `mapstructure:"reset_timezone"` // 日统计日切时区 (IANA名称，默认本地时区)
+	ResetHour     int    `mapstructure:"reset_hour"`     // 日切时刻 (0-23点)
+}
+
+// FeesConfig 各交易所手续费率 (%)，用于盈亏核算和盈亏平衡价差
+type FeesConfig struct {
+	BinanceMakerPercent float64 `mapstructure:"binance_maker_percent"`
+	BinanceTakerPercent float64 `mapstructure:"binance_taker_percent"`
+	LighterMakerPercent float64 `mapstructure:"lighter_maker_percent"`
+	LighterTakerPercent float64 `mapstructure:"lighter_taker_percent"`
+	FetchBinance        bool    `mapstructure:"fetch_binance"` // 启动时查询账户实际费率，覆盖 binance_* 配置
+
+	// Binance Maker挂单价差下限 = 往返手续费 + min_edge_percent，避免挂单成交后必然亏损
+	FeeAdjustedSpread bool    `mapstructure:"fee_adjusted_spread"`
+	MinEdgePercent    float64 `mapstructure:"min_edge_percent"` // 扣除往返手续费后的最小利润 (%)
+
+	// 按近30天成交量的手续费等级表 (按 min_volume 升序)，用于估算当前等级和距下一等级的成交额
+	BinanceTiers []FeeTierConfig `mapstructure:"binance_tiers"`
+	LighterTiers []FeeTierConfig `mapstructure:"lighter_tiers"`
+}
+
+// FeeTierConfig 手续费等级：近30天成交量达到 min_volume 时适用的费率 (%)，负数Maker费率为返佣
+type FeeTierConfig struct {
+	Name         string  `mapstructure:"name"`
+	MinVolume    float64 `mapstructure:"min_volume"`
+	MakerPercent float64 `mapstructure:"maker_percent"`
+	TakerPercent float64 `mapstructure:"taker_percent"`
+}
+
+type AdminConfig struct {
+	Enabled bool         `mapstructure:"enabled"` // 是否启用管理API
+	Listen  string       `mapstructure:"listen"`  // 监听地址
+	Tokens  []AdminToken `mapstructure:"tokens"`  // API令牌，为空时不鉴权 (必须绑定本机地址或配置客户端证书)
+	TLS     ServerTLS    `mapstructure:"tls"`     // HTTPS设置
+
+	ControlMinInterval time.Duration `mapstructure:"control_min_interval"` // 同一请求方调用同一控制接口的最小间隔，防止误操作连续触发 (0为不限制)
+}
+
+// Unauthenticated 监听地址不是本机且未配置令牌和客户端证书
+func (a *AdminConfig) Unauthenticated() bool {
+	return len(a.Tokens) == 0 && a.TLS.ClientCAFile == "" && !isLoopback(a.Listen)
+}
+
+// isLoopback 监听地址是否只绑定本机
+func isLoopback(listen string) bool {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ServerTLS 内置HTTP服务 (管理API、pprof) 的HTTPS设置：证书文件和自动申请证书二选一
+type ServerTLS struct {
+	Enabled          bool     `mapstructure:"enabled"`            // 是否启用HTTPS
+	CertFile         string   `mapstructure:"cert_file"`          // 证书 (PEM，可包含中间证书)
+	KeyFile          string   `mapstructure:"key_file"`           // 私钥 (PEM)
+	AutocertDomains  []string `mapstructure:"autocert_domains"`   // 通过ACME (Let's Encrypt) 自动申请证书的域名，需在443端口可访问
+	AutocertCacheDir string   `mapstructure:"autocert_cache_dir"` // 自动申请证书的缓存目录
+	AutocertEmail    string   `mapstructure:"autocert_email"`     // ACME账户联系邮箱 (可选)
+	ClientCAFile     string   `mapstructure:"client_ca_file"`     // 客户端证书CA (PEM)，配置后要求客户端证书 (mTLS)
+}
+
+// 管理API令牌角色
+const (
+	AdminRoleRead    = "read"    // 只读：查询状态、仓位、盈亏等
+	AdminRoleControl = "control" // 控制：只读接口以及紧急停止、暂停/恢复、平衡调整
+)
+
+// AdminToken 管理API令牌，请求头 Authorization: Bearer <token>
+type AdminToken struct {
+	Name  string `mapstructure:"name"`  // 令牌名称，用于日志标识请求方
+	Token string `mapstructure:"token"` // 令牌 (至少16个字符)
+	Role  string `mapstructure:"role"`  // read 或 control
+}
+
+// PprofConfig 性能分析服务配置
+type PprofConfig struct {
+	Enabled              bool      `mapstructure:"enabled"`                // 是否启用 /debug/pprof
+	Listen               string    `mapstructure:"listen"`                 // 监听地址，只应绑定本机
+	BlockProfileRate     int       `mapstructure:"block_profile_rate"`     // 阻塞采样率 (纳秒，0为不采样)
+	MutexProfileFraction int       `mapstructure:"mutex_profile_fraction"` // 锁竞争采样比例 (1/n，0为不采样)
+	TLS                  ServerTLS `mapstructure:"tls"`                    // HTTPS设置
+}
+
+// ShutdownConfig 退出时的收尾方式 (仅动态对冲策略)
+type ShutdownConfig struct {
+	Mode         string        `mapstructure:"mode"`          // none, drain, cancel, flatten
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"` // drain 模式等待Maker单成交的最长时间，超时后撤单
+}
+
+// PreflightConfig 启动前检查交易所连通性、API Key权限和账户，未通过时拒绝启动
+type PreflightConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
 type AppConfig struct {
 	Name        string `mapstructure:"name"`
 	Version     string `mapstructure:"version"`
@@ -114,17 +612,68 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	config.ConfigFile = v.ConfigFileUsed()
+	config.Lighter.applyNetworkDefaults()
 
 	return &config, nil
 }
 
+// ReadKillSwitchEngaged 重新读取配置文件中的 kill_switch.engaged，用于运行中检测开关变化
+func ReadKillSwitchEngaged(path string) (bool, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return false, fmt.Errorf("error reading config file: %w", err)
+	}
+	return v.GetBool("kill_switch.engaged"), nil
+}
+
 func setDefaults(v *viper.Viper) {
-	v.SetDefault("lighter.base_url", "https://api.lighter.xyz")
-	v.SetDefault("lighter.chain_id", 1)
+	v.SetDefault("lighter.testnet", false)
+	v.SetDefault("lighter.verify_api_key", true)
 	v.SetDefault("lighter.account_index", 1)
 	v.SetDefault("lighter.api_key_index", 0)
+	v.SetDefault("lighter.transport.audit_max_body_bytes", 4096)
 
 	v.SetDefault("binance.testnet", false)
+	v.SetDefault("binance.spot_base_url", "")
+	v.SetDefault("binance.futures_base_url", "")
+	v.SetDefault("binance.market", "spot")
+	v.SetDefault("binance.futures_leverage", 0)
+	v.SetDefault("binance.futures_margin_type", "")
+	v.SetDefault("binance.margin_isolated", false)
+	v.SetDefault("binance.request_weight_per_minute", 4800) // 交易所上限6000，预留余量
+	v.SetDefault("binance.futures_weight_per_minute", 1800) // 交易所上限2400，预留余量
+	v.SetDefault("binance.book_ticker", false)
+	v.SetDefault("binance.book_ticker_max_age", "3s")
+	v.SetDefault("binance.transport.audit_max_body_bytes", 4096)
+	v.SetDefault("binance.adaptive_throttle", true)
+	v.SetDefault("binance.throttle_start_percent", 70.0)
+	v.SetDefault("binance.throttle_max_slowdown", 4.0)
+
+	v.SetDefault("retry.max_attempts", 3)
+	v.SetDefault("retry.initial_backoff", "200ms")
+	v.SetDefault("retry.max_backoff", "3s")
+	v.SetDefault("retry.multiplier", 2.0)
+	v.SetDefault("retry.jitter", 0.2)
+
+	v.SetDefault("request_timeout.order", "10s")
+	v.SetDefault("request_timeout.query", "5s")
+
+	v.SetDefault("circuit_breaker.enabled", true)
+	v.SetDefault("circuit_breaker.failure_threshold", 5)
+	v.SetDefault("circuit_breaker.cooldown", "1m")
+
+	v.SetDefault("kill_switch.engaged", false)
+	v.SetDefault("kill_switch.sentinel_file", "data/KILL")
+	v.SetDefault("kill_switch.check_interval", time.Second)
+
+	v.SetDefault("price_feed.enabled", false)
+	v.SetDefault("price_feed.refresh_interval", time.Second)
+	v.SetDefault("price_feed.max_age", 5*time.Second)
+	v.SetDefault("price_feed.min_sources", 2)
+	v.SetDefault("price_feed.coinbase", true)
+	v.SetDefault("price_feed.coinbase_url", "https://api.coinbase.com")
 
 	v.SetDefault("trading.usdt_amount", 1000)
 	v.SetDefault("trading.usdc_amount", 1000)
@@ -136,6 +685,12 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("strategy.max_leverage", 3.0)
 	v.SetDefault("strategy.emergency_leverage", 5.0)
 	v.SetDefault("strategy.stop_duration", 10*time.Minute)
+	v.SetDefault("strategy.equity_refresh_interval", time.Minute)
+	v.SetDefault("strategy.starting_equity", 0.0)
+	v.SetDefault("strategy.max_drawdown_percent", 5.0)
+	v.SetDefault("strategy.emergency_drawdown_percent", 10.0)
+	v.SetDefault("strategy.max_symbol_notional", 0.0)
+	v.SetDefault("strategy.max_venue_notional", 0.0)
 
 	// 持续交易默认配置
 	v.SetDefault("strategy.continuous_mode", true)
@@ -155,7 +710,132 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("strategy.max_execution_delay", 500*time.Millisecond) // 最大500ms延迟
 	v.SetDefault("strategy.enable_pre_execution", true)                // 启用预执行
 	v.SetDefault("strategy.partial_fill_threshold", 0.5)               // 50%部分成交阈值
+	v.SetDefault("strategy.enable_concurrent_execution", true)         // 多笔成交并发对冲
+	v.SetDefault("strategy.max_concurrent_orders", 3)                  // 最多同时处理3笔订单
 	v.SetDefault("strategy.max_slippage_percent", 0.1)                 // 0.1%最大滑点
+	v.SetDefault("strategy.reject_on_slippage", false)                 // 滑点超限仅告警
+	v.SetDefault("strategy.execution_delay_buckets", []time.Duration{
+		50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond,
+		500 * time.Millisecond, time.Second, 2 * time.Second,
+	})
+
+	// 日终清仓默认配置
+	v.SetDefault("strategy.session_timezone", "Local")
+
+	v.SetDefault("strategy.enable_daily_flatten", false)
+	v.SetDefault("strategy.flatten_time", "23:00")        // 23:00撤单平仓
+	v.SetDefault("strategy.flatten_resume_time", "01:00") // 01:00恢复开仓
+
+	// 分片执行默认配置
+	v.SetDefault("strategy.enable_twap", false)
+	v.SetDefault("strategy.execution_algo", "twap")
+	v.SetDefault("strategy.vwap_lookback", time.Hour)
+	v.SetDefault("strategy.twap_window", 5*time.Minute)
+	v.SetDefault("strategy.twap_slices", 10)
+	v.SetDefault("strategy.twap_depth_percent", 0.1) // 统计最优价0.1%以内的深度
+	v.SetDefault("strategy.twap_depth_ratio", 0.5)   // 订单超过深度50%时分片
+
+	// 止损止盈默认配置
+	v.SetDefault("strategy.enable_protective_orders", false)
+	v.SetDefault("strategy.stop_loss_percent", 2.0)
+	v.SetDefault("strategy.take_profit_percent", 2.0)
+
+	// 挂单超时默认配置
+	v.SetDefault("strategy.max_order_age", 5*time.Minute)
+	v.SetDefault("strategy.reprice_stale_orders", true)
+
+	// 追价默认配置
+	v.SetDefault("strategy.enable_order_chasing", false)
+	v.SetDefault("strategy.chase_threshold_percent", 0.2)
+	v.SetDefault("strategy.chase_interval", 5*time.Second)
+	v.SetDefault("strategy.max_chases", 5)
+
+	// 余额检查默认配置
+	v.SetDefault("strategy.enable_balance_check", true)
+	v.SetDefault("strategy.enable_auto_transfer", false)
+	v.SetDefault("strategy.auto_transfer_asset", "USDC")
+	v.SetDefault("strategy.auto_transfer_min_amount", 50.0)
+	v.SetDefault("strategy.auto_transfer_max_amount", 0.0)
+
+	// 跨交易所保证金再平衡默认配置
+	v.SetDefault("strategy.enable_collateral_rebalance", false)
+	v.SetDefault("strategy.rebalance_check_interval", 5*time.Minute)
+	v.SetDefault("strategy.rebalance_threshold_percent", 30.0)
+	v.SetDefault("strategy.rebalance_min_amount", 100.0)
+	v.SetDefault("strategy.rebalance_alert_interval", time.Hour)
+	v.SetDefault("strategy.rebalance_asset", "USDC")
+	v.SetDefault("strategy.rebalance_auto_withdraw", false)
+	v.SetDefault("strategy.rebalance_max_withdraw_amount", 1000.0)
+
+	// USDC/USDT汇率默认配置
+	v.SetDefault("strategy.enable_usdc_rate", true)
+	v.SetDefault("strategy.usdc_rate_refresh", time.Minute)
+
+	// 盈亏归因默认配置
+	v.SetDefault("strategy.enable_funding_accrual", true)
+	v.SetDefault("strategy.funding_accrual_interval", 10*time.Minute)
+
+	// 流动性限额默认配置
+	v.SetDefault("strategy.enable_liquidity_sizing", false)
+	v.SetDefault("strategy.liquidity_depth_percent", 0.1) // 统计最优价0.1%以内的深度
+	v.SetDefault("strategy.max_liquidity_ratio", 0.2)     // 不超过深度的20%
+	v.SetDefault("strategy.min_order_size", 10.0)         // 低于10U跳过
+
+	// 价差触发默认配置
+	v.SetDefault("strategy.enable_spread_trigger", false)
+	v.SetDefault("strategy.spread_check_interval", time.Second)
+	v.SetDefault("strategy.min_spread_percent", 0.02) // 扣费后至少0.02%
+
+	// 标记价格偏离保护默认配置
+	v.SetDefault("strategy.enable_divergence_guard", false)
+	v.SetDefault("strategy.max_mark_index_divergence", 0.5) // 偏离超过0.5%暂停开仓
+	v.SetDefault("strategy.enable_liquidation_monitor", false)
+	v.SetDefault("strategy.liquidation_alert_percent", 10.0) // 距强平价10%以内告警
+	v.SetDefault("strategy.liquidation_derisk_percent", 0.0)
+	v.SetDefault("strategy.enable_reconciliation", false)
+	v.SetDefault("strategy.reconcile_tolerance_notional", 10.0)
+	v.SetDefault("strategy.reconcile_halt_notional", 0.0)
+	v.SetDefault("strategy.reconcile_auto_correct", true)
+	v.SetDefault("strategy.enable_volatility", false)
+	v.SetDefault("strategy.volatility_interval", "5m")
+	v.SetDefault("strategy.volatility_window", 48) // 5分钟K线48根，即最近4小时
+	v.SetDefault("strategy.volatility_refresh", time.Minute)
+	v.SetDefault("strategy.enable_adaptive_spread", false)
+	v.SetDefault("strategy.adaptive_spread_reference_atr", 0.2) // 5分钟K线ATR约0.2%时使用配置的价差
+	v.SetDefault("strategy.adaptive_spread_min_multiplier", 0.5)
+	v.SetDefault("strategy.adaptive_spread_max_multiplier", 3.0)
+	v.SetDefault("strategy.enable_shadow", false)
+	v.SetDefault("strategy.shadow_spread_percent", 0.0)
+	v.SetDefault("strategy.shadow_order_size", 0.0)
+	v.SetDefault("strategy.shadow_max_position_notional", 1000.0)
+	v.SetDefault("strategy.shadow_trading_interval", time.Duration(0))
+	v.SetDefault("strategy.shadow_max_order_age", time.Duration(0))
+	v.SetDefault("strategy.enable_watchdog", true)
+	v.SetDefault("strategy.watchdog_interval", 10*time.Second)
+	v.SetDefault("strategy.watchdog_stall_timeout", 2*time.Minute)
+	v.SetDefault("strategy.watchdog_action", "restart")
+	v.SetDefault("strategy.watchdog_max_restarts", 3)
+
+	// 资金费率套利默认配置
+	v.SetDefault("strategy.funding_symbols", []string{"BTC", "ETH"})
+	v.SetDefault("strategy.funding_min_rate_diff", 0.00005) // 0.005%/小时
+	v.SetDefault("strategy.funding_check_interval", time.Minute)
+
+	// 期现基差默认配置
+	v.SetDefault("strategy.basis_symbols", []string{"BTC", "ETH"})
+	v.SetDefault("strategy.basis_entry_annualized", 0.15) // 年化15%开仓
+	v.SetDefault("strategy.basis_exit_annualized", 0.03)  // 年化3%以下平仓
+	v.SetDefault("strategy.basis_convergence_window", 7*24*time.Hour)
+	v.SetDefault("strategy.basis_check_interval", 30*time.Second)
+
+	// 做市默认配置
+	v.SetDefault("strategy.mm_symbols", []string{"BTC", "ETH"})
+	v.SetDefault("strategy.mm_quote_size", 100.0)
+	v.SetDefault("strategy.mm_spread_percent", 0.05)
+	v.SetDefault("strategy.mm_max_inventory", 1000.0)
+	v.SetDefault("strategy.mm_skew_percent", 0.05)
+	v.SetDefault("strategy.mm_offload_threshold", 500.0)
+	v.SetDefault("strategy.mm_refresh_interval", 5*time.Second)
 
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.output", "logs/app.log")
@@ -164,6 +844,93 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("logging.max_backups", 3)
 	v.SetDefault("logging.compress", true)
 
+	v.SetDefault("journal.enabled", true)
+	v.SetDefault("journal.path", "data/trades.jsonl")
+
+	v.SetDefault("event_log.enabled", false)
+	v.SetDefault("event_log.path", "data/events.jsonl")
+
+	v.SetDefault("order_state.enabled", true)
+	v.SetDefault("order_state.path", "data/orders.json")
+	v.SetDefault("order_state.untracked_action", "cancel")
+
+	// 统计持久化默认配置
+	v.SetDefault("stats_state.enabled", true)
+	v.SetDefault("stats_state.path", "data/stats.json")
+	v.SetDefault("stats_state.interval", 30*time.Second)
+
+	v.SetDefault("symbols", []map[string]interface{}{
+		{
+			"symbol":               "BTC",
+			"binance_pair":         "BTCUSDC",
+			"lighter_market_index": 0,
+			"quantity_precision":   6,
+			"price_precision":      2,
+			"lighter_side":         "BUY",
+		},
+		{
+			"symbol":               "ETH",
+			"binance_pair":         "ETHUSDC",
+			"lighter_market_index": 1,
+			"quantity_precision":   5,
+			"price_precision":      2,
+			"lighter_side":         "SELL",
+		},
+	})
+
+	v.SetDefault("report.enabled", true)
+	v.SetDefault("report.dir", "reports")
+	v.SetDefault("report.formats", []string{"json", "html"})
+	v.SetDefault("report.timezone", "Local")
+
+	v.SetDefault("notify.send_timeout", "30s")
+	v.SetDefault("notify.connectivity_alert_after", "2m")
+	v.SetDefault("notify.daily_summary", true)
+	v.SetDefault("notify.dedup_window", "10m")
+	v.SetDefault("notify.pagerduty.enabled", false)
+	v.SetDefault("notify.pagerduty.url", "https://events.pagerduty.com/v2/enqueue")
+	v.SetDefault("notify.pagerduty.source", "lighter-trader")
+	v.SetDefault("notify.pagerduty.min_severity", SeverityCritical)
+	v.SetDefault("notify.opsgenie.enabled", false)
+	v.SetDefault("notify.opsgenie.url", "https://api.opsgenie.com")
+	v.SetDefault("notify.opsgenie.source", "lighter-trader")
+	v.SetDefault("notify.opsgenie.min_severity", SeverityCritical)
+	v.SetDefault("notify.smtp.enabled", false)
+	v.SetDefault("notify.smtp.port", 587)
+	v.SetDefault("notify.smtp.security", SMTPSecurityStartTLS)
+	v.SetDefault("notify.smtp.min_severity", SeverityInfo)
+	v.SetDefault("notify.smtp.subject_prefix", "[lighter-trader]")
+
+	v.SetDefault("stats.reset_timezone", "Local")
+	v.SetDefault("stats.reset_hour", 0)
+
+	// 手续费默认配置
+	v.SetDefault("fees.binance_maker_percent", 0.02) // Binance Maker 0.02%
+	v.SetDefault("fees.binance_taker_percent", 0.05) // Binance Taker 0.05%
+	v.SetDefault("fees.lighter_maker_percent", 0.0)  // Lighter标准账户免手续费
+	v.SetDefault("fees.lighter_taker_percent", 0.0)
+	v.SetDefault("fees.fetch_binance", false)
+	v.SetDefault("fees.fee_adjusted_spread", false)
+	v.SetDefault("fees.min_edge_percent", 0.0)
+
+	v.SetDefault("admin.enabled", false)
+	v.SetDefault("admin.listen", "127.0.0.1:8080")
+	v.SetDefault("admin.control_min_interval", "2s")
+	v.SetDefault("admin.tls.enabled", false)
+	v.SetDefault("admin.tls.autocert_cache_dir", "data/autocert")
+
+	v.SetDefault("pprof.enabled", false)
+	v.SetDefault("pprof.listen", "127.0.0.1:6060")
+	v.SetDefault("pprof.block_profile_rate", 0)
+	v.SetDefault("pprof.mutex_profile_fraction", 0)
+	v.SetDefault("pprof.tls.enabled", false)
+	v.SetDefault("pprof.tls.autocert_cache_dir", "data/autocert")
+
+	v.SetDefault("shutdown.mode", "none")
+	v.SetDefault("shutdown.drain_timeout", 2*time.Minute)
+
+	v.SetDefault("preflight.enabled", true)
+
 	v.SetDefault("app.name", "lighter-trader")
 	v.SetDefault("app.version", "1.0.0")
 	v.SetDefault("app.environment", "production")
@@ -173,38 +940,69 @@ func (c *Config) GetLogDir() string {
 	return filepath.Dir(c.Logging.Output)
 }
 
-func (c *Config) Validate() error {
-	// 验证策略类型
-	validStrategies := map[string]bool{
-		"lighter":       true,
-		"binance":       true,
-		"arbitrage":     true,
-		"dynamic_hedge": true,
+// ValidateLighterCredentials 验证Lighter凭证，由需要Lighter客户端的策略调用
+func (c *Config) ValidateLighterCredentials() error {
+	if c.Lighter.APIKey == "" {
+		return fmt.Errorf("lighter.api_key is required for %s strategy", c.Strategy.Type)
+	}
+	if c.Lighter.SecretKey == "" {
+		return fmt.Errorf("lighter.secret_key is required for %s strategy", c.Strategy.Type)
+	}
+	if c.Lighter.PrivateKey == "" && c.Lighter.Keystore == "" {
+		return fmt.Errorf("lighter.private_key or lighter.keystore is required for %s strategy", c.Strategy.Type)
 	}
-	if !validStrategies[c.Strategy.Type] {
-		return fmt.Errorf("strategy.type must be one of: lighter, binance, arbitrage, dynamic_hedge")
+	if c.Lighter.PrivateKey != "" && c.Lighter.Keystore != "" {
+		return fmt.Errorf("lighter.private_key and lighter.keystore are mutually exclusive")
 	}
+	return c.Lighter.validateNetwork()
+}
 
-	// 根据策略类型验证相应的配置
-	if c.Strategy.Type == "lighter" || c.Strategy.Type == "arbitrage" || c.Strategy.Type == "dynamic_hedge" {
-		if c.Lighter.APIKey == "" {
-			return fmt.Errorf("lighter.api_key is required for %s strategy", c.Strategy.Type)
-		}
-		if c.Lighter.SecretKey == "" {
-			return fmt.Errorf("lighter.secret_key is required for %s strategy", c.Strategy.Type)
+// applyNetworkDefaults 未配置接口地址或链ID时按 testnet 使用正式网或测试网的默认值
+func (c *LighterConfig) applyNetworkDefaults() {
+	if c.BaseURL == "" {
+		c.BaseURL = LighterMainnetBaseURL
+		if c.Testnet {
+			c.BaseURL = LighterTestnetBaseURL
 		}
-		if c.Lighter.PrivateKey == "" {
-			return fmt.Errorf("lighter.private_key is required for %s strategy", c.Strategy.Type)
+	}
+	if c.ChainID == 0 {
+		c.ChainID = LighterMainnetChainID
+		if c.Testnet {
+			c.ChainID = LighterTestnetChainID
 		}
 	}
+}
 
-	if c.Strategy.Type == "binance" || c.Strategy.Type == "arbitrage" || c.Strategy.Type == "dynamic_hedge" {
-		if c.Binance.APIKey == "" {
-			return fmt.Errorf("binance.api_key is required for %s strategy", c.Strategy.Type)
-		}
-		if c.Binance.SecretKey == "" {
-			return fmt.Errorf("binance.secret_key is required for %s strategy", c.Strategy.Type)
+// validateNetwork 检查接口地址和链ID与 testnet 一致，防止测试网密钥发往正式网 (或相反)
+func (c *LighterConfig) validateNetwork() error {
+	baseURL := strings.TrimRight(c.BaseURL, "/")
+	if c.Testnet {
+		if baseURL == LighterMainnetBaseURL || c.ChainID == LighterMainnetChainID {
+			return fmt.Errorf("lighter.testnet is enabled but lighter.base_url or lighter.chain_id points to mainnet")
 		}
+		return nil
+	}
+	if baseURL == LighterTestnetBaseURL || c.ChainID == LighterTestnetChainID {
+		return fmt.Errorf("lighter.base_url or lighter.chain_id points to testnet, set lighter.testnet: true")
+	}
+	return nil
+}
+
+// ValidateBinanceCredentials 验证Binance凭证，由需要Binance客户端的策略调用
+func (c *Config) ValidateBinanceCredentials() error {
+	if c.Binance.APIKey == "" {
+		return fmt.Errorf("binance.api_key is required for %s strategy", c.Strategy.Type)
+	}
+	if c.Binance.SecretKey == "" {
+		return fmt.Errorf("binance.secret_key is required for %s strategy", c.Strategy.Type)
+	}
+	return nil
+}
+
+func (c *Config) Validate() error {
+	// 策略类型由引擎的策略注册表校验，这里只要求已配置
+	if c.Strategy.Type == "" {
+		return fmt.Errorf("strategy.type is required")
 	}
 
 	if c.Trading.USDTAmount <= 0 {
@@ -219,6 +1017,567 @@ func (c *Config) Validate() error {
 	if c.Strategy.SpreadPercent < 0 {
 		return fmt.Errorf("strategy.spread_percent must be non-negative")
 	}
+	if c.Strategy.EquityRefreshInterval < 0 {
+		return fmt.Errorf("strategy.equity_refresh_interval must be non-negative")
+	}
+	if c.Strategy.EnablePreExecution && (c.Strategy.PartialFillThreshold <= 0 || c.Strategy.PartialFillThreshold > 1) {
+		return fmt.Errorf("strategy.partial_fill_threshold must be in (0, 1]")
+	}
+	if c.Strategy.EnableConcurrentExecution && c.Strategy.MaxConcurrentOrders < 1 {
+		return fmt.Errorf("strategy.max_concurrent_orders must be at least 1")
+	}
+	for i, b := range c.Strategy.ExecutionDelayBuckets {
+		if b <= 0 || (i > 0 && b <= c.Strategy.ExecutionDelayBuckets[i-1]) {
+			return fmt.Errorf("strategy.execution_delay_buckets must be positive and strictly increasing")
+		}
+	}
+
+	if c.Strategy.EnableDailyFlatten {
+		flattenAt, err := time.Parse("15:04", c.Strategy.FlattenTime)
+		if err != nil {
+			return fmt.Errorf("strategy.flatten_time must be HH:MM: %w", err)
+		}
+		resumeAt, err := time.Parse("15:04", c.Strategy.FlattenResumeTime)
+		if err != nil {
+			return fmt.Errorf("strategy.flatten_resume_time must be HH:MM: %w", err)
+		}
+		if flattenAt.Equal(resumeAt) {
+			return fmt.Errorf("strategy.flatten_time and strategy.flatten_resume_time must differ")
+		}
+	}
+
+	if _, err := time.LoadLocation(c.Strategy.SessionTimezone); err != nil {
+		return fmt.Errorf("strategy.session_timezone is invalid: %w", err)
+	}
+	validWeekdays := map[string]bool{
+		"sun": true, "sunday": true, "mon": true, "monday": true, "tue": true, "tuesday": true,
+		"wed": true, "wednesday": true, "thu": true, "thursday": true, "fri": true, "friday": true,
+		"sat": true, "saturday": true,
+	}
+	for i, w := range c.Strategy.TradingWindows {
+		if _, err := time.Parse("15:04", w.Start); err != nil {
+			return fmt.Errorf("strategy.trading_windows[%d].start must be HH:MM: %w", i, err)
+		}
+		if _, err := time.Parse("15:04", w.End); err != nil {
+			return fmt.Errorf("strategy.trading_windows[%d].end must be HH:MM: %w", i, err)
+		}
+		for _, day := range w.Days {
+			if !validWeekdays[strings.ToLower(strings.TrimSpace(day))] {
+				return fmt.Errorf("strategy.trading_windows[%d].days contains invalid weekday %q", i, day)
+			}
+		}
+	}
+
+	if c.Strategy.EnableTWAP {
+		if c.Strategy.TWAPSlices < 2 {
+			return fmt.Errorf("strategy.twap_slices must be at least 2")
+		}
+		if c.Strategy.TWAPWindow <= 0 {
+			return fmt.Errorf("strategy.twap_window must be positive")
+		}
+		if c.Strategy.TWAPDepthPercent <= 0 || c.Strategy.TWAPDepthRatio <= 0 {
+			return fmt.Errorf("strategy.twap_depth_percent and strategy.twap_depth_ratio must be positive")
+		}
+		if c.Strategy.ExecutionAlgo != "twap" && c.Strategy.ExecutionAlgo != "vwap" {
+			return fmt.Errorf("strategy.execution_algo must be one of: twap, vwap")
+		}
+		if c.Strategy.ExecutionAlgo == "vwap" && c.Strategy.VWAPLookback <= 0 {
+			return fmt.Errorf("strategy.vwap_lookback must be positive")
+		}
+	}
+
+	if c.Strategy.MaxOrderAge < 0 {
+		return fmt.Errorf("strategy.max_order_age must be non-negative")
+	}
+
+	if c.Strategy.EnableOrderChasing {
+		if c.Strategy.ChaseThresholdPercent <= 0 {
+			return fmt.Errorf("strategy.chase_threshold_percent must be positive")
+		}
+		if c.Strategy.ChaseInterval <= 0 {
+			return fmt.Errorf("strategy.chase_interval must be positive")
+		}
+		if c.Strategy.MaxChases < 0 {
+			return fmt.Errorf("strategy.max_chases must be non-negative")
+		}
+	}
+
+	if c.Strategy.EnableSpreadTrigger {
+		if c.Strategy.SpreadCheckInterval <= 0 {
+			return fmt.Errorf("strategy.spread_check_interval must be positive")
+		}
+	}
+
+	if c.Strategy.EnableDivergenceGuard && c.Strategy.MaxMarkIndexDivergence <= 0 {
+		return fmt.Errorf("strategy.max_mark_index_divergence must be positive")
+	}
+
+	if c.Strategy.EnableLiquidationMonitor {
+		if c.Strategy.LiquidationAlertPercent <= 0 || c.Strategy.LiquidationAlertPercent >= 100 {
+			return fmt.Errorf("strategy.liquidation_alert_percent must be between 0 and 100")
+		}
+		if c.Strategy.LiquidationDeRiskPercent < 0 || c.Strategy.LiquidationDeRiskPercent > c.Strategy.LiquidationAlertPercent {
+			return fmt.Errorf("strategy.liquidation_derisk_percent must be between 0 and strategy.liquidation_alert_percent")
+		}
+	}
+
+	if c.Strategy.EnableReconciliation {
+		if c.Strategy.ReconcileToleranceNotional < 0 || c.Strategy.ReconcileHaltNotional < 0 {
+			return fmt.Errorf("strategy.reconcile_tolerance_notional and strategy.reconcile_halt_notional must be non-negative")
+		}
+		if c.Strategy.ReconcileHaltNotional > 0 && c.Strategy.ReconcileHaltNotional < c.Strategy.ReconcileToleranceNotional {
+			return fmt.Errorf("strategy.reconcile_halt_notional must not be below strategy.reconcile_tolerance_notional")
+		}
+	}
+
+	if c.Strategy.EnableVolatility {
+		switch c.Strategy.VolatilityInterval {
+		case "1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "1d":
+		default:
+			return fmt.Errorf("invalid strategy.volatility_interval: %s", c.Strategy.VolatilityInterval)
+		}
+		// 多取两根K线 (首个收益率的前收和未收盘K线)，单次最多1000根
+		if c.Strategy.VolatilityWindow < 2 || c.Strategy.VolatilityWindow > 998 {
+			return fmt.Errorf("strategy.volatility_window must be between 2 and 998")
+		}
+		if c.Strategy.VolatilityRefresh <= 0 {
+			return fmt.Errorf("strategy.volatility_refresh must be positive")
+		}
+	}
+
+	if c.Strategy.EnableShadow {
+		if c.Strategy.ShadowSpreadPercent < 0 || c.Strategy.ShadowOrderSize < 0 {
+			return fmt.Errorf("strategy.shadow_spread_percent and strategy.shadow_order_size must be non-negative")
+		}
+		if c.Strategy.ShadowMaxPositionNotional <= 0 {
+			return fmt.Errorf("strategy.shadow_max_position_notional must be positive")
+		}
+		if c.Strategy.ShadowTradingInterval < 0 || c.Strategy.ShadowMaxOrderAge < 0 {
+			return fmt.Errorf("strategy.shadow_trading_interval and strategy.shadow_max_order_age must be non-negative")
+		}
+	}
+
+	if c.Strategy.EnableWatchdog {
+		if c.Strategy.WatchdogInterval <= 0 {
+			return fmt.Errorf("strategy.watchdog_interval must be positive")
+		}
+		if c.Strategy.WatchdogStallTimeout <= c.Strategy.MonitorInterval {
+			return fmt.Errorf("strategy.watchdog_stall_timeout must be greater than strategy.monitor_interval")
+		}
+		// 自适应降频会放大轮询间隔，最慢时也不能被误判为卡住
+		if c.Binance.AdaptiveThrottle &&
+			float64(c.Strategy.WatchdogStallTimeout) <= float64(c.Strategy.MonitorInterval)*c.Binance.ThrottleMaxSlowdown {
+			return fmt.Errorf("strategy.watchdog_stall_timeout must be greater than strategy.monitor_interval * binance.throttle_max_slowdown")
+		}
+		if c.Strategy.WatchdogAction != "restart" && c.Strategy.WatchdogAction != "exit" {
+			return fmt.Errorf("strategy.watchdog_action must be one of: restart, exit")
+		}
+		if c.Strategy.WatchdogMaxRestarts < 0 {
+			return fmt.Errorf("strategy.watchdog_max_restarts must be non-negative")
+		}
+	}
+
+	if c.Strategy.EnableAdaptiveSpread {
+		if !c.Strategy.EnableVolatility {
+			return fmt.Errorf("strategy.enable_adaptive_spread requires strategy.enable_volatility")
+		}
+		if c.Strategy.AdaptiveSpreadReferenceATR <= 0 {
+			return fmt.Errorf("strategy.adaptive_spread_reference_atr must be positive")
+		}
+		if c.Strategy.AdaptiveSpreadMinMultiplier <= 0 || c.Strategy.AdaptiveSpreadMaxMultiplier < c.Strategy.AdaptiveSpreadMinMultiplier {
+			return fmt.Errorf("strategy.adaptive_spread_min_multiplier must be positive and not above strategy.adaptive_spread_max_multiplier")
+		}
+	}
+
+	if c.Strategy.EnableAutoTransfer {
+		if !c.Strategy.EnableBalanceCheck {
+			return fmt.Errorf("strategy.enable_auto_transfer requires strategy.enable_balance_check")
+		}
+		if c.Binance.Market != "futures" {
+			return fmt.Errorf("strategy.enable_auto_transfer requires binance.market: futures")
+		}
+		if c.Strategy.AutoTransferAsset == "" {
+			return fmt.Errorf("strategy.auto_transfer_asset is required")
+		}
+		if c.Strategy.AutoTransferMinAmount < 0 || c.Strategy.AutoTransferMaxAmount < 0 {
+			return fmt.Errorf("strategy.auto_transfer_min_amount and strategy.auto_transfer_max_amount must be non-negative")
+		}
+		if c.Strategy.AutoTransferMaxAmount > 0 && c.Strategy.AutoTransferMaxAmount < c.Strategy.AutoTransferMinAmount {
+			return fmt.Errorf("strategy.auto_transfer_max_amount must not be below strategy.auto_transfer_min_amount")
+		}
+	}
+
+	if c.Strategy.EnableCollateralRebalance {
+		if c.Strategy.RebalanceCheckInterval <= 0 || c.Strategy.RebalanceAlertInterval <= 0 {
+			return fmt.Errorf("strategy.rebalance_check_interval and strategy.rebalance_alert_interval must be positive")
+		}
+		if c.Strategy.RebalanceThresholdPercent <= 0 || c.Strategy.RebalanceThresholdPercent >= 100 {
+			return fmt.Errorf("strategy.rebalance_threshold_percent must be in (0, 100)")
+		}
+		if c.Strategy.RebalanceMinAmount < 0 {
+			return fmt.Errorf("strategy.rebalance_min_amount must be non-negative")
+		}
+		if c.Strategy.RebalanceAsset == "" {
+			return fmt.Errorf("strategy.rebalance_asset is required")
+		}
+		if c.Strategy.RebalanceAutoWithdraw {
+			if c.Strategy.RebalanceWithdrawNetwork == "" || c.Strategy.RebalanceDepositAddress == "" {
+				return fmt.Errorf("strategy.rebalance_withdraw_network and strategy.rebalance_deposit_address are required when strategy.rebalance_auto_withdraw is enabled")
+			}
+			if c.Strategy.RebalanceMaxWithdrawAmount <= 0 {
+				return fmt.Errorf("strategy.rebalance_max_withdraw_amount must be positive")
+			}
+		}
+	}
+
+	if c.Strategy.EnableUSDCRate && c.Strategy.USDCRateRefresh <= 0 {
+		return fmt.Errorf("strategy.usdc_rate_refresh must be positive")
+	}
+
+	if c.Strategy.EnableFundingAccrual && c.Strategy.FundingAccrualInterval <= 0 {
+		return fmt.Errorf("strategy.funding_accrual_interval must be positive")
+	}
+
+	if c.Strategy.EnableLiquiditySizing {
+		if c.Strategy.LiquidityDepthPercent <= 0 {
+			return fmt.Errorf("strategy.liquidity_depth_percent must be positive")
+		}
+		if c.Strategy.MaxLiquidityRatio <= 0 || c.Strategy.MaxLiquidityRatio > 1 {
+			return fmt.Errorf("strategy.max_liquidity_ratio must be in (0, 1]")
+		}
+		if c.Strategy.MinOrderSize < 0 {
+			return fmt.Errorf("strategy.min_order_size must be non-negative")
+		}
+	}
+
+	if c.Strategy.EnableProtectiveOrders {
+		if c.Strategy.StopLossPercent < 0 || c.Strategy.StopLossPercent >= 100 {
+			return fmt.Errorf("strategy.stop_loss_percent must be between 0 and 100")
+		}
+		if c.Strategy.TakeProfitPercent < 0 || c.Strategy.TakeProfitPercent >= 100 {
+			return fmt.Errorf("strategy.take_profit_percent must be between 0 and 100")
+		}
+		if c.Strategy.StopLossPercent == 0 && c.Strategy.TakeProfitPercent == 0 {
+			return fmt.Errorf("strategy.stop_loss_percent or strategy.take_profit_percent must be positive")
+		}
+	}
+
+	if c.Binance.Market != "spot" && c.Binance.Market != "futures" && c.Binance.Market != "margin" {
+		return fmt.Errorf("binance.market must be one of: spot, futures, margin")
+	}
+	if c.Binance.Testnet && c.Binance.Market == "margin" {
+		return fmt.Errorf("binance.market: margin is not available on testnet, use spot or futures")
+	}
+	if c.Binance.FuturesLeverage < 0 || c.Binance.FuturesLeverage > 125 {
+		return fmt.Errorf("binance.futures_leverage must be between 0 and 125")
+	}
+	if c.Binance.FuturesMarginType != "" && c.Binance.FuturesMarginType != "cross" && c.Binance.FuturesMarginType != "isolated" {
+		return fmt.Errorf("binance.futures_margin_type must be one of: cross, isolated")
+	}
+	if c.Binance.Market == "futures" && c.Strategy.Type == "basis" {
+		return fmt.Errorf("basis strategy requires binance.market: spot or margin")
+	}
+
+	if c.Binance.RequestWeightPerMinute < 0 || c.Binance.FuturesWeightPerMinute < 0 {
+		return fmt.Errorf("binance.request_weight_per_minute and binance.futures_weight_per_minute must be non-negative")
+	}
+	if c.Binance.BookTicker && c.Binance.BookTickerMaxAge <= 0 {
+		return fmt.Errorf("binance.book_ticker_max_age must be positive when book_ticker is enabled")
+	}
+	if c.Binance.AdaptiveThrottle {
+		if c.Binance.ThrottleStartPercent <= 0 || c.Binance.ThrottleStartPercent >= 100 {
+			return fmt.Errorf("binance.throttle_start_percent must be between 0 and 100")
+		}
+		if c.Binance.ThrottleMaxSlowdown < 1 {
+			return fmt.Errorf("binance.throttle_max_slowdown must be at least 1")
+		}
+	}
+	if err := c.Binance.Transport.validate("binance.transport"); err != nil {
+		return err
+	}
+	if err := c.Lighter.Transport.validate("lighter.transport"); err != nil {
+		return err
+	}
+
+	if err := c.validateSymbols(); err != nil {
+		return err
+	}
+
+	if c.Strategy.Type == "funding_arb" {
+		if len(c.Strategy.FundingSymbols) == 0 {
+			return fmt.Errorf("strategy.funding_symbols must not be empty")
+		}
+		if err := c.checkSymbolsConfigured("strategy.funding_symbols", c.Strategy.FundingSymbols); err != nil {
+			return err
+		}
+		if c.Strategy.FundingMinRateDiff < 0 {
+			return fmt.Errorf("strategy.funding_min_rate_diff must be non-negative")
+		}
+		if c.Strategy.FundingCheckInterval <= 0 {
+			return fmt.Errorf("strategy.funding_check_interval must be positive")
+		}
+	}
+
+	if c.Strategy.Type == "basis" {
+		if len(c.Strategy.BasisSymbols) == 0 {
+			return fmt.Errorf("strategy.basis_symbols must not be empty")
+		}
+		if err := c.checkSymbolsConfigured("strategy.basis_symbols", c.Strategy.BasisSymbols); err != nil {
+			return err
+		}
+		if c.Strategy.BasisExitAnnualized < 0 || c.Strategy.BasisEntryAnnualized <= c.Strategy.BasisExitAnnualized {
+			return fmt.Errorf("strategy.basis_entry_annualized must be greater than strategy.basis_exit_annualized (>= 0)")
+		}
+		if c.Strategy.BasisConvergenceWindow <= 0 {
+			return fmt.Errorf("strategy.basis_convergence_window must be positive")
+		}
+		if c.Strategy.BasisCheckInterval <= 0 {
+			return fmt.Errorf("strategy.basis_check_interval must be positive")
+		}
+	}
+
+	if c.Strategy.Type == "market_making" {
+		if len(c.Strategy.MMSymbols) == 0 {
+			return fmt.Errorf("strategy.mm_symbols must not be empty")
+		}
+		if err := c.checkSymbolsConfigured("strategy.mm_symbols", c.Strategy.MMSymbols); err != nil {
+			return err
+		}
+		if c.Strategy.MMQuoteSize <= 0 {
+			return fmt.Errorf("strategy.mm_quote_size must be positive")
+		}
+		if c.Strategy.MMSpreadPercent <= 0 {
+			return fmt.Errorf("strategy.mm_spread_percent must be positive")
+		}
+		if c.Strategy.MMRefreshInterval <= 0 {
+			return fmt.Errorf("strategy.mm_refresh_interval must be positive")
+		}
+	}
+
+	if c.EventLog.Enabled {
+		if c.EventLog.Path == "" {
+			return fmt.Errorf("event_log.path is required")
+		}
+		if c.Journal.Enabled && c.EventLog.Path == c.Journal.Path {
+			return fmt.Errorf("event_log.path must differ from journal.path")
+		}
+	}
+
+	if c.OrderState.Enabled {
+		if c.OrderState.Path == "" {
+			return fmt.Errorf("order_state.path is required")
+		}
+		switch c.OrderState.UntrackedAction {
+		case "cancel", "adopt", "ignore":
+		default:
+			return fmt.Errorf("order_state.untracked_action must be one of: cancel, adopt, ignore")
+		}
+	}
+
+	if c.StatsState.Enabled {
+		if c.StatsState.Path == "" {
+			return fmt.Errorf("stats_state.path is required")
+		}
+		if c.StatsState.Interval <= 0 {
+			return fmt.Errorf("stats_state.interval must be positive")
+		}
+	}
+
+	if c.Report.Enabled {
+		if !c.Journal.Enabled {
+			return fmt.Errorf("report requires journal.enabled")
+		}
+		for _, format := range c.Report.Formats {
+			if format != "json" && format != "html" {
+				return fmt.Errorf("report.formats must contain only: json, html")
+			}
+		}
+		if _, err := time.LoadLocation(c.Report.Timezone); err != nil {
+			return fmt.Errorf("report.timezone is invalid: %w", err)
+		}
+	}
+
+	if c.Notify.SendTimeout <= 0 {
+		return fmt.Errorf("notify.send_timeout must be positive")
+	}
+	if c.Notify.ConnectivityAlertAfter < 0 {
+		return fmt.Errorf("notify.connectivity_alert_after must be non-negative")
+	}
+	if c.Notify.DedupWindow < 0 {
+		return fmt.Errorf("notify.dedup_window must be non-negative")
+	}
+	if err := c.Notify.validateRules(); err != nil {
+		return err
+	}
+	if c.Notify.PagerDuty.Enabled {
+		if c.Notify.PagerDuty.RoutingKey == "" || c.Notify.PagerDuty.URL == "" {
+			return fmt.Errorf("notify.pagerduty.routing_key and notify.pagerduty.url are required when pagerduty is enabled")
+		}
+		if err := validateSeverity("notify.pagerduty.min_severity", c.Notify.PagerDuty.MinSeverity); err != nil {
+			return err
+		}
+	}
+	if c.Notify.Opsgenie.Enabled {
+		if c.Notify.Opsgenie.APIKey == "" || c.Notify.Opsgenie.URL == "" {
+			return fmt.Errorf("notify.opsgenie.api_key and notify.opsgenie.url are required when opsgenie is enabled")
+		}
+		if err := validateSeverity("notify.opsgenie.min_severity", c.Notify.Opsgenie.MinSeverity); err != nil {
+			return err
+		}
+	}
+	if c.Notify.SMTP.Enabled {
+		smtp := c.Notify.SMTP
+		if smtp.Host == "" || smtp.Port <= 0 {
+			return fmt.Errorf("notify.smtp.host and notify.smtp.port are required when smtp is enabled")
+		}
+		if smtp.From == "" || len(smtp.To) == 0 {
+			return fmt.Errorf("notify.smtp.from and notify.smtp.to are required when smtp is enabled")
+		}
+		switch smtp.Security {
+		case SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone:
+		default:
+			return fmt.Errorf("notify.smtp.security must be one of: starttls, tls, none")
+		}
+		if err := validateSeverity("notify.smtp.min_severity", smtp.MinSeverity); err != nil {
+			return err
+		}
+	}
+
+	if c.Strategy.MaxDrawdownPercent < 0 || c.Strategy.EmergencyDrawdownPercent < 0 {
+		return fmt.Errorf("strategy.max_drawdown_percent and strategy.emergency_drawdown_percent must be non-negative")
+	}
+	if c.Strategy.StartingEquity < 0 {
+		return fmt.Errorf("strategy.starting_equity must be non-negative")
+	}
+	if c.Strategy.MaxDrawdownPercent > 0 && c.Strategy.EmergencyDrawdownPercent > 0 &&
+		c.Strategy.EmergencyDrawdownPercent < c.Strategy.MaxDrawdownPercent {
+		return fmt.Errorf("strategy.emergency_drawdown_percent must not be less than strategy.max_drawdown_percent")
+	}
+
+	if c.Strategy.MaxSymbolNotional < 0 || c.Strategy.MaxVenueNotional < 0 {
+		return fmt.Errorf("strategy.max_symbol_notional and strategy.max_venue_notional must be non-negative")
+	}
+
+	if c.Retry.MaxAttempts < 1 {
+		return fmt.Errorf("retry.max_attempts must be at least 1")
+	}
+	if c.Retry.InitialBackoff < 0 || c.Retry.MaxBackoff < 0 {
+		return fmt.Errorf("retry.initial_backoff and retry.max_backoff must be non-negative")
+	}
+	if c.Retry.Multiplier < 1 {
+		return fmt.Errorf("retry.multiplier must be at least 1")
+	}
+	if c.Retry.Jitter < 0 || c.Retry.Jitter > 1 {
+		return fmt.Errorf("retry.jitter must be between 0 and 1")
+	}
+	if c.RequestTimeout.Order < 0 || c.RequestTimeout.Query < 0 {
+		return fmt.Errorf("request_timeout.order and request_timeout.query must be non-negative")
+	}
+
+	if c.CircuitBreaker.Enabled {
+		if c.CircuitBreaker.FailureThreshold < 1 {
+			return fmt.Errorf("circuit_breaker.failure_threshold must be at least 1")
+		}
+		if c.CircuitBreaker.Cooldown <= 0 {
+			return fmt.Errorf("circuit_breaker.cooldown must be positive")
+		}
+	}
+
+	if c.KillSwitch.CheckInterval <= 0 {
+		return fmt.Errorf("kill_switch.check_interval must be positive")
+	}
+
+	if c.PriceFeed.Enabled {
+		if c.PriceFeed.RefreshInterval <= 0 || c.PriceFeed.MaxAge <= 0 {
+			return fmt.Errorf("price_feed.refresh_interval and price_feed.max_age must be positive")
+		}
+		sources := 2
+		if c.PriceFeed.Coinbase {
+			sources++
+		}
+		if c.PriceFeed.MinSources < 1 || c.PriceFeed.MinSources > sources {
+			return fmt.Errorf("price_feed.min_sources must be between 1 and %d", sources)
+		}
+	}
+
+	if _, err := time.LoadLocation(c.Stats.ResetTimezone); err != nil {
+		return fmt.Errorf("stats.reset_timezone is invalid: %w", err)
+	}
+	if c.Stats.ResetHour < 0 || c.Stats.ResetHour > 23 {
+		return fmt.Errorf("stats.reset_hour must be between 0 and 23")
+	}
+
+	if c.Fees.BinanceMakerPercent < 0 || c.Fees.BinanceTakerPercent < 0 ||
+		c.Fees.LighterMakerPercent < 0 || c.Fees.LighterTakerPercent < 0 {
+		return fmt.Errorf("fees.*_percent must be non-negative")
+	}
+	if c.Fees.MinEdgePercent < 0 {
+		return fmt.Errorf("fees.min_edge_percent must be non-negative")
+	}
+	for name, tiers := range map[string][]FeeTierConfig{"binance_tiers": c.Fees.BinanceTiers, "lighter_tiers": c.Fees.LighterTiers} {
+		for i, tier := range tiers {
+			if tier.Name == "" {
+				return fmt.Errorf("fees.%s[%d].name is required", name, i)
+			}
+			if tier.MinVolume < 0 || (i > 0 && tier.MinVolume <= tiers[i-1].MinVolume) {
+				return fmt.Errorf("fees.%s must be sorted by strictly increasing non-negative min_volume", name)
+			}
+		}
+	}
+
+	if c.Admin.Enabled && c.Admin.Listen == "" {
+		return fmt.Errorf("admin.listen is required when admin API is enabled")
+	}
+	tokens := make(map[string]bool)
+	for i, t := range c.Admin.Tokens {
+		if t.Name == "" {
+			return fmt.Errorf("admin.tokens[%d].name is required", i)
+		}
+		if len(t.Token) < 16 {
+			return fmt.Errorf("admin.tokens[%d] (%s): token must be at least 16 characters", i, t.Name)
+		}
+		if tokens[t.Token] {
+			return fmt.Errorf("admin.tokens[%d] (%s): duplicate token", i, t.Name)
+		}
+		tokens[t.Token] = true
+		if t.Role != AdminRoleRead && t.Role != AdminRoleControl {
+			return fmt.Errorf("admin.tokens[%d] (%s): role must be %s or %s", i, t.Name, AdminRoleRead, AdminRoleControl)
+		}
+	}
+	if c.Admin.ControlMinInterval < 0 {
+		return fmt.Errorf("admin.control_min_interval must be non-negative")
+	}
+	if err := c.Admin.TLS.validate("admin.tls"); err != nil {
+		return err
+	}
+	if c.Admin.Enabled && c.Admin.Unauthenticated() {
+		return fmt.Errorf("admin.listen %s is not a loopback address, configure admin.tokens or admin.tls.client_ca_file", c.Admin.Listen)
+	}
+
+	if c.Pprof.Enabled {
+		if c.Pprof.Listen == "" {
+			return fmt.Errorf("pprof.listen is required when pprof is enabled")
+		}
+		if c.Pprof.Listen == c.Admin.Listen && c.Admin.Enabled {
+			return fmt.Errorf("pprof.listen must differ from admin.listen")
+		}
+		if c.Pprof.BlockProfileRate < 0 || c.Pprof.MutexProfileFraction < 0 {
+			return fmt.Errorf("pprof.block_profile_rate and pprof.mutex_profile_fraction must be non-negative")
+		}
+		if err := c.Pprof.TLS.validate("pprof.tls"); err != nil {
+			return err
+		}
+	}
+
+	switch c.Shutdown.Mode {
+	case "none", "cancel", "flatten":
+	case "drain":
+		if c.Shutdown.DrainTimeout <= 0 {
+			return fmt.Errorf("shutdown.drain_timeout must be positive")
+		}
+	default:
+		return fmt.Errorf("shutdown.mode must be one of none, drain, cancel, flatten")
+	}
 
 	logDir := c.GetLogDir()
 	if err := os.MkdirAll(logDir, 0755); err != nil {
@@ -227,3 +1586,167 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// validateSeverity 检查告警严重程度
+func validateSeverity(key, severity string) error {
+	switch severity {
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+		return nil
+	default:
+		return fmt.Errorf("%s must be one of: info, warning, critical", key)
+	}
+}
+
+// validateRules 检查告警规则：事件类型不能重复，升级策略的次数递增且严重程度有效
+func (n *NotifyConfig) validateRules() error {
+	seen := make(map[string]bool)
+	for i, rule := range n.Rules {
+		prefix := fmt.Sprintf("notify.rules[%d]", i)
+		if rule.Event == "" {
+			return fmt.Errorf("%s.event is required", prefix)
+		}
+		if seen[rule.Event] {
+			return fmt.Errorf("%s.event %s is duplicated", prefix, rule.Event)
+		}
+		seen[rule.Event] = true
+
+		if rule.Severity != "" && rule.Severity != SeverityOff {
+			if err := validateSeverity(prefix+".severity", rule.Severity); err != nil {
+				return err
+			}
+		}
+		if rule.DedupWindow < 0 {
+			return fmt.Errorf("%s.dedup_window must be non-negative", prefix)
+		}
+
+		last := 1
+		for j, esc := range rule.Escalations {
+			key := fmt.Sprintf("%s.escalations[%d]", prefix, j)
+			if esc.Count <= last {
+				return fmt.Errorf("%s.count must be at least 2 and greater than the previous escalation", key)
+			}
+			last = esc.Count
+			if err := validateSeverity(key+".severity", esc.Severity); err != nil {
+				return err
+			}
+		}
+		if len(rule.Escalations) > 0 && rule.DedupWindow == 0 && n.DedupWindow == 0 {
+			return fmt.Errorf("%s.escalations require a dedup window", prefix)
+		}
+	}
+	return nil
+}
+
+// validate 检查证书来源：启用时证书文件和自动申请证书必须且只能配置一种，prefix 为配置路径
+func (t *ServerTLS) validate(prefix string) error {
+	if !t.Enabled {
+		return nil
+	}
+	files := t.CertFile != "" || t.KeyFile != ""
+	if files && len(t.AutocertDomains) > 0 {
+		return fmt.Errorf("%s: cert_file/key_file and autocert_domains are mutually exclusive", prefix)
+	}
+	if files && (t.CertFile == "" || t.KeyFile == "") {
+		return fmt.Errorf("%s.cert_file and %s.key_file must be set together", prefix, prefix)
+	}
+	if !files && len(t.AutocertDomains) == 0 {
+		return fmt.Errorf("%s: cert_file/key_file or autocert_domains is required when tls is enabled", prefix)
+	}
+	if len(t.AutocertDomains) > 0 && t.AutocertCacheDir == "" {
+		return fmt.Errorf("%s.autocert_cache_dir is required for autocert", prefix)
+	}
+	return nil
+}
+
+// validateSymbols 校验币种配置
+// validate 检查代理地址、DNS服务器和TLS版本，prefix 为配置路径
+func (t *TransportConfig) validate(prefix string) error {
+	if t.Proxy != "" {
+		u, err := url.Parse(t.Proxy)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("%s.proxy is not a valid url", prefix)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("%s.proxy scheme must be one of: http, https, socks5", prefix)
+		}
+	}
+
+	for _, server := range t.DNSServers {
+		host := server
+		if h, _, err := net.SplitHostPort(server); err == nil {
+			host = h
+		}
+		if net.ParseIP(host) == nil {
+			return fmt.Errorf("%s.dns_servers: invalid address %q (expected ip or ip:port)", prefix, server)
+		}
+	}
+
+	if t.TLSMinVersion != "" && t.TLSMinVersion != "1.2" && t.TLSMinVersion != "1.3" {
+		return fmt.Errorf("%s.tls_min_version must be one of: 1.2, 1.3", prefix)
+	}
+	if t.AuditLog != "" && t.AuditLog != "errors" && t.AuditLog != "all" {
+		return fmt.Errorf("%s.audit_log must be empty or one of: errors, all", prefix)
+	}
+	if t.AuditMaxBodyBytes < 0 {
+		return fmt.Errorf("%s.audit_max_body_bytes must be non-negative", prefix)
+	}
+	return nil
+}
+
+func (c *Config) validateSymbols() error {
+	if len(c.Symbols) == 0 {
+		return fmt.Errorf("symbols must not be empty")
+	}
+
+	seen := make(map[string]bool, len(c.Symbols))
+	markets := make(map[uint8]bool, len(c.Symbols))
+	for i, sym := range c.Symbols {
+		if sym.Symbol == "" || sym.BinancePair == "" {
+			return fmt.Errorf("symbols[%d]: symbol and binance_pair are required", i)
+		}
+		if seen[sym.Symbol] {
+			return fmt.Errorf("symbols[%d]: duplicate symbol %s", i, sym.Symbol)
+		}
+		if markets[sym.LighterMarketIndex] {
+			return fmt.Errorf("symbols[%d]: duplicate lighter_market_index %d", i, sym.LighterMarketIndex)
+		}
+		if sym.QuantityPrecision < 0 || sym.PricePrecision < 0 {
+			return fmt.Errorf("symbols[%d]: precisions must be non-negative", i)
+		}
+		if sym.LighterSide != "BUY" && sym.LighterSide != "SELL" {
+			return fmt.Errorf("symbols[%d]: lighter_side must be one of: BUY, SELL", i)
+		}
+		if sym.OrderSize < 0 || sym.Leverage < 0 || sym.MaxLeverage < 0 || sym.MaxNotional < 0 {
+			return fmt.Errorf("symbols[%d]: order_size, leverage, max_leverage and max_notional must be non-negative", i)
+		}
+		if sym.SpreadPercent < 0 || sym.BalanceTolerance < 0 {
+			return fmt.Errorf("symbols[%d]: spread_percent and balance_tolerance must be non-negative", i)
+		}
+		if sym.FuturesLeverage < 0 || sym.FuturesLeverage > 125 {
+			return fmt.Errorf("symbols[%d]: futures_leverage must be between 0 and 125", i)
+		}
+		seen[sym.Symbol] = true
+		markets[sym.LighterMarketIndex] = true
+	}
+
+	return nil
+}
+
+// checkSymbolsConfigured 确保策略引用的币种都在 symbols 中配置
+func (c *Config) checkSymbolsConfigured(key string, symbols []string) error {
+	for _, symbol := range symbols {
+		found := false
+		for _, sym := range c.Symbols {
+			if sym.Symbol == symbol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: symbol %s is not configured in symbols", key, symbol)
+		}
+	}
+	return nil
+}
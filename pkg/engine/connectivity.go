@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/breaker"
+)
+
+// connectivityCheckInterval 检查交易所中断时长的间隔
+const connectivityCheckInterval = 10 * time.Second
+
+// venueOutage 交易所中断情况：熔断打开 (含冷却后的试探) 到关闭之间
+type venueOutage struct {
+	since   time.Time
+	alerted bool // 已发布 CONNECTIVITY_LOST
+}
+
+// trackConnectivity 按熔断状态记录交易所中断：打开时开始计时，关闭时结束，已告警的发布 CONNECTIVITY_RESTORED
+func (e *Engine) trackConnectivity(venue string, state breaker.State) {
+	e.outageMu.Lock()
+	outage, ok := e.outages[venue]
+	switch state {
+	case breaker.StateOpen:
+		if !ok {
+			e.outages[venue] = &venueOutage{since: time.Now()}
+		}
+		e.outageMu.Unlock()
+		return
+	case breaker.StateClosed:
+		delete(e.outages, venue)
+	}
+	e.outageMu.Unlock()
+
+	if state == breaker.StateClosed && ok && outage.alerted {
+		duration := time.Since(outage.since)
+		e.logger.Info("Exchange connectivity restored", zap.String("venue", venue), zap.Duration("outage", duration))
+		e.publish(EventConnectivityRestored, map[string]interface{}{
+			"venue":     venue,
+			"outage_ms": duration.Milliseconds(),
+		})
+	}
+}
+
+// watchConnectivity 定期检查交易所中断时长，超过 notify.connectivity_alert_after 时发布一次 CONNECTIVITY_LOST，直到ctx取消
+func (e *Engine) watchConnectivity(ctx context.Context) {
+	ticker := time.NewTicker(connectivityCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for venue, since := range e.newOutages(now) {
+				duration := now.Sub(since)
+				e.logger.Error("Exchange connectivity lost",
+					zap.String("venue", venue),
+					zap.Duration("outage", duration),
+					zap.Duration("alert_after", e.cfg.Notify.ConnectivityAlertAfter),
+				)
+				e.publish(EventConnectivityLost, map[string]interface{}{
+					"venue":     venue,
+					"since":     since.Format(time.RFC3339),
+					"outage_ms": duration.Milliseconds(),
+				})
+			}
+		}
+	}
+}
+
+// newOutages 返回中断时长已超过告警窗口且尚未告警的交易所，并标记为已告警
+func (e *Engine) newOutages(now time.Time) map[string]time.Time {
+	e.outageMu.Lock()
+	defer e.outageMu.Unlock()
+
+	var due map[string]time.Time
+	for venue, outage := range e.outages {
+		if outage.alerted || now.Sub(outage.since) < e.cfg.Notify.ConnectivityAlertAfter {
+			continue
+		}
+		outage.alerted = true
+		if due == nil {
+			due = make(map[string]time.Time)
+		}
+		due[venue] = outage.since
+	}
+	return due
+}
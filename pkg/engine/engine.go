@@ -0,0 +1,844 @@
+// Package engine 提供可嵌入的交易引擎公共API。
+//
+// 除了直接运行 cmd/main.go，调用方也可以在自己的Go服务中嵌入策略引擎：
+//
+//	cfg, _ := config.Load()
+//	eng, err := engine.New(cfg)
+//	if err != nil {
+//		return err
+//	}
+//	go func() {
+//		for ev := range eng.Events() {
+//			fmt.Println(ev.Type, ev.Fields)
+//		}
+//	}()
+//	err = eng.Run(ctx) // 阻塞直到ctx取消或策略结束
+//
+// 策略内部的各类管理器（开仓、平仓、风控、对冲平衡等）位于 internal/strategy，
+// 对外不可见；引擎只暴露配置、运行控制、事件流和状态查询。
+package engine
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/internal/strategy"
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/breaker"
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/eventlog"
+	"cs-projects-backpack/pkg/keystore"
+	"cs-projects-backpack/pkg/killswitch"
+	"cs-projects-backpack/pkg/lighter"
+	"cs-projects-backpack/pkg/logger"
+	"cs-projects-backpack/pkg/metrics"
+	"cs-projects-backpack/pkg/notify"
+	"cs-projects-backpack/pkg/pricefeed"
+	"cs-projects-backpack/pkg/retry"
+)
+
+// eventBufferSize 事件通道缓冲大小，订阅方消费过慢时新事件会被丢弃
+const eventBufferSize = 256
+
+// TradingStats 交易统计信息
+type TradingStats = strategy.TradingStats
+
+// ExecutionStats 对冲执行统计信息
+type ExecutionStats = strategy.ExecutionStats
+
+// PnLSummary 已实现/未实现盈亏汇总
+type PnLSummary = strategy.PnLSummary
+
+// ShadowReport 影子模式与实盘的对比
+type ShadowReport = strategy.ShadowReport
+
+// RebalancePlan 跨交易所保证金再平衡计划
+type RebalancePlan = strategy.RebalancePlan
+
+// HedgeCycle 对冲周期 (串联Maker单、对冲、平衡调整和平仓)
+type HedgeCycle = strategy.HedgeCycle
+
+// EmergencyClosePreview 紧急平仓预览 (将发送的市价单、预计滑点和平仓后余额)
+type EmergencyClosePreview = strategy.EmergencyClosePreview
+
+// HedgeBalanceStatus 两个交易所的对冲平衡状态
+type HedgeBalanceStatus = strategy.HedgeBalanceStatus
+
+// KillSwitchStatus 紧急停止状态
+type KillSwitchStatus = killswitch.Status
+
+// EventType 引擎事件类型
+type EventType string
+
+const (
+	EventStarted       EventType = "STARTED"
+	EventStopped       EventType = "STOPPED"
+	EventPhaseChanged  EventType = EventType(strategy.EventPhaseChanged)
+	EventTradeRecorded EventType = EventType(strategy.EventTradeRecorded)
+
+	EventOrderFilled       EventType = EventType(strategy.EventOrderFilled)
+	EventOrderCancelled    EventType = EventType(strategy.EventOrderCancelled)
+	EventHedgeExecuted     EventType = EventType(strategy.EventHedgeExecuted)
+	EventHedgeFailed       EventType = EventType(strategy.EventHedgeFailed)
+	EventDelayExceeded     EventType = EventType(strategy.EventDelayExceeded)
+	EventRiskActionChanged EventType = EventType(strategy.EventRiskActionChanged)
+	EventLeverageThreshold EventType = EventType(strategy.EventLeverageThreshold)
+	EventHedgeImbalance    EventType = EventType(strategy.EventHedgeImbalance)
+	EventBalanceAdjusted   EventType = EventType(strategy.EventBalanceAdjusted)
+
+	EventLiquidationWarning  EventType = EventType(strategy.EventLiquidationWarning)
+	EventPositionDiscrepancy EventType = EventType(strategy.EventPositionDiscrepancy)
+	EventLoopStalled         EventType = EventType(strategy.EventLoopStalled)
+	EventPanicRecovered      EventType = EventType(strategy.EventPanicRecovered)
+
+	EventReportGenerated EventType = "REPORT_GENERATED"
+	EventDailySummary    EventType = "DAILY_SUMMARY" // 交易日结束时的运行摘要 (notify.daily_summary)
+
+	EventCircuitOpened EventType = "CIRCUIT_OPENED" // 交易所连续失败，暂停下单
+	EventCircuitClosed EventType = "CIRCUIT_CLOSED" // 交易所恢复，继续下单
+	EventKillSwitch    EventType = "KILL_SWITCH"    // 紧急停止：撤销挂单并停止交易
+	EventPaused        EventType = "PAUSED"         // 暂停开新仓
+	EventResumed       EventType = "RESUMED"        // 恢复开新仓
+
+	EventStreamReconnected EventType = "STREAM_RECONNECTED" // 行情推送断线后重新连上
+
+	EventConnectivityLost     EventType = "CONNECTIVITY_LOST"     // 交易所熔断持续打开超过 notify.connectivity_alert_after
+	EventConnectivityRestored EventType = "CONNECTIVITY_RESTORED" // 告警后交易所恢复
+)
+
+// Event 引擎事件
+type Event struct {
+	Type   EventType              `json:"type"`
+	Time   time.Time              `json:"time"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Status 引擎运行状态
+type Status struct {
+	Strategy   string          `json:"strategy"`
+	Running    bool            `json:"running"`
+	Phase      string          `json:"phase"`
+	Paused     bool            `json:"paused"`
+	StartedAt  time.Time       `json:"started_at"`
+	Stats      *TradingStats   `json:"stats,omitempty"`
+	Executions *ExecutionStats `json:"executions,omitempty"`
+	PnL        *PnLSummary     `json:"pnl,omitempty"`
+
+	Circuits   []breaker.Status `json:"circuits,omitempty"`
+	KillSwitch KillSwitchStatus `json:"kill_switch"`
+}
+
+// Engine 交易引擎
+type Engine struct {
+	cfg    *config.Config
+	logger *zap.Logger
+
+	events   chan Event
+	eventLog *eventlog.Log        // 结构化事件流 (nil为不记录)
+	alerts   *notify.AlertManager // 外部通知渠道和告警规则 (nil为未启用)
+
+	digestMu sync.Mutex
+	digest   *dailyDigest // 当前交易日的运行摘要 (nil为未启用)
+
+	outageMu   sync.Mutex
+	outages    map[string]*venueOutage     // 熔断打开中的交易所 (venue -> 中断情况)
+	breakers   map[string]*breaker.Breaker // 交易所熔断器 (venue -> breaker)，未启用时为空
+	killSwitch *killswitch.Switch
+
+	mu           sync.RWMutex
+	running      bool
+	closed       bool
+	startedAt    time.Time
+	cancelRun    context.CancelFunc
+	current      strategy.Strategy // 当前运行的策略
+	dynamicHedge *strategy.DynamicHedgeStrategy
+	binance      *binance.Client // 最近创建的Binance客户端，紧急停止时用于撤单
+}
+
+// New 根据配置创建交易引擎。若全局日志尚未初始化，将使用 cfg.Logging 初始化。
+func New(cfg *config.Config) (*Engine, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	if !logger.Initialized() {
+		if _, err := logger.Initialize(&cfg.Logging); err != nil {
+			return nil, fmt.Errorf("failed to initialize logger: %w", err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	def, ok := lookupStrategy(cfg.Strategy.Type)
+	if !ok {
+		return nil, fmt.Errorf("configuration validation failed: strategy.type must be one of: %s",
+			strings.Join(Strategies(), ", "))
+	}
+	if def.Lighter {
+		if err := cfg.ValidateLighterCredentials(); err != nil {
+			return nil, fmt.Errorf("configuration validation failed: %w", err)
+		}
+	}
+	if def.Binance {
+		if err := cfg.ValidateBinanceCredentials(); err != nil {
+			return nil, fmt.Errorf("configuration validation failed: %w", err)
+		}
+	}
+
+	e := &Engine{
+		cfg:        cfg,
+		logger:     logger.Named("engine"),
+		events:     make(chan Event, eventBufferSize),
+		breakers:   make(map[string]*breaker.Breaker),
+		outages:    make(map[string]*venueOutage),
+		killSwitch: killswitch.New(),
+	}
+
+	if cfg.CircuitBreaker.Enabled {
+		for _, venue := range []string{"binance", "lighter"} {
+			b := breaker.New(venue, cfg.CircuitBreaker.FailureThreshold, cfg.CircuitBreaker.Cooldown)
+			b.OnStateChange(e.onCircuitStateChange)
+			e.breakers[venue] = b
+		}
+	}
+
+	// 启动时解密Lighter私钥，运行期间不再读取密钥文件
+	if def.Lighter && cfg.Lighter.Keystore != "" {
+		if err := e.unlockLighterKeystore(); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// Events 返回引擎事件流。通道在 Run 返回后关闭。
+func (e *Engine) Events() <-chan Event {
+	return e.events
+}
+
+// Status 返回引擎当前状态
+func (e *Engine) Status() *Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	status := &Status{
+		Strategy:   e.cfg.Strategy.Type,
+		Running:    e.running,
+		StartedAt:  e.startedAt,
+		KillSwitch: e.killSwitch.Status(),
+	}
+
+	for _, venue := range []string{"binance", "lighter"} {
+		if b, ok := e.breakers[venue]; ok {
+			status.Circuits = append(status.Circuits, b.Status())
+		}
+	}
+
+	if e.current != nil {
+		status.Phase = e.current.Status().Phase
+	}
+
+	if e.dynamicHedge != nil {
+		status.Paused = e.dynamicHedge.IsPaused()
+		status.Stats = e.dynamicHedge.GetStats()
+		status.Executions = e.dynamicHedge.GetExecutionStats()
+		status.PnL = e.dynamicHedge.GetPnL()
+	}
+
+	return status
+}
+
+// Positions 返回当前仓位摘要，策略未运行动态对冲时返回nil
+func (e *Engine) Positions() map[string]interface{} {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.dynamicHedge == nil {
+		return nil
+	}
+	return e.dynamicHedge.GetPositionSummary()
+}
+
+// ShadowReport 返回影子模式与实盘的对比，未启用影子模式时返回nil
+func (e *Engine) ShadowReport() *ShadowReport {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.dynamicHedge == nil {
+		return nil
+	}
+	return e.dynamicHedge.GetShadowReport()
+}
+
+// RebalancePlan 返回最近一次的跨交易所保证金再平衡计划，未启用时返回nil
+func (e *Engine) RebalancePlan() *RebalancePlan {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.dynamicHedge == nil {
+		return nil
+	}
+	return e.dynamicHedge.GetRebalancePlan()
+}
+
+// ResumeRebalance 恢复因提现失败暂停的保证金自动提现，返回之前的失败原因 (未暂停时为空)。
+// 未运行动态对冲时返回 ErrNoDynamicHedge，未启用再平衡时返回 ErrRebalanceDisabled。source 说明请求来源，用于日志
+func (e *Engine) ResumeRebalance(source string) (string, error) {
+	e.mu.RLock()
+	s := e.dynamicHedge
+	e.mu.RUnlock()
+	if s == nil {
+		return "", ErrNoDynamicHedge
+	}
+
+	e.logger.Warn("Collateral auto withdraw resume requested", zap.String("source", source))
+	previous, ok := s.ResumeRebalance()
+	if !ok {
+		return "", ErrRebalanceDisabled
+	}
+	return previous, nil
+}
+
+// HedgeCycles 返回进行中和最近结束的对冲周期，未运行动态对冲时返回nil
+func (e *Engine) HedgeCycles() []HedgeCycle {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.dynamicHedge == nil {
+		return nil
+	}
+	return e.dynamicHedge.GetHedgeCycles()
+}
+
+// EmergencyClosePreview 计算紧急平仓将发送的市价单及预计滑点和平仓后余额，不下单。
+// 未运行动态对冲时返回 ErrNoDynamicHedge
+func (e *Engine) EmergencyClosePreview(ctx context.Context) (*EmergencyClosePreview, error) {
+	e.mu.RLock()
+	s := e.dynamicHedge
+	e.mu.RUnlock()
+	if s == nil {
+		return nil, ErrNoDynamicHedge
+	}
+	return s.PreviewEmergencyClosing(ctx)
+}
+
+// HedgeBalance 检查两个交易所各币种的仓位是否平衡，不下单。未运行动态对冲时返回 ErrNoDynamicHedge
+func (e *Engine) HedgeBalance() (*HedgeBalanceStatus, error) {
+	e.mu.RLock()
+	s := e.dynamicHedge
+	e.mu.RUnlock()
+	if s == nil {
+		return nil, ErrNoDynamicHedge
+	}
+	return s.GetHedgeBalanceStatus()
+}
+
+// AdjustHedgeBalance 立即执行一次仓位平衡调整，返回调整后的平衡状态。source 说明请求来源，用于日志
+func (e *Engine) AdjustHedgeBalance(ctx context.Context, source string) (*HedgeBalanceStatus, error) {
+	e.mu.RLock()
+	s := e.dynamicHedge
+	e.mu.RUnlock()
+	if s == nil {
+		return nil, ErrNoDynamicHedge
+	}
+
+	e.logger.Warn("Hedge balance adjustment requested", zap.String("source", source))
+	if err := s.ForceBalanceAdjustment(ctx, nil); err != nil {
+		return nil, err
+	}
+	return s.GetHedgeBalanceStatus()
+}
+
+// Run 运行配置的策略，阻塞直到ctx取消或策略执行结束。每个引擎实例只能运行一次。
+func (e *Engine) Run(ctx context.Context) error {
+	e.mu.Lock()
+	if e.running || e.closed {
+		e.mu.Unlock()
+		return fmt.Errorf("engine is already running or has finished")
+	}
+	e.running = true
+	e.startedAt = time.Now()
+	e.mu.Unlock()
+
+	defer func() {
+		e.publish(EventStopped, nil)
+		e.mu.Lock()
+		e.running = false
+		e.closed = true
+		close(e.events)
+		if e.eventLog != nil {
+			if err := e.eventLog.Close(); err != nil {
+				e.logger.Warn("Failed to close event log", zap.Error(err))
+			}
+			e.eventLog = nil
+		}
+		alerts := e.alerts
+		e.alerts = nil
+		e.mu.Unlock()
+
+		// 等待紧急告警等待发送的消息发完，不持有锁
+		if alerts != nil {
+			alerts.Close()
+		}
+	}()
+
+	if e.cfg.EventLog.Enabled {
+		eventLog, err := eventlog.Open(e.cfg.EventLog.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open event log: %w", err)
+		}
+		e.mu.Lock()
+		e.eventLog = eventLog
+		e.mu.Unlock()
+	}
+
+	if alerts := e.newAlertManager(); alerts != nil {
+		e.mu.Lock()
+		e.alerts = alerts
+		e.mu.Unlock()
+	}
+
+	e.publish(EventStarted, map[string]interface{}{"strategy": e.cfg.Strategy.Type})
+
+	// 启动前已满足紧急停止条件时不再开始交易
+	if e.killSwitch.Engaged() {
+		return ErrKilled
+	}
+	if reason := e.killSwitchReason(); reason != "" {
+		e.Kill(reason)
+		return ErrKilled
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	e.mu.Lock()
+	e.cancelRun = cancel
+	e.mu.Unlock()
+
+	go e.watchKillSwitch(runCtx)
+	if e.cfg.Notify.ConnectivityAlertAfter > 0 && len(e.breakers) > 0 {
+		go e.watchConnectivity(runCtx)
+	}
+	if e.cfg.Notify.DailySummary && e.notifying() {
+		go e.runDailySummary(runCtx)
+	}
+
+	err := e.runStrategy(runCtx)
+	if e.killSwitch.Engaged() {
+		return ErrKilled
+	}
+	return err
+}
+
+// runStrategy 按配置的策略类型从注册表查找并运行，运行前创建策略声明需要的交易所客户端
+func (e *Engine) runStrategy(ctx context.Context) error {
+	def, ok := lookupStrategy(e.cfg.Strategy.Type)
+	if !ok {
+		return fmt.Errorf("unknown strategy type: %s", e.cfg.Strategy.Type)
+	}
+
+	clients, err := e.newClients(ctx, def)
+	if err != nil {
+		return err
+	}
+	return def.Run(ctx, e, clients)
+}
+
+// publish 非阻塞地发布事件
+func (e *Engine) publish(eventType EventType, fields map[string]interface{}) {
+	event := Event{
+		Type:   eventType,
+		Time:   time.Now(),
+		Fields: fields,
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return
+	}
+
+	// 事件流同步写入，不受事件通道容量影响
+	e.recordEvent(event)
+	e.notifyEvent(event)
+	e.recordDigest(event)
+
+	select {
+	case e.events <- event:
+	default:
+		e.logger.Debug("Event channel full, dropping event", zap.String("type", string(eventType)))
+	}
+}
+
+// recordEvent 写入结构化事件流 (调用方持有读锁)。策略事件的发布方组件在 source 字段中，其余事件来自引擎
+func (e *Engine) recordEvent(event Event) {
+	if e.eventLog == nil {
+		return
+	}
+
+	source := "engine"
+	fields := event.Fields
+	if s, ok := fields["source"].(string); ok {
+		source = s
+		fields = make(map[string]interface{}, len(event.Fields)-1)
+		for k, v := range event.Fields {
+			if k != "source" {
+				fields[k] = v
+			}
+		}
+	}
+
+	if err := e.eventLog.Write(eventCategory(event.Type), string(event.Type), source, event.Time, fields); err != nil {
+		e.logger.Warn("Failed to write event log", zap.String("type", string(event.Type)), zap.Error(err))
+	}
+}
+
+// eventCategory 事件在事件流中的分类
+func eventCategory(t EventType) string {
+	switch t {
+	case EventOrderFilled, EventOrderCancelled, EventTradeRecorded:
+		return eventlog.CategoryOrder
+	case EventHedgeExecuted, EventHedgeFailed, EventDelayExceeded, EventHedgeImbalance, EventBalanceAdjusted:
+		return eventlog.CategoryHedge
+	case EventRiskActionChanged, EventLeverageThreshold, EventLiquidationWarning, EventPositionDiscrepancy, EventLoopStalled, EventPanicRecovered,
+		EventCircuitOpened, EventCircuitClosed, EventKillSwitch, EventConnectivityLost, EventConnectivityRestored:
+		return eventlog.CategoryRisk
+	default:
+		return eventlog.CategoryEngine
+	}
+}
+
+// configuredFees 配置的手续费率
+func (e *Engine) configuredFees() strategy.FeeSchedule {
+	return strategy.FeeSchedule{
+		BinanceMakerPercent: e.cfg.Fees.BinanceMakerPercent,
+		BinanceTakerPercent: e.cfg.Fees.BinanceTakerPercent,
+		LighterMakerPercent: e.cfg.Fees.LighterMakerPercent,
+		LighterTakerPercent: e.cfg.Fees.LighterTakerPercent,
+	}
+}
+
+// applySpreadFloor 启用 fees.fee_adjusted_spread 时，按往返手续费加最小利润设置Binance挂单价差下限
+func (e *Engine) applySpreadFloor(client *binance.Client, fees strategy.FeeSchedule) {
+	if !e.cfg.Fees.FeeAdjustedSpread {
+		return
+	}
+	floor := fees.BreakEvenSpreadPercent() + e.cfg.Fees.MinEdgePercent
+	client.SetMinSpreadPercent(floor)
+	e.logger.Info("Fee-adjusted maker spread enabled",
+		zap.Float64("round_trip_fee_percent", fees.BreakEvenSpreadPercent()),
+		zap.Float64("min_edge_percent", e.cfg.Fees.MinEdgePercent),
+		zap.Float64("min_spread_percent", floor),
+	)
+}
+
+// feeSchedule 按配置生成手续费率。启用 fees.fetch_binance 时以第一个币种的交易对查询账户实际费率，
+// 查询失败时使用配置值
+func (e *Engine) feeSchedule(ctx context.Context, binanceClient *binance.Client) strategy.FeeSchedule {
+	fees := e.configuredFees()
+
+	if e.cfg.Fees.FetchBinance && binanceClient != nil && len(e.cfg.Symbols) > 0 {
+		pair := e.symbolUniverse().BinancePair(e.cfg.Symbols[0].Symbol)
+		rates, err := binanceClient.GetCommissionRates(ctx, pair)
+		if err != nil {
+			e.logger.Warn("Failed to fetch Binance commission rates, using configured fees", zap.String("pair", pair), zap.Error(err))
+		} else {
+			fees.BinanceMakerPercent = rates.MakerPercent
+			fees.BinanceTakerPercent = rates.TakerPercent
+			// 按实际费率重新计算挂单价差下限
+			e.applySpreadFloor(binanceClient, fees)
+		}
+	}
+
+	e.logger.Info("Fee schedule",
+		zap.Float64("binance_maker_percent", fees.BinanceMakerPercent),
+		zap.Float64("binance_taker_percent", fees.BinanceTakerPercent),
+		zap.Float64("lighter_maker_percent", fees.LighterMakerPercent),
+		zap.Float64("lighter_taker_percent", fees.LighterTakerPercent),
+		zap.Float64("break_even_spread_percent", fees.BreakEvenSpreadPercent()),
+	)
+	return fees
+}
+
+// feeTiers 将手续费等级配置映射为策略使用的等级表
+func (e *Engine) feeTiers() map[string][]strategy.FeeTier {
+	tiers := make(map[string][]strategy.FeeTier)
+	for exchange, configured := range map[string][]config.FeeTierConfig{
+		"binance": e.cfg.Fees.BinanceTiers,
+		"lighter": e.cfg.Fees.LighterTiers,
+	} {
+		for _, t := range configured {
+			tiers[exchange] = append(tiers[exchange], strategy.FeeTier{
+				Name:         t.Name,
+				MinVolume:    t.MinVolume,
+				MakerPercent: t.MakerPercent,
+				TakerPercent: t.TakerPercent,
+			})
+		}
+	}
+	return tiers
+}
+
+// symbolUniverse 将币种配置映射为策略使用的币种集合
+func (e *Engine) symbolUniverse() *strategy.SymbolUniverse {
+	specs := make([]strategy.SymbolSpec, 0, len(e.cfg.Symbols))
+	for _, sym := range e.cfg.Symbols {
+		spec := strategy.SymbolSpec{
+			Symbol:             sym.Symbol,
+			BinancePair:        sym.BinancePair,
+			LighterMarketIndex: sym.LighterMarketIndex,
+			LighterSide:        sym.LighterSide,
+			OrderSize:          sym.OrderSize,
+			Leverage:           sym.Leverage,
+			MaxLeverage:        sym.MaxLeverage,
+			MaxNotional:        sym.MaxNotional,
+			SpreadPercent:      sym.SpreadPercent,
+			BalanceTolerance:   sym.BalanceTolerance,
+		}
+		if spec.OrderSize == 0 {
+			spec.OrderSize = float64(e.cfg.Trading.USDCAmount)
+		}
+		if spec.Leverage == 0 {
+			spec.Leverage = e.cfg.Trading.Leverage
+		}
+		if spec.MaxNotional == 0 {
+			spec.MaxNotional = e.cfg.Strategy.MaxSymbolNotional
+		}
+		specs = append(specs, spec)
+	}
+	return strategy.NewSymbolUniverse(specs)
+}
+
+// newPriceFeed 创建多源聚合价格：Binance、Lighter按 symbols 配置映射交易对，Coinbase按币种符号查询
+func (e *Engine) newPriceFeed(binanceClient *binance.Client, lighterClient *lighter.Client) *pricefeed.Feed {
+	symbols := make(map[string]config.SymbolConfig, len(e.cfg.Symbols))
+	for _, sym := range e.cfg.Symbols {
+		symbols[sym.Symbol] = sym
+	}
+
+	sources := []pricefeed.Source{
+		pricefeed.NewSource("binance", func(ctx context.Context, symbol string) (float64, error) {
+			sym, ok := symbols[symbol]
+			if !ok {
+				return 0, fmt.Errorf("symbol %s not configured", symbol)
+			}
+			return binanceClient.GetCurrentPrice(ctx, sym.BinancePair)
+		}),
+		pricefeed.NewSource("lighter", func(ctx context.Context, symbol string) (float64, error) {
+			sym, ok := symbols[symbol]
+			if !ok {
+				return 0, fmt.Errorf("symbol %s not configured", symbol)
+			}
+			return lighterClient.GetLastPrice(ctx, sym.LighterMarketIndex)
+		}),
+	}
+	if e.cfg.PriceFeed.Coinbase {
+		sources = append(sources, pricefeed.NewCoinbaseSource(e.cfg.PriceFeed.CoinbaseURL, "USD"))
+	}
+
+	e.logger.Info("Aggregated price feed enabled",
+		zap.Int("sources", len(sources)),
+		zap.Int("min_sources", e.cfg.PriceFeed.MinSources),
+		zap.Duration("max_age", e.cfg.PriceFeed.MaxAge),
+	)
+
+	return pricefeed.New(e.cfg.PriceFeed.MaxAge, e.cfg.PriceFeed.MinSources, sources...)
+}
+
+// newBinanceClient 创建Binance客户端并加载交易对下单规则
+func (e *Engine) newBinanceClient(ctx context.Context) (*binance.Client, error) {
+	client, err := binance.NewClient(&e.cfg.Binance, e.cfg.Symbols)
+	if err != nil {
+		return nil, err
+	}
+	client.SetRetryPolicy(e.retryPolicy())
+	client.SetRequestTimeouts(e.cfg.RequestTimeout.Order, e.cfg.RequestTimeout.Query)
+	if b, ok := e.breakers["binance"]; ok {
+		client.SetCircuitBreaker(b)
+	}
+	client.SetKillSwitch(e.killSwitch)
+	client.SetStreamReconnectHandler(func(gap time.Duration) {
+		e.onStreamReconnect("binance", gap)
+	})
+	if err := metrics.Register(client.Metrics()); err != nil {
+		e.logger.Warn("Failed to register Binance weight metrics", zap.Error(err))
+	}
+	e.applySpreadFloor(client, e.configuredFees())
+
+	e.mu.Lock()
+	e.binance = client
+	e.mu.Unlock()
+
+	if e.cfg.Preflight.Enabled {
+		if err := client.Preflight(ctx); err != nil {
+			return nil, fmt.Errorf("binance preflight failed: %w", err)
+		}
+	}
+
+	// 合约市场需要确认持仓模式后才能正确下单
+	if err := client.InitFutures(ctx); err != nil {
+		return nil, fmt.Errorf("failed to init Binance futures: %w", err)
+	}
+
+	// 加载失败时继续使用配置中的精度
+	if err := client.LoadExchangeFilters(ctx); err != nil {
+		e.logger.Warn("Failed to load Binance exchange filters, falling back to configured precisions", zap.Error(err))
+	}
+
+	// 最优挂单价推送，取价不再逐单请求REST接口
+	if e.cfg.Binance.BookTicker {
+		client.StartBookTicker(ctx, e.cfg.Binance.BookTickerMaxAge)
+	}
+
+	return client, nil
+}
+
+// newClients 按策略声明创建交易所客户端，先创建Lighter客户端
+func (e *Engine) newClients(ctx context.Context, def StrategyDefinition) (*Clients, error) {
+	clients := &Clients{}
+	if def.Lighter {
+		client, err := e.newLighterClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Lighter client: %w", err)
+		}
+		clients.Lighter = client
+	}
+	if def.Binance {
+		client, err := e.newBinanceClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Binance client: %w", err)
+		}
+		clients.Binance = client
+	}
+	return clients, nil
+}
+
+// newLighterClient 创建Lighter客户端，启用 lighter.verify_api_key 时核对API公钥
+func (e *Engine) newLighterClient(ctx context.Context) (*lighter.Client, error) {
+	client, err := lighter.NewClient(&e.cfg.Lighter)
+	if err != nil {
+		return nil, err
+	}
+	client.SetRetryPolicy(e.retryPolicy())
+	client.SetRequestTimeouts(e.cfg.RequestTimeout.Order, e.cfg.RequestTimeout.Query)
+	if b, ok := e.breakers["lighter"]; ok {
+		client.SetCircuitBreaker(b)
+	}
+	client.SetKillSwitch(e.killSwitch)
+
+	if e.cfg.Preflight.Enabled && len(e.cfg.Symbols) > 0 {
+		if err := client.Preflight(ctx, e.cfg.Symbols[0].LighterMarketIndex); err != nil {
+			return nil, fmt.Errorf("lighter preflight failed: %w", err)
+		}
+	}
+	if e.cfg.Lighter.VerifyAPIKey {
+		if err := client.VerifyAPIKey(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// unlockLighterKeystore 解密 lighter.keystore，将私钥写入 cfg.Lighter.PrivateKey
+func (e *Engine) unlockLighterKeystore() error {
+	passphrase, err := keystore.Passphrase(e.cfg.Lighter.KeystorePassphraseCommand)
+	if err != nil {
+		return fmt.Errorf("failed to get keystore passphrase: %w", err)
+	}
+
+	key, err := keystore.Load(e.cfg.Lighter.Keystore, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to unlock lighter keystore %s: %w", e.cfg.Lighter.Keystore, err)
+	}
+
+	e.cfg.Lighter.PrivateKey = hex.EncodeToString(key)
+	e.logger.Info("Lighter keystore unlocked", zap.String("keystore", e.cfg.Lighter.Keystore))
+	return nil
+}
+
+// onStreamReconnect 行情推送重连后发布事件，并让动态对冲立即核对断线期间的订单
+func (e *Engine) onStreamReconnect(venue string, gap time.Duration) {
+	e.publish(EventStreamReconnected, map[string]interface{}{
+		"venue":  venue,
+		"gap_ms": gap.Milliseconds(),
+	})
+
+	e.mu.RLock()
+	hedge := e.dynamicHedge
+	e.mu.RUnlock()
+	if hedge != nil {
+		hedge.RecoverStreamGap(gap)
+	}
+}
+
+// onCircuitStateChange 熔断状态变化时记录日志并发布告警事件
+func (e *Engine) onCircuitStateChange(venue string, from, to breaker.State, lastErr error) {
+	fields := map[string]interface{}{
+		"venue": venue,
+		"from":  string(from),
+		"to":    string(to),
+	}
+
+	switch to {
+	case breaker.StateOpen:
+		e.logger.Error("Circuit breaker opened, pausing new orders on venue",
+			zap.String("venue", venue),
+			zap.Int("failure_threshold", e.cfg.CircuitBreaker.FailureThreshold),
+			zap.Duration("cooldown", e.cfg.CircuitBreaker.Cooldown),
+			zap.Error(lastErr),
+		)
+		fields["cooldown"] = e.cfg.CircuitBreaker.Cooldown.String()
+		if lastErr != nil {
+			fields["error"] = lastErr.Error()
+		}
+		e.publish(EventCircuitOpened, fields)
+	case breaker.StateHalfOpen:
+		e.logger.Info("Circuit breaker cooldown elapsed, probing venue", zap.String("venue", venue))
+	case breaker.StateClosed:
+		e.logger.Info("Circuit breaker closed, venue recovered", zap.String("venue", venue))
+		e.publish(EventCircuitClosed, fields)
+	}
+	e.trackConnectivity(venue, to)
+}
+
+// retryPolicy 将重试配置映射为交易所接口重试策略
+func (e *Engine) retryPolicy() retry.Policy {
+	return retry.Policy{
+		MaxAttempts:    e.cfg.Retry.MaxAttempts,
+		InitialBackoff: e.cfg.Retry.InitialBackoff,
+		MaxBackoff:     e.cfg.Retry.MaxBackoff,
+		Multiplier:     e.cfg.Retry.Multiplier,
+		Jitter:         e.cfg.Retry.Jitter,
+	}
+}
+
+// runLifecycle 启动策略并等待结束，ctx取消时停止策略
+func (e *Engine) runLifecycle(ctx context.Context, name string, s strategy.Strategy) error {
+	if err := s.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start %s strategy: %w", name, err)
+	}
+
+	e.mu.Lock()
+	e.current = s
+	e.mu.Unlock()
+	e.logger.Info("Press Ctrl+C to stop the strategy...")
+
+	select {
+	case <-ctx.Done():
+		s.Stop()
+		e.logger.Info(name + " strategy stopped due to shutdown signal")
+		return ctx.Err()
+	case <-s.Done():
+		return s.Status().Err
+	}
+}
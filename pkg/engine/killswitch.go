@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/config"
+)
+
+// ErrKilled 引擎因紧急停止而结束
+var ErrKilled = errors.New("engine halted by kill switch")
+
+// killCancelTimeout 紧急停止时撤单的超时时间
+const killCancelTimeout = 15 * time.Second
+
+// Kill 触发紧急停止：立即拒绝两个交易所的新开仓单 (对冲和只减仓单除外)，撤销动态对冲的Maker单并对冲撤单前的成交，
+// 再停止策略并撤销所有已配置交易对的Binance挂单。返回撤单数量，重复调用不会再次撤单。
+func (e *Engine) Kill(reason string) (int, error) {
+	if !e.killSwitch.Engage(reason) {
+		return 0, nil
+	}
+
+	e.logger.Error("Kill switch engaged, halting trading", zap.String("reason", reason))
+
+	e.mu.RLock()
+	cancel := e.cancelRun
+	s := e.dynamicHedge
+	e.mu.RUnlock()
+
+	ctx, cancelTimeout := context.WithTimeout(context.Background(), killCancelTimeout)
+	defer cancelTimeout()
+
+	// 先停止策略会使撤单前刚成交的部分无人对冲，先由订单监控撤单并完成对冲
+	var errs []error
+	cancelled := 0
+	if s != nil {
+		n, err := s.CancelWorkingOrders(ctx, "KILL_SWITCH")
+		if err != nil {
+			errs = append(errs, err)
+		}
+		cancelled += n
+	}
+
+	if cancel != nil {
+		cancel()
+	}
+
+	n, err := e.cancelAllOpenOrders(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	cancelled += n
+	err = errors.Join(errs...)
+	if err != nil {
+		e.logger.Error("Failed to cancel all open orders on kill switch", zap.Int("cancelled", cancelled), zap.Error(err))
+	} else {
+		e.logger.Warn("All open orders cancelled on kill switch", zap.Int("cancelled", cancelled))
+	}
+
+	fields := map[string]interface{}{
+		"reason":    reason,
+		"cancelled": cancelled,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	e.publish(EventKillSwitch, fields)
+
+	return cancelled, err
+}
+
+// cancelAllOpenOrders 撤销所有已配置交易对的Binance挂单。
+// Lighter只下IOC市价单，没有需要撤销的挂单。
+func (e *Engine) cancelAllOpenOrders(ctx context.Context) (int, error) {
+	e.mu.RLock()
+	client := e.binance
+	e.mu.RUnlock()
+
+	if client == nil {
+		var err error
+		client, err = binance.NewClient(&e.cfg.Binance, e.cfg.Symbols)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create Binance client: %w", err)
+		}
+		client.SetRetryPolicy(e.retryPolicy())
+		client.SetRequestTimeouts(e.cfg.RequestTimeout.Order, e.cfg.RequestTimeout.Query)
+	}
+
+	var errs []error
+	cancelled := 0
+	for _, sym := range e.cfg.Symbols {
+		n, err := client.CancelAllOpenOrders(ctx, sym.BinancePair)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		cancelled += n
+	}
+
+	return cancelled, errors.Join(errs...)
+}
+
+// watchKillSwitch 定期检查哨兵文件和配置开关，直到ctx取消
+func (e *Engine) watchKillSwitch(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.KillSwitch.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if reason := e.killSwitchReason(); reason != "" {
+				e.Kill(reason)
+				return
+			}
+		}
+	}
+}
+
+// killSwitchReason 检查紧急停止条件，未触发时返回空字符串
+func (e *Engine) killSwitchReason() string {
+	if path := e.cfg.KillSwitch.SentinelFile; path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Sprintf("sentinel file %s exists", path)
+		}
+	}
+
+	if e.cfg.KillSwitch.Engaged {
+		return "config flag kill_switch.engaged is set"
+	}
+
+	// 运行中修改配置文件的开关同样生效
+	if e.cfg.ConfigFile != "" {
+		engaged, err := config.ReadKillSwitchEngaged(e.cfg.ConfigFile)
+		if err != nil {
+			e.logger.Debug("Failed to re-read kill switch flag", zap.Error(err))
+		} else if engaged {
+			return "config flag kill_switch.engaged was set in " + e.cfg.ConfigFile
+		}
+	}
+
+	return ""
+}
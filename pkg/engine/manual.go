@@ -0,0 +1,298 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/config"
+)
+
+// ManualOrder 手动下单参数
+type ManualOrder struct {
+	Venue  string  // binance, lighter
+	Symbol string  // 内部币种符号，如 BTC
+	Side   string  // BUY, SELL
+	Amount float64 // 下单金额 (Binance为交易对计价币，Lighter为USDT)
+	Price  float64 // Binance限价，0为市价单；Lighter只支持市价单
+}
+
+// ManualOrderResult 手动下单或平仓的结果
+type ManualOrderResult struct {
+	Venue       string  `json:"venue"`
+	Symbol      string  `json:"symbol"`
+	Side        string  `json:"side"`
+	OrderID     string  `json:"order_id"` // Binance订单ID或Lighter交易哈希
+	Status      string  `json:"status,omitempty"`
+	Price       float64 `json:"price,omitempty"`
+	Quantity    float64 `json:"quantity,omitempty"`     // 下单数量 (币)，Lighter按金额下单时为0
+	ExecutedQty float64 `json:"executed_qty,omitempty"` // Binance已成交数量
+}
+
+// PlaceManualOrder 使用配置的交易所客户端直接下单，供运维人员人工干预，不经过策略和风控。
+// Binance指定价格时下限价单，否则按最新价格把金额换算为数量下市价单；Lighter按金额和币种杠杆下市价单
+func (e *Engine) PlaceManualOrder(ctx context.Context, order ManualOrder) (*ManualOrderResult, error) {
+	sym, err := e.symbolConfig(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if order.Side != "BUY" && order.Side != "SELL" {
+		return nil, fmt.Errorf("invalid side %q: must be BUY or SELL", order.Side)
+	}
+	if order.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	e.logger.Warn("Placing manual order",
+		zap.String("venue", order.Venue),
+		zap.String("symbol", order.Symbol),
+		zap.String("side", order.Side),
+		zap.Float64("amount", order.Amount),
+		zap.Float64("price", order.Price),
+	)
+
+	result := &ManualOrderResult{Venue: order.Venue, Symbol: order.Symbol, Side: order.Side}
+	switch order.Venue {
+	case "binance":
+		client, err := e.manualBinanceClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var status *binance.OrderStatus
+		if order.Price > 0 {
+			status, err = client.PlaceLimitOrderAt(ctx, sym.BinancePair, order.Side, order.Amount, order.Price)
+			result.Quantity = order.Amount / order.Price
+		} else {
+			var price float64
+			price, err = client.GetCurrentPrice(ctx, sym.BinancePair)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get price for %s: %w", sym.BinancePair, err)
+			}
+			result.Quantity = order.Amount / price
+			status, err = client.PlaceMarketOrder(ctx, sym.BinancePair, order.Side, result.Quantity, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.OrderID = strconv.FormatInt(status.OrderID, 10)
+		result.Status = status.Status
+		result.Price = status.Price
+		result.ExecutedQty = status.ExecutedQty
+
+	case "lighter":
+		if order.Price > 0 {
+			return nil, fmt.Errorf("lighter only supports market orders")
+		}
+		// Lighter按整数USDT金额下单，不截断小数，避免 0.5 变为 0
+		if order.Amount != math.Trunc(order.Amount) {
+			return nil, fmt.Errorf("lighter amount must be a whole number of USDT, got %v", order.Amount)
+		}
+		client, err := e.newLighterClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Lighter client: %w", err)
+		}
+
+		leverage := sym.Leverage
+		if leverage == 0 {
+			leverage = e.cfg.Trading.Leverage
+		}
+		place := client.PlaceLong
+		if order.Side == "SELL" {
+			place = client.PlaceShort
+		}
+		tx, err := place(ctx, sym.LighterMarketIndex, int64(order.Amount), leverage)
+		if err != nil {
+			return nil, err
+		}
+		result.OrderID = tx.GetTxHash()
+
+	default:
+		return nil, fmt.Errorf("invalid venue %q: must be binance or lighter", order.Venue)
+	}
+
+	e.logger.Warn("Manual order placed",
+		zap.String("venue", result.Venue),
+		zap.String("symbol", result.Symbol),
+		zap.String("order_id", result.OrderID),
+		zap.String("status", result.Status),
+	)
+	return result, nil
+}
+
+// CancelManualOrder 撤销币种的一笔Binance挂单 (Lighter只有即时成交的市价单，没有挂单)
+func (e *Engine) CancelManualOrder(ctx context.Context, symbol string, orderID int64) error {
+	sym, err := e.symbolConfig(symbol)
+	if err != nil {
+		return err
+	}
+	client, err := e.manualBinanceClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Warn("Cancelling manual order", zap.String("symbol", symbol), zap.Int64("order_id", orderID))
+	return client.CancelOrder(ctx, sym.BinancePair, orderID)
+}
+
+// CancelManualOrders 撤销币种在Binance的全部挂单，返回撤单数量
+func (e *Engine) CancelManualOrders(ctx context.Context, symbol string) (int, error) {
+	sym, err := e.symbolConfig(symbol)
+	if err != nil {
+		return 0, err
+	}
+	client, err := e.manualBinanceClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	e.logger.Warn("Cancelling all open orders manually", zap.String("symbol", symbol))
+	return client.CancelAllOpenOrders(ctx, sym.BinancePair)
+}
+
+// CloseManualPosition 以市价单平掉币种在指定交易所 (binance, lighter 或 all) 的实际持仓，没有持仓时不下单。
+// Binance现货市场没有持仓，需要用 PlaceManualOrder 卖出
+func (e *Engine) CloseManualPosition(ctx context.Context, venue, symbol string) ([]ManualOrderResult, error) {
+	sym, err := e.symbolConfig(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if venue != "binance" && venue != "lighter" && venue != "all" {
+		return nil, fmt.Errorf("invalid venue %q: must be binance, lighter or all", venue)
+	}
+
+	e.logger.Warn("Closing position manually", zap.String("venue", venue), zap.String("symbol", symbol))
+
+	var results []ManualOrderResult
+	if venue == "binance" || venue == "all" {
+		result, err := e.closeBinancePosition(ctx, sym)
+		if err != nil {
+			return results, fmt.Errorf("failed to close Binance position: %w", err)
+		}
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+	if venue == "lighter" || venue == "all" {
+		client, err := e.newLighterClient(ctx)
+		if err != nil {
+			return results, fmt.Errorf("failed to create Lighter client: %w", err)
+		}
+		closed, err := client.ClosePositions(ctx, []uint8{sym.LighterMarketIndex})
+		for _, c := range closed {
+			side := "SELL"
+			if c.Position.Size < 0 {
+				side = "BUY"
+			}
+			results = append(results, ManualOrderResult{
+				Venue:    "lighter",
+				Symbol:   symbol,
+				Side:     side,
+				OrderID:  c.Tx.GetTxHash(),
+				Quantity: abs(c.Position.Size),
+			})
+		}
+		if err != nil {
+			return results, fmt.Errorf("failed to close Lighter position: %w", err)
+		}
+	}
+	return results, nil
+}
+
+// closeBinancePosition 以只减仓市价单平掉Binance持仓 (合约为只减仓单)，没有持仓时返回nil
+func (e *Engine) closeBinancePosition(ctx context.Context, sym config.SymbolConfig) (*ManualOrderResult, error) {
+	client, err := e.manualBinanceClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !client.HasPositions() {
+		return nil, fmt.Errorf("positions are not available on %s market, sell the balance with order place", client.Market())
+	}
+
+	positions, err := client.GetPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pos, ok := positions[sym.Symbol]
+	if !ok || pos.Size == 0 {
+		e.logger.Info("No Binance position to close", zap.String("symbol", sym.Symbol))
+		return nil, nil
+	}
+
+	side := "SELL"
+	if pos.Size < 0 {
+		side = "BUY"
+	}
+	status, err := client.PlaceMarketOrder(ctx, sym.BinancePair, side, abs(pos.Size), client.Market() == binance.MarketFutures)
+	if err != nil {
+		return nil, err
+	}
+	return &ManualOrderResult{
+		Venue:       "binance",
+		Symbol:      sym.Symbol,
+		Side:        side,
+		OrderID:     strconv.FormatInt(status.OrderID, 10),
+		Status:      status.Status,
+		Price:       status.Price,
+		Quantity:    abs(pos.Size),
+		ExecutedQty: status.ExecutedQty,
+	}, nil
+}
+
+// symbolConfig 查找币种配置
+func (e *Engine) symbolConfig(symbol string) (config.SymbolConfig, error) {
+	for _, sym := range e.cfg.Symbols {
+		if sym.Symbol == symbol {
+			return sym, nil
+		}
+	}
+	return config.SymbolConfig{}, fmt.Errorf("symbol %s not configured", symbol)
+}
+
+// manualBinanceClient 返回运行中的Binance客户端，尚未创建时为单次命令创建只用于REST下单的客户端：
+// 不启动最优挂单价推送、不注册指标，也不修改合约保证金模式和杠杆 (只查询持仓模式)
+func (e *Engine) manualBinanceClient(ctx context.Context) (*binance.Client, error) {
+	e.mu.RLock()
+	client := e.binance
+	e.mu.RUnlock()
+	if client != nil {
+		return client, nil
+	}
+
+	client, err := binance.NewClient(&e.cfg.Binance, e.cfg.Symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Binance client: %w", err)
+	}
+	client.SetRetryPolicy(e.retryPolicy())
+	client.SetRequestTimeouts(e.cfg.RequestTimeout.Order, e.cfg.RequestTimeout.Query)
+	client.SetKillSwitch(e.killSwitch)
+
+	if err := client.LoadPositionMode(ctx); err != nil {
+		return nil, err
+	}
+	// 加载失败时继续使用配置中的精度
+	if err := client.LoadExchangeFilters(ctx); err != nil {
+		e.logger.Warn("Failed to load Binance exchange filters, falling back to configured precisions", zap.Error(err))
+	}
+
+	e.mu.Lock()
+	if e.binance == nil {
+		e.binance = client
+	} else {
+		client = e.binance
+	}
+	e.mu.Unlock()
+	return client, nil
+}
+
+// abs 绝对值
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
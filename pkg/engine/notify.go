@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/internal/strategy"
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/notify"
+)
+
+// newAlertManager 按配置创建通知渠道和告警规则，没有启用任何渠道时返回nil
+func (e *Engine) newAlertManager() *notify.AlertManager {
+	n := notify.New(e.cfg.Notify.SendTimeout)
+	if smtp := &e.cfg.Notify.SMTP; smtp.Enabled {
+		n.Add(notify.NewSMTP(smtp), notify.Severity(smtp.MinSeverity))
+		e.logger.Info("SMTP notifications enabled",
+			zap.String("host", smtp.Host),
+			zap.Int("port", smtp.Port),
+			zap.Strings("to", smtp.To),
+			zap.String("min_severity", smtp.MinSeverity),
+		)
+	}
+
+	if pd := &e.cfg.Notify.PagerDuty; pd.Enabled {
+		n.Add(notify.NewPagerDuty(pd), notify.Severity(pd.MinSeverity))
+		e.logger.Info("PagerDuty notifications enabled", zap.String("min_severity", pd.MinSeverity))
+	}
+	if og := &e.cfg.Notify.Opsgenie; og.Enabled {
+		n.Add(notify.NewOpsgenie(og), notify.Severity(og.MinSeverity))
+		e.logger.Info("Opsgenie notifications enabled", zap.String("url", og.URL), zap.String("min_severity", og.MinSeverity))
+	}
+
+	if n.Channels() == 0 {
+		n.Close()
+		return nil
+	}
+
+	rules := make(map[string]notify.Rule, len(e.cfg.Notify.Rules))
+	for _, r := range e.cfg.Notify.Rules {
+		rule := notify.Rule{DedupWindow: r.DedupWindow}
+		if r.Severity == config.SeverityOff {
+			rule.Disabled = true
+		} else {
+			rule.Severity = notify.Severity(r.Severity)
+		}
+		for _, esc := range r.Escalations {
+			rule.Escalations = append(rule.Escalations, notify.Escalation{Count: esc.Count, Severity: notify.Severity(esc.Severity)})
+		}
+		rules[r.Event] = rule
+	}
+	return notify.NewAlertManager(n, e.cfg.Notify.DedupWindow, rules)
+}
+
+// notifyEvent 把需要通知的事件转为告警提交到告警管理 (调用方持有读锁)，默认严重程度：
+// 紧急平仓、紧急停止和交易所连接中断为critical，对冲失败、爆仓预警、仓位不一致、杠杆达到上限的80%等为warning，
+// 日报、交易日摘要和杠杆达到50%或回落为info
+func (e *Engine) notifyEvent(event Event) {
+	if e.alerts == nil {
+		return
+	}
+
+	msg := notify.Message{Event: string(event.Type), Time: event.Time, Severity: notify.SeverityWarning}
+	switch event.Type {
+	case EventRiskActionChanged:
+		switch event.Fields["new_action"] {
+		case string(strategy.RiskActionEmergencyClose):
+			msg.Severity = notify.SeverityCritical
+			msg.Title = "Emergency close triggered"
+			msg.Key = "emergency-close"
+		case string(strategy.RiskActionStopOpening), string(strategy.RiskActionStartClosing):
+			msg.Title = fmt.Sprintf("Risk action changed to %v", event.Fields["new_action"])
+		default:
+			return
+		}
+	case EventLeverageThreshold:
+		if event.Fields["rising"] != true {
+			msg.Severity = notify.SeverityInfo
+			msg.Title = fmt.Sprintf("%v leverage back below %v%% of max", event.Fields["exchange"], event.Fields["threshold_percent"])
+			break
+		}
+		if pct, _ := event.Fields["threshold_percent"].(float64); pct < 80 {
+			msg.Severity = notify.SeverityInfo
+		}
+		msg.Title = fmt.Sprintf("%v leverage reached %v%% of max", event.Fields["exchange"], event.Fields["threshold_percent"])
+	case EventKillSwitch:
+		msg.Severity = notify.SeverityCritical
+		msg.Title = "Kill switch engaged"
+		msg.Key = "kill-switch"
+	case EventConnectivityLost:
+		msg.Severity = notify.SeverityCritical
+		msg.Title = fmt.Sprintf("Lost connectivity to %v", event.Fields["venue"])
+		msg.Key = fmt.Sprintf("connectivity-%v", event.Fields["venue"])
+	case EventConnectivityRestored:
+		msg.Severity = notify.SeverityCritical
+		msg.Title = fmt.Sprintf("Connectivity to %v restored", event.Fields["venue"])
+		msg.Key = fmt.Sprintf("connectivity-%v", event.Fields["venue"])
+		msg.Resolve = true
+	case EventHedgeFailed:
+		msg.Title = fmt.Sprintf("Hedge failed for %v on %v", event.Fields["symbol"], event.Fields["exchange"])
+	case EventDelayExceeded:
+		msg.Title = fmt.Sprintf("Hedge delay for %v exceeded %vms", event.Fields["symbol"], event.Fields["max_delay_ms"])
+	case EventHedgeImbalance:
+		msg.Title = fmt.Sprintf("Hedge imbalance on %v", event.Fields["symbol"])
+	case EventLiquidationWarning:
+		msg.Title = fmt.Sprintf("Liquidation risk for %v on %v", event.Fields["symbol"], event.Fields["exchange"])
+	case EventPositionDiscrepancy:
+		msg.Title = fmt.Sprintf("Position discrepancy for %v on %v", event.Fields["symbol"], event.Fields["exchange"])
+	case EventLoopStalled:
+		msg.Title = fmt.Sprintf("Loop %v stalled", event.Fields["loop"])
+	case EventPanicRecovered:
+		msg.Title = fmt.Sprintf("Recovered from panic in %v", event.Fields["component"])
+	case EventCircuitOpened:
+		msg.Title = fmt.Sprintf("Circuit opened for %v", event.Fields["venue"])
+	case EventDailySummary:
+		msg.Severity = notify.SeverityInfo
+		msg.Title = fmt.Sprintf("Daily summary %v", event.Fields["date"])
+	case EventReportGenerated:
+		msg.Severity = notify.SeverityInfo
+		msg.Title = fmt.Sprintf("Daily PnL report %v", event.Fields["date"])
+	default:
+		return
+	}
+	msg.Body = formatFields(event)
+
+	e.alerts.Fire(msg)
+}
+
+// notifying 是否启用了外部通知渠道
+func (e *Engine) notifying() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.alerts != nil
+}
+
+// formatFields 把事件字段按名称排序输出为 "key: value" 行
+func formatFields(event Event) string {
+	keys := make([]string, 0, len(event.Fields))
+	for k := range event.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "event: %s\ntime: %s\n", event.Type, event.Time.Format("2006-01-02 15:04:05 MST"))
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s: %v\n", k, event.Fields[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// ErrNotPausable 当前策略不支持暂停 (仅动态对冲支持)
+var ErrNotPausable = errors.New("pause is only supported by a running dynamic_hedge strategy")
+
+// ErrNoDynamicHedge 操作只支持运行中的动态对冲策略
+var ErrNoDynamicHedge = errors.New("operation requires a running dynamic_hedge strategy")
+
+// ErrRebalanceDisabled 未启用跨交易所保证金再平衡
+var ErrRebalanceDisabled = errors.New("collateral rebalance is not enabled")
+
+// Pause 暂停开新仓，已有订单的监控和对冲照常进行。source 说明请求来源，用于日志和事件
+func (e *Engine) Pause(source string) error {
+	e.mu.RLock()
+	s := e.dynamicHedge
+	e.mu.RUnlock()
+	if s == nil {
+		return ErrNotPausable
+	}
+
+	if s.Pause() {
+		e.logger.Warn("Strategy paused", zap.String("source", source))
+		e.publish(EventPaused, map[string]interface{}{"source": source})
+	}
+	return nil
+}
+
+// Resume 恢复开新仓
+func (e *Engine) Resume(source string) error {
+	e.mu.RLock()
+	s := e.dynamicHedge
+	e.mu.RUnlock()
+	if s == nil {
+		return ErrNotPausable
+	}
+
+	if s.Resume() {
+		e.logger.Info("Strategy resumed", zap.String("source", source))
+		e.publish(EventResumed, map[string]interface{}{"source": source})
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"cs-projects-backpack/pkg/binance"
+	"cs-projects-backpack/pkg/lighter"
+)
+
+// Clients 引擎为策略创建的交易所客户端，策略未声明需要的客户端为nil
+type Clients struct {
+	Lighter *lighter.Client
+	Binance *binance.Client
+}
+
+// StrategyFunc 策略入口，阻塞直到ctx取消或策略结束
+type StrategyFunc func(ctx context.Context, e *Engine, clients *Clients) error
+
+// StrategyDefinition 注册的策略：声明需要的交易所客户端和入口函数。
+// 引擎只为声明需要的交易所校验凭证和创建客户端
+type StrategyDefinition struct {
+	Lighter bool
+	Binance bool
+	Run     StrategyFunc
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]StrategyDefinition)
+)
+
+// RegisterStrategy 注册策略，strategy.type 配置为 name 时运行。
+// 通常在策略文件的 init 中调用，名称重复或缺少入口函数时panic
+func RegisterStrategy(name string, def StrategyDefinition) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if def.Run == nil {
+		panic(fmt.Sprintf("engine: strategy %q has no run function", name))
+	}
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("engine: strategy %q already registered", name))
+	}
+	registry[name] = def
+}
+
+// Strategies 返回已注册的策略名称 (按名称排序)
+func Strategies() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupStrategy 查找已注册的策略
+func lookupStrategy(name string) (StrategyDefinition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	def, ok := registry[name]
+	return def, ok
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/report"
+)
+
+// reportCheckInterval 日切检查间隔
+const reportCheckInterval = time.Minute
+
+// runDailyReports 在每次日切时为前一天生成盈亏日报，直到ctx取消
+func (e *Engine) runDailyReports(ctx context.Context) {
+	loc, err := time.LoadLocation(e.cfg.Report.Timezone)
+	if err != nil {
+		e.logger.Error("Invalid report timezone, daily reports disabled", zap.Error(err))
+		return
+	}
+
+	currentDay := time.Now().In(loc).Format("2006-01-02")
+
+	ticker := time.NewTicker(reportCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			today := now.In(loc).Format("2006-01-02")
+			if today == currentDay {
+				continue
+			}
+			currentDay = today
+			e.generateDailyReport(now.In(loc).AddDate(0, 0, -1), loc)
+		}
+	}
+}
+
+// generateDailyReport 生成指定日期的日报
+func (e *Engine) generateDailyReport(day time.Time, loc *time.Location) {
+	daily, paths, err := report.Generate(e.cfg.Journal.Path, day, loc, e.cfg.Report.Dir, e.cfg.Report.Formats)
+	if err != nil {
+		e.logger.Error("Failed to generate daily report", zap.Error(err))
+		return
+	}
+
+	e.logger.Info("Daily PnL report generated",
+		zap.String("date", daily.Date),
+		zap.Float64("volume", daily.Total.Volume),
+		zap.Float64("realized_pnl", daily.Total.RealizedPnL),
+		zap.Float64("fees", daily.Total.Fees),
+		zap.Float64("funding", daily.Total.Funding),
+		zap.Float64("net_pnl", daily.Total.NetPnL),
+		zap.Strings("files", paths),
+	)
+	e.publish(EventReportGenerated, map[string]interface{}{
+		"date":         daily.Date,
+		"trades":       daily.Total.Trades,
+		"volume":       daily.Total.Volume,
+		"realized_pnl": daily.Total.RealizedPnL,
+		"fees":         daily.Total.Fees,
+		"funding":      daily.Total.Funding,
+		"net_pnl":      daily.Total.NetPnL,
+		"files":        paths,
+	})
+}
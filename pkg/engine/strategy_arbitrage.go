@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"context"
+
+	"cs-projects-backpack/internal/strategy"
+)
+
+func init() {
+	RegisterStrategy("arbitrage", StrategyDefinition{
+		Lighter: true,
+		Binance: true,
+		Run:     runArbitrageStrategy,
+	})
+}
+
+// runArbitrageStrategy 一次性套利：Lighter Taker与Binance Maker同时下单
+func runArbitrageStrategy(ctx context.Context, e *Engine, clients *Clients) error {
+	e.logger.Info("=== Running Arbitrage Strategy ===")
+
+	lighterClient := clients.Lighter
+	binanceClient := clients.Binance
+
+	arbitrageConfig := &strategy.ArbitrageConfig{
+		USDTAmount:    e.cfg.Trading.USDTAmount,
+		USDCAmount:    e.cfg.Trading.USDCAmount,
+		Leverage:      e.cfg.Trading.Leverage,
+		SpreadPercent: e.cfg.Strategy.SpreadPercent,
+	}
+
+	arbitrageStrategy := strategy.NewArbitrageStrategy(
+		strategy.NewLighterStrategy(lighterClient, e.symbolUniverse(), nil),
+		strategy.NewBinanceStrategy(binanceClient, e.symbolUniverse(), nil),
+		arbitrageConfig,
+	)
+	return e.runLifecycle(ctx, "Arbitrage", arbitrageStrategy)
+}
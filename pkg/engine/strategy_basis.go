@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"context"
+
+	"cs-projects-backpack/internal/strategy"
+)
+
+func init() {
+	RegisterStrategy("basis", StrategyDefinition{
+		Lighter: true,
+		Binance: true,
+		Run:     runBasisStrategy,
+	})
+}
+
+// runBasisStrategy 期现基差：基差偏离时开仓，收敛后平仓
+func runBasisStrategy(ctx context.Context, e *Engine, clients *Clients) error {
+	e.logger.Info("=== Running Spot-Perp Basis Strategy ===")
+
+	lighterClient := clients.Lighter
+	binanceClient := clients.Binance
+
+	basisConfig := &strategy.BasisConfig{
+		Symbols:           e.cfg.Strategy.BasisSymbols,
+		OrderSize:         float64(e.cfg.Trading.USDCAmount),
+		Leverage:          e.cfg.Trading.Leverage,
+		SpreadPercent:     e.cfg.Strategy.SpreadPercent,
+		EntryAnnualized:   e.cfg.Strategy.BasisEntryAnnualized,
+		ExitAnnualized:    e.cfg.Strategy.BasisExitAnnualized,
+		ConvergenceWindow: e.cfg.Strategy.BasisConvergenceWindow,
+		CheckInterval:     e.cfg.Strategy.BasisCheckInterval,
+	}
+
+	basisStrategy := strategy.NewBasisStrategy(
+		strategy.NewLighterStrategy(lighterClient, e.symbolUniverse(), nil),
+		strategy.NewBinanceStrategy(binanceClient, e.symbolUniverse(), nil),
+		basisConfig,
+	)
+	return e.runLifecycle(ctx, "Basis", basisStrategy)
+}
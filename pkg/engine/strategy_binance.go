@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"context"
+
+	"cs-projects-backpack/internal/strategy"
+)
+
+func init() {
+	RegisterStrategy("binance", StrategyDefinition{
+		Lighter: false,
+		Binance: true,
+		Run:     runBinanceStrategy,
+	})
+}
+
+// runBinanceStrategy Binance Maker策略：按币种配置在Binance挂限价单
+func runBinanceStrategy(ctx context.Context, e *Engine, clients *Clients) error {
+	e.logger.Info("=== Running Binance Strategy ===")
+
+	binanceClient := clients.Binance
+
+	binanceConfig := &strategy.BinanceConfig{
+		USDCAmount:    float64(e.cfg.Trading.USDCAmount),
+		SpreadPercent: e.cfg.Strategy.SpreadPercent,
+	}
+
+	binanceStrategy := strategy.NewBinanceStrategy(binanceClient, e.symbolUniverse(), binanceConfig)
+	return e.runLifecycle(ctx, "Binance", binanceStrategy)
+}
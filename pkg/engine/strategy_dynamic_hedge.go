@@ -0,0 +1,428 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/internal/strategy"
+	"cs-projects-backpack/pkg/eventbus"
+	"cs-projects-backpack/pkg/journal"
+	"cs-projects-backpack/pkg/metrics"
+)
+
+func init() {
+	RegisterStrategy("dynamic_hedge", StrategyDefinition{
+		Lighter: true,
+		Binance: true,
+		Run:     runDynamicHedgeStrategy,
+	})
+}
+
+// runDynamicHedgeStrategy 动态对冲：Binance Maker成交后在Lighter对冲，按杠杆和回撤风控
+func runDynamicHedgeStrategy(ctx context.Context, e *Engine, clients *Clients) error {
+	e.logger.Info("=== Running Dynamic Hedge Strategy ===")
+
+	cfg := e.cfg
+
+	statsLocation, err := time.LoadLocation(cfg.Stats.ResetTimezone)
+	if err != nil {
+		return fmt.Errorf("invalid stats timezone: %w", err)
+	}
+
+	sessionLocation, err := time.LoadLocation(cfg.Strategy.SessionTimezone)
+	if err != nil {
+		return fmt.Errorf("invalid session timezone: %w", err)
+	}
+	tradingWindows := make([]strategy.TradingWindow, 0, len(cfg.Strategy.TradingWindows))
+	for i, w := range cfg.Strategy.TradingWindows {
+		window, err := strategy.ParseTradingWindow(w.Days, w.Start, w.End)
+		if err != nil {
+			return fmt.Errorf("invalid trading window %d: %w", i, err)
+		}
+		tradingWindows = append(tradingWindows, window)
+	}
+
+	dynamicConfig := &strategy.DynamicHedgeConfig{
+		OrderSize:         float64(cfg.Trading.USDCAmount), // 使用USDC作为基准
+		MaxLeverage:       cfg.Strategy.MaxLeverage,
+		EmergencyLeverage: cfg.Strategy.EmergencyLeverage,
+		StopDuration:      cfg.Strategy.StopDuration,
+		EquityRefresh:     cfg.Strategy.EquityRefreshInterval,
+		MonitorInterval:   cfg.Strategy.MonitorInterval,
+
+		StartingEquity:           cfg.Strategy.StartingEquity,
+		MaxDrawdownPercent:       cfg.Strategy.MaxDrawdownPercent,
+		EmergencyDrawdownPercent: cfg.Strategy.EmergencyDrawdownPercent,
+		MaxVenueNotional:         cfg.Strategy.MaxVenueNotional,
+		SpreadPercent:            cfg.Strategy.SpreadPercent,
+
+		// 持续交易配置
+		ContinuousMode:  cfg.Strategy.ContinuousMode,
+		TradingInterval: cfg.Strategy.TradingInterval,
+		VolumeTarget:    cfg.Strategy.VolumeTarget,
+		MaxDailyTrades:  cfg.Strategy.MaxDailyTrades,
+		StatsLocation:   statsLocation,
+		StatsResetHour:  cfg.Stats.ResetHour,
+
+		// 对冲平衡配置
+		EnableHedgeBalancing: cfg.Strategy.EnableHedgeBalancing,
+		BalanceCheckInterval: cfg.Strategy.BalanceCheckInterval,
+		BalanceTolerance:     cfg.Strategy.BalanceTolerance,
+		MinBalanceAdjust:     cfg.Strategy.MinBalanceAdjust,
+
+		// 快速执行配置
+		EnableFastExecution:       cfg.Strategy.EnableFastExecution,
+		FastCheckInterval:         cfg.Strategy.FastCheckInterval,
+		MaxExecutionDelay:         cfg.Strategy.MaxExecutionDelay,
+		RetryPolicy:               e.retryPolicy(),
+		EnablePreExecution:        cfg.Strategy.EnablePreExecution,
+		PartialFillThreshold:      cfg.Strategy.PartialFillThreshold,
+		EnableConcurrentExecution: cfg.Strategy.EnableConcurrentExecution,
+		MaxConcurrentOrders:       cfg.Strategy.MaxConcurrentOrders,
+		MaxSlippagePercent:        cfg.Strategy.MaxSlippagePercent,
+		RejectOnSlippage:          cfg.Strategy.RejectOnSlippage,
+		DelayBuckets:              cfg.Strategy.ExecutionDelayBuckets,
+
+		// 交易时间窗口
+		TradingWindows:  tradingWindows,
+		SessionLocation: sessionLocation,
+
+		// 日终清仓配置
+		EnableDailyFlatten: cfg.Strategy.EnableDailyFlatten,
+		FlattenTime:        cfg.Strategy.FlattenTime,
+		FlattenResumeTime:  cfg.Strategy.FlattenResumeTime,
+
+		// 分片执行配置
+		EnableTWAP:       cfg.Strategy.EnableTWAP,
+		ExecutionAlgo:    cfg.Strategy.ExecutionAlgo,
+		VWAPLookback:     cfg.Strategy.VWAPLookback,
+		TWAPWindow:       cfg.Strategy.TWAPWindow,
+		TWAPSlices:       cfg.Strategy.TWAPSlices,
+		TWAPDepthPercent: cfg.Strategy.TWAPDepthPercent,
+		TWAPDepthRatio:   cfg.Strategy.TWAPDepthRatio,
+
+		// 止损止盈配置
+		EnableProtectiveOrders: cfg.Strategy.EnableProtectiveOrders,
+		StopLossPercent:        cfg.Strategy.StopLossPercent,
+		TakeProfitPercent:      cfg.Strategy.TakeProfitPercent,
+
+		// 挂单超时配置
+		MaxOrderAge:        cfg.Strategy.MaxOrderAge,
+		RepriceStaleOrders: cfg.Strategy.RepriceStaleOrders,
+
+		// 追价配置
+		EnableOrderChasing:    cfg.Strategy.EnableOrderChasing,
+		ChaseThresholdPercent: cfg.Strategy.ChaseThresholdPercent,
+		ChaseInterval:         cfg.Strategy.ChaseInterval,
+		MaxChases:             cfg.Strategy.MaxChases,
+
+		// 余额检查
+		EnableBalanceCheck:    cfg.Strategy.EnableBalanceCheck,
+		EnableAutoTransfer:    cfg.Strategy.EnableAutoTransfer,
+		AutoTransferAsset:     cfg.Strategy.AutoTransferAsset,
+		AutoTransferMinAmount: cfg.Strategy.AutoTransferMinAmount,
+		AutoTransferMaxAmount: cfg.Strategy.AutoTransferMaxAmount,
+
+		// 跨交易所保证金再平衡
+		EnableCollateralRebalance:  cfg.Strategy.EnableCollateralRebalance,
+		RebalanceCheckInterval:     cfg.Strategy.RebalanceCheckInterval,
+		RebalanceThresholdPercent:  cfg.Strategy.RebalanceThresholdPercent,
+		RebalanceMinAmount:         cfg.Strategy.RebalanceMinAmount,
+		RebalanceAlertInterval:     cfg.Strategy.RebalanceAlertInterval,
+		RebalanceAsset:             cfg.Strategy.RebalanceAsset,
+		RebalanceAutoWithdraw:      cfg.Strategy.RebalanceAutoWithdraw,
+		RebalanceWithdrawNetwork:   cfg.Strategy.RebalanceWithdrawNetwork,
+		RebalanceDepositAddress:    cfg.Strategy.RebalanceDepositAddress,
+		RebalanceMaxWithdrawAmount: cfg.Strategy.RebalanceMaxWithdrawAmount,
+
+		// USDC/USDT汇率
+		EnableUSDCRate:  cfg.Strategy.EnableUSDCRate,
+		USDCRateRefresh: cfg.Strategy.USDCRateRefresh,
+
+		// 盈亏归因
+		EnableFundingAccrual:   cfg.Strategy.EnableFundingAccrual,
+		FundingAccrualInterval: cfg.Strategy.FundingAccrualInterval,
+
+		// 流动性限额配置
+		EnableLiquiditySizing: cfg.Strategy.EnableLiquiditySizing,
+		LiquidityDepthPercent: cfg.Strategy.LiquidityDepthPercent,
+		MaxLiquidityRatio:     cfg.Strategy.MaxLiquidityRatio,
+		MinOrderSize:          cfg.Strategy.MinOrderSize,
+
+		// 价差触发配置
+		EnableSpreadTrigger: cfg.Strategy.EnableSpreadTrigger,
+		SpreadCheckInterval: cfg.Strategy.SpreadCheckInterval,
+		MinSpreadPercent:    cfg.Strategy.MinSpreadPercent,
+
+		// 标记价格偏离保护
+		EnableDivergenceGuard:  cfg.Strategy.EnableDivergenceGuard,
+		MaxMarkIndexDivergence: cfg.Strategy.MaxMarkIndexDivergence,
+
+		// 强平价监控
+		EnableLiquidationMonitor: cfg.Strategy.EnableLiquidationMonitor,
+		LiquidationAlertPercent:  cfg.Strategy.LiquidationAlertPercent,
+		LiquidationDeRiskPercent: cfg.Strategy.LiquidationDeRiskPercent,
+
+		// 仓位对账
+		EnableReconciliation:       cfg.Strategy.EnableReconciliation,
+		ReconcileToleranceNotional: cfg.Strategy.ReconcileToleranceNotional,
+		ReconcileHaltNotional:      cfg.Strategy.ReconcileHaltNotional,
+		ReconcileAutoCorrect:       cfg.Strategy.ReconcileAutoCorrect,
+
+		// 滚动波动率
+		EnableVolatility:   cfg.Strategy.EnableVolatility,
+		VolatilityInterval: cfg.Strategy.VolatilityInterval,
+		VolatilityWindow:   cfg.Strategy.VolatilityWindow,
+		VolatilityRefresh:  cfg.Strategy.VolatilityRefresh,
+
+		// 波动率自适应价差
+		EnableAdaptiveSpread:        cfg.Strategy.EnableAdaptiveSpread,
+		AdaptiveSpreadReferenceATR:  cfg.Strategy.AdaptiveSpreadReferenceATR,
+		AdaptiveSpreadMinMultiplier: cfg.Strategy.AdaptiveSpreadMinMultiplier,
+		AdaptiveSpreadMaxMultiplier: cfg.Strategy.AdaptiveSpreadMaxMultiplier,
+
+		// 影子模式
+		EnableShadow: cfg.Strategy.EnableShadow,
+		ShadowParams: strategy.ShadowParams{
+			SpreadPercent:       cfg.Strategy.ShadowSpreadPercent,
+			OrderSize:           cfg.Strategy.ShadowOrderSize,
+			MaxPositionNotional: cfg.Strategy.ShadowMaxPositionNotional,
+			TradingInterval:     cfg.Strategy.ShadowTradingInterval,
+			MaxOrderAge:         cfg.Strategy.ShadowMaxOrderAge,
+		},
+
+		// 看门狗
+		EnableWatchdog:       cfg.Strategy.EnableWatchdog,
+		WatchdogInterval:     cfg.Strategy.WatchdogInterval,
+		WatchdogStallTimeout: cfg.Strategy.WatchdogStallTimeout,
+		WatchdogAction:       cfg.Strategy.WatchdogAction,
+		WatchdogMaxRestarts:  cfg.Strategy.WatchdogMaxRestarts,
+
+		// 订单恢复
+		UntrackedOrderAction: cfg.OrderState.UntrackedAction,
+
+		// 统计持久化
+		StatsSnapshotInterval: cfg.StatsState.Interval,
+
+		Fees:     e.feeSchedule(ctx, clients.Binance),
+		FeeTiers: e.feeTiers(),
+	}
+
+	e.logger.Info("Starting dynamic hedge strategy with config",
+		zap.Float64("order_size", dynamicConfig.OrderSize),
+		zap.Float64("max_leverage", dynamicConfig.MaxLeverage),
+		zap.Float64("emergency_leverage", dynamicConfig.EmergencyLeverage),
+		zap.Duration("stop_duration", dynamicConfig.StopDuration),
+		zap.Duration("equity_refresh_interval", dynamicConfig.EquityRefresh),
+		zap.Float64("max_drawdown_percent", dynamicConfig.MaxDrawdownPercent),
+		zap.Float64("emergency_drawdown_percent", dynamicConfig.EmergencyDrawdownPercent),
+		zap.Float64("max_venue_notional", dynamicConfig.MaxVenueNotional),
+		zap.Duration("monitor_interval", dynamicConfig.MonitorInterval),
+		zap.Bool("continuous_mode", dynamicConfig.ContinuousMode),
+		zap.Duration("trading_interval", dynamicConfig.TradingInterval),
+		zap.Float64("volume_target", dynamicConfig.VolumeTarget),
+		zap.Int("max_daily_trades", dynamicConfig.MaxDailyTrades),
+		zap.String("stats_reset_timezone", cfg.Stats.ResetTimezone),
+		zap.Int("stats_reset_hour", dynamicConfig.StatsResetHour),
+		zap.Bool("enable_hedge_balancing", dynamicConfig.EnableHedgeBalancing),
+		zap.Duration("balance_check_interval", dynamicConfig.BalanceCheckInterval),
+		zap.Float64("balance_tolerance", dynamicConfig.BalanceTolerance),
+		zap.Float64("min_balance_adjust", dynamicConfig.MinBalanceAdjust),
+		zap.Bool("enable_fast_execution", dynamicConfig.EnableFastExecution),
+		zap.Duration("fast_check_interval", dynamicConfig.FastCheckInterval),
+		zap.Duration("max_execution_delay", dynamicConfig.MaxExecutionDelay),
+		zap.Bool("enable_pre_execution", dynamicConfig.EnablePreExecution),
+		zap.Float64("partial_fill_threshold", dynamicConfig.PartialFillThreshold),
+		zap.Bool("enable_concurrent_execution", dynamicConfig.EnableConcurrentExecution),
+		zap.Int("max_concurrent_orders", dynamicConfig.MaxConcurrentOrders),
+		zap.Float64("max_slippage_percent", dynamicConfig.MaxSlippagePercent),
+		zap.Bool("reject_on_slippage", dynamicConfig.RejectOnSlippage),
+		zap.Int("trading_windows", len(dynamicConfig.TradingWindows)),
+		zap.String("session_timezone", cfg.Strategy.SessionTimezone),
+		zap.Bool("enable_daily_flatten", dynamicConfig.EnableDailyFlatten),
+		zap.String("flatten_time", dynamicConfig.FlattenTime),
+		zap.String("flatten_resume_time", dynamicConfig.FlattenResumeTime),
+		zap.Bool("enable_twap", dynamicConfig.EnableTWAP),
+		zap.String("execution_algo", dynamicConfig.ExecutionAlgo),
+		zap.Duration("twap_window", dynamicConfig.TWAPWindow),
+		zap.Int("twap_slices", dynamicConfig.TWAPSlices),
+		zap.Bool("enable_protective_orders", dynamicConfig.EnableProtectiveOrders),
+		zap.Float64("stop_loss_percent", dynamicConfig.StopLossPercent),
+		zap.Float64("take_profit_percent", dynamicConfig.TakeProfitPercent),
+		zap.Duration("max_order_age", dynamicConfig.MaxOrderAge),
+		zap.Bool("reprice_stale_orders", dynamicConfig.RepriceStaleOrders),
+		zap.Bool("enable_order_chasing", dynamicConfig.EnableOrderChasing),
+		zap.Float64("chase_threshold_percent", dynamicConfig.ChaseThresholdPercent),
+		zap.Int("max_chases", dynamicConfig.MaxChases),
+		zap.Bool("enable_balance_check", dynamicConfig.EnableBalanceCheck),
+		zap.Bool("enable_auto_transfer", dynamicConfig.EnableAutoTransfer),
+		zap.Bool("enable_collateral_rebalance", dynamicConfig.EnableCollateralRebalance),
+		zap.Bool("rebalance_auto_withdraw", dynamicConfig.RebalanceAutoWithdraw),
+		zap.Bool("enable_usdc_rate", dynamicConfig.EnableUSDCRate),
+		zap.Bool("enable_funding_accrual", dynamicConfig.EnableFundingAccrual),
+		zap.Bool("enable_liquidity_sizing", dynamicConfig.EnableLiquiditySizing),
+		zap.Float64("max_liquidity_ratio", dynamicConfig.MaxLiquidityRatio),
+		zap.Bool("enable_spread_trigger", dynamicConfig.EnableSpreadTrigger),
+		zap.Float64("min_spread_percent", dynamicConfig.MinSpreadPercent),
+		zap.Bool("enable_divergence_guard", dynamicConfig.EnableDivergenceGuard),
+		zap.Float64("max_mark_index_divergence", dynamicConfig.MaxMarkIndexDivergence),
+		zap.Bool("enable_liquidation_monitor", dynamicConfig.EnableLiquidationMonitor),
+		zap.Float64("liquidation_alert_percent", dynamicConfig.LiquidationAlertPercent),
+		zap.Float64("liquidation_derisk_percent", dynamicConfig.LiquidationDeRiskPercent),
+		zap.Bool("enable_reconciliation", dynamicConfig.EnableReconciliation),
+		zap.Float64("reconcile_halt_notional", dynamicConfig.ReconcileHaltNotional),
+		zap.Bool("enable_volatility", dynamicConfig.EnableVolatility),
+		zap.Bool("enable_adaptive_spread", dynamicConfig.EnableAdaptiveSpread),
+		zap.Bool("enable_shadow", dynamicConfig.EnableShadow),
+		zap.Bool("enable_watchdog", dynamicConfig.EnableWatchdog),
+		zap.String("watchdog_action", dynamicConfig.WatchdogAction),
+	)
+
+	lighterClient := clients.Lighter
+	binanceClient := clients.Binance
+
+	dynamicHedgeStrategy := strategy.NewDynamicHedgeStrategy(
+		strategy.NewLighterStrategy(lighterClient, e.symbolUniverse(), nil),
+		strategy.NewBinanceStrategy(binanceClient, e.symbolUniverse(), nil),
+		dynamicConfig,
+	)
+	if err := metrics.Register(dynamicHedgeStrategy.ExecutionMetrics()); err != nil {
+		e.logger.Warn("Failed to register execution metrics", zap.Error(err))
+	}
+
+	// 策略事件总线转发到引擎事件流
+	unsubscribe := dynamicHedgeStrategy.EventBus().Subscribe(func(ev eventbus.Event) {
+		// 字段在订阅方之间共享，复制后再补充来源
+		fields := make(map[string]interface{}, len(ev.Fields)+1)
+		for k, v := range ev.Fields {
+			fields[k] = v
+		}
+		fields["source"] = ev.Source
+		e.publish(EventType(ev.Type), fields)
+	})
+	defer unsubscribe()
+
+	// 多源聚合价格
+	if cfg.PriceFeed.Enabled {
+		feed := e.newPriceFeed(binanceClient, lighterClient)
+		symbols := make([]string, 0, len(cfg.Symbols))
+		for _, sym := range cfg.Symbols {
+			symbols = append(symbols, sym.Symbol)
+		}
+		go feed.Run(ctx, symbols, cfg.PriceFeed.RefreshInterval)
+		dynamicHedgeStrategy.SetPriceFeed(feed)
+	}
+
+	e.mu.Lock()
+	e.current = dynamicHedgeStrategy
+	e.dynamicHedge = dynamicHedgeStrategy
+	e.mu.Unlock()
+
+	// 成交日志
+	if cfg.Journal.Enabled {
+		tradeJournal, err := journal.Open(cfg.Journal.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open trade journal: %w", err)
+		}
+		defer tradeJournal.Close()
+		dynamicHedgeStrategy.SetTradeJournal(tradeJournal)
+
+		// 日切盈亏日报
+		if cfg.Report.Enabled {
+			go e.runDailyReports(ctx)
+		}
+	}
+
+	// 活跃订单持久化，启动时恢复上次运行留下的订单
+	if cfg.OrderState.Enabled {
+		orderStore, err := strategy.OpenOrderStore(cfg.OrderState.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open order state: %w", err)
+		}
+		dynamicHedgeStrategy.SetOrderStore(orderStore)
+	}
+
+	// 统计持久化，启动时恢复交易统计和执行统计
+	if cfg.StatsState.Enabled {
+		statsStore, err := strategy.OpenStatsStore(cfg.StatsState.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open stats state: %w", err)
+		}
+		dynamicHedgeStrategy.SetStatsStore(statsStore)
+	}
+
+	// 策略使用独立的上下文，退出信号到达后仍可按 shutdown.mode 收尾
+	strategyCtx, stopStrategy := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopStrategy()
+
+	// Start the dynamic hedge strategy
+	if err := dynamicHedgeStrategy.Start(strategyCtx); err != nil {
+		return fmt.Errorf("failed to start dynamic hedge strategy: %w", err)
+	}
+
+	e.logger.Info("Dynamic hedge strategy started successfully")
+	e.logger.Info("Press Ctrl+C to stop the strategy gracefully...")
+
+	// Wait for context cancellation (Ctrl+C) or strategy failure (watchdog)
+	var failure error
+	select {
+	case <-ctx.Done():
+		e.logger.Info("Shutdown signal received, stopping dynamic hedge strategy...")
+	case failure = <-dynamicHedgeStrategy.Failed():
+		e.logger.Error("Dynamic hedge strategy failed, stopping", zap.Error(failure))
+	}
+
+	// 紧急停止已撤单，只在平仓模式下继续平仓 (平仓单在紧急停止后仍然放行)
+	if !e.killSwitch.Engaged() || cfg.Shutdown.Mode == strategy.ShutdownModeFlatten {
+		if err := dynamicHedgeStrategy.Shutdown(cfg.Shutdown.Mode, cfg.Shutdown.DrainTimeout); err != nil {
+			e.logger.Error("Shutdown procedure failed", zap.String("mode", cfg.Shutdown.Mode), zap.Error(err))
+		}
+	}
+	stopStrategy()
+
+	// 获取最终统计信息
+	if stats := dynamicHedgeStrategy.GetStats(); stats != nil {
+		e.logger.Info("Final trading statistics",
+			zap.Float64("daily_volume", stats.DailyVolume),
+			zap.Int("daily_trades", stats.DailyTrades),
+			zap.Float64("total_volume", stats.TotalVolume),
+			zap.Int("total_trades", stats.TotalTrades),
+			zap.Float64("realized_pnl", stats.RealizedPnL),
+			zap.Float64("unrealized_pnl", stats.UnrealizedPnL),
+			zap.Float64("fees", stats.Fees),
+			zap.Float64("total_pnl", stats.TotalPnL),
+			zap.Int("pnl_days", len(stats.DailyPnLHistory)+1),
+			zap.Float64("sharpe_ratio", stats.SharpeRatio),
+			zap.Float64("sortino_ratio", stats.SortinoRatio),
+			zap.Float64("win_rate", stats.WinRate),
+			zap.Float64("profit_factor", stats.ProfitFactor),
+		)
+	}
+
+	// 获取执行性能统计
+	if execStats := dynamicHedgeStrategy.GetExecutionStats(); execStats != nil {
+		e.logger.Info("Final execution performance statistics",
+			zap.Int64("total_executions", execStats.TotalExecutions),
+			zap.Int64("successful_executions", execStats.SuccessfulExecutions),
+			zap.Float64("success_rate", float64(execStats.SuccessfulExecutions)/float64(execStats.TotalExecutions)*100),
+			zap.Duration("average_delay", execStats.AverageDelay),
+			zap.Duration("min_delay", execStats.MinDelay),
+			zap.Duration("max_delay", execStats.MaxDelay),
+			zap.Float64("avg_adverse_percent", execStats.AvgAdversePercent),
+			zap.Float64("max_adverse_percent", execStats.MaxAdversePercent),
+			zap.Any("delay_distribution", execStats.DelayHistogram),
+		)
+	}
+
+	// 停止
+	dynamicHedgeStrategy.Stop()
+	e.logger.Info("Dynamic hedge strategy stopped successfully")
+
+	if failure != nil {
+		return fmt.Errorf("dynamic hedge strategy failed: %w", failure)
+	}
+	return ctx.Err()
+}
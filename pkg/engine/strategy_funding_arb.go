@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"context"
+
+	"cs-projects-backpack/internal/strategy"
+)
+
+func init() {
+	RegisterStrategy("funding_arb", StrategyDefinition{
+		Lighter: true,
+		Binance: true,
+		Run:     runFundingArbStrategy,
+	})
+}
+
+// runFundingArbStrategy 资金费率套利：两边持有反向仓位，赚取资金费率差
+func runFundingArbStrategy(ctx context.Context, e *Engine, clients *Clients) error {
+	e.logger.Info("=== Running Funding Rate Arbitrage Strategy ===")
+
+	lighterClient := clients.Lighter
+	binanceClient := clients.Binance
+
+	fundingConfig := &strategy.FundingArbConfig{
+		Symbols:       e.cfg.Strategy.FundingSymbols,
+		OrderSize:     float64(e.cfg.Trading.USDCAmount),
+		Leverage:      e.cfg.Trading.Leverage,
+		SpreadPercent: e.cfg.Strategy.SpreadPercent,
+		MinRateDiff:   e.cfg.Strategy.FundingMinRateDiff,
+		CheckInterval: e.cfg.Strategy.FundingCheckInterval,
+	}
+
+	fundingArbStrategy := strategy.NewFundingArbStrategy(
+		strategy.NewLighterStrategy(lighterClient, e.symbolUniverse(), nil),
+		strategy.NewBinanceStrategy(binanceClient, e.symbolUniverse(), nil),
+		fundingConfig,
+	)
+	return e.runLifecycle(ctx, "Funding arbitrage", fundingArbStrategy)
+}
@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"context"
+
+	"cs-projects-backpack/internal/strategy"
+)
+
+func init() {
+	RegisterStrategy("lighter", StrategyDefinition{
+		Lighter: true,
+		Binance: false,
+		Run:     runLighterStrategy,
+	})
+}
+
+// runLighterStrategy Lighter Taker策略：按币种配置在Lighter下单
+func runLighterStrategy(ctx context.Context, e *Engine, clients *Clients) error {
+	e.logger.Info("=== Running Lighter Strategy ===")
+
+	lighterClient := clients.Lighter
+
+	lighterConfig := &strategy.LighterConfig{
+		USDTAmount: e.cfg.Trading.USDTAmount,
+		Leverage:   e.cfg.Trading.Leverage,
+	}
+
+	lighterStrategy := strategy.NewLighterStrategy(lighterClient, e.symbolUniverse(), lighterConfig)
+	return e.runLifecycle(ctx, "Lighter", lighterStrategy)
+}
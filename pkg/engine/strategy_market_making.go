@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"context"
+
+	"cs-projects-backpack/internal/strategy"
+)
+
+func init() {
+	RegisterStrategy("market_making", StrategyDefinition{
+		Lighter: true,
+		Binance: true,
+		Run:     runMarketMakingStrategy,
+	})
+}
+
+// runMarketMakingStrategy 库存感知做市：按库存偏移调整双边报价
+func runMarketMakingStrategy(ctx context.Context, e *Engine, clients *Clients) error {
+	e.logger.Info("=== Running Market Making Strategy ===")
+
+	lighterClient := clients.Lighter
+	binanceClient := clients.Binance
+
+	mmConfig := &strategy.MarketMakingConfig{
+		Symbols:          e.cfg.Strategy.MMSymbols,
+		QuoteSize:        e.cfg.Strategy.MMQuoteSize,
+		SpreadPercent:    e.cfg.Strategy.MMSpreadPercent,
+		MaxInventory:     e.cfg.Strategy.MMMaxInventory,
+		SkewPercent:      e.cfg.Strategy.MMSkewPercent,
+		OffloadThreshold: e.cfg.Strategy.MMOffloadThreshold,
+		Leverage:         e.cfg.Trading.Leverage,
+		RefreshInterval:  e.cfg.Strategy.MMRefreshInterval,
+	}
+
+	marketMakingStrategy := strategy.NewMarketMakingStrategy(
+		strategy.NewLighterStrategy(lighterClient, e.symbolUniverse(), nil),
+		strategy.NewBinanceStrategy(binanceClient, e.symbolUniverse(), nil),
+		mmConfig,
+	)
+	return e.runLifecycle(ctx, "Market making", marketMakingStrategy)
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// dailyDigest 当前交易日的运行摘要，由事件累计，日切时作为 DAILY_SUMMARY 发布
+type dailyDigest struct {
+	since         time.Time // 统计开始时间 (日切或引擎启动)
+	trades        int
+	volume        float64
+	hedges        int
+	hedgeFailures int
+	imbalances    int
+	delayBreaches int
+	maxDelayMs    int64
+
+	// 日切时的累计总盈亏和手续费，当日数值为与之的差值 (策略启动后才能获取)
+	basePnL  float64
+	baseFees float64
+	baseSet  bool
+}
+
+// recordDigest 把事件累计到当日摘要 (调用方持有读锁)
+func (e *Engine) recordDigest(event Event) {
+	e.digestMu.Lock()
+	defer e.digestMu.Unlock()
+
+	d := e.digest
+	if d == nil {
+		return
+	}
+	switch event.Type {
+	case EventTradeRecorded:
+		d.trades++
+		if v, ok := event.Fields["volume"].(float64); ok {
+			d.volume += v
+		}
+	case EventHedgeExecuted:
+		d.hedges++
+		if ms, ok := event.Fields["latency_ms"].(int64); ok && ms > d.maxDelayMs {
+			d.maxDelayMs = ms
+		}
+	case EventHedgeFailed:
+		d.hedgeFailures++
+	case EventHedgeImbalance:
+		d.imbalances++
+	case EventDelayExceeded:
+		d.delayBreaches++
+	}
+}
+
+// runDailySummary 按交易日 (stats.reset_timezone 和 stats.reset_hour) 在日切时发布前一交易日的摘要，直到ctx取消
+func (e *Engine) runDailySummary(ctx context.Context) {
+	loc, err := time.LoadLocation(e.cfg.Stats.ResetTimezone)
+	if err != nil {
+		e.logger.Error("Invalid stats timezone, daily summary disabled", zap.Error(err))
+		return
+	}
+	tradingDay := func(t time.Time) string {
+		return t.In(loc).Add(-time.Duration(e.cfg.Stats.ResetHour) * time.Hour).Format("2006-01-02")
+	}
+
+	e.digestMu.Lock()
+	e.digest = &dailyDigest{since: time.Now()}
+	e.digestMu.Unlock()
+	defer func() {
+		e.digestMu.Lock()
+		e.digest = nil
+		e.digestMu.Unlock()
+	}()
+
+	currentDay := tradingDay(time.Now())
+	e.logger.Info("Daily summary enabled",
+		zap.String("timezone", loc.String()),
+		zap.Int("reset_hour", e.cfg.Stats.ResetHour),
+	)
+
+	ticker := time.NewTicker(reportCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			pnl, fees, ok := e.pnlTotals()
+			day := tradingDay(now)
+			if day == currentDay {
+				e.digestMu.Lock()
+				if ok && !e.digest.baseSet {
+					e.digest.basePnL, e.digest.baseFees, e.digest.baseSet = pnl, fees, true
+				}
+				e.digestMu.Unlock()
+				continue
+			}
+
+			e.digestMu.Lock()
+			d := *e.digest
+			e.digest = &dailyDigest{since: now, basePnL: pnl, baseFees: fees, baseSet: ok}
+			e.digestMu.Unlock()
+
+			e.publishDailySummary(currentDay, &d, pnl, fees, ok)
+			currentDay = day
+		}
+	}
+}
+
+// publishDailySummary 记录日志并发布交易日的摘要，无法获取盈亏时不包含盈亏和手续费
+func (e *Engine) publishDailySummary(day string, d *dailyDigest, pnl, fees float64, ok bool) {
+	fields := map[string]interface{}{
+		"date":             day,
+		"since":            d.since.Format(time.RFC3339),
+		"trades":           d.trades,
+		"volume":           d.volume,
+		"hedges":           d.hedges,
+		"hedge_failures":   d.hedgeFailures,
+		"imbalance_events": d.imbalances,
+		"delay_breaches":   d.delayBreaches,
+		"max_delay_ms":     d.maxDelayMs,
+	}
+	if ok && d.baseSet {
+		fields["pnl"] = pnl - d.basePnL
+		fields["fees"] = fees - d.baseFees
+	}
+
+	e.logger.Info("Daily summary",
+		zap.String("date", day),
+		zap.Int("trades", d.trades),
+		zap.Float64("volume", d.volume),
+		zap.Int("hedge_failures", d.hedgeFailures),
+		zap.Int("imbalance_events", d.imbalances),
+		zap.Int64("max_delay_ms", d.maxDelayMs),
+	)
+	e.publish(EventDailySummary, fields)
+}
+
+// pnlTotals 当前的累计总盈亏 (已扣手续费) 和手续费，策略未运行动态对冲时返回false
+func (e *Engine) pnlTotals() (pnl, fees float64, ok bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.dynamicHedge == nil {
+		return 0, 0, false
+	}
+	stats := e.dynamicHedge.GetStats()
+	if stats == nil {
+		return 0, 0, false
+	}
+	return stats.TotalPnL, stats.Fees, true
+}
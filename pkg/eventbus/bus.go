@@ -0,0 +1,148 @@
+// Package eventbus 进程内发布/订阅事件总线：策略内部组件发布成交、对冲和风控事件，
+// 统计、通知、持久化和管理API等订阅方互不依赖，也不依赖策略内部结构。
+package eventbus
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// subscriberBufferSize 每个订阅方的事件缓冲大小，消费过慢时新事件会被丢弃
+const subscriberBufferSize = 256
+
+// Event 总线事件
+type Event struct {
+	Type   string                 `json:"type"`
+	Source string                 `json:"source"` // 发布方组件，如 order-monitor, risk-manager
+	Time   time.Time              `json:"time"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Handler 事件处理函数，在订阅方自己的goroutine中按发布顺序调用
+type Handler func(Event)
+
+// subscription 订阅
+type subscription struct {
+	types   map[string]bool // 订阅的事件类型，空为全部
+	events  chan Event
+	done    chan struct{}
+	handler Handler
+}
+
+// Bus 事件总线。发布不阻塞：每个订阅方有独立的缓冲和goroutine，慢订阅方不影响交易路径和其他订阅方
+type Bus struct {
+	mu     sync.RWMutex
+	subs   map[*subscription]struct{}
+	closed bool
+	logger *zap.Logger
+}
+
+// New 创建事件总线
+func New() *Bus {
+	return &Bus{
+		subs:   make(map[*subscription]struct{}),
+		logger: logger.Named("event-bus"),
+	}
+}
+
+// Subscribe 订阅事件，types 为空时订阅全部类型。返回的函数取消订阅，已缓冲的事件会先处理完
+func (b *Bus) Subscribe(handler Handler, types ...string) (unsubscribe func()) {
+	sub := &subscription{
+		events:  make(chan Event, subscriberBufferSize),
+		done:    make(chan struct{}),
+		handler: handler,
+	}
+	if len(types) > 0 {
+		sub.types = make(map[string]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.done)
+		return func() {}
+	}
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		defer close(sub.done)
+		for ev := range sub.events {
+			sub.handler(ev)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			if _, ok := b.subs[sub]; ok {
+				delete(b.subs, sub)
+				close(sub.events)
+			}
+			b.mu.Unlock()
+			<-sub.done
+		})
+	}
+}
+
+// Publish 发布事件，不阻塞
+func (b *Bus) Publish(source, eventType string, fields map[string]interface{}) {
+	if b == nil {
+		return
+	}
+
+	ev := Event{
+		Type:   eventType,
+		Source: source,
+		Time:   time.Now(),
+		Fields: fields,
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+
+	for sub := range b.subs {
+		if sub.types != nil && !sub.types[eventType] {
+			continue
+		}
+		select {
+		case sub.events <- ev:
+		default:
+			b.logger.Debug("Subscriber buffer full, dropping event",
+				zap.String("type", eventType),
+				zap.String("source", source),
+			)
+		}
+	}
+}
+
+// Close 关闭总线并等待全部订阅方处理完已缓冲的事件，之后的发布会被忽略
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	subs := b.subs
+	b.subs = make(map[*subscription]struct{})
+	for sub := range subs {
+		close(sub.events)
+	}
+	b.mu.Unlock()
+
+	for sub := range subs {
+		<-sub.done
+	}
+}
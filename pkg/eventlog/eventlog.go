@@ -0,0 +1,135 @@
+// Package eventlog 把订单、对冲、风控和引擎事件写入独立的JSON Lines事件流，
+// 与应用日志分开，字段格式稳定，供下游工具直接消费。
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// SchemaVersion 事件记录格式版本。只新增字段时不变，字段改名、删除或含义变化时递增
+const SchemaVersion = 1
+
+// 事件分类
+const (
+	CategoryOrder  = "order"  // 订单成交、撤单
+	CategoryHedge  = "hedge"  // 对冲执行、失败、仓位平衡
+	CategoryRisk   = "risk"   // 风控行动、强平告警、仓位差异、熔断、紧急停止
+	CategoryEngine = "engine" // 启停、阶段变化、暂停恢复等
+)
+
+// Record 事件流中的一条记录。Symbol、Exchange、OrderID 从事件字段中提取到顶层，其余字段放在 Fields
+type Record struct {
+	Schema   int                    `json:"schema"`
+	Seq      uint64                 `json:"seq"` // 本次运行内递增，从1开始
+	Time     time.Time              `json:"time"`
+	Category string                 `json:"category"`
+	Type     string                 `json:"type"`
+	Source   string                 `json:"source"` // 发布方组件，如 engine, order-monitor, risk-manager
+	Symbol   string                 `json:"symbol,omitempty"`
+	Exchange string                 `json:"exchange,omitempty"`
+	OrderID  string                 `json:"order_id,omitempty"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Log 只追加的事件流文件
+type Log struct {
+	path   string
+	file   *os.File
+	writer *bufio.Writer
+	seq    uint64
+	mu     sync.Mutex
+	logger *zap.Logger
+}
+
+// Open 打开(或创建)事件流文件
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log %s: %w", path, err)
+	}
+
+	log := logger.Named("event-log")
+	log.Info("Event log opened", zap.String("path", path))
+
+	return &Log{
+		path:   path,
+		file:   file,
+		writer: bufio.NewWriter(file),
+		logger: log,
+	}, nil
+}
+
+// Write 追加一条事件。fields 中的 symbol、exchange、order_id 提升为顶层字段，不修改调用方的map
+func (l *Log) Write(category, eventType, source string, t time.Time, fields map[string]interface{}) error {
+	record := Record{
+		Schema:   SchemaVersion,
+		Time:     t,
+		Category: category,
+		Type:     eventType,
+		Source:   source,
+	}
+	if len(fields) > 0 {
+		record.Fields = make(map[string]interface{}, len(fields))
+	}
+	for k, v := range fields {
+		switch k {
+		case "symbol":
+			record.Symbol = fmt.Sprint(v)
+		case "exchange":
+			record.Exchange = fmt.Sprint(v)
+		case "order_id":
+			record.OrderID = fmt.Sprint(v)
+		default:
+			record.Fields[k] = v
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	record.Seq = l.seq
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %s: %w", eventType, err)
+	}
+	if _, err := l.writer.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	// 每条事件立即落盘，下游可以实时跟踪文件
+	if err := l.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush event log: %w", err)
+	}
+	return nil
+}
+
+// Path 返回事件流文件路径
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Close 关闭事件流
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,97 @@
+package journal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// 支持的导出格式
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+var csvHeader = []string{
+	"time", "venue", "symbol", "side", "size", "price", "fee", "funding",
+	"order_id", "hedge_link", "cycle_id", "latency_ms", "adverse", "reason",
+}
+
+// Export 将成交日志导出为指定格式的文件
+func Export(journalPath, format, outPath string) (int, error) {
+	entries, err := ReadAll(journalPath)
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export file %s: %w", outPath, err)
+	}
+	defer out.Close()
+
+	switch format {
+	case FormatCSV:
+		err = WriteCSV(out, entries)
+	case FormatParquet:
+		err = WriteParquet(out, entries)
+	default:
+		return 0, fmt.Errorf("unsupported export format: %s (expected csv or parquet)", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return len(entries), nil
+}
+
+// WriteCSV 以CSV格式写出成交记录
+func WriteCSV(w io.Writer, entries []*Entry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, e := range entries {
+		record := []string{
+			e.Time.UTC().Format(time.RFC3339Nano),
+			e.Venue,
+			e.Symbol,
+			e.Side,
+			strconv.FormatFloat(e.Size, 'f', -1, 64),
+			strconv.FormatFloat(e.Price, 'f', -1, 64),
+			strconv.FormatFloat(e.Fee, 'f', -1, 64),
+			strconv.FormatFloat(e.Funding, 'f', -1, 64),
+			e.OrderID,
+			e.HedgeLink,
+			e.CycleID,
+			strconv.FormatInt(e.LatencyMs, 10),
+			strconv.FormatFloat(e.Adverse, 'f', -1, 64),
+			e.Reason,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv record: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteParquet 以Parquet格式写出成交记录
+func WriteParquet(w io.Writer, entries []*Entry) error {
+	rows := make([]Entry, len(entries))
+	for i, e := range entries {
+		rows[i] = *e
+	}
+
+	if err := parquet.Write(w, rows); err != nil {
+		return fmt.Errorf("failed to write parquet: %w", err)
+	}
+	return nil
+}
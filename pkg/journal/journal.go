@@ -0,0 +1,142 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// Entry 单笔成交记录
+type Entry struct {
+	Time      time.Time `json:"time" parquet:"time,timestamp(millisecond)"`
+	Venue     string    `json:"venue" parquet:"venue,dict"`                // binance, lighter
+	Symbol    string    `json:"symbol" parquet:"symbol,dict"`              // BTC, ETH
+	Side      string    `json:"side" parquet:"side,dict"`                  // BUY, SELL
+	Size      float64   `json:"size" parquet:"size"`                       // 成交规模 (USDT/USDC)
+	Price     float64   `json:"price" parquet:"price"`                     // 成交价格 (0为未知，如Lighter市价对冲)
+	Fee       float64   `json:"fee" parquet:"fee"`                         // 手续费
+	Funding   float64   `json:"funding,omitempty" parquet:"funding"`       // 资金费 (正数收取，负数支付)
+	OrderID   string    `json:"order_id" parquet:"order_id"`               // 订单ID或交易哈希
+	HedgeLink string    `json:"hedge_link,omitempty" parquet:"hedge_link"` // 对应的另一条腿订单ID
+	CycleID   string    `json:"cycle_id,omitempty" parquet:"cycle_id"`     // 所属的对冲周期ID
+	LatencyMs int64     `json:"latency_ms" parquet:"latency_ms"`           // 成交到对冲完成的延迟
+	Adverse   float64   `json:"adverse,omitempty" parquet:"adverse"`       // 成交到对冲完成之间未对冲敞口的最大浮亏 (USD，仅HEDGE)
+	Reason    string    `json:"reason,omitempty" parquet:"reason,dict"`    // MAKER_FILL, HEDGE, EMERGENCY, FUNDING
+}
+
+// Journal 只追加的成交日志 (JSON Lines)
+type Journal struct {
+	path   string
+	file   *os.File
+	writer *bufio.Writer
+	mu     sync.Mutex
+	logger *zap.Logger
+}
+
+// Open 打开(或创建)成交日志文件
+func Open(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal %s: %w", path, err)
+	}
+
+	log := logger.Named("trade-journal")
+	log.Info("Trade journal opened", zap.String("path", path))
+
+	return &Journal{
+		path:   path,
+		file:   file,
+		writer: bufio.NewWriter(file),
+		logger: log,
+	}, nil
+}
+
+// Record 追加一条成交记录
+func (j *Journal) Record(entry *Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.writer.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	// 每条记录立即落盘，保证崩溃后不丢失成交
+	if err := j.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush journal: %w", err)
+	}
+
+	j.logger.Debug("Trade recorded to journal",
+		zap.String("venue", entry.Venue),
+		zap.String("symbol", entry.Symbol),
+		zap.String("side", entry.Side),
+		zap.Float64("size", entry.Size),
+		zap.Float64("price", entry.Price),
+	)
+
+	return nil
+}
+
+// Path 返回日志文件路径
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Close 关闭成交日志
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.writer.Flush(); err != nil {
+		return err
+	}
+	return j.file.Close()
+}
+
+// ReadAll 读取日志文件中的全部成交记录
+func ReadAll(path string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("invalid journal entry at line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,162 @@
+// Package keystore 以口令加密保存签名私钥：scrypt派生密钥，AES-256-GCM加密，JSON格式存储
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrWrongPassphrase 口令错误或密钥文件被篡改
+var ErrWrongPassphrase = errors.New("keystore: wrong passphrase or corrupted file")
+
+const (
+	version = 1
+
+	// scrypt参数，解密一次约需100ms和64MB内存
+	scryptN = 1 << 16
+	scryptR = 8
+	scryptP = 1
+	keyLen  = 32
+	saltLen = 32
+)
+
+// kdfParams scrypt参数
+type kdfParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"` // hex
+}
+
+// file 密钥文件格式
+type file struct {
+	Version    int       `json:"version"`
+	Cipher     string    `json:"cipher"`
+	KDF        string    `json:"kdf"`
+	KDFParams  kdfParams `json:"kdfparams"`
+	Nonce      string    `json:"nonce"`      // hex
+	Ciphertext string    `json:"ciphertext"` // hex，含GCM认证标签
+}
+
+// Encrypt 用口令加密私钥，返回密钥文件内容
+func Encrypt(secret, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase is required")
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt, scryptN, scryptR, scryptP)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return json.MarshalIndent(file{
+		Version: version,
+		Cipher:  "aes-256-gcm",
+		KDF:     "scrypt",
+		KDFParams: kdfParams{
+			N:    scryptN,
+			R:    scryptR,
+			P:    scryptP,
+			Salt: hex.EncodeToString(salt),
+		},
+		Nonce:      hex.EncodeToString(nonce),
+		Ciphertext: hex.EncodeToString(gcm.Seal(nil, nonce, secret, nil)),
+	}, "", "  ")
+}
+
+// Decrypt 用口令解密密钥文件内容
+func Decrypt(data, passphrase []byte) ([]byte, error) {
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore: %w", err)
+	}
+	if f.Version != version || f.Cipher != "aes-256-gcm" || f.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported keystore: version %d, cipher %s, kdf %s", f.Version, f.Cipher, f.KDF)
+	}
+
+	salt, err := hex.DecodeString(f.KDFParams.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+	nonce, err := hex.DecodeString(f.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(f.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore ciphertext: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt, f.KDFParams.N, f.KDFParams.R, f.KDFParams.P)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid keystore nonce length %d", len(nonce))
+	}
+
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return secret, nil
+}
+
+// Load 读取并解密密钥文件
+func Load(path string, passphrase []byte) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	return Decrypt(data, passphrase)
+}
+
+// Save 加密私钥并写入密钥文件 (权限0600)，文件已存在时返回错误
+func Save(path string, secret, passphrase []byte) error {
+	data, err := Encrypt(secret, passphrase)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create keystore: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	return f.Close()
+}
+
+// newGCM 由口令派生AES-256密钥
+func newGCM(passphrase, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, n, r, p, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
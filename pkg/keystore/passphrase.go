@@ -0,0 +1,66 @@
+package keystore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// PassphraseEnv 口令环境变量
+const PassphraseEnv = "LIGHTER_KEYSTORE_PASSPHRASE"
+
+// Passphrase 依次从环境变量、口令命令 (如调用KMS解密)、终端输入获取口令
+func Passphrase(command string) ([]byte, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return []byte(p), nil
+	}
+
+	if command != "" {
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("passphrase command failed: %w", err)
+		}
+		p := bytes.TrimRight(out, "\r\n")
+		if len(p) == 0 {
+			return nil, fmt.Errorf("passphrase command returned empty output")
+		}
+		return p, nil
+	}
+
+	return Prompt("Keystore passphrase: ")
+}
+
+// Prompt 从终端读取一行输入且不回显，标准输入不是终端时返回错误
+func Prompt(prompt string) ([]byte, error) {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil, fmt.Errorf("stdin is not a terminal, set %s or a passphrase command", PassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	if err := stty("-echo"); err != nil {
+		return nil, fmt.Errorf("failed to disable terminal echo: %w", err)
+	}
+	defer func() {
+		_ = stty("echo")
+		fmt.Fprintln(os.Stderr)
+	}()
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
+
+// stty 设置终端模式
+func stty(mode string) error {
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
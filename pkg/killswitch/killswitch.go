@@ -0,0 +1,105 @@
+package killswitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrEngaged 紧急停止后拒绝下单
+var ErrEngaged = errors.New("kill switch engaged")
+
+// Status 紧急停止状态快照
+type Status struct {
+	Engaged   bool      `json:"engaged"`
+	Reason    string    `json:"reason,omitempty"`
+	EngagedAt time.Time `json:"engaged_at,omitempty"`
+}
+
+// Switch 紧急停止开关，触发后保持锁定直到进程重启
+type Switch struct {
+	mu        sync.RWMutex
+	engaged   bool
+	reason    string
+	engagedAt time.Time
+}
+
+// New 创建紧急停止开关
+func New() *Switch {
+	return &Switch{}
+}
+
+// Engage 触发紧急停止，首次触发返回true
+func (s *Switch) Engage(reason string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.engaged {
+		return false
+	}
+	s.engaged = true
+	s.reason = reason
+	s.engagedAt = time.Now()
+	return true
+}
+
+// Engaged 是否已触发
+func (s *Switch) Engaged() bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engaged
+}
+
+// Allow 检查是否允许下单，触发后返回 ErrEngaged
+func (s *Switch) Allow() error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.engaged {
+		return fmt.Errorf("%w: %s", ErrEngaged, s.reason)
+	}
+	return nil
+}
+
+// AllowOrder 同 Allow，但降低风险的订单始终放行：reduceOnly 为只减仓单，
+// 或 ctx 经 Exempt 标记 (对冲已成交的订单、紧急平仓)。紧急停止时已成交的订单仍需对冲，仓位仍需能平掉
+func (s *Switch) AllowOrder(ctx context.Context, reduceOnly bool) error {
+	if reduceOnly || Exempted(ctx) {
+		return nil
+	}
+	return s.Allow()
+}
+
+type exemptKey struct{}
+
+// Exempt 标记 ctx 中的下单为降低风险的订单，紧急停止后仍然放行
+func Exempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, exemptKey{}, true)
+}
+
+// Exempted ctx 是否经 Exempt 标记
+func Exempted(ctx context.Context) bool {
+	exempt, _ := ctx.Value(exemptKey{}).(bool)
+	return exempt
+}
+
+// Status 获取状态快照
+func (s *Switch) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return Status{
+		Engaged:   s.engaged,
+		Reason:    s.reason,
+		EngagedAt: s.engagedAt,
+	}
+}
@@ -0,0 +1,154 @@
+package lighter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// accountPath 账户查询接口
+const accountPath = "/api/v1/account"
+
+type accountResponse struct {
+	apiResponse
+	Accounts []accountInfo `json:"accounts"`
+}
+
+type accountInfo struct {
+	TotalAssetValue  string `json:"total_asset_value"`
+	AvailableBalance string `json:"available_balance"` // 可用于开仓的保证金
+	Positions        []struct {
+		MarketID      int    `json:"market_id"`
+		Symbol        string `json:"symbol"`
+		Sign          int    `json:"sign"` // 1为多头，-1为空头
+		Position      string `json:"position"`
+		AvgEntryPrice string `json:"avg_entry_price"`
+		PositionValue string `json:"position_value"`
+
+		LiquidationPrice string `json:"liquidation_price"`
+	} `json:"positions"`
+}
+
+// Position Lighter持仓
+type Position struct {
+	MarketIndex uint8
+	Symbol      string
+	Size        float64 // 持仓数量 (正数做多，负数做空)
+	EntryPrice  float64 // 开仓均价
+	Value       float64 // 持仓价值 (按标记价格，带方向)
+
+	LiquidationPrice float64 // 强平价格 (0为交易所未提供)
+}
+
+// getAccount 查询当前账户信息
+func (c *Client) getAccount(ctx context.Context) (*accountInfo, error) {
+	query := url.Values{}
+	query.Set("by", "index")
+	query.Set("value", strconv.FormatInt(c.accountIndex, 10))
+
+	var result accountResponse
+	if err := c.getJSON(ctx, accountPath, query, &result); err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if err := result.err(); err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if len(result.Accounts) == 0 {
+		return nil, fmt.Errorf("account %d not found", c.accountIndex)
+	}
+
+	return &result.Accounts[0], nil
+}
+
+// GetAccountEquity 获取账户权益 (USDC)，即保证金加未实现盈亏
+func (c *Client) GetAccountEquity(ctx context.Context) (float64, error) {
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	equity, err := strconv.ParseFloat(account.TotalAssetValue, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse total asset value: %w", err)
+	}
+
+	c.logger.Debug("Fetched Lighter account equity", zap.Float64("equity", equity))
+
+	return equity, nil
+}
+
+// GetAvailableBalance 获取账户可用于开仓的保证金 (USDT)
+func (c *Client) GetAvailableBalance(ctx context.Context) (float64, error) {
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	available, err := strconv.ParseFloat(account.AvailableBalance, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse available balance: %w", err)
+	}
+
+	c.logger.Debug("Fetched Lighter available balance", zap.Float64("available", available))
+
+	return available, nil
+}
+
+// GetPositions 获取账户当前持仓，忽略数量为0的市场
+func (c *Client) GetPositions(ctx context.Context) ([]Position, error) {
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]Position, 0, len(account.Positions))
+	for _, p := range account.Positions {
+		size, err := strconv.ParseFloat(p.Position, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s position: %w", p.Symbol, err)
+		}
+		if size == 0 {
+			continue
+		}
+		entryPrice, err := strconv.ParseFloat(p.AvgEntryPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s entry price: %w", p.Symbol, err)
+		}
+		value, err := strconv.ParseFloat(p.PositionValue, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s position value: %w", p.Symbol, err)
+		}
+
+		var liquidationPrice float64
+		if p.LiquidationPrice != "" {
+			liquidationPrice, err = strconv.ParseFloat(p.LiquidationPrice, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s liquidation price: %w", p.Symbol, err)
+			}
+		}
+
+		// 接口返回的数量和价值为绝对值，方向由 sign 表示
+		size, value = math.Abs(size), math.Abs(value)
+		if p.Sign < 0 {
+			size, value = -size, -value
+		}
+
+		positions = append(positions, Position{
+			MarketIndex: uint8(p.MarketID),
+			Symbol:      p.Symbol,
+			Size:        size,
+			EntryPrice:  entryPrice,
+			Value:       value,
+
+			LiquidationPrice: liquidationPrice,
+		})
+	}
+
+	c.logger.Debug("Fetched Lighter positions", zap.Int("positions", len(positions)))
+
+	return positions, nil
+}
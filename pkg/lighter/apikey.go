@@ -0,0 +1,65 @@
+package lighter
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// apiKeysPath API密钥查询接口
+const apiKeysPath = "/api/v1/apikeys"
+
+type apiKeysResponse struct {
+	apiResponse
+	APIKeys []struct {
+		AccountIndex int64  `json:"account_index"`
+		APIKeyIndex  uint8  `json:"api_key_index"`
+		PublicKey    string `json:"public_key"`
+	} `json:"api_keys"`
+}
+
+// VerifyAPIKey 查询账户在当前网络登记的API公钥，与本地私钥对应的公钥比对。
+// 测试网密钥用在正式网 (或相反) 时账户不存在或公钥不一致，在下单前返回错误
+func (c *Client) VerifyAPIKey(ctx context.Context) error {
+	query := url.Values{}
+	query.Set("account_index", strconv.FormatInt(c.accountIndex, 10))
+	query.Set("api_key_index", strconv.Itoa(int(c.apiKeyIndex)))
+
+	var result apiKeysResponse
+	if err := c.getJSON(ctx, apiKeysPath, query, &result); err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	if err := result.err(); err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	for _, key := range result.APIKeys {
+		if key.AccountIndex != c.accountIndex || key.APIKeyIndex != c.apiKeyIndex {
+			continue
+		}
+
+		pubKey := c.signer.PubKeyBytes()
+		local := hex.EncodeToString(pubKey[:])
+		remote := strings.ToLower(strings.TrimPrefix(key.PublicKey, "0x"))
+		if remote != local {
+			return fmt.Errorf("api key %d of account %d does not match the configured private key (wrong network or rotated key?)",
+				c.apiKeyIndex, c.accountIndex)
+		}
+
+		c.logger.Info("Lighter API key verified",
+			zap.String("base_url", c.config.BaseURL),
+			zap.Uint32("chain_id", c.chainId),
+			zap.Int64("account_index", c.accountIndex),
+			zap.Uint8("api_key_index", c.apiKeyIndex),
+		)
+		return nil
+	}
+
+	return fmt.Errorf("api key %d of account %d not found on %s (wrong network?)",
+		c.apiKeyIndex, c.accountIndex, c.config.BaseURL)
+}
@@ -0,0 +1,170 @@
+package lighter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"go.uber.org/zap"
+
+	"github.com/elliottech/lighter-go/types/txtypes"
+)
+
+// sendTxBatchPath 批量提交已签名交易的接口
+const sendTxBatchPath = "/api/v1/sendTxBatch"
+
+// maxBatchTxs 单次批量提交的交易数上限
+const maxBatchTxs = 50
+
+type sendTxBatchResponse struct {
+	apiResponse
+	TxHash []string `json:"tx_hash"`
+}
+
+// ClosedPosition 批量平仓中已提交平仓单的持仓
+type ClosedPosition struct {
+	Position Position
+	Tx       *txtypes.L2CreateOrderTxInfo
+}
+
+// PlaceMarketOrders 批量下市价单：按连续nonce逐笔签名后一次提交，
+// 超过 maxBatchTxs 笔时分多次提交。返回的交易与 reqs 的前缀一一对应：
+// 某一批提交失败时返回之前各批已提交的交易和错误，调用方需要处理已提交的部分
+func (c *Client) PlaceMarketOrders(ctx context.Context, reqs []*MarketOrderRequest) ([]*txtypes.L2CreateOrderTxInfo, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	c.logger.Info("Creating market order batch", zap.Int("orders", len(reqs)))
+
+	reduceOnly := true
+	for _, req := range reqs {
+		reduceOnly = reduceOnly && req.ReduceOnly == 1
+	}
+	if err := c.killSwitch.AllowOrder(ctx, reduceOnly); err != nil {
+		return nil, err
+	}
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	details := make(map[uint8]*orderBookDetail)
+	baseAmounts := make([]int64, len(reqs))
+	for i, req := range reqs {
+		baseAmount, err := c.orderBaseAmount(ctx, req, details)
+		if err != nil {
+			return nil, err
+		}
+		baseAmounts[i] = baseAmount
+	}
+
+	nonce := c.reserveNonces(len(reqs))
+	txs := make([]*txtypes.L2CreateOrderTxInfo, len(reqs))
+	for i, req := range reqs {
+		tx, err := c.createOrderTransaction(req, baseAmounts[i], nonce+int64(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create order transaction for market %d: %w", req.MarketIndex, err)
+		}
+		txs[i] = tx
+	}
+
+	for start := 0; start < len(txs); start += maxBatchTxs {
+		end := min(start+maxBatchTxs, len(txs))
+		if err := c.sendTxBatch(ctx, txs[start:end]); err != nil {
+			c.logger.Error("Failed to submit market order batch",
+				zap.Error(err),
+				zap.Int("submitted", start),
+				zap.Int("orders", len(txs)),
+			)
+			return txs[:start], err
+		}
+	}
+
+	c.logger.Info("Market order batch submitted successfully", zap.Int("orders", len(txs)))
+
+	return txs, nil
+}
+
+// ClosePositions 按账户实际持仓以一批只减仓市价单平掉指定市场的仓位，没有持仓的市场跳过。
+// 部分提交失败时返回已提交平仓单的持仓和错误
+func (c *Client) ClosePositions(ctx context.Context, marketIndexes []uint8) ([]ClosedPosition, error) {
+	positions, err := c.GetPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[uint8]bool, len(marketIndexes))
+	for _, m := range marketIndexes {
+		wanted[m] = true
+	}
+
+	var closing []Position
+	var reqs []*MarketOrderRequest
+	for i := range positions {
+		pos := positions[i]
+		if !wanted[pos.MarketIndex] || pos.Size == 0 {
+			continue
+		}
+		req, err := c.closeOrderRequest(ctx, &pos)
+		if err != nil {
+			return nil, err
+		}
+		closing = append(closing, pos)
+		reqs = append(reqs, req)
+	}
+	if len(reqs) == 0 {
+		c.logger.Info("No positions to close")
+		return nil, nil
+	}
+
+	txs, err := c.PlaceMarketOrders(ctx, reqs)
+
+	closed := make([]ClosedPosition, len(txs))
+	for i, tx := range txs {
+		closed[i] = ClosedPosition{Position: closing[i], Tx: tx}
+	}
+	return closed, err
+}
+
+// sendTxBatch 一次提交多笔已签名交易
+func (c *Client) sendTxBatch(ctx context.Context, txs []*txtypes.L2CreateOrderTxInfo) error {
+	// []uint8 会被编码为base64字符串，交易类型按整数数组提交
+	txTypes := make([]int, len(txs))
+	infos := make([]string, len(txs))
+	for i, tx := range txs {
+		info, err := tx.GetTxInfo()
+		if err != nil {
+			return fmt.Errorf("failed to encode transaction: %w", err)
+		}
+		txTypes[i] = int(tx.GetTxType())
+		infos[i] = info
+	}
+
+	typesJSON, err := json.Marshal(txTypes)
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction types: %w", err)
+	}
+	infosJSON, err := json.Marshal(infos)
+	if err != nil {
+		return fmt.Errorf("failed to encode transactions: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("tx_types", string(typesJSON))
+	form.Set("tx_infos", string(infosJSON))
+
+	var result sendTxBatchResponse
+	if err := c.postForm(ctx, sendTxBatchPath, form, &result); err != nil {
+		return fmt.Errorf("failed to send transaction batch: %w", err)
+	}
+	if err := result.err(); err != nil {
+		return fmt.Errorf("failed to send transaction batch: %w", err)
+	}
+
+	c.logger.Debug("Transaction batch sent",
+		zap.Int("txs", len(txs)),
+		zap.Strings("tx_hashes", result.TxHash),
+	)
+	return nil
+}
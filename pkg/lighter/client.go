@@ -4,12 +4,19 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"net/http"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	"cs-projects-backpack/pkg/breaker"
 	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/killswitch"
 	"cs-projects-backpack/pkg/logger"
+	"cs-projects-backpack/pkg/retry"
+	"cs-projects-backpack/pkg/transport"
 
 	"github.com/elliottech/lighter-go/signer"
 	"github.com/elliottech/lighter-go/types"
@@ -17,26 +24,33 @@ import (
 )
 
 type Client struct {
-	signer       signer.Signer
+	signer       signer.KeyManager
 	config       *config.LighterConfig
 	chainId      uint32
 	accountIndex int64
 	apiKeyIndex  uint8
+	httpClient   *http.Client
+	retryPolicy  retry.Policy       // REST接口重试策略
+	queryTimeout time.Duration      // 查询的单次请求超时 (0为不限制)
+	orderTimeout time.Duration      // 提交交易的单次请求超时 (0为不限制)
+	breaker      *breaker.Breaker   // 连续失败熔断 (nil为不启用)
+	killSwitch   *killswitch.Switch // 紧急停止开关 (nil为不启用)
 	logger       *zap.Logger
+
+	// 交易nonce按毫秒时间戳分配，并发下单时保证严格递增不重复
+	nonceMu   sync.Mutex
+	lastNonce int64
 }
 
 type MarketOrderRequest struct {
 	MarketIndex uint8
-	USDTAmount  int64 // USDT数量
-	Leverage    int   // 杠杆倍数
+	USDTAmount  int64 // USDT名义金额，按标记价格换算为下单数量
+	Leverage    int   // 杠杆倍数 (仅用于日志，保证金由账户杠杆设置决定，不影响下单数量)
 	IsAsk       uint8 // 0=买入(做多), 1=卖出(做空)
+	BaseAmount  int64 // 基础资产数量 (市场最小单位)，大于0时直接使用，忽略USDTAmount
+	ReduceOnly  uint8 // 1=只减仓 (平仓订单)
 }
 
-const (
-	BTCMarketIndex uint8 = 0
-	ETHMarketIndex uint8 = 1
-)
-
 func NewClient(cfg *config.LighterConfig) (*Client, error) {
 	log := logger.Named("lighter-client")
 
@@ -63,6 +77,14 @@ func NewClient(cfg *config.LighterConfig) (*Client, error) {
 		return nil, fmt.Errorf("failed to create signer: %w", err)
 	}
 
+	httpClient, err := transport.NewHTTPClient(&cfg.Transport, "lighter")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Lighter HTTP client: %w", err)
+	}
+	if proxy, _ := transport.ProxyURL(&cfg.Transport); proxy != nil {
+		log.Info("Using proxy for Lighter", zap.String("proxy", proxy.Redacted()))
+	}
+
 	log.Info("Lighter client initialized",
 		zap.String("base_url", cfg.BaseURL),
 		zap.Uint32("chain_id", cfg.ChainID),
@@ -76,36 +98,75 @@ func NewClient(cfg *config.LighterConfig) (*Client, error) {
 		chainId:      cfg.ChainID,
 		accountIndex: cfg.AccountIndex,
 		apiKeyIndex:  cfg.APIKeyIndex,
+		httpClient:   httpClient,
+		retryPolicy:  retry.DefaultPolicy(),
+		queryTimeout: retry.DefaultQueryTimeout,
+		orderTimeout: retry.DefaultOrderTimeout,
 		logger:       log,
 	}, nil
 }
 
-func (c *Client) createOrderTransaction(req *MarketOrderRequest) (*txtypes.L2CreateOrderTxInfo, error) {
-	now := time.Now()
-	nonce := now.UnixMilli()
-	expiredAt := now.Add(30 * time.Minute).UnixMilli()
+// reserveNonces 分配 n 个连续nonce，返回第一个：取当前毫秒时间戳，不大于上次分配的值时顺延
+func (c *Client) reserveNonces(n int) int64 {
+	c.nonceMu.Lock()
+	defer c.nonceMu.Unlock()
+
+	nonce := time.Now().UnixMilli()
+	if nonce <= c.lastNonce {
+		nonce = c.lastNonce + 1
+	}
+	c.lastNonce = nonce + int64(n) - 1
+	return nonce
+}
+
+// orderBaseAmount 返回订单的基础资产数量：指定了 BaseAmount 时直接使用，
+// 否则按市场标记价格将 USDTAmount 换算为币数量，再按数量精度换算 (向下取整)。
+// details 缓存同一批订单已查询的市场详情，可为nil
+func (c *Client) orderBaseAmount(ctx context.Context, req *MarketOrderRequest, details map[uint8]*orderBookDetail) (int64, error) {
+	if req.BaseAmount > 0 {
+		return req.BaseAmount, nil
+	}
+	if req.USDTAmount <= 0 {
+		return 0, fmt.Errorf("invalid order amount %d for market %d", req.USDTAmount, req.MarketIndex)
+	}
+
+	detail := details[req.MarketIndex]
+	if detail == nil {
+		var err error
+		detail, err = c.getMarketDetail(ctx, req.MarketIndex)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get mark price for market %d: %w", req.MarketIndex, err)
+		}
+		if details != nil {
+			details[req.MarketIndex] = detail
+		}
+	}
+
+	return detail.notionalBaseAmount(req.USDTAmount)
+}
 
-	// 计算基础资产数量 (USDT * 杠杆倍数)
-	// 注意：这里的计算可能需要根据Lighter的实际单位进行调整
-	leveragedAmount := req.USDTAmount * int64(req.Leverage)
+// createOrderTransaction 构造并签名市价单交易，nonce 同时作为客户端订单编号
+func (c *Client) createOrderTransaction(req *MarketOrderRequest, baseAmount, nonce int64) (*txtypes.L2CreateOrderTxInfo, error) {
+	expiredAt := time.Now().Add(30 * time.Minute).UnixMilli()
 
 	c.logger.Debug("Creating order transaction",
 		zap.Uint8("market_index", req.MarketIndex),
 		zap.Int64("usdt_amount", req.USDTAmount),
 		zap.Int("leverage", req.Leverage),
-		zap.Int64("leveraged_amount", leveragedAmount),
+		zap.Int64("base_amount", baseAmount),
 		zap.Uint8("is_ask", req.IsAsk),
+		zap.Uint8("reduce_only", req.ReduceOnly),
 	)
 
 	createOrderReq := &types.CreateOrderTxReq{
 		MarketIndex:      req.MarketIndex,
 		ClientOrderIndex: nonce,
-		BaseAmount:       leveragedAmount,       // 使用杠杆后的数量
+		BaseAmount:       baseAmount,
 		Price:            txtypes.NilOrderPrice, // 市价单无需指定价格
 		IsAsk:            req.IsAsk,
 		Type:             txtypes.MarketOrder,
 		TimeInForce:      txtypes.ImmediateOrCancel,
-		ReduceOnly:       0, // 开仓订单
+		ReduceOnly:       req.ReduceOnly,
 		TriggerPrice:     txtypes.NilOrderTriggerPrice,
 		OrderExpiry:      txtypes.NilOrderExpiry,
 	}
@@ -121,6 +182,7 @@ func (c *Client) createOrderTransaction(req *MarketOrderRequest) (*txtypes.L2Cre
 	return types.ConstructCreateOrderTx(c.signer, c.chainId, createOrderReq, transactOpts)
 }
 
+// PlaceMarketOrder 签名并提交一笔市价单，交易所接受提交后才返回交易
 func (c *Client) PlaceMarketOrder(ctx context.Context, req *MarketOrderRequest) (*txtypes.L2CreateOrderTxInfo, error) {
 	c.logger.Info("Creating market order",
 		zap.Uint8("market_index", req.MarketIndex),
@@ -129,7 +191,19 @@ func (c *Client) PlaceMarketOrder(ctx context.Context, req *MarketOrderRequest)
 		zap.Uint8("is_ask", req.IsAsk),
 	)
 
-	orderTx, err := c.createOrderTransaction(req)
+	if err := c.killSwitch.AllowOrder(ctx, req.ReduceOnly == 1); err != nil {
+		return nil, err
+	}
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	baseAmount, err := c.orderBaseAmount(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	orderTx, err := c.createOrderTransaction(req, baseAmount, c.reserveNonces(1))
 	if err != nil {
 		c.logger.Error("Failed to create order transaction",
 			zap.Error(err),
@@ -138,7 +212,16 @@ func (c *Client) PlaceMarketOrder(ctx context.Context, req *MarketOrderRequest)
 		return nil, fmt.Errorf("failed to create order transaction: %w", err)
 	}
 
-	c.logger.Info("Market order created successfully",
+	if err := c.sendTxBatch(ctx, []*txtypes.L2CreateOrderTxInfo{orderTx}); err != nil {
+		c.logger.Error("Failed to submit market order",
+			zap.Error(err),
+			zap.Uint8("market_index", req.MarketIndex),
+			zap.String("tx_hash", orderTx.GetTxHash()),
+		)
+		return nil, err
+	}
+
+	c.logger.Info("Market order submitted successfully",
 		zap.String("tx_hash", orderTx.GetTxHash()),
 		zap.Uint8("market_index", req.MarketIndex),
 		zap.Int64("usdt_amount", req.USDTAmount),
@@ -148,14 +231,16 @@ func (c *Client) PlaceMarketOrder(ctx context.Context, req *MarketOrderRequest)
 	return orderTx, nil
 }
 
-func (c *Client) PlaceBTCLong(ctx context.Context, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error) {
-	c.logger.Info("Placing BTC long order",
+// PlaceLong 在指定市场按USDT金额开多
+func (c *Client) PlaceLong(ctx context.Context, marketIndex uint8, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error) {
+	c.logger.Info("Placing long order",
+		zap.Uint8("market_index", marketIndex),
 		zap.Int64("usdt_amount", usdtAmount),
 		zap.Int("leverage", leverage),
 	)
 
 	req := &MarketOrderRequest{
-		MarketIndex: BTCMarketIndex,
+		MarketIndex: marketIndex,
 		USDTAmount:  usdtAmount,
 		Leverage:    leverage,
 		IsAsk:       0, // 0 = 买入(做多)
@@ -164,14 +249,16 @@ func (c *Client) PlaceBTCLong(ctx context.Context, usdtAmount int64, leverage in
 	return c.PlaceMarketOrder(ctx, req)
 }
 
-func (c *Client) PlaceETHShort(ctx context.Context, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error) {
-	c.logger.Info("Placing ETH short order",
+// PlaceShort 在指定市场按USDT金额开空
+func (c *Client) PlaceShort(ctx context.Context, marketIndex uint8, usdtAmount int64, leverage int) (*txtypes.L2CreateOrderTxInfo, error) {
+	c.logger.Info("Placing short order",
+		zap.Uint8("market_index", marketIndex),
 		zap.Int64("usdt_amount", usdtAmount),
 		zap.Int("leverage", leverage),
 	)
 
 	req := &MarketOrderRequest{
-		MarketIndex: ETHMarketIndex,
+		MarketIndex: marketIndex,
 		USDTAmount:  usdtAmount,
 		Leverage:    leverage,
 		IsAsk:       1, // 1 = 卖出(做空)
@@ -179,3 +266,34 @@ func (c *Client) PlaceETHShort(ctx context.Context, usdtAmount int64, leverage i
 
 	return c.PlaceMarketOrder(ctx, req)
 }
+
+// closeOrderRequest 按持仓构造只减仓市价单
+func (c *Client) closeOrderRequest(ctx context.Context, pos *Position) (*MarketOrderRequest, error) {
+	baseAmount, err := c.baseAmount(ctx, pos.MarketIndex, math.Abs(pos.Size))
+	if err != nil {
+		return nil, err
+	}
+	if baseAmount <= 0 {
+		return nil, fmt.Errorf("position size %f too small to close in market %d", pos.Size, pos.MarketIndex)
+	}
+
+	// 多头卖出平仓，空头买入平仓
+	var isAsk uint8
+	if pos.Size > 0 {
+		isAsk = 1
+	}
+
+	c.logger.Warn("Closing position",
+		zap.Uint8("market_index", pos.MarketIndex),
+		zap.String("symbol", pos.Symbol),
+		zap.Float64("size", pos.Size),
+		zap.Int64("base_amount", baseAmount),
+	)
+
+	return &MarketOrderRequest{
+		MarketIndex: pos.MarketIndex,
+		IsAsk:       isAsk,
+		BaseAmount:  baseAmount,
+		ReduceOnly:  1,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package lighter
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// fundingRatesPath 资金费率查询接口
+const fundingRatesPath = "/api/v1/funding-rates"
+
+type fundingRatesResponse struct {
+	apiResponse
+	FundingRates []struct {
+		MarketID int     `json:"market_id"`
+		Exchange string  `json:"exchange"`
+		Symbol   string  `json:"symbol"`
+		Rate     float64 `json:"rate"`
+	} `json:"funding_rates"`
+}
+
+// GetFundingRates 获取Lighter各市场当前资金费率 (每小时结算一次)，返回 symbol -> rate
+func (c *Client) GetFundingRates(ctx context.Context) (map[string]float64, error) {
+	var result fundingRatesResponse
+	if err := c.getJSON(ctx, fundingRatesPath, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to get funding rates: %w", err)
+	}
+	if err := result.err(); err != nil {
+		return nil, fmt.Errorf("failed to get funding rates: %w", err)
+	}
+
+	rates := make(map[string]float64)
+	for _, fr := range result.FundingRates {
+		if fr.Exchange != "" && fr.Exchange != "lighter" {
+			continue
+		}
+		rates[fr.Symbol] = fr.Rate
+	}
+
+	c.logger.Debug("Fetched Lighter funding rates", zap.Int("markets", len(rates)))
+
+	return rates, nil
+}
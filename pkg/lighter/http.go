@@ -0,0 +1,130 @@
+package lighter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"cs-projects-backpack/pkg/breaker"
+	"cs-projects-backpack/pkg/killswitch"
+	"cs-projects-backpack/pkg/retry"
+)
+
+// apiResponse Lighter REST接口的通用返回字段
+type apiResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (r *apiResponse) err() error {
+	if r.Code != 0 && r.Code != http.StatusOK {
+		return fmt.Errorf("%s (code %d)", r.Message, r.Code)
+	}
+	return nil
+}
+
+// SetRetryPolicy 设置REST接口重试策略
+func (c *Client) SetRetryPolicy(p retry.Policy) {
+	c.retryPolicy = p
+}
+
+// SetRequestTimeouts 设置单次请求超时，超时的请求被中断，不会阻塞对冲路径，按重试策略重试
+func (c *Client) SetRequestTimeouts(order, query time.Duration) {
+	c.orderTimeout = order
+	c.queryTimeout = query
+}
+
+// SetCircuitBreaker 设置熔断器，连续失败达到阈值后暂停下单
+func (c *Client) SetCircuitBreaker(b *breaker.Breaker) {
+	c.breaker = b
+}
+
+// SetKillSwitch 设置紧急停止开关，触发后拒绝所有下单
+func (c *Client) SetKillSwitch(s *killswitch.Switch) {
+	c.killSwitch = s
+}
+
+// getJSON 请求Lighter REST接口并解析JSON结果，网络错误、429和5xx按重试策略重试
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, result interface{}) error {
+	err := retry.Do(ctx, c.retryPolicy, "lighter "+path, func(ctx context.Context) error {
+		_, err := retry.WithTimeout(ctx, c.queryTimeout, func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, c.doGetJSON(ctx, path, query, result)
+		})
+		return err
+	})
+	c.recordResult(ctx, err)
+	return err
+}
+
+// recordResult 计入熔断器：网络错误、超时、429和5xx计为失败
+func (c *Client) recordResult(ctx context.Context, err error) {
+	switch {
+	case err == nil:
+		c.breaker.Success()
+	case ctx.Err() != nil:
+	case retry.IsRetryable(err):
+		c.breaker.Failure(err)
+	default:
+		c.breaker.Success()
+	}
+}
+
+// doGetJSON 执行单次GET请求
+func (c *Client) doGetJSON(ctx context.Context, path string, query url.Values, result interface{}) error {
+	endpoint := strings.TrimRight(c.config.BaseURL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+
+	return c.doJSON(req, path, result)
+}
+
+// postForm 以表单提交Lighter REST接口并解析JSON结果。仅用于提交已签名交易：
+// 交易按nonce去重，重复提交不会重复成交，因此与GET请求一样按重试策略重试
+func (c *Client) postForm(ctx context.Context, path string, form url.Values, result interface{}) error {
+	err := retry.Do(ctx, c.retryPolicy, "lighter "+path, func(ctx context.Context) error {
+		_, err := retry.WithTimeout(ctx, c.orderTimeout, func(ctx context.Context) (struct{}, error) {
+			endpoint := strings.TrimRight(c.config.BaseURL, "/") + path
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+			if err != nil {
+				return struct{}{}, retry.Permanent(fmt.Errorf("failed to create request: %w", err))
+			}
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return struct{}{}, c.doJSON(req, path, result)
+		})
+		return err
+	})
+	c.recordResult(ctx, err)
+	return err
+}
+
+// doJSON 发送请求并解析JSON结果
+func (c *Client) doJSON(req *http.Request, path string, result interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed: %w", path, &retry.StatusError{
+			StatusCode: resp.StatusCode,
+			Message:    http.StatusText(resp.StatusCode),
+		})
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package lighter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// orderBookDetailsPath 市场详情查询接口
+const orderBookDetailsPath = "/api/v1/orderBookDetails"
+
+type orderBookDetailsResponse struct {
+	apiResponse
+	OrderBookDetails []orderBookDetail `json:"order_book_details"`
+}
+
+type orderBookDetail struct {
+	MarketID       int     `json:"market_id"`
+	Symbol         string  `json:"symbol"`
+	SizeDecimals   int     `json:"size_decimals"` // 下单数量精度，BaseAmount = 数量 * 10^SizeDecimals
+	LastTradePrice float64 `json:"last_trade_price"`
+	MarkPrice      float64 `json:"mark_price"`
+	IndexPrice     float64 `json:"index_price"`
+}
+
+// getMarketDetail 查询市场详情
+func (c *Client) getMarketDetail(ctx context.Context, marketIndex uint8) (*orderBookDetail, error) {
+	query := url.Values{}
+	query.Set("market_id", strconv.Itoa(int(marketIndex)))
+
+	var result orderBookDetailsResponse
+	if err := c.getJSON(ctx, orderBookDetailsPath, query, &result); err != nil {
+		return nil, err
+	}
+	if err := result.err(); err != nil {
+		return nil, err
+	}
+
+	for i := range result.OrderBookDetails {
+		if result.OrderBookDetails[i].MarketID == int(marketIndex) {
+			return &result.OrderBookDetails[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("market %d not found", marketIndex)
+}
+
+// GetLastPrice 获取永续合约市场的最新成交价
+func (c *Client) GetLastPrice(ctx context.Context, marketIndex uint8) (float64, error) {
+	detail, err := c.getMarketDetail(ctx, marketIndex)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get price for market %d: %w", marketIndex, err)
+	}
+	if detail.LastTradePrice <= 0 {
+		return 0, fmt.Errorf("no price data for market %d", marketIndex)
+	}
+
+	return detail.LastTradePrice, nil
+}
+
+// GetMarkIndexPrice 获取永续合约市场的标记价格和指数价格
+func (c *Client) GetMarkIndexPrice(ctx context.Context, marketIndex uint8) (mark, index float64, err error) {
+	detail, err := c.getMarketDetail(ctx, marketIndex)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get mark price for market %d: %w", marketIndex, err)
+	}
+	if detail.MarkPrice <= 0 || detail.IndexPrice <= 0 {
+		return 0, 0, fmt.Errorf("no mark/index price data for market %d", marketIndex)
+	}
+
+	return detail.MarkPrice, detail.IndexPrice, nil
+}
+
+// baseAmount 按市场数量精度将币数量换算为下单的基础资产数量 (向下取整，避免超过持仓)
+func (c *Client) baseAmount(ctx context.Context, marketIndex uint8, size float64) (int64, error) {
+	detail, err := c.getMarketDetail(ctx, marketIndex)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get size decimals for market %d: %w", marketIndex, err)
+	}
+
+	return detail.baseAmount(size), nil
+}
+
+// baseAmount 按数量精度将币数量换算为基础资产数量 (向下取整)
+func (d *orderBookDetail) baseAmount(size float64) int64 {
+	return int64(math.Floor(size*math.Pow10(d.SizeDecimals) + 1e-9))
+}
+
+// notionalBaseAmount 按标记价格将USDT名义金额换算为基础资产数量
+func (d *orderBookDetail) notionalBaseAmount(notional int64) (int64, error) {
+	if d.MarkPrice <= 0 {
+		return 0, fmt.Errorf("no mark price for market %d", d.MarketID)
+	}
+	baseAmount := d.baseAmount(float64(notional) / d.MarkPrice)
+	if baseAmount <= 0 {
+		return 0, fmt.Errorf("notional %d too small for market %d at mark price %f", notional, d.MarketID, d.MarkPrice)
+	}
+	return baseAmount, nil
+}
+
+// orderBookOrdersPath 盘口挂单查询接口
+const orderBookOrdersPath = "/api/v1/orderBookOrders"
+
+// depthLimit 盘口查询档数
+const depthLimit = 100
+
+type orderBookOrdersResponse struct {
+	apiResponse
+	Asks []bookOrder `json:"asks"`
+	Bids []bookOrder `json:"bids"`
+}
+
+type bookOrder struct {
+	Price               string `json:"price"`
+	RemainingBaseAmount string `json:"remaining_base_amount"`
+}
+
+// GetDepthNotional 获取距最优价 withinPercent 范围内的挂单名义金额。
+// side 为BUY时统计卖盘 (买单吃掉的流动性)，为SELL时统计买盘。
+func (c *Client) GetDepthNotional(ctx context.Context, marketIndex uint8, side string, withinPercent float64) (float64, error) {
+	levels, err := c.depthLevels(ctx, marketIndex, side)
+	if err != nil {
+		return 0, err
+	}
+
+	best := levels[0].price
+	var notional float64
+	for _, l := range levels {
+		if math.Abs(l.price-best)/best*100 > withinPercent {
+			break
+		}
+		notional += l.price * l.quantity
+	}
+
+	return notional, nil
+}
+
+// EstimateMarketFill 按当前盘口 (前100笔挂单) 估算市价单逐档成交的均价，返回最优价和成交均价。
+// side 为市价单方向，quantity 为基础资产数量，盘口深度不足以成交 quantity 时返回错误
+func (c *Client) EstimateMarketFill(ctx context.Context, marketIndex uint8, side string, quantity float64) (best, avg float64, err error) {
+	levels, err := c.depthLevels(ctx, marketIndex, side)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	best = levels[0].price
+	var filled, cost float64
+	for _, l := range levels {
+		take := math.Min(l.quantity, quantity-filled)
+		filled += take
+		cost += take * l.price
+		if filled >= quantity {
+			return best, cost / filled, nil
+		}
+	}
+
+	return best, 0, fmt.Errorf("insufficient depth for market %d: %.8f of %.8f", marketIndex, filled, quantity)
+}
+
+// depthLevel 盘口价位
+type depthLevel struct{ price, quantity float64 }
+
+// depthLevels 获取市价单 side 方向会吃掉的盘口，按价格从优到劣排序：BUY为卖盘 (价格升序)，SELL为买盘 (价格降序)
+func (c *Client) depthLevels(ctx context.Context, marketIndex uint8, side string) ([]depthLevel, error) {
+	query := url.Values{}
+	query.Set("market_id", strconv.Itoa(int(marketIndex)))
+	query.Set("limit", strconv.Itoa(depthLimit))
+
+	var result orderBookOrdersResponse
+	if err := c.getJSON(ctx, orderBookOrdersPath, query, &result); err != nil {
+		return nil, fmt.Errorf("failed to get depth for market %d: %w", marketIndex, err)
+	}
+	if err := result.err(); err != nil {
+		return nil, fmt.Errorf("failed to get depth for market %d: %w", marketIndex, err)
+	}
+
+	orders := result.Bids
+	if side == "BUY" {
+		orders = result.Asks
+	}
+
+	levels := make([]depthLevel, 0, len(orders))
+	for _, o := range orders {
+		price, err := strconv.ParseFloat(o.Price, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse depth price: %w", err)
+		}
+		quantity, err := strconv.ParseFloat(o.RemainingBaseAmount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse depth quantity: %w", err)
+		}
+		if price <= 0 || quantity <= 0 {
+			continue
+		}
+		levels = append(levels, depthLevel{price, quantity})
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("empty order book for market %d", marketIndex)
+	}
+
+	// 卖盘最优价为最低价，买盘为最高价
+	sort.SliceStable(levels, func(i, j int) bool {
+		if side == "BUY" {
+			return levels[i].price < levels[j].price
+		}
+		return levels[i].price > levels[j].price
+	})
+	return levels, nil
+}
@@ -0,0 +1,116 @@
+package lighter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/elliottech/lighter-go/types"
+)
+
+// activeOrdersPath 账户挂单查询接口 (需要认证令牌)
+const activeOrdersPath = "/api/v1/accountActiveOrders"
+
+// authTokenTTL 认证令牌有效期
+const authTokenTTL = 10 * time.Minute
+
+type activeOrdersResponse struct {
+	apiResponse
+	Orders []struct {
+		OrderIndex          int64  `json:"order_index"`
+		ClientOrderIndex    int64  `json:"client_order_index"`
+		MarketIndex         int    `json:"market_index"`
+		InitialBaseAmount   string `json:"initial_base_amount"`
+		RemainingBaseAmount string `json:"remaining_base_amount"`
+		Price               string `json:"price"`
+		IsAsk               bool   `json:"is_ask"`
+		Type                string `json:"type"`
+	} `json:"orders"`
+}
+
+// OpenOrder Lighter上未完成的挂单
+type OpenOrder struct {
+	OrderIndex       int64
+	ClientOrderIndex int64
+	MarketIndex      uint8
+	Side             string  // BUY, SELL
+	Type             string  // limit, market, stop-loss, ...
+	Price            float64 // 挂单价格
+	Size             float64 // 下单数量 (币)
+	RemainingSize    float64 // 未成交数量 (币)
+}
+
+// authToken 生成只读接口使用的认证令牌
+func (c *Client) authToken() (string, error) {
+	token, err := types.ConstructAuthToken(c.signer, time.Now().Add(authTokenTTL), &types.TransactOpts{
+		FromAccountIndex: &c.accountIndex,
+		ApiKeyIndex:      &c.apiKeyIndex,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create auth token: %w", err)
+	}
+	return token, nil
+}
+
+// GetOpenOrders 获取账户在市场上的全部挂单 (包括非本进程下的订单)
+func (c *Client) GetOpenOrders(ctx context.Context, marketIndex uint8) ([]OpenOrder, error) {
+	token, err := c.authToken()
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("account_index", strconv.FormatInt(c.accountIndex, 10))
+	query.Set("market_id", strconv.Itoa(int(marketIndex)))
+	query.Set("auth", token)
+
+	var result activeOrdersResponse
+	if err := c.getJSON(ctx, activeOrdersPath, query, &result); err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+	if err := result.err(); err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	orders := make([]OpenOrder, 0, len(result.Orders))
+	for _, o := range result.Orders {
+		price, err := strconv.ParseFloat(o.Price, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse order %d price: %w", o.OrderIndex, err)
+		}
+		size, err := strconv.ParseFloat(o.InitialBaseAmount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse order %d size: %w", o.OrderIndex, err)
+		}
+		remaining, err := strconv.ParseFloat(o.RemainingBaseAmount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse order %d remaining size: %w", o.OrderIndex, err)
+		}
+
+		side := "BUY"
+		if o.IsAsk {
+			side = "SELL"
+		}
+		orders = append(orders, OpenOrder{
+			OrderIndex:       o.OrderIndex,
+			ClientOrderIndex: o.ClientOrderIndex,
+			MarketIndex:      uint8(o.MarketIndex),
+			Side:             side,
+			Type:             o.Type,
+			Price:            price,
+			Size:             size,
+			RemainingSize:    remaining,
+		})
+	}
+
+	c.logger.Debug("Fetched Lighter open orders",
+		zap.Uint8("market_index", marketIndex),
+		zap.Int("orders", len(orders)),
+	)
+
+	return orders, nil
+}
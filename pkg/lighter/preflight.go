@@ -0,0 +1,30 @@
+package lighter
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Preflight 启动前检查账户和签名密钥：账户索引在当前网络存在，
+// 并用私钥签发的认证令牌查询 marketIndex 市场的挂单，确认服务端接受该API密钥
+func (c *Client) Preflight(ctx context.Context, marketIndex uint8) error {
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		return fmt.Errorf("%w (check lighter.account_index and lighter.testnet)", err)
+	}
+
+	if _, err := c.GetOpenOrders(ctx, marketIndex); err != nil {
+		return fmt.Errorf("signed request rejected for account %d api key %d: %w (check lighter.private_key and lighter.api_key_index)",
+			c.accountIndex, c.apiKeyIndex, err)
+	}
+
+	c.logger.Info("Lighter preflight passed",
+		zap.String("base_url", c.config.BaseURL),
+		zap.Int64("account_index", c.accountIndex),
+		zap.Uint8("api_key_index", c.apiKeyIndex),
+		zap.String("total_asset_value", account.TotalAssetValue),
+	)
+	return nil
+}
@@ -70,6 +70,11 @@ func GetLogger() *zap.Logger {
 	return globalLogger
 }
 
+// Initialized 检查全局日志是否已初始化
+func Initialized() bool {
+	return globalLogger != nil
+}
+
 func Sync() {
 	if globalLogger != nil {
 		_ = globalLogger.Sync()
@@ -0,0 +1,40 @@
+// Package metrics 维护进程内的Prometheus指标注册表，管理API通过 /metrics 导出。
+package metrics
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// registry 独立注册表，只包含本进程注册的指标和Go运行时、进程指标
+var registry = newRegistry()
+
+func newRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return r
+}
+
+// Register 注册指标。同名指标已注册时 (如策略重启后重新创建) 先注销旧指标再注册
+func Register(c prometheus.Collector) error {
+	err := registry.Register(c)
+
+	var existing prometheus.AlreadyRegisteredError
+	if errors.As(err, &existing) {
+		registry.Unregister(existing.ExistingCollector)
+		err = registry.Register(c)
+	}
+	return err
+}
+
+// Handler 以Prometheus文本格式导出全部指标
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
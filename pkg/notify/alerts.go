@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// Rule 单个事件类型的告警规则
+type Rule struct {
+	Disabled    bool          // 不通知该事件
+	Severity    Severity      // 覆盖事件的默认严重程度 (为空时不覆盖)
+	DedupWindow time.Duration // 相同告警的去重窗口 (0为使用默认窗口)
+	Escalations []Escalation  // 按重复次数升级，按 Count 从小到大排列
+}
+
+// Escalation 升级策略：去重窗口内同一告警第 Count 次出现时以 Severity 重新发送
+type Escalation struct {
+	Count    int
+	Severity Severity
+}
+
+// alertState 同一告警 (事件类型、Key 和标题相同) 的发送情况
+type alertState struct {
+	key        string    // 消息的 Key，解除告警时按它清理
+	first      time.Time // 本轮首次出现时间
+	last       time.Time // 最近出现时间
+	sent       time.Time // 最近发送时间
+	severity   Severity  // 本轮最近发送的严重程度
+	count      int       // 本轮出现次数
+	suppressed int       // 最近发送后被合并的次数
+}
+
+// AlertManager 告警管理：按事件类型的规则确定严重程度，去重窗口内的相同告警只发送一次，
+// 重复次数达到升级策略时提高严重程度立即发送。距上次出现超过去重窗口后重新开始计数
+type AlertManager struct {
+	notifier *Notifier
+	window   time.Duration   // 默认去重窗口 (0为不去重)
+	rules    map[string]Rule // 事件类型 -> 规则
+
+	mu     sync.Mutex
+	alerts map[string]*alertState
+
+	logger *zap.Logger
+}
+
+// NewAlertManager 创建告警管理，window 为默认去重窗口，rules 按事件类型索引
+func NewAlertManager(notifier *Notifier, window time.Duration, rules map[string]Rule) *AlertManager {
+	return &AlertManager{
+		notifier: notifier,
+		window:   window,
+		rules:    rules,
+		alerts:   make(map[string]*alertState),
+		logger:   logger.Named("alerts"),
+	}
+}
+
+// Fire 按规则处理告警后提交到通知渠道。解除告警的消息直接发送，并清除同一 Key 的告警状态
+func (m *AlertManager) Fire(msg Message) {
+	rule := m.rules[msg.Event]
+	if rule.Disabled {
+		return
+	}
+	if rule.Severity != "" {
+		msg.Severity = rule.Severity
+	}
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+
+	if msg.Resolve {
+		m.resolve(msg.Key)
+		m.notifier.Notify(msg)
+		return
+	}
+
+	window := rule.DedupWindow
+	if window == 0 {
+		window = m.window
+	}
+	if window <= 0 {
+		m.notifier.Notify(msg)
+		return
+	}
+
+	if send, ok := m.track(msg, rule, window); ok {
+		m.notifier.Notify(send)
+	}
+}
+
+// track 更新告警状态，返回要发送的消息 (可能已升级或附带合并次数)；被合并时返回false
+func (m *AlertManager) track(msg Message, rule Rule, window time.Duration) (Message, bool) {
+	id := msg.Event + "\x00" + msg.Key + "\x00" + msg.Title
+	now := msg.Time
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prune(now)
+
+	st, ok := m.alerts[id]
+	switch {
+	case !ok:
+		st = &alertState{key: msg.Key, first: now}
+		m.alerts[id] = st
+	case now.Sub(st.last) >= window:
+		// 新一轮重新计数，上一轮被合并的次数在本次发送时带上
+		st.first, st.count, st.severity = now, 0, ""
+	}
+	st.last = now
+	st.count++
+
+	severity := msg.Severity
+	for _, esc := range rule.Escalations {
+		if st.count >= esc.Count && esc.Severity.rank() > severity.rank() {
+			severity = esc.Severity
+		}
+	}
+
+	escalated := !st.sent.IsZero() && severity.rank() > st.severity.rank()
+	if !st.sent.IsZero() && now.Sub(st.sent) < window && !escalated {
+		st.suppressed++
+		m.logger.Debug("Alert suppressed",
+			zap.String("event", msg.Event),
+			zap.String("title", msg.Title),
+			zap.Int("count", st.count),
+		)
+		return Message{}, false
+	}
+
+	if severity != msg.Severity {
+		m.logger.Warn("Alert escalated",
+			zap.String("event", msg.Event),
+			zap.String("title", msg.Title),
+			zap.String("from", string(msg.Severity)),
+			zap.String("to", string(severity)),
+			zap.Int("count", st.count),
+		)
+		msg.Title = "[ESCALATED] " + msg.Title
+		msg.Body += fmt.Sprintf("\noccurred %d times since %s\n", st.count, st.first.Format("2006-01-02 15:04:05 MST"))
+		msg.Severity = severity
+	}
+	if st.suppressed > 0 {
+		msg.Body += fmt.Sprintf("\n%d identical alerts suppressed since %s\n", st.suppressed, st.sent.Format("2006-01-02 15:04:05 MST"))
+	}
+
+	st.sent = now
+	st.severity = severity
+	st.suppressed = 0
+	return msg, true
+}
+
+// resolve 清除 Key 对应的告警状态，之后再次触发时重新发送
+func (m *AlertManager) resolve(key string) {
+	if key == "" {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, st := range m.alerts {
+		if st.key == key {
+			delete(m.alerts, id)
+		}
+	}
+}
+
+// prune 清理超过去重窗口不再出现、也没有待报告的合并次数的告警状态 (调用方持有锁)。
+// 规则可能设置更长的窗口，按所有窗口中的最大值判断
+func (m *AlertManager) prune(now time.Time) {
+	longest := m.window
+	for _, rule := range m.rules {
+		if rule.DedupWindow > longest {
+			longest = rule.DedupWindow
+		}
+	}
+	for id, st := range m.alerts {
+		if now.Sub(st.last) > longest && st.suppressed == 0 {
+			delete(m.alerts, id)
+		}
+	}
+}
+
+// Close 等待通知渠道发完队列中的消息
+func (m *AlertManager) Close() {
+	m.notifier.Close()
+}
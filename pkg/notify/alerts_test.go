@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "notify-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if _, err := logger.Initialize(&config.LoggingConfig{Level: "error", Output: filepath.Join(dir, "test.log")}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// recordChannel 记录收到的消息
+type recordChannel struct {
+	mu   sync.Mutex
+	msgs []Message
+}
+
+func (c *recordChannel) Name() string { return "record" }
+
+func (c *recordChannel) Send(ctx context.Context, msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.msgs = append(c.msgs, msg)
+	return nil
+}
+
+// fire 一次告警，at 为相对起始时间的偏移
+type fire struct {
+	at      time.Duration
+	key     string
+	resolve bool
+}
+
+// sent 期望发送的消息
+type sent struct {
+	title    string
+	severity Severity
+	note     string // 正文中应包含的内容
+}
+
+func TestAlertDedupAndEscalation(t *testing.T) {
+	const title = "hedge failed"
+	escalating := map[string]Rule{
+		"hedge_failed": {
+			DedupWindow: time.Minute,
+			Escalations: []Escalation{{Count: 3, Severity: SeverityCritical}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		rules  map[string]Rule
+		fires  []fire
+		want   []sent
+	}{
+		{
+			name:  "no window sends every alert",
+			fires: []fire{{at: 0}, {at: time.Second}},
+			want:  []sent{{title: title, severity: SeverityWarning}, {title: title, severity: SeverityWarning}},
+		},
+		{
+			name:   "duplicates within window suppressed",
+			window: time.Minute,
+			fires:  []fire{{at: 0}, {at: 10 * time.Second}, {at: 20 * time.Second}},
+			want:   []sent{{title: title, severity: SeverityWarning}},
+		},
+		{
+			name:   "resent after window with suppressed count",
+			window: time.Minute,
+			fires:  []fire{{at: 0}, {at: 30 * time.Second}, {at: 70 * time.Second}},
+			want: []sent{
+				{title: title, severity: SeverityWarning},
+				{title: title, severity: SeverityWarning, note: "1 identical alerts suppressed"},
+			},
+		},
+		{
+			name:   "different keys not deduplicated",
+			window: time.Minute,
+			fires:  []fire{{at: 0, key: "BTC"}, {at: time.Second, key: "ETH"}, {at: 2 * time.Second, key: "BTC"}},
+			want:   []sent{{title: title, severity: SeverityWarning}, {title: title, severity: SeverityWarning}},
+		},
+		{
+			name:   "rule window overrides default",
+			window: time.Hour,
+			rules:  map[string]Rule{"hedge_failed": {DedupWindow: time.Minute}},
+			fires:  []fire{{at: 0}, {at: 61 * time.Second}},
+			want:   []sent{{title: title, severity: SeverityWarning}, {title: title, severity: SeverityWarning}},
+		},
+		{
+			name:  "escalates on repeat count within window",
+			rules: escalating,
+			fires: []fire{{at: 0}, {at: time.Second}, {at: 2 * time.Second}, {at: 3 * time.Second}},
+			want: []sent{
+				{title: title, severity: SeverityWarning},
+				{title: "[ESCALATED] " + title, severity: SeverityCritical, note: "occurred 3 times"},
+			},
+		},
+		{
+			name:  "quiet gap restarts escalation count",
+			rules: escalating,
+			fires: []fire{{at: 0}, {at: time.Second}, {at: 2 * time.Minute}, {at: 2*time.Minute + time.Second}},
+			want: []sent{
+				{title: title, severity: SeverityWarning},
+				{title: title, severity: SeverityWarning, note: "1 identical alerts suppressed"},
+			},
+		},
+		{
+			name:   "resolve clears dedup state",
+			window: time.Minute,
+			fires:  []fire{{at: 0, key: "BTC"}, {at: time.Second, key: "BTC", resolve: true}, {at: 2 * time.Second, key: "BTC"}},
+			want: []sent{
+				{title: title, severity: SeverityWarning},
+				{title: title, severity: SeverityWarning},
+				{title: title, severity: SeverityWarning},
+			},
+		},
+		{
+			name:  "rule severity override",
+			rules: map[string]Rule{"hedge_failed": {Severity: SeverityCritical}},
+			fires: []fire{{at: 0}},
+			want:  []sent{{title: title, severity: SeverityCritical}},
+		},
+		{
+			name:  "disabled rule drops alerts",
+			rules: map[string]Rule{"hedge_failed": {Disabled: true}},
+			fires: []fire{{at: 0}, {at: time.Minute}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := &recordChannel{}
+			notifier := New(time.Second)
+			notifier.Add(channel, SeverityInfo)
+			alerts := NewAlertManager(notifier, tt.window, tt.rules)
+
+			start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			for _, f := range tt.fires {
+				alerts.Fire(Message{
+					Severity: SeverityWarning,
+					Event:    "hedge_failed",
+					Title:    title,
+					Time:     start.Add(f.at),
+					Key:      f.key,
+					Resolve:  f.resolve,
+				})
+			}
+			alerts.Close()
+
+			if len(channel.msgs) != len(tt.want) {
+				t.Fatalf("sent %d messages, want %d", len(channel.msgs), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := channel.msgs[i]
+				if got.Title != want.title || got.Severity != want.severity {
+					t.Errorf("message %d = %q (%s), want %q (%s)", i, got.Title, got.Severity, want.title, want.severity)
+				}
+				if !strings.Contains(got.Body, want.note) {
+					t.Errorf("message %d body %q does not contain %q", i, got.Body, want.note)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package notify 把紧急告警和日报推送到外部通知渠道 (邮件等)。
+// 消息在后台队列中发送，不阻塞交易路径；每个渠道可以设置接收的最低严重程度。
+// AlertManager 在发送前按事件类型调整严重程度、合并重复告警并按重复次数升级。
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// queueSize 待发送消息队列容量，队列满时丢弃新消息
+const queueSize = 64
+
+// Severity 消息严重程度
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"     // 日报等不需要处理的消息
+	SeverityWarning  Severity = "warning"  // 对冲失败、仓位不一致等需要关注的告警
+	SeverityCritical Severity = "critical" // 紧急平仓、紧急停止等需要立即处理的告警
+)
+
+// rank 严重程度排序，未知值视为info
+func (s Severity) rank() int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Message 通知消息
+type Message struct {
+	Severity Severity
+	Event    string // 触发消息的事件类型
+	Title    string
+	Body     string
+	Time     time.Time
+	Key      string // 告警去重键，呼叫渠道用于合并同一告警和解除告警 (为空时每条消息独立)
+	Resolve  bool   // 告警已解除 (呼叫渠道关闭 Key 对应的告警，邮件照常发送)
+}
+
+// Channel 通知渠道
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// route 渠道及其接收的最低严重程度
+type route struct {
+	channel     Channel
+	minSeverity Severity
+}
+
+// Notifier 通知分发：Notify 入队后由后台协程依次发送到各渠道，Close 等待队列发完
+type Notifier struct {
+	routes  []route
+	timeout time.Duration // 单条消息在单个渠道的发送超时
+
+	mu     sync.RWMutex
+	queue  chan Message
+	closed bool
+	done   chan struct{}
+
+	logger *zap.Logger
+}
+
+// New 创建通知分发并启动后台发送协程，timeout 为单条消息在单个渠道的发送超时
+func New(timeout time.Duration) *Notifier {
+	n := &Notifier{
+		timeout: timeout,
+		queue:   make(chan Message, queueSize),
+		done:    make(chan struct{}),
+		logger:  logger.Named("notify"),
+	}
+	go n.run()
+	return n
+}
+
+// Add 添加通知渠道，只接收严重程度不低于 minSeverity 的消息。需在 Notify 之前调用
+func (n *Notifier) Add(channel Channel, minSeverity Severity) {
+	n.routes = append(n.routes, route{channel: channel, minSeverity: minSeverity})
+}
+
+// Channels 已添加的渠道数量
+func (n *Notifier) Channels() int {
+	return len(n.routes)
+}
+
+// Notify 非阻塞地提交消息，队列满或已关闭时丢弃
+func (n *Notifier) Notify(msg Message) {
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.closed {
+		return
+	}
+
+	select {
+	case n.queue <- msg:
+	default:
+		n.logger.Warn("Notification queue full, dropping message",
+			zap.String("event", msg.Event),
+			zap.String("title", msg.Title),
+		)
+	}
+}
+
+// Close 停止接收新消息，等待队列中的消息发送完成
+func (n *Notifier) Close() {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+	<-n.done
+}
+
+// run 依次发送队列中的消息，直到队列关闭
+func (n *Notifier) run() {
+	defer close(n.done)
+	for msg := range n.queue {
+		for _, r := range n.routes {
+			if msg.Severity.rank() < r.minSeverity.rank() {
+				continue
+			}
+			n.send(r.channel, msg)
+		}
+	}
+}
+
+// send 在超时内发送到单个渠道，失败时记录日志 (不重试)
+func (n *Notifier) send(channel Channel, msg Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := channel.Send(ctx, msg); err != nil {
+		n.logger.Error("Failed to send notification",
+			zap.String("channel", channel.Name()),
+			zap.String("event", msg.Event),
+			zap.String("title", msg.Title),
+			zap.Error(err),
+		)
+		return
+	}
+	n.logger.Info("Notification sent",
+		zap.String("channel", channel.Name()),
+		zap.String("event", msg.Event),
+		zap.String("severity", string(msg.Severity)),
+		zap.Duration("elapsed", time.Since(start)),
+	)
+}
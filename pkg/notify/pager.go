@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"cs-projects-backpack/pkg/config"
+)
+
+// PagerDuty 通过 Events API v2 呼叫值班，Resolve 消息按 Key 解除告警
+type PagerDuty struct {
+	cfg    *config.PagerDutyConfig
+	client *http.Client
+}
+
+// NewPagerDuty 创建PagerDuty通知渠道
+func NewPagerDuty(cfg *config.PagerDutyConfig) *PagerDuty {
+	return &PagerDuty{cfg: cfg, client: &http.Client{}}
+}
+
+// Name 渠道名称
+func (p *PagerDuty) Name() string {
+	return "pagerduty"
+}
+
+// Send 触发或解除告警
+func (p *PagerDuty) Send(ctx context.Context, msg Message) error {
+	event := map[string]interface{}{
+		"routing_key":  p.cfg.RoutingKey,
+		"event_action": "trigger",
+	}
+	if msg.Key != "" {
+		event["dedup_key"] = msg.Key
+	}
+	if msg.Resolve {
+		if msg.Key == "" {
+			return nil
+		}
+		event["event_action"] = "resolve"
+	} else {
+		event["payload"] = map[string]interface{}{
+			"summary":        msg.Title,
+			"source":         p.cfg.Source,
+			"severity":       string(msg.Severity),
+			"timestamp":      msg.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+			"component":      msg.Event,
+			"custom_details": map[string]string{"details": msg.Body},
+		}
+	}
+
+	return postJSON(ctx, p.client, p.cfg.URL, nil, event)
+}
+
+// Opsgenie 通过 Alert API 呼叫值班，Resolve 消息按 Key (alias) 关闭告警
+type Opsgenie struct {
+	cfg    *config.OpsgenieConfig
+	client *http.Client
+}
+
+// NewOpsgenie 创建Opsgenie通知渠道
+func NewOpsgenie(cfg *config.OpsgenieConfig) *Opsgenie {
+	return &Opsgenie{cfg: cfg, client: &http.Client{}}
+}
+
+// Name 渠道名称
+func (o *Opsgenie) Name() string {
+	return "opsgenie"
+}
+
+// Send 创建或关闭告警，critical为P1，warning为P3，info为P5
+func (o *Opsgenie) Send(ctx context.Context, msg Message) error {
+	header := http.Header{"Authorization": []string{"GenieKey " + o.cfg.APIKey}}
+	base := strings.TrimRight(o.cfg.URL, "/") + "/v2/alerts"
+
+	if msg.Resolve {
+		if msg.Key == "" {
+			return nil
+		}
+		endpoint := base + "/" + url.PathEscape(msg.Key) + "/close?identifierType=alias"
+		return postJSON(ctx, o.client, endpoint, header, map[string]string{"note": msg.Title})
+	}
+
+	priority := "P5"
+	switch msg.Severity {
+	case SeverityCritical:
+		priority = "P1"
+	case SeverityWarning:
+		priority = "P3"
+	}
+	alert := map[string]interface{}{
+		"message":     truncate(msg.Title, 130),
+		"description": truncate(msg.Body, 15000),
+		"priority":    priority,
+		"source":      o.cfg.Source,
+		"tags":        []string{msg.Event},
+	}
+	if msg.Key != "" {
+		alert["alias"] = msg.Key
+	}
+	return postJSON(ctx, o.client, base, header, alert)
+}
+
+// postJSON 发送JSON请求，非2xx响应返回包含响应体的错误
+func postJSON(ctx context.Context, client *http.Client, endpoint string, header http.Header, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned %s: %s", endpoint, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// truncate 按字符截断超出接口长度限制的文本
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
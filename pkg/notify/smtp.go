@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"cs-projects-backpack/pkg/config"
+)
+
+// SMTP 邮件通知渠道
+type SMTP struct {
+	cfg *config.SMTPConfig
+}
+
+// NewSMTP 创建邮件通知渠道
+func NewSMTP(cfg *config.SMTPConfig) *SMTP {
+	return &SMTP{cfg: cfg}
+}
+
+// Name 渠道名称
+func (s *SMTP) Name() string {
+	return "smtp"
+}
+
+// Send 连接SMTP服务器发送一封纯文本邮件。security 为 tls 时使用隐式TLS (通常为465端口)，
+// starttls 时在明文连接上升级 (通常为587端口)，none 时不加密 (只应用于本机中继)
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if s.cfg.Security == config.SMTPSecurityTLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake failed: %w", err)
+	}
+	defer client.Close()
+
+	if s.cfg.Security == config.SMTPSecurityStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("smtp starttls failed: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	for _, to := range s.cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s failed: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(s.compose(msg)); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected mail: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose 生成邮件头和quoted-printable编码的正文，critical消息的主题带 [URGENT] 标记
+func (s *SMTP) compose(msg Message) []byte {
+	subject := msg.Title
+	if msg.Severity == SeverityCritical {
+		subject = "[URGENT] " + subject
+	}
+	if s.cfg.SubjectPrefix != "" {
+		subject = s.cfg.SubjectPrefix + " " + subject
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(s.cfg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", msg.Time.Format(time.RFC1123Z))
+	if msg.Severity == SeverityCritical {
+		buf.WriteString("X-Priority: 1\r\nImportance: high\r\n")
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	_, _ = qp.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n")))
+	_ = qp.Close()
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,76 @@
+package pricefeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCoinbaseURL Coinbase公开行情接口地址
+const DefaultCoinbaseURL = "https://api.coinbase.com"
+
+// coinbaseSource Coinbase现货价格，作为外部指数参考
+type coinbaseSource struct {
+	baseURL    string
+	currency   string
+	httpClient *http.Client
+}
+
+type coinbasePriceResponse struct {
+	Data struct {
+		Amount   string `json:"amount"`
+		Base     string `json:"base"`
+		Currency string `json:"currency"`
+	} `json:"data"`
+}
+
+// NewCoinbaseSource 创建Coinbase价格源，currency 为计价货币 (USD)
+func NewCoinbaseSource(baseURL, currency string) Source {
+	if baseURL == "" {
+		baseURL = DefaultCoinbaseURL
+	}
+	if currency == "" {
+		currency = "USD"
+	}
+	return &coinbaseSource{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		currency:   currency,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *coinbaseSource) Name() string { return "coinbase" }
+
+func (s *coinbaseSource) Price(ctx context.Context, symbol string) (float64, error) {
+	endpoint := fmt.Sprintf("%s/v2/prices/%s-%s/spot", s.baseURL, symbol, s.currency)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("coinbase request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("coinbase returned status %d for %s", resp.StatusCode, symbol)
+	}
+
+	var result coinbasePriceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode coinbase response: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(result.Data.Amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse coinbase price: %w", err)
+	}
+	return price, nil
+}
@@ -0,0 +1,182 @@
+package pricefeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// ErrStale 有效报价源不足，聚合价格不可用
+var ErrStale = errors.New("price feed stale")
+
+// Source 价格源
+type Source interface {
+	Name() string
+	Price(ctx context.Context, symbol string) (float64, error)
+}
+
+// sourceFunc 以函数实现的价格源
+type sourceFunc struct {
+	name string
+	fn   func(ctx context.Context, symbol string) (float64, error)
+}
+
+func (s *sourceFunc) Name() string { return s.name }
+
+func (s *sourceFunc) Price(ctx context.Context, symbol string) (float64, error) {
+	return s.fn(ctx, symbol)
+}
+
+// NewSource 以函数创建价格源，symbol 为内部币种符号 (BTC, ETH)
+func NewSource(name string, fn func(ctx context.Context, symbol string) (float64, error)) Source {
+	return &sourceFunc{name: name, fn: fn}
+}
+
+// Quote 单个价格源的报价
+type Quote struct {
+	Source string    `json:"source"`
+	Price  float64   `json:"price"`
+	At     time.Time `json:"at"`
+}
+
+// Price 聚合价格
+type Price struct {
+	Symbol string    `json:"symbol"`
+	Median float64   `json:"median"`          // 有效报价的中位数
+	Quotes []Quote   `json:"quotes"`          // 参与聚合的有效报价
+	Stale  []string  `json:"stale,omitempty"` // 报价过期或缺失的价格源
+	At     time.Time `json:"at"`              // 最早一个有效报价的时间
+}
+
+// Feed 多源聚合价格：定期从各价格源获取报价，取未过期报价的中位数。
+// 有效报价源少于 minSources 时返回 ErrStale，单个价格源故障不影响聚合结果
+type Feed struct {
+	sources    []Source
+	maxAge     time.Duration
+	minSources int
+	logger     *zap.Logger
+
+	mu     sync.RWMutex
+	quotes map[string]map[string]Quote // symbol -> source -> 最新报价
+}
+
+// New 创建聚合价格，maxAge 为报价有效期
+func New(maxAge time.Duration, minSources int, sources ...Source) *Feed {
+	return &Feed{
+		sources:    sources,
+		maxAge:     maxAge,
+		minSources: minSources,
+		logger:     logger.Named("price-feed"),
+		quotes:     make(map[string]map[string]Quote),
+	}
+}
+
+// Run 每隔 interval 刷新全部币种的报价，阻塞直到ctx取消
+func (f *Feed) Run(ctx context.Context, symbols []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, symbol := range symbols {
+			f.Refresh(ctx, symbol)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh 并发从全部价格源获取报价，失败的价格源保留上一次报价直到过期
+func (f *Feed) Refresh(ctx context.Context, symbol string) {
+	var wg sync.WaitGroup
+	for _, src := range f.sources {
+		wg.Add(1)
+		go func(src Source) {
+			defer wg.Done()
+
+			price, err := src.Price(ctx, symbol)
+			if err == nil && price <= 0 {
+				err = fmt.Errorf("invalid price %f", price)
+			}
+			if err != nil {
+				f.logger.Debug("Failed to fetch price",
+					zap.String("source", src.Name()),
+					zap.String("symbol", symbol),
+					zap.Error(err),
+				)
+				return
+			}
+
+			f.mu.Lock()
+			if f.quotes[symbol] == nil {
+				f.quotes[symbol] = make(map[string]Quote)
+			}
+			f.quotes[symbol][src.Name()] = Quote{Source: src.Name(), Price: price, At: time.Now()}
+			f.mu.Unlock()
+		}(src)
+	}
+	wg.Wait()
+}
+
+// Last 返回缓存报价的聚合价格，不发起请求
+func (f *Feed) Last(symbol string) (*Price, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	now := time.Now()
+	result := &Price{Symbol: symbol}
+	for _, src := range f.sources {
+		q, ok := f.quotes[symbol][src.Name()]
+		if !ok || now.Sub(q.At) > f.maxAge {
+			result.Stale = append(result.Stale, src.Name())
+			continue
+		}
+		result.Quotes = append(result.Quotes, q)
+		if result.At.IsZero() || q.At.Before(result.At) {
+			result.At = q.At
+		}
+	}
+
+	if len(result.Quotes) == 0 || len(result.Quotes) < f.minSources {
+		return result, fmt.Errorf("%w: %s has %d fresh sources, need %d (stale: %v)",
+			ErrStale, symbol, len(result.Quotes), f.minSources, result.Stale)
+	}
+
+	result.Median = median(result.Quotes)
+	return result, nil
+}
+
+// Price 返回聚合价格，缓存报价不足时先刷新一次
+func (f *Feed) Price(ctx context.Context, symbol string) (*Price, error) {
+	if p, err := f.Last(symbol); err == nil {
+		return p, nil
+	}
+
+	f.Refresh(ctx, symbol)
+	return f.Last(symbol)
+}
+
+// median 报价中位数，偶数个时取中间两个的平均值
+func median(quotes []Quote) float64 {
+	prices := make([]float64, len(quotes))
+	for i, q := range quotes {
+		prices[i] = q.Price
+	}
+	sort.Float64s(prices)
+
+	mid := len(prices) / 2
+	if len(prices)%2 == 0 {
+		return (prices[mid-1] + prices[mid]) / 2
+	}
+	return prices[mid]
+}
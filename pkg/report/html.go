@@ -0,0 +1,65 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+)
+
+var dailyTemplate = template.Must(template.New("daily").Funcs(template.FuncMap{
+	"money": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Daily PnL Report {{.Report.Date}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 6px 12px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+tfoot td { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Daily PnL Report — {{.Report.Date}}</h1>
+<p>Timezone: {{.Report.Timezone}} · Generated at: {{.Report.GeneratedAt.Format "2006-01-02 15:04:05"}}</p>
+<table>
+<thead>
+<tr><th>Venue</th><th>Trades</th><th>Volume</th><th>Realized PnL</th><th>Fees</th><th>Funding</th><th>Net PnL</th></tr>
+</thead>
+<tbody>
+{{range .Venues}}<tr><td>{{.Venue}}</td><td>{{.Trades}}</td><td>{{money .Volume}}</td><td>{{money .RealizedPnL}}</td><td>{{money .Fees}}</td><td>{{money .Funding}}</td><td>{{money .NetPnL}}</td></tr>
+{{end}}</tbody>
+<tfoot>
+{{with .Report.Total}}<tr><td>Total</td><td>{{.Trades}}</td><td>{{money .Volume}}</td><td>{{money .RealizedPnL}}</td><td>{{money .Fees}}</td><td>{{money .Funding}}</td><td>{{money .NetPnL}}</td></tr>{{end}}
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+func writeHTML(report *DailyReport, path string) error {
+	venues := make([]*VenueReport, 0, len(report.Venues))
+	for _, v := range report.Venues {
+		venues = append(venues, v)
+	}
+	sort.Slice(venues, func(i, j int) bool { return venues[i].Venue < venues[j].Venue })
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report %s: %w", path, err)
+	}
+	defer file.Close()
+
+	data := struct {
+		Report *DailyReport
+		Venues []*VenueReport
+	}{report, venues}
+
+	if err := dailyTemplate.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to render html report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"cs-projects-backpack/pkg/journal"
+)
+
+// 支持的报告格式
+const (
+	FormatJSON = "json"
+	FormatHTML = "html"
+)
+
+// VenueReport 单个交易所的日报数据
+type VenueReport struct {
+	Venue       string  `json:"venue"`
+	Trades      int     `json:"trades"`
+	Volume      float64 `json:"volume"`       // 成交额 (USDT/USDC)
+	RealizedPnL float64 `json:"realized_pnl"` // 已实现盈亏 (未扣费)
+	Fees        float64 `json:"fees"`         // 手续费
+	Funding     float64 `json:"funding"`      // 资金费 (正数收取，负数支付)
+	NetPnL      float64 `json:"net_pnl"`      // 净盈亏 = 已实现 - 手续费 + 资金费
+}
+
+// DailyReport 每日盈亏报告
+type DailyReport struct {
+	Date        string                  `json:"date"` // YYYY-MM-DD
+	Timezone    string                  `json:"timezone"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	Venues      map[string]*VenueReport `json:"venues"`
+	Total       *VenueReport            `json:"total"`
+}
+
+// holding 平均成本法下的持仓
+type holding struct {
+	qty      float64 // 币数量 (正数多头，负数空头)
+	avgPrice float64
+}
+
+// apply 应用一笔成交，返回本次实现的盈亏
+func (h *holding) apply(qty, price float64) float64 {
+	// 同方向加仓或从空仓开仓
+	if h.qty == 0 || (h.qty > 0) == (qty > 0) {
+		total := h.qty + qty
+		h.avgPrice = (h.avgPrice*math.Abs(h.qty) + price*math.Abs(qty)) / math.Abs(total)
+		h.qty = total
+		return 0
+	}
+
+	// 反方向减仓
+	closed := math.Min(math.Abs(qty), math.Abs(h.qty))
+	direction := 1.0
+	if h.qty < 0 {
+		direction = -1.0
+	}
+	realized := closed * (price - h.avgPrice) * direction
+
+	remaining := h.qty + qty
+	if remaining != 0 && (remaining > 0) != (h.qty > 0) {
+		// 反手：剩余部分按成交价开新仓
+		h.avgPrice = price
+	} else if remaining == 0 {
+		h.avgPrice = 0
+	}
+	h.qty = remaining
+
+	return realized
+}
+
+// Build 根据成交日志生成指定日期的日报。
+// 为了得到正确的持仓成本，会回放该日期之前的全部成交，但只统计当天的数据。
+func Build(entries []*journal.Entry, day time.Time, loc *time.Location) *DailyReport {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	y, m, d := day.In(loc).Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1)
+
+	report := &DailyReport{
+		Date:        start.Format("2006-01-02"),
+		Timezone:    loc.String(),
+		GeneratedAt: time.Now(),
+		Venues:      make(map[string]*VenueReport),
+		Total:       &VenueReport{Venue: "total"},
+	}
+
+	sorted := make([]*journal.Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	holdings := make(map[string]*holding) // venue/symbol -> holding
+
+	for _, e := range sorted {
+		if !e.Time.Before(end) {
+			break
+		}
+
+		var realized float64
+		if e.Price > 0 && e.Size > 0 {
+			key := e.Venue + "/" + e.Symbol
+			h, ok := holdings[key]
+			if !ok {
+				h = &holding{}
+				holdings[key] = h
+			}
+			qty := e.Size / e.Price
+			if e.Side == "SELL" {
+				qty = -qty
+			}
+			realized = h.apply(qty, e.Price)
+		}
+
+		if e.Time.Before(start) {
+			continue
+		}
+
+		venue, ok := report.Venues[e.Venue]
+		if !ok {
+			venue = &VenueReport{Venue: e.Venue}
+			report.Venues[e.Venue] = venue
+		}
+
+		if e.Size > 0 {
+			venue.Trades++
+			venue.Volume += e.Size
+		}
+		venue.RealizedPnL += realized
+		venue.Fees += e.Fee
+		venue.Funding += e.Funding
+	}
+
+	for _, venue := range report.Venues {
+		venue.NetPnL = venue.RealizedPnL - venue.Fees + venue.Funding
+
+		report.Total.Trades += venue.Trades
+		report.Total.Volume += venue.Volume
+		report.Total.RealizedPnL += venue.RealizedPnL
+		report.Total.Fees += venue.Fees
+		report.Total.Funding += venue.Funding
+		report.Total.NetPnL += venue.NetPnL
+	}
+
+	return report
+}
+
+// Write 将日报按指定格式写入目录，返回写出的文件路径
+func Write(report *DailyReport, dir string, formats []string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	var paths []string
+	for _, format := range formats {
+		path := filepath.Join(dir, fmt.Sprintf("pnl-%s.%s", report.Date, format))
+
+		var err error
+		switch format {
+		case FormatJSON:
+			err = writeJSON(report, path)
+		case FormatHTML:
+			err = writeHTML(report, path)
+		default:
+			err = fmt.Errorf("unsupported report format: %s", format)
+		}
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+func writeJSON(report *DailyReport, path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	return nil
+}
+
+// Generate 读取成交日志并生成指定日期的日报文件
+func Generate(journalPath string, day time.Time, loc *time.Location, dir string, formats []string) (*DailyReport, []string, error) {
+	entries, err := journal.ReadAll(journalPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+
+	// 成交日志不存在时生成空报告
+	report := Build(entries, day, loc)
+	paths, err := Write(report, dir, formats)
+	return report, paths, err
+}
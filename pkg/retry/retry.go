@@ -0,0 +1,221 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/logger"
+)
+
+// Policy 重试策略：指数退避 + 抖动
+type Policy struct {
+	MaxAttempts    int           // 最大尝试次数 (含首次，<=1 表示不重试)
+	InitialBackoff time.Duration // 首次重试等待时间
+	MaxBackoff     time.Duration // 单次等待上限
+	Multiplier     float64       // 退避倍数
+	Jitter         float64       // 抖动比例 (0-1)，等待时间在 ±Jitter 范围内随机
+
+	// Retryable 判断错误是否可重试，为空时使用 IsRetryable
+	Retryable func(err error) bool
+}
+
+// DefaultPolicy 默认重试策略
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     3 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetryable 返回使用指定错误分类的策略副本
+func (p Policy) WithRetryable(fn func(err error) bool) Policy {
+	p.Retryable = fn
+	return p
+}
+
+// Backoff 计算第 attempt 次失败后的等待时间 (attempt 从1开始)
+func (p Policy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+
+	return time.Duration(backoff)
+}
+
+// permanentError 标记不可重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 包装错误使其不再重试
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// 单次请求的默认超时时间，客户端未设置时使用
+const (
+	DefaultQueryTimeout = 5 * time.Second  // 查询和撤单
+	DefaultOrderTimeout = 10 * time.Second // 下单
+)
+
+// ErrTimeout 单次请求超过超时时间 (调用方上下文未取消)，请求可能已到达交易所
+var ErrTimeout = errors.New("request timed out")
+
+// WithTimeout 为单次请求设置超时后执行 fn，timeout<=0 时不设置。
+// 超时且调用方 ctx 未取消时返回包装 ErrTimeout 的错误：查询按网络错误重试，下单视为结果未知
+func WithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := fn(attemptCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %s: %w", ErrTimeout, timeout, err)
+	}
+	return result, err
+}
+
+// StatusError HTTP状态码错误，供交易所客户端包装非200响应
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsRetryable 通用错误分类：网络抖动、超时 (含 WithTimeout 单次请求超时)、429和5xx可重试，
+// ctx取消、Permanent包装的错误和其他业务错误不重试
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return false
+	}
+	if errors.Is(err, ErrTimeout) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsNotSent 判断请求是否确定未到达交易所 (连接建立失败)，
+// 用于下单等非幂等请求：超时等结果未知的错误重发可能导致重复下单
+func IsNotSent(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// Do 按策略执行 fn，可重试错误按指数退避重试，返回最后一次的错误
+func Do(ctx context.Context, p Policy, op string, fn func(ctx context.Context) error) error {
+	_, err := DoValue(ctx, p, op, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// DoValue 同 Do，返回 fn 的结果
+func DoValue[T any](ctx context.Context, p Policy, op string, fn func(ctx context.Context) (T, error)) (T, error) {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+
+		if attempt >= attempts || !retryable(err) || ctx.Err() != nil {
+			if attempt > 1 {
+				return result, fmt.Errorf("%s failed after %d attempts: %w", op, attempt, err)
+			}
+			return result, err
+		}
+
+		backoff := p.Backoff(attempt)
+		logger.Named("retry").Warn("Exchange call failed, retrying",
+			zap.String("op", op),
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", attempts),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			var zero T
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
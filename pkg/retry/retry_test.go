@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "retry-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if _, err := logger.Initialize(&config.LoggingConfig{Level: "error", Output: filepath.Join(dir, "test.log")}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool // 幂等请求可重试
+		notSent   bool // 确定未到达交易所，下单可重发
+	}{
+		{name: "nil", err: nil},
+		{name: "business error", err: errors.New("insufficient balance")},
+		{name: "permanent", err: Permanent(io.EOF)},
+		{name: "caller canceled", err: context.Canceled},
+		{name: "caller deadline", err: fmt.Errorf("get order: %w", context.DeadlineExceeded)},
+		{name: "attempt timeout", err: fmt.Errorf("%w after 5s: %w", ErrTimeout, context.DeadlineExceeded), retryable: true},
+		{name: "status 429", err: &StatusError{StatusCode: http.StatusTooManyRequests}, retryable: true},
+		{name: "status 503", err: &StatusError{StatusCode: http.StatusServiceUnavailable}, retryable: true},
+		{name: "status 400", err: &StatusError{StatusCode: http.StatusBadRequest}},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, retryable: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, retryable: true},
+		{name: "connection refused", err: fmt.Errorf("create order: %w", syscall.ECONNREFUSED), retryable: true, notSent: true},
+		{name: "dial failure", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}, retryable: true, notSent: true},
+		{name: "dns failure", err: &net.DNSError{Err: "no such host", Name: "api.binance.com"}, retryable: true, notSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.retryable)
+			}
+			if got := IsNotSent(tt.err); got != tt.notSent {
+				t.Errorf("IsNotSent(%v) = %v, want %v", tt.err, got, tt.notSent)
+			}
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	block := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+
+	_, err := WithTimeout(t.Context(), time.Millisecond, block)
+	if !errors.Is(err, ErrTimeout) || !IsRetryable(err) || IsNotSent(err) {
+		t.Fatalf("attempt timeout: err = %v, want retryable ErrTimeout that may have been sent", err)
+	}
+
+	// 调用方超时不是单次请求超时，不重试
+	ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+	defer cancel()
+	_, err = WithTimeout(ctx, time.Hour, block)
+	if errors.Is(err, ErrTimeout) || IsRetryable(err) {
+		t.Fatalf("caller timeout: err = %v, want non-retryable", err)
+	}
+}
+
+func TestDoValueAttempts(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+	errTransient := &StatusError{StatusCode: http.StatusBadGateway}
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		errs      []error // 各次尝试的错误，超出部分成功
+		attempts  int
+		succeeded bool
+	}{
+		{name: "first attempt succeeds", policy: policy, attempts: 1, succeeded: true},
+		{name: "transient then success", policy: policy, errs: []error{errTransient, errTransient}, attempts: 3, succeeded: true},
+		{name: "transient exhausts attempts", policy: policy, errs: []error{errTransient, errTransient, errTransient}, attempts: 3},
+		{name: "permanent stops", policy: policy, errs: []error{Permanent(errTransient)}, attempts: 1},
+		{name: "business error stops", policy: policy, errs: []error{errors.New("insufficient balance")}, attempts: 1},
+		{name: "custom classification", policy: policy.WithRetryable(IsNotSent), errs: []error{errTransient}, attempts: 1},
+		{
+			name:      "custom classification resends unsent",
+			policy:    policy.WithRetryable(IsNotSent),
+			errs:      []error{syscall.ECONNREFUSED},
+			attempts:  2,
+			succeeded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			result, err := DoValue(t.Context(), tt.policy, "test", func(ctx context.Context) (int, error) {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return 0, tt.errs[attempts-1]
+				}
+				return attempts, nil
+			})
+
+			if attempts != tt.attempts {
+				t.Fatalf("attempts = %d, want %d", attempts, tt.attempts)
+			}
+			if succeeded := err == nil; succeeded != tt.succeeded {
+				t.Fatalf("err = %v, want succeeded %v", err, tt.succeeded)
+			}
+			if tt.succeeded && result != tt.attempts {
+				t.Fatalf("result = %d, want %d", result, tt.attempts)
+			}
+		})
+	}
+}
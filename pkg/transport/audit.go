@@ -0,0 +1,210 @@
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cs-projects-backpack/pkg/config"
+	"cs-projects-backpack/pkg/logger"
+)
+
+// 请求审计日志模式
+const (
+	AuditOff    = ""       // 不记录
+	AuditErrors = "errors" // 只记录网络错误和HTTP状态码>=400的请求
+	AuditAll    = "all"    // 记录全部请求
+)
+
+// redacted 替换敏感值的占位符
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders 需要隐藏的请求/响应头 (按 http.CanonicalHeaderKey)
+var sensitiveHeaders = map[string]bool{
+	"X-Mbx-Apikey":        true,
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// sensitiveParams 需要隐藏的查询/表单参数和JSON字段 (小写比较)
+var sensitiveParams = map[string]bool{
+	"signature":   true,
+	"auth":        true,
+	"apikey":      true,
+	"api_key":     true,
+	"secret":      true,
+	"secretkey":   true,
+	"secret_key":  true,
+	"private_key": true,
+	"privatekey":  true,
+	"token":       true,
+	"listenkey":   true,
+}
+
+// jsonFieldPattern JSON中的字符串字段，用于按字段名隐藏值
+var jsonFieldPattern = regexp.MustCompile(`"([A-Za-z_]+)"\s*:\s*"[^"]*"`)
+
+// auditTransport 记录请求和响应 (隐藏密钥、签名和认证令牌)，用于排查失败订单
+type auditTransport struct {
+	base    http.RoundTripper
+	mode    string
+	maxBody int
+	logger  *zap.Logger
+}
+
+func newAuditTransport(base http.RoundTripper, cfg *config.TransportConfig, name string) http.RoundTripper {
+	if cfg.AuditLog == AuditOff {
+		return base
+	}
+	return &auditTransport{
+		base:    base,
+		mode:    cfg.AuditLog,
+		maxBody: cfg.AuditMaxBodyBytes,
+		logger:  logger.Named("http-audit").With(zap.String("venue", name)),
+	}
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, reqBody, err := t.captureRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err == nil && t.mode == AuditErrors && resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	fields := []zap.Field{
+		zap.String("method", req.Method),
+		zap.String("url", redactURL(req.URL)),
+		zap.Any("request_headers", redactHeaders(req.Header)),
+		zap.String("request_body", t.truncate(redactBody(reqBody, req.Header.Get("Content-Type")))),
+		zap.Duration("elapsed", elapsed),
+	}
+
+	if err != nil {
+		t.logger.Warn("HTTP request failed", append(fields, zap.Error(err))...)
+		return resp, err
+	}
+
+	respBody := t.captureResponseBody(resp)
+	fields = append(fields,
+		zap.Int("status", resp.StatusCode),
+		zap.Any("response_headers", redactHeaders(resp.Header)),
+		zap.String("response_body", t.truncate(redactBody(respBody, resp.Header.Get("Content-Type")))),
+	)
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.logger.Warn("HTTP request returned error status", fields...)
+	} else {
+		t.logger.Info("HTTP request", fields...)
+	}
+	return resp, nil
+}
+
+// captureRequestBody 读取请求体，返回带有相同请求体的请求副本供后续发送
+func (t *auditTransport) captureRequestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	return clone, body, nil
+}
+
+// captureResponseBody 读取响应体并放回，供调用方解析
+func (t *auditTransport) captureResponseBody(resp *http.Response) []byte {
+	if resp.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+// truncate 截断过长的请求/响应体，maxBody<=0 时不截断
+func (t *auditTransport) truncate(body string) string {
+	if t.maxBody > 0 && len(body) > t.maxBody {
+		return body[:t.maxBody] + "...(truncated)"
+	}
+	return body
+}
+
+// redactURL 隐藏查询参数中的签名和认证令牌
+func redactURL(u *url.URL) string {
+	c := *u
+	c.User = nil
+	c.RawQuery = redactValues(u.Query()).Encode()
+	return c.String()
+}
+
+// redactValues 隐藏敏感参数的值
+func redactValues(values url.Values) url.Values {
+	for key := range values {
+		if sensitiveParams[strings.ToLower(key)] {
+			values[key] = []string{redacted}
+		}
+	}
+	return values
+}
+
+// redactHeaders 隐藏API Key、认证和Cookie等请求/响应头
+func redactHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for key, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(key)] {
+			result[key] = redacted
+			continue
+		}
+		result[key] = strings.Join(values, ",")
+	}
+	return result
+}
+
+// redactBody 隐藏请求/响应体中的敏感字段：JSON按字段名，表单按参数名
+func redactBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return jsonFieldPattern.ReplaceAllStringFunc(string(body), func(field string) string {
+			name := jsonFieldPattern.FindStringSubmatch(field)[1]
+			if sensitiveParams[strings.ToLower(name)] {
+				return `"` + name + `":"` + redacted + `"`
+			}
+			return field
+		})
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			return redactValues(values).Encode()
+		}
+		return redacted
+	}
+	return string(body)
+}
@@ -0,0 +1,136 @@
+// Package transport 按配置创建交易所客户端使用的HTTP客户端，支持HTTP/SOCKS5代理、自定义DNS和TLS设置，
+// 用于只能经代理访问交易所的网络环境。
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"cs-projects-backpack/pkg/config"
+)
+
+// dialTimeout 建立TCP连接 (含DNS查询) 的超时时间
+const dialTimeout = 10 * time.Second
+
+// NewHTTPClient 按配置创建HTTP客户端，配置为空时与默认客户端行为一致 (读取 HTTPS_PROXY 等环境变量)。
+// name 为交易所名称，用于请求审计日志
+func NewHTTPClient(cfg *config.TransportConfig, name string) (*http.Client, error) {
+	proxy, err := ProxyURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+		Resolver:  newResolver(cfg.DNSServers),
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.TLSClientConfig = tlsConfig
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return &http.Client{Transport: newAuditTransport(transport, cfg, name)}, nil
+}
+
+// ProxyURL 解析配置的代理地址，未配置时返回nil
+func ProxyURL(cfg *config.TransportConfig) (*url.URL, error) {
+	if cfg.Proxy == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(cfg.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (expected http, https or socks5)", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url: missing host")
+	}
+	return u, nil
+}
+
+// newTLSConfig 按配置创建TLS设置：最低版本、额外信任的CA证书和是否跳过证书校验
+func newTLSConfig(cfg *config.TransportConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.TLSServerName,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+
+	switch cfg.TLSMinVersion {
+	case "", "1.2":
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported tls_min_version %q (expected 1.2 or 1.3)", cfg.TLSMinVersion)
+	}
+
+	if cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls_ca_file: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in tls_ca_file %s", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// newResolver 使用指定DNS服务器的解析器，依次轮换服务器；未配置时返回nil (使用系统解析)
+func newResolver(servers []string) *net.Resolver {
+	if len(servers) == 0 {
+		return nil
+	}
+
+	addrs := make([]string, len(servers))
+	for i, server := range servers {
+		addrs[i] = withDefaultPort(server)
+	}
+
+	var next atomic.Uint32
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			addr := addrs[int(next.Add(1)-1)%len(addrs)]
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// withDefaultPort DNS服务器地址未带端口时补上53
+func withDefaultPort(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(server, "53")
+}